@@ -0,0 +1,109 @@
+package http
+
+import (
+	"avito-intership/alert"
+	"avito-intership/apperror"
+	"avito-intership/auth"
+	"avito-intership/models"
+	"avito-intership/problem"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	useCase alert.AlertUseCase
+}
+
+func NewHandler(useCase alert.AlertUseCase) *Handler {
+	return &Handler{
+		useCase: useCase,
+	}
+}
+
+func (h Handler) writeJSON(value interface{}, w http.ResponseWriter) {
+	w.Header().Add("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		problem.Write(w, err)
+	}
+}
+
+func (h Handler) parseId(r *http.Request, w http.ResponseWriter) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil || id <= 0 {
+		problem.Write(w, apperror.BadArgument("id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+// parseThreshold читает необязательный порог, отсутствие параметра отключает уведомление
+func (h Handler) parseThreshold(name string, r *http.Request, w http.ResponseWriter) (*float32, bool) {
+	value := r.FormValue(name)
+	if value == "" {
+		return nil, true
+	}
+
+	threshold, err := strconv.ParseFloat(value, 32)
+	if err != nil {
+		problem.Write(w, apperror.BadArgument(name))
+		return nil, false
+	}
+
+	result := float32(threshold)
+	return &result, true
+}
+
+func (h Handler) GetSettingsEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	if err := auth.AuthorizeOwn(r.Context(), auth.ScopeAlerts, auth.ScopeOwnAlerts, id); err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	settings, err := h.useCase.GetSettings(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(settings, w)
+}
+
+func (h Handler) SetSettingsEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	if err := auth.AuthorizeOwn(r.Context(), auth.ScopeAlerts, auth.ScopeOwnAlerts, id); err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	lowBalance, ok := h.parseThreshold("low_balance", r, w)
+	if !ok {
+		return
+	}
+
+	largeDebit, ok := h.parseThreshold("large_debit", r, w)
+	if !ok {
+		return
+	}
+
+	settings, err := h.useCase.SetSettings(&models.AlertSettings{UserId: id, LowBalance: lowBalance,
+		LargeDebit: largeDebit})
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(settings, w)
+}
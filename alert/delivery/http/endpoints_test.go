@@ -0,0 +1,156 @@
+package http
+
+import (
+	"avito-intership/alert"
+	"avito-intership/apperror"
+	"avito-intership/auth"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"avito-intership/problem"
+	"avito-intership/server/middleware"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type handlerSuite struct {
+	suite.Suite
+
+	useCase       *mocks.AlertUseCase
+	testingServer *httptest.Server
+}
+
+func (suite *handlerSuite) SetupSuite() {
+	useCase := new(mocks.AlertUseCase)
+
+	router := mux.NewRouter()
+	router.Use(middleware.WithPrincipal(&models.Principal{Service: "notifications",
+		Scopes: []string{auth.ScopeAlerts}}))
+	RegisterEndpoints(router, useCase)
+
+	suite.testingServer = httptest.NewServer(router)
+	suite.useCase = useCase
+}
+
+func (suite *handlerSuite) TearDownSuite() {
+	defer suite.testingServer.Close()
+}
+
+func (suite *handlerSuite) put(userId string, data url.Values) *http.Response {
+	request, err := http.NewRequest(http.MethodPut,
+		fmt.Sprintf("%s/api/v1/balance/%s/alerts", suite.testingServer.URL, userId),
+		strings.NewReader(data.Encode()))
+	suite.Require().NoError(err)
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	response, err := http.DefaultClient.Do(request)
+	suite.Require().NoError(err, "request should not produce error")
+
+	return response
+}
+
+func (suite *handlerSuite) TestGetSettings() {
+	lowBalance := float32(500)
+	suite.useCase.On("GetSettings", int64(1)).Return(&models.AlertSettings{UserId: 1, LowBalance: &lowBalance}, nil)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/1/alerts", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.AlertSettings
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(float32(500), *responseBody.LowBalance)
+	suite.Nil(responseBody.LargeDebit)
+}
+
+func (suite *handlerSuite) TestSetSettings() {
+	suite.useCase.On("SetSettings", mock.MatchedBy(func(s *models.AlertSettings) bool {
+		return s != nil && s.UserId == 2 && s.LowBalance != nil && *s.LowBalance == 100 && s.LargeDebit == nil
+	})).Return(func(s *models.AlertSettings) *models.AlertSettings { return s }, nil)
+
+	data := url.Values{}
+	data.Set("low_balance", "100")
+
+	response := suite.put("2", data)
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+}
+
+func (suite *handlerSuite) TestSetSettings_BadThreshold() {
+	data := url.Values{}
+	data.Set("large_debit", "a lot")
+
+	response := suite.put("3", data)
+	defer response.Body.Close()
+
+	var responseBody problem.Problem
+	err := json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+	suite.Equal(apperror.InvalidArgument, responseBody.Code)
+	suite.Equal("large_debit", responseBody.Param)
+	suite.useCase.AssertNotCalled(suite.T(), "SetSettings", mock.MatchedBy(func(s *models.AlertSettings) bool {
+		return s != nil && s.UserId == 3
+	}))
+}
+
+func (suite *handlerSuite) TestSetSettings_Rejected() {
+	suite.useCase.On("SetSettings", mock.MatchedBy(func(s *models.AlertSettings) bool {
+		return s != nil && s.UserId == 4
+	})).Return(nil, alert.ErrBadSettings)
+
+	data := url.Values{}
+	data.Set("low_balance", "-5")
+
+	response := suite.put("4", data)
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *handlerSuite) TestBadId() {
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/0/alerts", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+// TestUserSettings проверяет, что пользователь настраивает уведомления только для своего счета
+func (suite *handlerSuite) TestUserSettings() {
+	useCase := new(mocks.AlertUseCase)
+	router := mux.NewRouter()
+	router.Use(middleware.WithPrincipal(&models.Principal{UserId: 7, Scopes: auth.UserScopes}))
+	RegisterEndpoints(router, useCase)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	useCase.On("GetSettings", int64(7)).Return(&models.AlertSettings{UserId: 7}, nil)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/7/alerts", server.URL))
+	suite.NoError(err, "request should not produce error")
+	response.Body.Close()
+	suite.Equal(http.StatusOK, response.StatusCode)
+
+	response, err = http.Get(fmt.Sprintf("%s/api/v1/balance/8/alerts", server.URL))
+	suite.NoError(err, "request should not produce error")
+	response.Body.Close()
+	suite.Equal(http.StatusForbidden, response.StatusCode)
+	useCase.AssertNotCalled(suite.T(), "GetSettings", int64(8))
+}
+
+func TestHandler(t *testing.T) {
+	suite.Run(t, new(handlerSuite))
+}
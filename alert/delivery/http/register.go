@@ -0,0 +1,16 @@
+package http
+
+import (
+	"avito-intership/alert"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+func RegisterEndpoints(router *mux.Router, uc alert.AlertUseCase) {
+	handler := NewHandler(uc)
+
+	router.HandleFunc("/api/v1/balance/{id:[0-9]+}/alerts", handler.GetSettingsEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/api/v1/balance/{id:[0-9]+}/alerts", handler.SetSettingsEndpoint).
+		Methods(http.MethodOptions, http.MethodPut)
+}
@@ -0,0 +1,5 @@
+package alert
+
+import "avito-intership/apperror"
+
+var ErrBadSettings = apperror.New(apperror.InvalidArgument, "alert thresholds must be positive")
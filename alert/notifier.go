@@ -0,0 +1,7 @@
+package alert
+
+import "avito-intership/models"
+
+type Notifier interface {
+	Notify(alert *models.Alert) error
+}
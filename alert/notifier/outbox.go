@@ -0,0 +1,27 @@
+package notifier
+
+import (
+	"avito-intership/models"
+	"avito-intership/outbox"
+	"encoding/json"
+)
+
+// OutboxNotifier отправляет уведомления событиями outbox с типом уведомления,
+// их получают подписчики на события, например на "balance.low" или "balance.*"
+type OutboxNotifier struct {
+	events outbox.EventRepository
+}
+
+func NewOutboxNotifier(events outbox.EventRepository) *OutboxNotifier {
+	return &OutboxNotifier{events: events}
+}
+
+func (n *OutboxNotifier) Notify(alert *models.Alert) error {
+	payload, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	_, err = n.events.CreateEvent(&models.Event{Type: alert.Type, UserId: alert.UserId, Payload: payload})
+	return err
+}
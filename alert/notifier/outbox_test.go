@@ -0,0 +1,42 @@
+package notifier
+
+import (
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"encoding/json"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type outboxNotifierSuite struct {
+	suite.Suite
+	events   *mocks.EventRepository
+	notifier *OutboxNotifier
+}
+
+func (suite *outboxNotifierSuite) SetupTest() {
+	suite.events = new(mocks.EventRepository)
+	suite.notifier = NewOutboxNotifier(suite.events)
+}
+
+func (suite *outboxNotifierSuite) TestNotify() {
+	alert := &models.Alert{Type: "balance.low", UserId: 1, Threshold: 500, Balance: 300}
+	suite.events.On("CreateEvent", mock.MatchedBy(func(e *models.Event) bool {
+		if e == nil || e.Type != "balance.low" || e.UserId != 1 {
+			return false
+		}
+
+		var payload models.Alert
+		return json.Unmarshal(e.Payload, &payload) == nil && payload.Balance == 300 && payload.Threshold == 500
+	})).Return(&models.Event{Id: 1}, nil)
+
+	err := suite.notifier.Notify(alert)
+
+	suite.NoError(err)
+	suite.events.AssertExpectations(suite.T())
+}
+
+func TestOutboxNotifier(t *testing.T) {
+	suite.Run(t, new(outboxNotifierSuite))
+}
@@ -0,0 +1,19 @@
+package alert
+
+import "avito-intership/models"
+
+// Типы уведомлений, совпадают с типами событий, через которые они доставляются
+const (
+	LowBalance = "balance.low"
+	LargeDebit = "balance.large_debit"
+)
+
+type AlertRepository interface {
+	// GetSettings возвращает пустые настройки, если пользователь их не задавал
+	GetSettings(userId int64) (*models.AlertSettings, error)
+	// SetSettings сохраняет настройки и сбрасывает признак отправленного уведомления о низком балансе
+	SetSettings(settings *models.AlertSettings) (*models.AlertSettings, error)
+	// SetLowBalanceNotified меняет признак отправленного уведомления о низком балансе
+	// и возвращает true, если признак изменился
+	SetLowBalanceNotified(userId int64, notified bool) (bool, error)
+}
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"avito-intership/models"
+	"database/sql"
+)
+
+type AlertRepository struct {
+	db *sql.DB
+}
+
+func NewAlertRepository(dbConn *sql.DB) *AlertRepository {
+	return &AlertRepository{dbConn}
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSettings(userId int64, row scanner) (*models.AlertSettings, error) {
+	settings := models.AlertSettings{UserId: userId}
+	var lowBalance, largeDebit sql.NullFloat64
+
+	err := row.Scan(&lowBalance, &largeDebit)
+	if err == sql.ErrNoRows {
+		return &settings, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if lowBalance.Valid {
+		value := float32(lowBalance.Float64)
+		settings.LowBalance = &value
+	}
+	if largeDebit.Valid {
+		value := float32(largeDebit.Float64)
+		settings.LargeDebit = &value
+	}
+
+	return &settings, nil
+}
+
+func (r AlertRepository) GetSettings(userId int64) (*models.AlertSettings, error) {
+	return scanSettings(userId, r.db.QueryRow(
+		"SELECT low_balance, large_debit FROM alert_settings WHERE user_id = $1", userId))
+}
+
+func (r AlertRepository) SetSettings(settings *models.AlertSettings) (*models.AlertSettings, error) {
+	return scanSettings(settings.UserId, r.db.QueryRow(
+		`INSERT INTO alert_settings (user_id, low_balance, large_debit) VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET low_balance = EXCLUDED.low_balance, large_debit = EXCLUDED.large_debit,
+			low_balance_notified = FALSE, updated_at = NOW()
+		RETURNING low_balance, large_debit`,
+		settings.UserId, settings.LowBalance, settings.LargeDebit))
+}
+
+// SetLowBalanceNotified меняет признак одним запросом, поэтому при одновременных списаниях
+// переход через порог увидит только одно из них
+func (r AlertRepository) SetLowBalanceNotified(userId int64, notified bool) (bool, error) {
+	result, err := r.db.Exec(
+		"UPDATE alert_settings SET low_balance_notified = $2 WHERE user_id = $1 AND low_balance_notified <> $2",
+		userId, notified)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
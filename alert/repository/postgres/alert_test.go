@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"avito-intership/alert"
+	"avito-intership/models"
+	"avito-intership/utils"
+	"database/sql"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/suite"
+	"log"
+	"testing"
+)
+
+type alertRepositorySuite struct {
+	suite.Suite
+
+	db       *sql.DB
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+
+	repository alert.AlertRepository
+}
+
+func (suite *alertRepositorySuite) SetupSuite() {
+	db, pool, resource := utils.DockerDBUp()
+	err := utils.InitTable(db, "../../../init.sql")
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	suite.repository = NewAlertRepository(db)
+	suite.db = db
+	suite.pool = pool
+	suite.resource = resource
+}
+
+func (suite *alertRepositorySuite) TestGetSettings_NotConfigured() {
+	settings, err := suite.repository.GetSettings(100)
+
+	suite.NoError(err, "getting settings should not produce error")
+	suite.Equal(int64(100), settings.UserId)
+	suite.Nil(settings.LowBalance)
+	suite.Nil(settings.LargeDebit)
+}
+
+func (suite *alertRepositorySuite) TestSetSettings() {
+	lowBalance := float32(500)
+	settings, err := suite.repository.SetSettings(&models.AlertSettings{UserId: 1, LowBalance: &lowBalance})
+	suite.NoError(err, "setting settings should not produce error")
+	suite.Equal(float32(500), *settings.LowBalance)
+	suite.Nil(settings.LargeDebit)
+
+	largeDebit := float32(10000)
+	_, err = suite.repository.SetSettings(&models.AlertSettings{UserId: 1, LargeDebit: &largeDebit})
+	suite.NoError(err, "setting settings should not produce error")
+
+	settings, err = suite.repository.GetSettings(1)
+	suite.NoError(err, "getting settings should not produce error")
+	suite.Nil(settings.LowBalance, "absent threshold disables alert")
+	suite.Equal(float32(10000), *settings.LargeDebit)
+}
+
+func (suite *alertRepositorySuite) TestSetLowBalanceNotified() {
+	lowBalance := float32(500)
+	_, err := suite.repository.SetSettings(&models.AlertSettings{UserId: 2, LowBalance: &lowBalance})
+	suite.Require().NoError(err, "setting settings should not produce error")
+
+	changed, err := suite.repository.SetLowBalanceNotified(2, true)
+	suite.NoError(err)
+	suite.True(changed)
+
+	changed, err = suite.repository.SetLowBalanceNotified(2, true)
+	suite.NoError(err)
+	suite.False(changed, "already notified")
+
+	changed, err = suite.repository.SetLowBalanceNotified(2, false)
+	suite.NoError(err)
+	suite.True(changed)
+
+	_, err = suite.repository.SetLowBalanceNotified(2, true)
+	suite.NoError(err)
+	_, err = suite.repository.SetSettings(&models.AlertSettings{UserId: 2, LowBalance: &lowBalance})
+	suite.NoError(err)
+	changed, err = suite.repository.SetLowBalanceNotified(2, true)
+	suite.NoError(err)
+	suite.True(changed, "changing settings re-arms alert")
+}
+
+func (suite *alertRepositorySuite) TearDownSuite() {
+	err := utils.DropTable(suite.db, []string{"alert_settings"})
+	if err != nil {
+		log.Println(err)
+	}
+
+	if err := suite.pool.Purge(suite.resource); err != nil {
+		log.Fatalf("Could not purge resource: %s", err)
+	}
+}
+
+func TestAlertRepository(t *testing.T) {
+	suite.Run(t, new(alertRepositorySuite))
+}
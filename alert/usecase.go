@@ -0,0 +1,10 @@
+package alert
+
+import "avito-intership/models"
+
+type AlertUseCase interface {
+	GetSettings(userId int64) (*models.AlertSettings, error)
+	SetSettings(settings *models.AlertSettings) (*models.AlertSettings, error)
+	// Evaluate проверяет уведомления пользователя после изменения его баланса, debit - сумма списания или 0
+	Evaluate(userId int64, debit float32) error
+}
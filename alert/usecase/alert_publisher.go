@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"avito-intership/alert"
+	"avito-intership/models"
+	"avito-intership/outbox"
+	"encoding/json"
+	"log"
+)
+
+// AlertPublisher проверяет уведомления по событиям outbox об операциях с балансом. События записываются
+// в одной транзакции с любой операцией, поэтому уведомления срабатывают и для корректировок, одобренных операций,
+// сделок и ваучеров. Уведомления следят за основным балансом, поэтому начисление и сгорание бонусов пропускаются,
+// а из суммы операции вычитается бонусная часть. Ошибка проверки возвращается, чтобы событие было отправлено повторно,
+// повторная доставка уведомлений отсекается по подписке и событию
+type AlertPublisher struct {
+	alerts alert.AlertUseCase
+}
+
+func NewAlertPublisher(alerts alert.AlertUseCase) *AlertPublisher {
+	return &AlertPublisher{alerts: alerts}
+}
+
+func (p *AlertPublisher) Publish(event *models.Event) error {
+	switch event.Type {
+	case outbox.EventBalanceCredited, outbox.EventBalanceDebited, outbox.EventTransferCompleted:
+	default:
+		return nil
+	}
+
+	var transaction models.Transaction
+	if err := json.Unmarshal(event.Payload, &transaction); err != nil {
+		log.Println(err)
+		return nil
+	}
+
+	var debit float32
+	if main := transaction.Amount - transaction.Bonus; main < 0 {
+		debit = -main
+	}
+
+	return p.alerts.Evaluate(transaction.UserId, debit)
+}
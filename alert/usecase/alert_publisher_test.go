@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"avito-intership/alert"
+	"avito-intership/balance"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"avito-intership/outbox"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type alertPublisherSuite struct {
+	suite.Suite
+	alerts    *mocks.AlertUseCase
+	publisher *AlertPublisher
+}
+
+func (suite *alertPublisherSuite) SetupTest() {
+	suite.alerts = new(mocks.AlertUseCase)
+	suite.publisher = NewAlertPublisher(suite.alerts)
+}
+
+func (suite *alertPublisherSuite) event(transaction *models.Transaction) *models.Event {
+	payload, err := json.Marshal(transaction)
+	suite.NoError(err)
+
+	return &models.Event{Type: outbox.TransactionEventType(transaction), UserId: transaction.UserId, Payload: payload}
+}
+
+func (suite *alertPublisherSuite) TestPublish_Debit() {
+	suite.alerts.On("Evaluate", int64(1), float32(150)).Return(nil)
+
+	err := suite.publisher.Publish(suite.event(&models.Transaction{UserId: 1, Amount: -150,
+		Type: balance.AdjustmentType}))
+
+	suite.NoError(err)
+	suite.alerts.AssertExpectations(suite.T())
+}
+
+func (suite *alertPublisherSuite) TestPublish_Credit() {
+	suite.alerts.On("Evaluate", int64(2), float32(0)).Return(nil)
+
+	err := suite.publisher.Publish(suite.event(&models.Transaction{UserId: 2, Amount: 500, TargetId: 1,
+		Type: balance.TransferType}))
+
+	suite.NoError(err)
+	suite.alerts.AssertExpectations(suite.T())
+}
+
+func (suite *alertPublisherSuite) TestPublish_AlertEventSkipped() {
+	err := suite.publisher.Publish(&models.Event{Type: alert.LowBalance, UserId: 1, Payload: []byte(`{}`)})
+
+	suite.NoError(err)
+	suite.alerts.AssertNotCalled(suite.T(), "Evaluate", mock.Anything, mock.Anything)
+}
+
+func (suite *alertPublisherSuite) TestPublish_BonusPart() {
+	suite.alerts.On("Evaluate", int64(4), float32(70)).Return(nil)
+
+	err := suite.publisher.Publish(suite.event(&models.Transaction{UserId: 4, Amount: -100, Bonus: -30,
+		Type: balance.WithdrawType}))
+
+	suite.NoError(err)
+	suite.alerts.AssertExpectations(suite.T())
+}
+
+func (suite *alertPublisherSuite) TestPublish_BonusSkipped() {
+	for _, transaction := range []*models.Transaction{
+		{UserId: 5, Amount: 50, Bonus: 50, Type: balance.BonusType},
+		{UserId: 5, Amount: -10, Bonus: -10, Type: balance.BonusExpiredType},
+	} {
+		err := suite.publisher.Publish(suite.event(transaction))
+		suite.NoError(err)
+	}
+
+	suite.alerts.AssertNotCalled(suite.T(), "Evaluate", mock.Anything, mock.Anything)
+}
+
+func (suite *alertPublisherSuite) TestPublish_AlertError() {
+	suite.alerts.On("Evaluate", int64(3), float32(10)).Return(errors.New("db is down"))
+
+	err := suite.publisher.Publish(suite.event(&models.Transaction{UserId: 3, Amount: -10,
+		Type: balance.AdjustmentType}))
+
+	suite.Error(err, "event should be published again")
+}
+
+func TestAlertPublisher(t *testing.T) {
+	suite.Run(t, new(alertPublisherSuite))
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"avito-intership/alert"
+	"avito-intership/balance"
+	"avito-intership/models"
+	"log"
+	"time"
+)
+
+type AlertUseCase struct {
+	repo        alert.AlertRepository
+	balanceRepo balance.Repository
+	notifier    alert.Notifier
+}
+
+func NewAlertUseCase(repo alert.AlertRepository, balanceRepo balance.Repository, notifier alert.Notifier) *AlertUseCase {
+	return &AlertUseCase{
+		repo:        repo,
+		balanceRepo: balanceRepo,
+		notifier:    notifier,
+	}
+}
+
+func (u AlertUseCase) GetSettings(userId int64) (*models.AlertSettings, error) {
+	return u.repo.GetSettings(userId)
+}
+
+func (u AlertUseCase) SetSettings(settings *models.AlertSettings) (*models.AlertSettings, error) {
+	if (settings.LowBalance != nil && *settings.LowBalance <= 0) ||
+		(settings.LargeDebit != nil && *settings.LargeDebit <= 0) {
+		return nil, alert.ErrBadSettings
+	}
+
+	return u.repo.SetSettings(settings)
+}
+
+// Evaluate уведомляет о низком балансе только при переходе через порог: пока баланс остается ниже порога,
+// повторные уведомления не отправляются, а после пополнения до порога уведомление снова становится возможным
+func (u AlertUseCase) Evaluate(userId int64, debit float32) error {
+	settings, err := u.repo.GetSettings(userId)
+	if err != nil {
+		return err
+	}
+	if settings.LowBalance == nil && settings.LargeDebit == nil {
+		return nil
+	}
+
+	amount, err := u.balanceRepo.GetBalance(userId)
+	if err != nil {
+		return err
+	}
+
+	if settings.LargeDebit != nil && debit > *settings.LargeDebit {
+		err = u.notifier.Notify(&models.Alert{Type: alert.LargeDebit, UserId: userId, Threshold: *settings.LargeDebit,
+			Balance: amount, Amount: debit, CreatedAt: time.Now()})
+		if err != nil {
+			return err
+		}
+	}
+
+	if settings.LowBalance == nil {
+		return nil
+	}
+
+	low := amount < *settings.LowBalance
+	changed, err := u.repo.SetLowBalanceNotified(userId, low)
+	if err != nil || !changed || !low {
+		return err
+	}
+
+	err = u.notifier.Notify(&models.Alert{Type: alert.LowBalance, UserId: userId, Threshold: *settings.LowBalance,
+		Balance: amount, CreatedAt: time.Now()})
+	if err != nil {
+		// Уведомление не ушло, поэтому следующее изменение баланса должно попробовать снова
+		if _, resetErr := u.repo.SetLowBalanceNotified(userId, false); resetErr != nil {
+			log.Println(resetErr)
+		}
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"avito-intership/alert"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type alertUseCaseSuite struct {
+	suite.Suite
+	repository  *mocks.AlertRepository
+	balanceRepo *mocks.Repository
+	notifier    *mocks.Notifier
+	useCase     alert.AlertUseCase
+}
+
+func (suite *alertUseCaseSuite) SetupTest() {
+	suite.repository = new(mocks.AlertRepository)
+	suite.balanceRepo = new(mocks.Repository)
+	suite.notifier = new(mocks.Notifier)
+	suite.useCase = NewAlertUseCase(suite.repository, suite.balanceRepo, suite.notifier)
+}
+
+func (suite *alertUseCaseSuite) settings(lowBalance float32, largeDebit float32) {
+	settings := &models.AlertSettings{UserId: 1}
+	if lowBalance > 0 {
+		settings.LowBalance = &lowBalance
+	}
+	if largeDebit > 0 {
+		settings.LargeDebit = &largeDebit
+	}
+	suite.repository.On("GetSettings", int64(1)).Return(settings, nil)
+}
+
+func alertOfType(alertType string) interface{} {
+	return mock.MatchedBy(func(a *models.Alert) bool {
+		return a != nil && a.Type == alertType
+	})
+}
+
+func (suite *alertUseCaseSuite) TestSetSettings_Validation() {
+	negative := float32(-1)
+	_, err := suite.useCase.SetSettings(&models.AlertSettings{UserId: 1, LowBalance: &negative})
+
+	suite.Equal(alert.ErrBadSettings, err)
+	suite.repository.AssertNotCalled(suite.T(), "SetSettings", mock.Anything)
+}
+
+func (suite *alertUseCaseSuite) TestEvaluate_NoSettings() {
+	suite.settings(0, 0)
+
+	err := suite.useCase.Evaluate(1, 100)
+
+	suite.NoError(err)
+	suite.balanceRepo.AssertNotCalled(suite.T(), "GetBalance", mock.Anything)
+}
+
+func (suite *alertUseCaseSuite) TestEvaluate_LowBalanceCrossed() {
+	suite.settings(500, 0)
+	suite.balanceRepo.On("GetBalance", int64(1)).Return(float32(300), nil)
+	suite.repository.On("SetLowBalanceNotified", int64(1), true).Return(true, nil)
+	suite.notifier.On("Notify", mock.MatchedBy(func(a *models.Alert) bool {
+		return a != nil && a.Type == alert.LowBalance && a.Balance == 300 && a.Threshold == 500
+	})).Return(nil)
+
+	err := suite.useCase.Evaluate(1, 50)
+
+	suite.NoError(err)
+	suite.notifier.AssertNumberOfCalls(suite.T(), "Notify", 1)
+}
+
+func (suite *alertUseCaseSuite) TestEvaluate_LowBalanceAlreadyNotified() {
+	suite.settings(500, 0)
+	suite.balanceRepo.On("GetBalance", int64(1)).Return(float32(200), nil)
+	suite.repository.On("SetLowBalanceNotified", int64(1), true).Return(false, nil)
+
+	err := suite.useCase.Evaluate(1, 100)
+
+	suite.NoError(err)
+	suite.notifier.AssertNotCalled(suite.T(), "Notify", mock.Anything)
+}
+
+func (suite *alertUseCaseSuite) TestEvaluate_BalanceRecovered() {
+	suite.settings(500, 0)
+	suite.balanceRepo.On("GetBalance", int64(1)).Return(float32(800), nil)
+	suite.repository.On("SetLowBalanceNotified", int64(1), false).Return(true, nil)
+
+	err := suite.useCase.Evaluate(1, 0)
+
+	suite.NoError(err)
+	suite.notifier.AssertNotCalled(suite.T(), "Notify", mock.Anything)
+}
+
+func (suite *alertUseCaseSuite) TestEvaluate_NotifyFailureRearms() {
+	suite.settings(500, 0)
+	suite.balanceRepo.On("GetBalance", int64(1)).Return(float32(300), nil)
+	suite.repository.On("SetLowBalanceNotified", int64(1), true).Return(true, nil)
+	suite.repository.On("SetLowBalanceNotified", int64(1), false).Return(true, nil)
+	suite.notifier.On("Notify", alertOfType(alert.LowBalance)).Return(errors.New("outbox unavailable"))
+
+	err := suite.useCase.Evaluate(1, 50)
+
+	suite.Error(err)
+	suite.repository.AssertCalled(suite.T(), "SetLowBalanceNotified", int64(1), false)
+}
+
+func (suite *alertUseCaseSuite) TestEvaluate_LargeDebit() {
+	suite.settings(0, 1000)
+	suite.balanceRepo.On("GetBalance", int64(1)).Return(float32(5000), nil)
+	suite.notifier.On("Notify", mock.MatchedBy(func(a *models.Alert) bool {
+		return a != nil && a.Type == alert.LargeDebit && a.Amount == 1500
+	})).Return(nil)
+
+	suite.NoError(suite.useCase.Evaluate(1, 1500))
+	suite.NoError(suite.useCase.Evaluate(1, 1000), "debit equal to the threshold is not large")
+
+	suite.notifier.AssertNumberOfCalls(suite.T(), "Notify", 1)
+	suite.repository.AssertNotCalled(suite.T(), "SetLowBalanceNotified", mock.Anything, mock.Anything)
+}
+
+func TestAlertUseCase(t *testing.T) {
+	suite.Run(t, new(alertUseCaseSuite))
+}
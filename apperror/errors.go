@@ -0,0 +1,75 @@
+package apperror
+
+import "errors"
+
+// Code - стабильный код ошибки; в отличие от текста ошибки, на него можно опираться в клиентах
+type Code string
+
+const (
+	InvalidArgument   Code = "INVALID_ARGUMENT"
+	Unauthorized      Code = "UNAUTHORIZED"
+	PermissionDenied  Code = "PERMISSION_DENIED"
+	NotFound          Code = "NOT_FOUND"
+	Conflict          Code = "CONFLICT"
+	InsufficientFunds Code = "INSUFFICIENT_FUNDS"
+	LimitExceeded     Code = "LIMIT_EXCEEDED"
+	ConversionFailed  Code = "CONVERSION_FAILED"
+	TransferHeld      Code = "TRANSFER_HELD"
+	ApprovalRequired  Code = "APPROVAL_REQUIRED"
+	Unavailable       Code = "UNAVAILABLE"
+	Internal          Code = "INTERNAL"
+)
+
+// Error - ошибка предметной области с кодом, по которому транспорт выбирает ответ
+type Error struct {
+	Code    Code
+	Message string
+	// Параметр запроса, к которому относится ошибка
+	Param string
+	// Тот же запрос может выполниться успешно, если повторить его позже
+	Retryable bool
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func NewRetryable(code Code, message string) *Error {
+	return &Error{Code: code, Message: message, Retryable: true}
+}
+
+// WithParam создает ошибку, относящуюся к параметру param
+func WithParam(code Code, param string, message string) *Error {
+	return &Error{Code: code, Message: message, Param: param}
+}
+
+// BadArgument - ошибка разбора параметра запроса
+func BadArgument(param string) *Error {
+	return WithParam(InvalidArgument, param, "Bad "+param+" argument")
+}
+
+// Coder реализуют ошибки, которые несут дополнительные данные и поэтому не могут быть *Error
+type Coder interface {
+	AppError() *Error
+}
+
+var errInternal = New(Internal, "Server error")
+
+// From возвращает описание ошибки err; ошибки без кода считаются внутренними, их текст клиенту не передается
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	var coder Coder
+	if errors.As(err, &coder) {
+		return coder.AppError()
+	}
+
+	return errInternal
+}
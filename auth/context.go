@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"avito-intership/models"
+	"context"
+)
+
+type principalKey struct{}
+
+func NewContext(ctx context.Context, principal *models.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext возвращает вызывающего, сохраненного при аутентификации, либо nil
+func FromContext(ctx context.Context) *models.Principal {
+	principal, _ := ctx.Value(principalKey{}).(*models.Principal)
+	return principal
+}
+
+// Authorize проверяет, что вызывающему выдано право scope, а если это пользователь - что операция
+// выполняется с его счетом userId. Без вызывающего в ctx операция запрещена
+func Authorize(ctx context.Context, scope string, userId int64) error {
+	principal := FromContext(ctx)
+	if principal == nil {
+		return ErrUnauthenticated
+	}
+
+	if !contains(principal.Scopes, scope) {
+		return ErrNoScope
+	}
+
+	if principal.UserId != 0 && principal.UserId != userId {
+		return ErrForeignAccount
+	}
+
+	return nil
+}
+
+// AuthorizeOwn проверяет право serviceScope у сервиса, а у пользователя - отдельное право userScope,
+// например пользователь погашает ваучер на свой счет, не имея права зачислять средства
+func AuthorizeOwn(ctx context.Context, serviceScope string, userScope string, userId int64) error {
+	if principal := FromContext(ctx); principal != nil && principal.UserId != 0 {
+		return Authorize(ctx, userScope, userId)
+	}
+
+	return Authorize(ctx, serviceScope, userId)
+}
+
+// Operator возвращает имя оператора, выполняющего служебный метод, - сервис ключа с правом ScopeOperator.
+// Имя берется из ключа, а не из параметров запроса, чтобы одобрить операцию мог только другой оператор
+func Operator(ctx context.Context) (string, error) {
+	principal := FromContext(ctx)
+	if principal == nil {
+		return "", ErrUnauthenticated
+	}
+
+	if principal.UserId != 0 || principal.Service == "" || !contains(principal.Scopes, ScopeOperator) {
+		return "", ErrNoScope
+	}
+
+	return principal.Service, nil
+}
+
+// AuthorizeAny разрешает пользователю операцию, затрагивающую несколько счетов, если один из них - его,
+// например пользователь - сторона сделки
+func AuthorizeAny(ctx context.Context, scope string, userIds ...int64) error {
+	var err error = ErrForeignAccount
+	for _, userId := range userIds {
+		if err = Authorize(ctx, scope, userId); err != ErrForeignAccount {
+			return err
+		}
+	}
+
+	return err
+}
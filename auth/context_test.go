@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"avito-intership/models"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAuthorize_Service(t *testing.T) {
+	ctx := NewContext(context.Background(), &models.Principal{Service: BillingService,
+		Scopes: []string{ScopeBalanceRead, ScopeDebit}})
+
+	assert.NoError(t, Authorize(ctx, ScopeDebit, 1))
+	assert.NoError(t, Authorize(ctx, ScopeBalanceRead, 2), "services work with any account")
+	assert.Equal(t, ErrNoScope, Authorize(ctx, ScopeTransfer, 1))
+}
+
+func TestAuthorize_User(t *testing.T) {
+	ctx := NewContext(context.Background(), &models.Principal{UserId: 1, Scopes: UserScopes})
+
+	assert.NoError(t, Authorize(ctx, ScopeBalanceRead, 1))
+	assert.NoError(t, Authorize(ctx, ScopeTransfer, 1))
+	assert.Equal(t, ErrForeignAccount, Authorize(ctx, ScopeBalanceRead, 2))
+	assert.Equal(t, ErrForeignAccount, Authorize(ctx, ScopeTransfer, 2))
+	assert.Equal(t, ErrNoScope, Authorize(ctx, ScopeDebit, 1))
+}
+
+func TestAuthorizeAny(t *testing.T) {
+	user := NewContext(context.Background(), &models.Principal{UserId: 1, Scopes: UserScopes})
+	service := NewContext(context.Background(), &models.Principal{Service: "marketplace",
+		Scopes: []string{ScopeDeals}})
+
+	assert.NoError(t, AuthorizeAny(user, ScopeDeals, 2, 1))
+	assert.Equal(t, ErrForeignAccount, AuthorizeAny(user, ScopeDeals, 2, 3))
+	assert.Equal(t, ErrNoScope, AuthorizeAny(user, ScopeDebit, 1, 2))
+	assert.NoError(t, AuthorizeAny(service, ScopeDeals, 2, 3))
+	assert.Equal(t, ErrUnauthenticated, AuthorizeAny(context.Background(), ScopeDeals, 1))
+}
+
+func TestAuthorizeOwn(t *testing.T) {
+	user := NewContext(context.Background(), &models.Principal{UserId: 1, Scopes: UserScopes})
+	service := NewContext(context.Background(), &models.Principal{Service: "marketing",
+		Scopes: []string{ScopeCredit}})
+
+	assert.NoError(t, AuthorizeOwn(user, ScopeCredit, ScopeRedeemVouchers, 1))
+	assert.Equal(t, ErrForeignAccount, AuthorizeOwn(user, ScopeCredit, ScopeRedeemVouchers, 2))
+	assert.Equal(t, ErrNoScope, Authorize(user, ScopeCredit, 1), "redeeming doesn't grant credit")
+	assert.NoError(t, AuthorizeOwn(service, ScopeCredit, ScopeRedeemVouchers, 2))
+	assert.Equal(t, ErrNoScope, AuthorizeOwn(service, ScopeAlerts, ScopeOwnAlerts, 2))
+	assert.Equal(t, ErrUnauthenticated, AuthorizeOwn(context.Background(), ScopeCredit, ScopeRedeemVouchers, 1))
+}
+
+func TestUserScopes_NotIssuedToKeys(t *testing.T) {
+	assert.False(t, IsScope(ScopeRedeemVouchers))
+	assert.False(t, IsScope(ScopeOwnAlerts))
+	assert.False(t, IsUserScope(ScopeCredit))
+	assert.False(t, IsUserScope(ScopeAlerts))
+}
+
+func TestOperator(t *testing.T) {
+	operator := NewContext(context.Background(), &models.Principal{Service: "alice", Scopes: []string{ScopeOperator}})
+	service := NewContext(context.Background(), &models.Principal{Service: BillingService,
+		Scopes: []string{ScopeDebit}})
+	user := NewContext(context.Background(), &models.Principal{UserId: 1, Scopes: []string{ScopeOperator}})
+
+	name, err := Operator(operator)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice", name)
+
+	_, err = Operator(service)
+	assert.Equal(t, ErrNoScope, err)
+	_, err = Operator(user)
+	assert.Equal(t, ErrNoScope, err, "users can't be operators")
+	_, err = Operator(context.Background())
+	assert.Equal(t, ErrUnauthenticated, err)
+}
+
+func TestAuthorize_NoPrincipal(t *testing.T) {
+	assert.Equal(t, ErrUnauthenticated, Authorize(context.Background(), ScopeBalanceRead, 1))
+}
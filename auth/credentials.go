@@ -0,0 +1,26 @@
+package auth
+
+import (
+	"avito-intership/models"
+	"strings"
+)
+
+// ApiKeyHeader - заголовок HTTP и ключ метаданных gRPC, в котором внутренние сервисы передают ключ API;
+// пользователи передают токен в Authorization: Bearer
+const ApiKeyHeader = "X-Api-Key"
+
+const bearerPrefix = "Bearer "
+
+// Authenticate определяет вызывающего по ключу API, а если ключа нет - по токену пользователя
+// из значения заголовка Authorization
+func Authenticate(uc AuthUseCase, apiKey string, authorization string) (*models.Principal, error) {
+	if apiKey != "" {
+		return uc.AuthenticateKey(apiKey)
+	}
+
+	if token := strings.TrimPrefix(authorization, bearerPrefix); token != authorization && token != "" {
+		return uc.AuthenticateToken(token)
+	}
+
+	return nil, ErrUnauthenticated
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/auth"
+	"avito-intership/problem"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type AdminHandler struct {
+	useCase auth.AuthUseCase
+}
+
+func NewAdminHandler(useCase auth.AuthUseCase) *AdminHandler {
+	return &AdminHandler{
+		useCase: useCase,
+	}
+}
+
+type StatusMessage struct {
+	Success bool    `json:"success"`
+	Message *string `json:"message"`
+}
+
+func (h AdminHandler) writeStatus(success bool, message *string, w *http.ResponseWriter) {
+	status := StatusMessage{
+		Success: success,
+		Message: message,
+	}
+
+	(*w).Header().Add("Content-Type", "application/json")
+	_ = json.NewEncoder(*w).Encode(status)
+}
+
+func (h AdminHandler) writeJSON(value interface{}, w http.ResponseWriter) {
+	w.Header().Add("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		problem.Write(w, err)
+	}
+}
+
+func (h AdminHandler) parseId(r *http.Request, w http.ResponseWriter) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil || id <= 0 {
+		problem.Write(w, apperror.BadArgument("id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (h AdminHandler) GetKeysEndpoint(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.useCase.GetKeys()
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(keys, w)
+}
+
+// IssueKeyEndpoint принимает права списком через запятую в scopes
+func (h AdminHandler) IssueKeyEndpoint(w http.ResponseWriter, r *http.Request) {
+	var scopes []string
+	if value := r.FormValue("scopes"); value != "" {
+		scopes = strings.Split(value, ",")
+	}
+
+	key, err := h.useCase.IssueKey(r.FormValue("service"), scopes)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(key, w)
+}
+
+func (h AdminHandler) RevokeKeyEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	err := h.useCase.RevokeKey(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeStatus(true, nil, &w)
+}
+
+// IssueUserTokenEndpoint выдает токен пользователя; его запрашивает бэкенд клиента после входа пользователя
+func (h AdminHandler) IssueUserTokenEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	token, err := h.useCase.IssueUserToken(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(token, w)
+}
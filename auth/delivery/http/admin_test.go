@@ -0,0 +1,104 @@
+package http
+
+import (
+	"avito-intership/auth"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+type adminHandlerSuite struct {
+	suite.Suite
+
+	useCase       *mocks.AuthUseCase
+	testingServer *httptest.Server
+}
+
+func (suite *adminHandlerSuite) SetupSuite() {
+	useCase := new(mocks.AuthUseCase)
+
+	router := mux.NewRouter()
+	RegisterAdminEndpoints(router.PathPrefix("/api/v1/admin").Subrouter(), useCase)
+
+	suite.testingServer = httptest.NewServer(router)
+	suite.useCase = useCase
+}
+
+func (suite *adminHandlerSuite) TearDownSuite() {
+	defer suite.testingServer.Close()
+}
+
+func (suite *adminHandlerSuite) TestIssueKey() {
+	suite.useCase.On("IssueKey", "billing", []string{"balance:read", "balance:debit"}).
+		Return(&models.ApiKey{Id: 1, Service: "billing", Key: "ak_key"}, nil)
+
+	data := url.Values{}
+	data.Set("service", "billing")
+	data.Set("scopes", "balance:read,balance:debit")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/api-keys", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.ApiKey
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal("ak_key", responseBody.Key)
+}
+
+func (suite *adminHandlerSuite) TestIssueKey_DebitForOtherService() {
+	suite.useCase.On("IssueKey", "marketplace", []string{"balance:debit"}).Return(nil, auth.ErrBadKey)
+
+	data := url.Values{}
+	data.Set("service", "marketplace")
+	data.Set("scopes", "balance:debit")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/api-keys", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *adminHandlerSuite) TestRevokeKey_NotFound() {
+	suite.useCase.On("RevokeKey", int64(404)).Return(auth.ErrKeyNotFound)
+
+	request, err := http.NewRequest(http.MethodDelete,
+		fmt.Sprintf("%s/api/v1/admin/api-keys/404", suite.testingServer.URL), nil)
+	suite.NoError(err, "creating request should not produce error")
+
+	response, err := http.DefaultClient.Do(request)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusNotFound, response.StatusCode)
+}
+
+func (suite *adminHandlerSuite) TestIssueUserToken() {
+	suite.useCase.On("IssueUserToken", int64(1)).
+		Return(&models.UserToken{UserId: 1, Token: "jwt", Scopes: auth.UserScopes}, nil)
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/admin/users/1/token", suite.testingServer.URL), "", nil)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.UserToken
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal("jwt", responseBody.Token)
+}
+
+func TestAdminHandler(t *testing.T) {
+	suite.Run(t, new(adminHandlerSuite))
+}
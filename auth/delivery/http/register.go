@@ -0,0 +1,22 @@
+package http
+
+import (
+	"avito-intership/auth"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// RegisterAdminEndpoints регистрирует выпуск ключей API и токенов пользователей,
+// router - подмаршрутизатор /api/v1/admin
+func RegisterAdminEndpoints(router *mux.Router, uc auth.AuthUseCase) {
+	handler := NewAdminHandler(uc)
+
+	router.HandleFunc("/api-keys", handler.GetKeysEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/api-keys", handler.IssueKeyEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/api-keys/{id:[0-9]+}", handler.RevokeKeyEndpoint).
+		Methods(http.MethodOptions, http.MethodDelete)
+	router.HandleFunc("/users/{id:[0-9]+}/token", handler.IssueUserTokenEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+}
@@ -0,0 +1,15 @@
+package auth
+
+import "avito-intership/apperror"
+
+var (
+	ErrUnauthenticated = apperror.New(apperror.Unauthorized,
+		"api key or bearer token is missing, invalid, expired or revoked")
+	ErrNoScope        = apperror.New(apperror.PermissionDenied, "caller has no scope for the operation")
+	ErrForeignAccount = apperror.New(apperror.PermissionDenied,
+		"user token allows operations only with the user's own account")
+	ErrKeyNotFound = apperror.New(apperror.NotFound, "api key not found")
+	ErrBadKey      = apperror.New(apperror.InvalidArgument,
+		"api key must have a service and at least one known scope; balance:debit is issued only to billing")
+	ErrTokensDisabled = apperror.New(apperror.Unavailable, "user tokens are disabled")
+)
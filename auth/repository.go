@@ -0,0 +1,12 @@
+package auth
+
+import "avito-intership/models"
+
+type KeyRepository interface {
+	// CreateKey сохраняет ключ с хэшем hash, сам ключ не сохраняется
+	CreateKey(key *models.ApiKey, hash []byte) (*models.ApiKey, error)
+	// GetKeyByHash возвращает неотозванный ключ с хэшем hash
+	GetKeyByHash(hash []byte) (*models.ApiKey, error)
+	GetKeys() ([]*models.ApiKey, error)
+	RevokeKey(id int64) error
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"avito-intership/auth"
+	"avito-intership/models"
+	"database/sql"
+	"github.com/lib/pq"
+)
+
+type KeyRepository struct {
+	db *sql.DB
+}
+
+func NewKeyRepository(dbConn *sql.DB) *KeyRepository {
+	return &KeyRepository{dbConn}
+}
+
+const keyColumns = "id, service, prefix, scopes, created_at, revoked_at"
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanKey(row scanner) (*models.ApiKey, error) {
+	var key models.ApiKey
+	var revokedAt sql.NullTime
+	err := row.Scan(&key.Id, &key.Service, &key.Prefix, pq.Array(&key.Scopes), &key.CreatedAt, &revokedAt)
+	if err == sql.ErrNoRows {
+		return nil, auth.ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+
+	return &key, nil
+}
+
+func (r KeyRepository) CreateKey(key *models.ApiKey, hash []byte) (*models.ApiKey, error) {
+	return scanKey(r.db.QueryRow(
+		`INSERT INTO api_keys (service, key_hash, prefix, scopes) VALUES ($1, $2, $3, $4)
+		RETURNING `+keyColumns,
+		key.Service, hash, key.Prefix, pq.Array(key.Scopes)))
+}
+
+func (r KeyRepository) GetKeyByHash(hash []byte) (*models.ApiKey, error) {
+	return scanKey(r.db.QueryRow(
+		"SELECT "+keyColumns+" FROM api_keys WHERE key_hash = $1 AND revoked_at IS NULL", hash))
+}
+
+func (r KeyRepository) GetKeys() ([]*models.ApiKey, error) {
+	rows, err := r.db.Query("SELECT " + keyColumns + " FROM api_keys ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]*models.ApiKey, 0)
+	for rows.Next() {
+		key, err := scanKey(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, key)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// RevokeKey отзывает ключ; отозванный ключ остается в списке, чтобы было видно, кому он был выдан
+func (r KeyRepository) RevokeKey(id int64) error {
+	result, err := r.db.Exec("UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL", id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return auth.ErrKeyNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"avito-intership/auth"
+	"avito-intership/models"
+	"avito-intership/utils"
+	"database/sql"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/suite"
+	"log"
+	"testing"
+)
+
+type keyRepositorySuite struct {
+	suite.Suite
+
+	db       *sql.DB
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+
+	keys auth.KeyRepository
+}
+
+func (suite *keyRepositorySuite) SetupSuite() {
+	db, pool, resource := utils.DockerDBUp()
+	err := utils.InitTable(db, "../../../init.sql")
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	suite.keys = NewKeyRepository(db)
+	suite.db = db
+	suite.pool = pool
+	suite.resource = resource
+}
+
+func (suite *keyRepositorySuite) TestKeyLifecycle() {
+	hash := []byte("hash of ak_billing")
+	created, err := suite.keys.CreateKey(&models.ApiKey{Service: "billing", Prefix: "ak_billing",
+		Scopes: []string{auth.ScopeBalanceRead, auth.ScopeDebit}}, hash)
+	suite.Require().NoError(err, "creating key should not produce error")
+	suite.Equal([]string{auth.ScopeBalanceRead, auth.ScopeDebit}, created.Scopes)
+
+	key, err := suite.keys.GetKeyByHash(hash)
+	suite.NoError(err, "getting key should not produce error")
+	suite.Equal(created.Id, key.Id)
+
+	_, err = suite.keys.GetKeyByHash([]byte("other hash"))
+	suite.Equal(auth.ErrKeyNotFound, err)
+
+	suite.NoError(suite.keys.RevokeKey(created.Id))
+	_, err = suite.keys.GetKeyByHash(hash)
+	suite.Equal(auth.ErrKeyNotFound, err, "revoked key is not found")
+	suite.Equal(auth.ErrKeyNotFound, suite.keys.RevokeKey(created.Id))
+
+	keys, err := suite.keys.GetKeys()
+	suite.NoError(err, "getting keys should not produce error")
+	suite.Len(keys, 1)
+	suite.NotNil(keys[0].RevokedAt)
+}
+
+func (suite *keyRepositorySuite) TearDownSuite() {
+	err := utils.DropTable(suite.db, []string{"api_keys"})
+	if err != nil {
+		log.Println(err)
+	}
+
+	if err := suite.pool.Purge(suite.resource); err != nil {
+		log.Fatalf("Could not purge resource: %s", err)
+	}
+}
+
+func TestKeyRepository(t *testing.T) {
+	suite.Run(t, new(keyRepositorySuite))
+}
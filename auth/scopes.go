@@ -0,0 +1,49 @@
+package auth
+
+const (
+	// ScopeBalanceRead - чтение баланса и истории счета
+	ScopeBalanceRead = "balance:read"
+	// ScopeTransfer - переводы и разделение платежа со счета
+	ScopeTransfer = "transfer"
+	// ScopeCredit - зачисление на счет, в том числе погашение ваучеров
+	ScopeCredit = "balance:credit"
+	// ScopeDebit - списание со счета, в том числе покупка услуг
+	ScopeDebit = "balance:debit"
+	// ScopeDeals - безопасные сделки; пользователю доступны только сделки, в которых он покупатель или продавец
+	ScopeDeals = "deals"
+	// ScopeAlerts - настройка уведомлений о балансе
+	ScopeAlerts = "alerts"
+	// ScopeOperator - решения по операциям и корректировки баланса в служебных методах от имени сервиса ключа
+	ScopeOperator = "operator"
+	// ScopeRedeemVouchers - погашение ваучеров пользователем на свой счет, не дает права зачислять средства
+	ScopeRedeemVouchers = "vouchers:redeem"
+	// ScopeOwnAlerts - настройка пользователем уведомлений о своем балансе
+	ScopeOwnAlerts = "alerts:own"
+)
+
+// BillingService - единственный сервис, которому можно выдать ScopeDebit
+const BillingService = "billing"
+
+// scopes - права, которые можно выдать ключу API; права только для пользователей ключам не выдаются
+var scopes = []string{ScopeBalanceRead, ScopeTransfer, ScopeCredit, ScopeDebit, ScopeDeals, ScopeAlerts, ScopeOperator}
+
+// UserScopes - права токена пользователя, остальные права пользователю не выдаются
+var UserScopes = []string{ScopeBalanceRead, ScopeTransfer, ScopeDeals, ScopeRedeemVouchers, ScopeOwnAlerts}
+
+func IsScope(scope string) bool {
+	return contains(scopes, scope)
+}
+
+func IsUserScope(scope string) bool {
+	return contains(UserScopes, scope)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,13 @@
+package auth
+
+import "avito-intership/models"
+
+type AuthUseCase interface {
+	IssueKey(service string, scopes []string) (*models.ApiKey, error)
+	GetKeys() ([]*models.ApiKey, error)
+	RevokeKey(id int64) error
+	IssueUserToken(userId int64) (*models.UserToken, error)
+	// AuthenticateKey и AuthenticateToken возвращают вызывающего либо ErrUnauthenticated
+	AuthenticateKey(key string) (*models.Principal, error)
+	AuthenticateToken(token string) (*models.Principal, error)
+}
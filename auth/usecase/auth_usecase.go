@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"avito-intership/auth"
+	"avito-intership/models"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"github.com/golang-jwt/jwt/v4"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	keyLength = 32
+	// keyPrefix отличает ключи API от токенов пользователей в логах и при случайной публикации
+	keyPrefix = "ak_"
+	// prefixLength - длина начала ключа, которое сохраняется открыто, чтобы ключ можно было узнать в списке
+	prefixLength = len(keyPrefix) + 8
+	// userTokenLifetime - время жизни токена пользователя; отозвать токен нельзя, поэтому оно короткое
+	userTokenLifetime = time.Hour
+)
+
+// userClaims - содержимое токена пользователя: sub - id пользователя, scope - права через пробел
+type userClaims struct {
+	Scope string `json:"scope"`
+	jwt.RegisteredClaims
+}
+
+// AuthUseCase выпускает ключи API внутренних сервисов и токены пользователей и проверяет их.
+// Ключи хранятся в базе в виде SHA-256, токены пользователей - JWT с подписью HS256
+// секретом JWT_SECRET, поэтому проверить их может любой экземпляр сервиса
+type AuthUseCase struct {
+	keys   auth.KeyRepository
+	secret []byte
+	now    func() time.Time
+}
+
+func NewAuthUseCase(keys auth.KeyRepository, secret string) *AuthUseCase {
+	return &AuthUseCase{
+		keys:   keys,
+		secret: []byte(secret),
+		now:    time.Now,
+	}
+}
+
+func hashKey(key string) []byte {
+	hash := sha256.Sum256([]byte(key))
+	return hash[:]
+}
+
+func newKey() (string, error) {
+	key := make([]byte, keyLength)
+	_, err := rand.Read(key)
+	if err != nil {
+		return "", err
+	}
+
+	return keyPrefix + base64.RawURLEncoding.EncodeToString(key), nil
+}
+
+// IssueKey выпускает ключ сервису service, ключ возвращается только здесь
+func (u *AuthUseCase) IssueKey(service string, scopes []string) (*models.ApiKey, error) {
+	service = strings.TrimSpace(service)
+	if service == "" || len(scopes) == 0 {
+		return nil, auth.ErrBadKey
+	}
+
+	keyScopes := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if !auth.IsScope(scope) || scope == auth.ScopeDebit && service != auth.BillingService {
+			return nil, auth.ErrBadKey
+		}
+		keyScopes = append(keyScopes, scope)
+	}
+
+	key, err := newKey()
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := u.keys.CreateKey(&models.ApiKey{Service: service, Prefix: key[:prefixLength], Scopes: keyScopes},
+		hashKey(key))
+	if err != nil {
+		return nil, err
+	}
+	created.Key = key
+
+	return created, nil
+}
+
+func (u *AuthUseCase) GetKeys() ([]*models.ApiKey, error) {
+	return u.keys.GetKeys()
+}
+
+func (u *AuthUseCase) RevokeKey(id int64) error {
+	return u.keys.RevokeKey(id)
+}
+
+func (u *AuthUseCase) AuthenticateKey(key string) (*models.Principal, error) {
+	if !strings.HasPrefix(key, keyPrefix) {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	apiKey, err := u.keys.GetKeyByHash(hashKey(key))
+	if err == auth.ErrKeyNotFound {
+		return nil, auth.ErrUnauthenticated
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Principal{Service: apiKey.Service, Scopes: apiKey.Scopes}, nil
+}
+
+func (u *AuthUseCase) IssueUserToken(userId int64) (*models.UserToken, error) {
+	if len(u.secret) == 0 {
+		return nil, auth.ErrTokensDisabled
+	}
+
+	now := u.now().Truncate(time.Second)
+	expiresAt := now.Add(userTokenLifetime)
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, userClaims{
+		Scope: strings.Join(auth.UserScopes, " "),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatInt(userId, 10),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}).SignedString(u.secret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.UserToken{
+		UserId:    userId,
+		Token:     token,
+		Scopes:    auth.UserScopes,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// AuthenticateToken принимает только токены HS256 со сроком действия. Права, которые пользователю
+// не выдаются, игнорируются, даже если токен подписан правильным секретом
+func (u *AuthUseCase) AuthenticateToken(token string) (*models.Principal, error) {
+	if len(u.secret) == 0 {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	var claims userClaims
+	_, err := jwt.NewParser(jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithoutClaimsValidation()).
+		ParseWithClaims(token, &claims, func(*jwt.Token) (interface{}, error) { return u.secret, nil })
+	if err != nil || !claims.VerifyExpiresAt(u.now(), true) || !claims.VerifyNotBefore(u.now(), false) {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	userId, err := strconv.ParseInt(claims.Subject, 10, 64)
+	if err != nil || userId <= 0 {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	scopes := make([]string, 0, len(auth.UserScopes))
+	for _, scope := range strings.Fields(claims.Scope) {
+		if auth.IsUserScope(scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return &models.Principal{UserId: userId, Scopes: scopes}, nil
+}
@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"avito-intership/auth"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"bytes"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+	"time"
+)
+
+type authUseCaseSuite struct {
+	suite.Suite
+	keys    *mocks.KeyRepository
+	useCase *AuthUseCase
+	now     time.Time
+}
+
+func (suite *authUseCaseSuite) SetupTest() {
+	suite.keys = new(mocks.KeyRepository)
+	suite.useCase = NewAuthUseCase(suite.keys, "secret")
+	suite.now = time.Date(2021, 11, 20, 12, 0, 0, 0, time.UTC)
+	suite.useCase.now = func() time.Time { return suite.now }
+}
+
+func (suite *authUseCaseSuite) TestIssueKey() {
+	var hash []byte
+	suite.keys.On("CreateKey", mock.MatchedBy(func(k *models.ApiKey) bool {
+		return k.Service == "billing" && len(k.Scopes) == 2 && k.Key == ""
+	}), mock.Anything).Run(func(args mock.Arguments) {
+		hash = args.Get(1).([]byte)
+	}).Return(func(k *models.ApiKey, _ []byte) *models.ApiKey { return k }, nil)
+
+	key, err := suite.useCase.IssueKey(" billing ", []string{auth.ScopeDebit, " balance:read"})
+
+	suite.NoError(err)
+	suite.True(strings.HasPrefix(key.Key, keyPrefix))
+	suite.True(strings.HasPrefix(key.Key, key.Prefix))
+	suite.Len(key.Prefix, prefixLength)
+	suite.True(bytes.Equal(hashKey(key.Key), hash), "only the hash of the key is stored")
+}
+
+func (suite *authUseCaseSuite) TestIssueKey_Validation() {
+	cases := []struct {
+		service string
+		scopes  []string
+	}{
+		{"", []string{auth.ScopeBalanceRead}},
+		{"billing", nil},
+		{"billing", []string{"balance:write"}},
+		{"marketplace", []string{auth.ScopeTransfer, auth.ScopeDebit}},
+	}
+
+	for _, c := range cases {
+		_, err := suite.useCase.IssueKey(c.service, c.scopes)
+		suite.Equal(auth.ErrBadKey, err, c.service)
+	}
+	suite.keys.AssertNotCalled(suite.T(), "CreateKey", mock.Anything, mock.Anything)
+}
+
+func (suite *authUseCaseSuite) TestAuthenticateKey() {
+	key := keyPrefix + "known"
+	suite.keys.On("GetKeyByHash", hashKey(key)).
+		Return(&models.ApiKey{Id: 1, Service: "billing", Scopes: []string{auth.ScopeDebit}}, nil)
+
+	principal, err := suite.useCase.AuthenticateKey(key)
+
+	suite.NoError(err)
+	suite.Equal(&models.Principal{Service: "billing", Scopes: []string{auth.ScopeDebit}}, principal)
+}
+
+func (suite *authUseCaseSuite) TestAuthenticateKey_Unknown() {
+	suite.keys.On("GetKeyByHash", mock.Anything).Return(nil, auth.ErrKeyNotFound)
+
+	_, err := suite.useCase.AuthenticateKey(keyPrefix + "revoked")
+	suite.Equal(auth.ErrUnauthenticated, err)
+
+	_, err = suite.useCase.AuthenticateKey("not a key")
+	suite.Equal(auth.ErrUnauthenticated, err)
+	suite.keys.AssertNumberOfCalls(suite.T(), "GetKeyByHash", 1)
+}
+
+func (suite *authUseCaseSuite) TestUserToken() {
+	token, err := suite.useCase.IssueUserToken(1)
+	suite.NoError(err)
+	suite.Equal(suite.now.Add(userTokenLifetime), token.ExpiresAt)
+
+	principal, err := suite.useCase.AuthenticateToken(token.Token)
+	suite.NoError(err)
+	suite.Equal(&models.Principal{UserId: 1, Scopes: auth.UserScopes}, principal)
+}
+
+func (suite *authUseCaseSuite) TestUserToken_Expired() {
+	token, err := suite.useCase.IssueUserToken(1)
+	suite.NoError(err)
+
+	suite.now = suite.now.Add(userTokenLifetime)
+	_, err = suite.useCase.AuthenticateToken(token.Token)
+	suite.Equal(auth.ErrUnauthenticated, err)
+}
+
+func (suite *authUseCaseSuite) TestUserToken_OtherSecret() {
+	token, err := NewAuthUseCase(suite.keys, "other").IssueUserToken(1)
+	suite.NoError(err)
+
+	_, err = suite.useCase.AuthenticateToken(token.Token)
+	suite.Equal(auth.ErrUnauthenticated, err)
+}
+
+func (suite *authUseCaseSuite) TestUserToken_NoneAlgorithm() {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, userClaims{
+		Scope: auth.ScopeTransfer,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "1",
+			ExpiresAt: jwt.NewNumericDate(suite.now.Add(time.Hour)),
+		},
+	}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	suite.NoError(err)
+
+	_, err = suite.useCase.AuthenticateToken(token)
+	suite.Equal(auth.ErrUnauthenticated, err)
+}
+
+func (suite *authUseCaseSuite) TestUserToken_ExtraScopesIgnored() {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, userClaims{
+		Scope: "balance:read balance:debit balance:credit",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "1",
+			ExpiresAt: jwt.NewNumericDate(suite.now.Add(time.Hour)),
+		},
+	}).SignedString([]byte("secret"))
+	suite.NoError(err)
+
+	principal, err := suite.useCase.AuthenticateToken(token)
+	suite.NoError(err)
+	suite.Equal([]string{auth.ScopeBalanceRead}, principal.Scopes)
+}
+
+func (suite *authUseCaseSuite) TestUserToken_Disabled() {
+	useCase := NewAuthUseCase(suite.keys, "")
+
+	_, err := useCase.IssueUserToken(1)
+	suite.Equal(auth.ErrTokensDisabled, err)
+
+	_, err = useCase.AuthenticateToken("token")
+	suite.Equal(auth.ErrUnauthenticated, err)
+}
+
+func TestAuthUseCase(t *testing.T) {
+	suite.Run(t, new(authUseCaseSuite))
+}
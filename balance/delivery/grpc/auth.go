@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"avito-intership/auth"
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// authenticate определяет вызывающего по метаданным вызова так же, как HTTP API - по заголовкам;
+// metadata.MD.Get не учитывает регистр ключа
+func authenticate(ctx context.Context, uc auth.AuthUseCase) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	first := func(key string) string {
+		if values := md.Get(key); len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+
+	principal, err := auth.Authenticate(uc, first(auth.ApiKeyHeader), first("authorization"))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return auth.NewContext(ctx, principal), nil
+}
+
+// UnaryAuthInterceptor отклоняет вызовы без действующего ключа API или токена пользователя
+func UnaryAuthInterceptor(uc auth.AuthUseCase) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authenticate(ctx, uc)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuthInterceptor отклоняет потоковые вызовы без действующего ключа API или токена пользователя
+func StreamAuthInterceptor(uc auth.AuthUseCase) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		ctx, err := authenticate(stream.Context(), uc)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
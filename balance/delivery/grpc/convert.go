@@ -0,0 +1,88 @@
+package grpc
+
+import (
+	"avito-intership/balance/delivery/grpc/pb"
+	"avito-intership/models"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toBalance(userBalance *models.Balance) *pb.Balance {
+	result := &pb.Balance{
+		UserId:   userBalance.UserId,
+		Amount:   userBalance.Amount,
+		Bonus:    userBalance.Bonus,
+		Currency: userBalance.Currency,
+	}
+	if userBalance.BonusExpiresAt != nil {
+		result.BonusExpiresAt = timestamppb.New(*userBalance.BonusExpiresAt)
+	}
+	if userBalance.Rate != nil {
+		result.Rate = &pb.Rate{
+			Value:     userBalance.Rate.Value,
+			Applied:   userBalance.AppliedRate,
+			UpdatedAt: timestamppb.New(userBalance.Rate.UpdatedAt),
+			Age:       int64(userBalance.Rate.Age().Seconds()),
+			Stale:     userBalance.Rate.Stale,
+		}
+	}
+
+	return result
+}
+
+func toConversion(conversion *models.Conversion) *pb.Conversion {
+	if conversion == nil {
+		return nil
+	}
+
+	result := &pb.Conversion{
+		Amount:      conversion.Amount,
+		From:        conversion.From,
+		Currency:    conversion.Currency,
+		AppliedRate: conversion.AppliedRate,
+		Fee:         conversion.Fee,
+	}
+	if conversion.Rate != nil {
+		result.Rate = conversion.Rate.Value
+	}
+
+	return result
+}
+
+func toPendingOperation(operation *models.PendingOperation) *pb.PendingOperation {
+	return &pb.PendingOperation{
+		Id:        operation.Id,
+		ExpiresAt: timestamppb.New(operation.ExpiresAt),
+	}
+}
+
+func toPayouts(payouts []*models.Payout) []*pb.Payout {
+	result := make([]*pb.Payout, 0, len(payouts))
+	for _, payout := range payouts {
+		result = append(result, &pb.Payout{UserId: payout.UserId, Amount: payout.Amount, Fee: payout.Fee})
+	}
+
+	return result
+}
+
+func toTransaction(transaction *models.Transaction) *pb.Transaction {
+	result := &pb.Transaction{
+		Id:        transaction.Id,
+		UserId:    transaction.UserId,
+		Amount:    transaction.Amount,
+		TargetId:  transaction.TargetId,
+		Type:      transaction.Type,
+		Time:      timestamppb.New(transaction.Time),
+		Status:    transaction.Status,
+		Fee:       transaction.Fee,
+		Bonus:     transaction.Bonus,
+		Converted: toConversion(transaction.Converted),
+	}
+	if transaction.Reference != nil {
+		result.Reference = *transaction.Reference
+	}
+	if transaction.ProductName != nil {
+		result.ProductName = *transaction.ProductName
+	}
+
+	return result
+}
@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/balance"
+	"errors"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"log"
+	"strconv"
+	"time"
+)
+
+var grpcCodes = map[apperror.Code]codes.Code{
+	apperror.InvalidArgument:   codes.InvalidArgument,
+	apperror.Unauthorized:      codes.Unauthenticated,
+	apperror.PermissionDenied:  codes.PermissionDenied,
+	apperror.NotFound:          codes.NotFound,
+	apperror.Conflict:          codes.FailedPrecondition,
+	apperror.InsufficientFunds: codes.FailedPrecondition,
+	apperror.LimitExceeded:     codes.ResourceExhausted,
+	apperror.ConversionFailed:  codes.Unavailable,
+	apperror.TransferHeld:      codes.FailedPrecondition,
+	apperror.ApprovalRequired:  codes.FailedPrecondition,
+	apperror.Unavailable:       codes.Unavailable,
+	apperror.Internal:          codes.Internal,
+}
+
+// errorDomain - домен стабильных кодов ошибок в ErrorInfo
+const errorDomain = "balance.v1"
+
+// statusError переводит ошибку use case в код gRPC, соответствующий коду ответа HTTP API.
+// Стабильный код ошибки, как поле code в ответах HTTP API, передается в деталях ErrorInfo
+func statusError(err error) error {
+	appErr := apperror.From(err)
+	code, ok := grpcCodes[appErr.Code]
+	if !ok || code == codes.Internal {
+		log.Println(err)
+		return status.Error(codes.Internal, "Server error")
+	}
+
+	errStatus := status.New(code, appErr.Message)
+
+	// Вместо заголовка Retry-After время до сброса лимита передается в RetryInfo
+	var limitErr *balance.LimitExceededError
+	if errors.As(err, &limitErr) {
+		detailed, detailsErr := errStatus.WithDetails(&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(time.Duration(limitErr.RetryAfter()) * time.Second),
+		})
+		if detailsErr != nil {
+			log.Println(detailsErr)
+			return errStatus.Err()
+		}
+		errStatus = detailed
+	}
+
+	metadata := map[string]string{"retryable": strconv.FormatBool(appErr.Retryable)}
+	if appErr.Param != "" {
+		metadata["param"] = appErr.Param
+	}
+	detailed, detailsErr := errStatus.WithDetails(&errdetails.ErrorInfo{
+		Reason:   string(appErr.Code),
+		Domain:   errorDomain,
+		Metadata: metadata,
+	})
+	if detailsErr != nil {
+		log.Println(detailsErr)
+		return errStatus.Err()
+	}
+
+	return detailed.Err()
+}
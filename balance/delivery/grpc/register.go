@@ -0,0 +1,11 @@
+package grpc
+
+import (
+	"avito-intership/balance"
+	"avito-intership/balance/delivery/grpc/pb"
+	"google.golang.org/grpc"
+)
+
+func RegisterService(server *grpc.Server, uc balance.UseCase) {
+	pb.RegisterBalanceServiceServer(server, NewServer(uc))
+}
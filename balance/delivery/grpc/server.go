@@ -0,0 +1,232 @@
+package grpc
+
+import (
+	"avito-intership/auth"
+	"avito-intership/balance"
+	"avito-intership/balance/delivery/grpc/pb"
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"context"
+	"errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPerPage int64 = 100
+	maxPerPage     int64 = 1000
+)
+
+// Server реализует pb.BalanceServiceServer поверх того же balance.UseCase, что и HTTP API
+type Server struct {
+	pb.UnimplementedBalanceServiceServer
+	useCase balance.UseCase
+}
+
+func NewServer(useCase balance.UseCase) *Server {
+	return &Server{
+		useCase: useCase,
+	}
+}
+
+func badArgument(name string) error {
+	return status.Error(codes.InvalidArgument, "Bad "+name+" argument")
+}
+
+// parseCurrency возвращает валюту запроса, по умолчанию рубли
+func parseCurrency(currency string) (string, error) {
+	if currency == "" {
+		return exchange.RUB, nil
+	}
+
+	// Неизвестная валюта отклоняется до обращения к источнику курсов
+	if !exchange.IsSupported(currency) {
+		return "", badArgument("currency")
+	}
+
+	return currency, nil
+}
+
+func (s *Server) GetBalance(ctx context.Context, request *pb.GetBalanceRequest) (*pb.Balance, error) {
+	if request.UserId <= 0 {
+		return nil, badArgument("user_id")
+	}
+
+	if err := auth.Authorize(ctx, auth.ScopeBalanceRead, request.UserId); err != nil {
+		return nil, statusError(err)
+	}
+
+	currency, err := parseCurrency(request.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// В отличие от HTTP API, при ошибке конвертации баланс в рублях не возвращается: ответ gRPC с ошибкой
+	// не несет данных, клиент получает UNAVAILABLE с кодом CONVERSION_FAILED и может запросить баланс в рублях
+	userBalance, err := s.useCase.GetBalance(request.UserId, currency)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return toBalance(userBalance), nil
+}
+
+func (s *Server) ChangeBalance(ctx context.Context, request *pb.ChangeBalanceRequest) (*pb.ChangeBalanceResponse, error) {
+	if request.UserId <= 0 {
+		return nil, badArgument("user_id")
+	}
+
+	scope := auth.ScopeCredit
+	if request.Amount < 0 {
+		scope = auth.ScopeDebit
+	}
+	if err := auth.Authorize(ctx, scope, request.UserId); err != nil {
+		return nil, statusError(err)
+	}
+
+	product := balance.RefillId
+	if request.Amount < 0 {
+		if request.ProductId < 0 {
+			return nil, badArgument("product_id")
+		}
+		product = request.ProductId
+	}
+
+	currency, err := parseCurrency(request.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	var conversion *models.Conversion
+	if currency == exchange.RUB {
+		err = s.useCase.ChangeBalance(request.UserId, request.Amount, product)
+	} else {
+		conversion, err = s.useCase.ChangeBalanceInCurrency(request.UserId, request.Amount, product, currency)
+	}
+
+	var approvalErr *balance.ApprovalRequiredError
+	if errors.As(err, &approvalErr) {
+		return &pb.ChangeBalanceResponse{
+			Status:           pb.OperationStatus_OPERATION_STATUS_PENDING_APPROVAL,
+			PendingOperation: toPendingOperation(approvalErr.Operation),
+		}, nil
+	}
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pb.ChangeBalanceResponse{
+		Status:     pb.OperationStatus_OPERATION_STATUS_COMPLETED,
+		Conversion: toConversion(conversion),
+	}, nil
+}
+
+func (s *Server) TransferMoney(ctx context.Context, request *pb.TransferMoneyRequest) (*pb.TransferMoneyResponse, error) {
+	if request.SrcUserId <= 0 {
+		return nil, badArgument("src_user_id")
+	}
+	if request.DstUserId <= 0 {
+		return nil, badArgument("dst_user_id")
+	}
+	if request.Amount <= 0 {
+		return nil, badArgument("amount")
+	}
+
+	if err := auth.Authorize(ctx, auth.ScopeTransfer, request.SrcUserId); err != nil {
+		return nil, statusError(err)
+	}
+
+	var payouts []*models.Payout
+	var err error
+	if request.Commission == nil {
+		err = s.useCase.TransferMoney(request.SrcUserId, request.DstUserId, request.Amount)
+	} else {
+		payouts, err = s.useCase.TransferMoneyWithCommission(request.SrcUserId, request.DstUserId, request.Amount,
+			&models.Commission{Percent: request.Commission.Percent, Min: request.Commission.Min,
+				Fixed: request.Commission.Fixed})
+	}
+
+	var approvalErr *balance.ApprovalRequiredError
+	var heldErr *balance.TransferHeldError
+	if errors.As(err, &approvalErr) {
+		return &pb.TransferMoneyResponse{
+			Status:           pb.OperationStatus_OPERATION_STATUS_PENDING_APPROVAL,
+			PendingOperation: toPendingOperation(approvalErr.Operation),
+		}, nil
+	}
+	if errors.As(err, &heldErr) {
+		return &pb.TransferMoneyResponse{
+			Status:   pb.OperationStatus_OPERATION_STATUS_HELD_FOR_REVIEW,
+			ReviewId: heldErr.Review.Id,
+		}, nil
+	}
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pb.TransferMoneyResponse{
+		Status:  pb.OperationStatus_OPERATION_STATUS_COMPLETED,
+		Payouts: toPayouts(payouts),
+	}, nil
+}
+
+// GetHistory читает историю страницами по per_page и отправляет операции по одной,
+// пока не встретится неполная страница или клиент не отменит вызов
+func (s *Server) GetHistory(request *pb.GetHistoryRequest, stream pb.BalanceService_GetHistoryServer) error {
+	if request.UserId <= 0 {
+		return badArgument("user_id")
+	}
+
+	if err := auth.Authorize(stream.Context(), auth.ScopeBalanceRead, request.UserId); err != nil {
+		return statusError(err)
+	}
+
+	page := request.Page
+	if page == 0 {
+		page = 1
+	}
+	if page < 0 {
+		return badArgument("page")
+	}
+
+	perPage := request.PerPage
+	if perPage == 0 {
+		perPage = defaultPerPage
+	}
+	if perPage < 0 || perPage > maxPerPage {
+		return badArgument("per_page")
+	}
+
+	currency, err := parseCurrency(request.Currency)
+	if err != nil {
+		return err
+	}
+
+	sort := balance.SortDate
+	if request.Sort == pb.HistorySort_HISTORY_SORT_AMOUNT {
+		sort = balance.SortAmount
+	}
+
+	for ; ; page++ {
+		if err = stream.Context().Err(); err != nil {
+			return status.FromContextError(err).Err()
+		}
+
+		// При ошибке конвертации операции отправляются в рублях без поля converted
+		transactions, err := s.useCase.GetHistory(request.UserId, page, perPage, sort, request.Desc, currency)
+		if err != nil && err != balance.ErrConversion {
+			return statusError(err)
+		}
+
+		for _, transaction := range transactions {
+			err = stream.Send(toTransaction(transaction))
+			if err != nil {
+				return err
+			}
+		}
+
+		if int64(len(transactions)) < perPage {
+			return nil
+		}
+	}
+}
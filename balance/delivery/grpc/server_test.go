@@ -0,0 +1,292 @@
+package grpc
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/auth"
+	"avito-intership/balance"
+	"avito-intership/balance/delivery/grpc/pb"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+type serverSuite struct {
+	suite.Suite
+
+	useCase    *mocks.UseCase
+	auth       *mocks.AuthUseCase
+	grpcServer *grpc.Server
+	connection *grpc.ClientConn
+	client     pb.BalanceServiceClient
+	// Вызовы от имени сервиса со всеми правами
+	ctx context.Context
+}
+
+func (suite *serverSuite) SetupTest() {
+	suite.useCase = new(mocks.UseCase)
+	suite.auth = new(mocks.AuthUseCase)
+	suite.auth.On("AuthenticateKey", "ak_billing").Return(&models.Principal{Service: auth.BillingService,
+		Scopes: []string{auth.ScopeBalanceRead, auth.ScopeTransfer, auth.ScopeCredit, auth.ScopeDebit}}, nil)
+	suite.auth.On("AuthenticateToken", "user-1").
+		Return(&models.Principal{UserId: 1, Scopes: auth.UserScopes}, nil)
+	suite.ctx = metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "ak_billing")
+
+	listener := bufconn.Listen(1 << 20)
+	suite.grpcServer = grpc.NewServer(grpc.UnaryInterceptor(UnaryAuthInterceptor(suite.auth)),
+		grpc.StreamInterceptor(StreamAuthInterceptor(suite.auth)))
+	RegisterService(suite.grpcServer, suite.useCase)
+	go func() {
+		_ = suite.grpcServer.Serve(listener)
+	}()
+
+	connection, err := grpc.Dial("bufnet", grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.Dial()
+		}))
+	suite.Require().NoError(err)
+
+	suite.connection = connection
+	suite.client = pb.NewBalanceServiceClient(connection)
+}
+
+func (suite *serverSuite) TearDownTest() {
+	_ = suite.connection.Close()
+	suite.grpcServer.Stop()
+}
+
+func (suite *serverSuite) TestGetBalance() {
+	expiresAt := time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC)
+	suite.useCase.On("GetBalance", int64(1), "RUB").
+		Return(&models.Balance{UserId: 1, Amount: 1000, Bonus: 50, BonusExpiresAt: &expiresAt, Currency: "RUB"}, nil)
+
+	response, err := suite.client.GetBalance(suite.ctx, &pb.GetBalanceRequest{UserId: 1})
+
+	suite.NoError(err)
+	suite.Equal(float32(1000), response.Amount)
+	suite.Equal(float32(50), response.Bonus)
+	suite.True(expiresAt.Equal(response.BonusExpiresAt.AsTime()))
+}
+
+func (suite *serverSuite) TestGetBalance_ConversionFailed() {
+	suite.useCase.On("GetBalance", int64(1), "USD").
+		Return(&models.Balance{UserId: 1, Amount: 1000, Currency: "RUB"}, balance.ErrConversion)
+
+	_, err := suite.client.GetBalance(suite.ctx, &pb.GetBalanceRequest{UserId: 1, Currency: "USD"})
+
+	suite.Equal(codes.Unavailable, status.Code(err))
+	details := status.Convert(err).Details()
+	suite.Require().Len(details, 1)
+	errorInfo, ok := details[0].(*errdetails.ErrorInfo)
+	suite.Require().True(ok)
+	suite.Equal(string(apperror.ConversionFailed), errorInfo.Reason)
+	suite.Equal("true", errorInfo.Metadata["retryable"])
+}
+
+func (suite *serverSuite) TestGetBalance_BadArguments() {
+	_, err := suite.client.GetBalance(suite.ctx, &pb.GetBalanceRequest{UserId: 0})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = suite.client.GetBalance(suite.ctx, &pb.GetBalanceRequest{UserId: 1, Currency: "XXX"})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+	suite.useCase.AssertNotCalled(suite.T(), "GetBalance", mock.Anything, mock.Anything)
+}
+
+func (suite *serverSuite) TestChangeBalance() {
+	suite.useCase.On("ChangeBalance", int64(1), float32(-100), int64(5)).Return(nil)
+
+	response, err := suite.client.ChangeBalance(suite.ctx,
+		&pb.ChangeBalanceRequest{UserId: 1, Amount: -100, ProductId: 5})
+
+	suite.NoError(err)
+	suite.Equal(pb.OperationStatus_OPERATION_STATUS_COMPLETED, response.Status)
+	suite.Nil(response.Conversion)
+}
+
+func (suite *serverSuite) TestChangeBalance_InCurrency() {
+	suite.useCase.On("ChangeBalanceInCurrency", int64(1), float32(10), balance.RefillId, "USD").
+		Return(&models.Conversion{Amount: 735, From: "USD", Currency: "RUB", Rate: &models.Rate{Value: 73.5}}, nil)
+
+	response, err := suite.client.ChangeBalance(suite.ctx,
+		&pb.ChangeBalanceRequest{UserId: 1, Amount: 10, ProductId: 5, Currency: "USD"})
+
+	suite.NoError(err)
+	suite.Equal(float32(735), response.Conversion.Amount)
+	suite.Equal(float32(73.5), response.Conversion.Rate)
+}
+
+func (suite *serverSuite) TestChangeBalance_PendingApproval() {
+	expiresAt := time.Date(2021, 11, 19, 2, 16, 0, 0, time.UTC)
+	suite.useCase.On("ChangeBalance", int64(1), float32(200000), balance.RefillId).
+		Return(&balance.ApprovalRequiredError{Operation: &models.PendingOperation{Id: 3, ExpiresAt: expiresAt}})
+
+	response, err := suite.client.ChangeBalance(suite.ctx,
+		&pb.ChangeBalanceRequest{UserId: 1, Amount: 200000})
+
+	suite.NoError(err)
+	suite.Equal(pb.OperationStatus_OPERATION_STATUS_PENDING_APPROVAL, response.Status)
+	suite.Equal(int64(3), response.PendingOperation.Id)
+	suite.True(expiresAt.Equal(response.PendingOperation.ExpiresAt.AsTime()))
+}
+
+func (suite *serverSuite) TestChangeBalance_Errors() {
+	suite.useCase.On("ChangeBalance", int64(1), float32(-100), int64(5)).Return(balance.ErrTooLowBalance)
+	suite.useCase.On("ChangeBalance", int64(2), float32(-100), int64(5)).Return(io.ErrUnexpectedEOF)
+	suite.useCase.On("ChangeBalanceInCurrency", int64(1), float32(-1), int64(5), "USD").
+		Return(nil, balance.ErrRateUnavailable)
+
+	_, err := suite.client.ChangeBalance(suite.ctx,
+		&pb.ChangeBalanceRequest{UserId: 1, Amount: -100, ProductId: 5})
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+
+	_, err = suite.client.ChangeBalance(suite.ctx,
+		&pb.ChangeBalanceRequest{UserId: 2, Amount: -100, ProductId: 5})
+	suite.Equal(codes.Internal, status.Code(err))
+	suite.Equal("Server error", status.Convert(err).Message(), "internal errors are not exposed")
+
+	_, err = suite.client.ChangeBalance(suite.ctx,
+		&pb.ChangeBalanceRequest{UserId: 1, Amount: -1, ProductId: 5, Currency: "USD"})
+	suite.Equal(codes.Unavailable, status.Code(err))
+
+	_, err = suite.client.ChangeBalance(suite.ctx,
+		&pb.ChangeBalanceRequest{UserId: 1, Amount: -1, ProductId: -1})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *serverSuite) TestTransferMoney() {
+	suite.useCase.On("TransferMoneyWithCommission", int64(1), int64(2), float32(100),
+		&models.Commission{Percent: 1}).
+		Return([]*models.Payout{{UserId: 2, Amount: 100, Fee: 1}}, nil)
+
+	response, err := suite.client.TransferMoney(suite.ctx, &pb.TransferMoneyRequest{
+		SrcUserId: 1, DstUserId: 2, Amount: 100, Commission: &pb.Commission{Percent: 1}})
+
+	suite.NoError(err)
+	suite.Equal(pb.OperationStatus_OPERATION_STATUS_COMPLETED, response.Status)
+	suite.Len(response.Payouts, 1)
+	suite.Equal(float32(1), response.Payouts[0].Fee)
+}
+
+func (suite *serverSuite) TestTransferMoney_Held() {
+	suite.useCase.On("TransferMoney", int64(1), int64(2), float32(100)).
+		Return(&balance.TransferHeldError{Review: &models.TransferReview{Id: 9}})
+
+	response, err := suite.client.TransferMoney(suite.ctx,
+		&pb.TransferMoneyRequest{SrcUserId: 1, DstUserId: 2, Amount: 100})
+
+	suite.NoError(err)
+	suite.Equal(pb.OperationStatus_OPERATION_STATUS_HELD_FOR_REVIEW, response.Status)
+	suite.Equal(int64(9), response.ReviewId)
+}
+
+func (suite *serverSuite) TestTransferMoney_Errors() {
+	suite.useCase.On("TransferMoney", int64(1), int64(2), float32(100)).Return(balance.ErrTransferBlocked)
+	suite.useCase.On("TransferMoney", int64(1), int64(3), float32(100)).Return(&balance.LimitExceededError{
+		Limit: &models.Limit{Operation: "transfer", Period: "day"}, ResetsAt: time.Now().Add(time.Hour)})
+
+	_, err := suite.client.TransferMoney(suite.ctx,
+		&pb.TransferMoneyRequest{SrcUserId: 1, DstUserId: 2, Amount: 100})
+	suite.Equal(codes.PermissionDenied, status.Code(err))
+
+	_, err = suite.client.TransferMoney(suite.ctx,
+		&pb.TransferMoneyRequest{SrcUserId: 1, DstUserId: 3, Amount: 100})
+	suite.Equal(codes.ResourceExhausted, status.Code(err))
+	details := status.Convert(err).Details()
+	suite.Require().Len(details, 2)
+	retryInfo, ok := details[0].(*errdetails.RetryInfo)
+	suite.Require().True(ok)
+	suite.InDelta(time.Hour.Seconds(), retryInfo.RetryDelay.AsDuration().Seconds(), 2)
+	errorInfo, ok := details[1].(*errdetails.ErrorInfo)
+	suite.Require().True(ok)
+	suite.Equal(string(apperror.LimitExceeded), errorInfo.Reason)
+
+	_, err = suite.client.TransferMoney(suite.ctx,
+		&pb.TransferMoneyRequest{SrcUserId: 1, DstUserId: 2, Amount: 0})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *serverSuite) TestGetHistory() {
+	suite.useCase.On("GetHistory", int64(1), int64(1), int64(2), balance.SortAmount, true, "RUB").
+		Return([]*models.Transaction{{Id: 1}, {Id: 2}}, nil)
+	suite.useCase.On("GetHistory", int64(1), int64(2), int64(2), balance.SortAmount, true, "RUB").
+		Return([]*models.Transaction{{Id: 3}}, nil)
+
+	stream, err := suite.client.GetHistory(suite.ctx, &pb.GetHistoryRequest{UserId: 1, PerPage: 2,
+		Sort: pb.HistorySort_HISTORY_SORT_AMOUNT, Desc: true})
+	suite.Require().NoError(err)
+
+	ids := make([]int64, 0)
+	for {
+		transaction, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		suite.Require().NoError(err)
+		ids = append(ids, transaction.Id)
+	}
+
+	suite.Equal([]int64{1, 2, 3}, ids)
+	suite.useCase.AssertNumberOfCalls(suite.T(), "GetHistory", 2)
+}
+
+func (suite *serverSuite) TestGetHistory_Failed() {
+	suite.useCase.On("GetHistory", int64(1), int64(1), int64(100), balance.SortDate, false, "RUB").
+		Return(nil, io.ErrUnexpectedEOF)
+
+	stream, err := suite.client.GetHistory(suite.ctx, &pb.GetHistoryRequest{UserId: 1})
+	suite.Require().NoError(err)
+
+	_, err = stream.Recv()
+	suite.Equal(codes.Internal, status.Code(err))
+
+	stream, err = suite.client.GetHistory(suite.ctx, &pb.GetHistoryRequest{UserId: 1, PerPage: 5000})
+	suite.Require().NoError(err)
+
+	_, err = stream.Recv()
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *serverSuite) TestUnauthenticated() {
+	_, err := suite.client.GetBalance(context.Background(), &pb.GetBalanceRequest{UserId: 1})
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+
+	stream, err := suite.client.GetHistory(context.Background(), &pb.GetHistoryRequest{UserId: 1})
+	suite.Require().NoError(err)
+
+	_, err = stream.Recv()
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+	suite.useCase.AssertNotCalled(suite.T(), "GetBalance", mock.Anything, mock.Anything)
+}
+
+func (suite *serverSuite) TestUserToken() {
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer user-1")
+	suite.useCase.On("GetBalance", int64(1), "RUB").Return(&models.Balance{UserId: 1, Currency: "RUB"}, nil)
+
+	_, err := suite.client.GetBalance(ctx, &pb.GetBalanceRequest{UserId: 1})
+	suite.NoError(err)
+
+	_, err = suite.client.GetBalance(ctx, &pb.GetBalanceRequest{UserId: 2})
+	suite.Equal(codes.PermissionDenied, status.Code(err), "user token reads only the user's own balance")
+
+	_, err = suite.client.TransferMoney(ctx, &pb.TransferMoneyRequest{SrcUserId: 2, DstUserId: 1, Amount: 100})
+	suite.Equal(codes.PermissionDenied, status.Code(err))
+
+	_, err = suite.client.ChangeBalance(ctx, &pb.ChangeBalanceRequest{UserId: 1, Amount: -100, ProductId: 1})
+	suite.Equal(codes.PermissionDenied, status.Code(err), "only billing debits for products")
+	suite.useCase.AssertNotCalled(suite.T(), "ChangeBalance", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestServer(t *testing.T) {
+	suite.Run(t, new(serverSuite))
+}
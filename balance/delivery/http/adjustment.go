@@ -0,0 +1,130 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/auth"
+	"avito-intership/balance"
+	"avito-intership/models"
+	"avito-intership/problem"
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+// AdjustmentStatus - ответ на корректировку, ожидающую одобрения
+type AdjustmentStatus struct {
+	PendingStatus
+	Adjustment *models.Adjustment `json:"adjustment"`
+}
+
+type AdjustmentHandler struct {
+	Handler
+	adjustments balance.AdjustmentUseCase
+}
+
+func NewAdjustmentHandler(useCase balance.UseCase, adjustments balance.AdjustmentUseCase) *AdjustmentHandler {
+	return &AdjustmentHandler{
+		Handler:     Handler{useCase: useCase},
+		adjustments: adjustments,
+	}
+}
+
+func (h AdjustmentHandler) writeJSON(value interface{}, w http.ResponseWriter) {
+	w.Header().Add("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		problem.Write(w, err)
+	}
+}
+
+func (h AdjustmentHandler) AdjustEndpoint(w http.ResponseWriter, r *http.Request) {
+	operator, err := auth.Operator(r.Context())
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	userId, err := strconv.ParseInt(r.FormValue("user_id"), 10, 64)
+	if err != nil || userId <= 0 {
+		problem.Write(w, apperror.BadArgument("user_id"))
+		return
+	}
+
+	amount, err := strconv.ParseFloat(r.FormValue("amount"), 32)
+	if err != nil {
+		problem.Write(w, apperror.BadArgument("amount"))
+		return
+	}
+
+	adjustment, err := h.adjustments.Adjust(&models.Adjustment{
+		UserId:   userId,
+		Amount:   float32(amount),
+		Operator: operator,
+		Reason:   r.FormValue("reason"),
+		Comment:  r.FormValue("comment"),
+	})
+
+	var approvalErr *balance.ApprovalRequiredError
+	if errors.As(err, &approvalErr) {
+		message := err.Error()
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(AdjustmentStatus{
+			PendingStatus{StatusMessage{Success: false, Message: &message}, apperror.ApprovalRequired,
+				approvalErr.Operation}, adjustment})
+		return
+	}
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(adjustment, w)
+}
+
+func (h AdjustmentHandler) GetAdjustmentsEndpoint(w http.ResponseWriter, r *http.Request) {
+	filter := &models.AdjustmentFilter{
+		Operator: r.FormValue("operator"),
+		Reason:   r.FormValue("reason"),
+	}
+
+	if userId := r.FormValue("user_id"); userId != "" {
+		id, err := strconv.ParseInt(userId, 10, 64)
+		if err != nil || id <= 0 {
+			problem.Write(w, apperror.BadArgument("user_id"))
+			return
+		}
+		filter.UserId = id
+	}
+
+	if filter.Reason != "" && !balance.IsAdjustmentReason(filter.Reason) {
+		problem.Write(w, apperror.BadArgument("reason"))
+		return
+	}
+
+	adjustments, err := h.adjustments.GetAdjustments(filter)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(adjustments, w)
+}
+
+func (h AdjustmentHandler) GetAdjustmentEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil || id <= 0 {
+		problem.Write(w, apperror.BadArgument("id"))
+		return
+	}
+
+	adjustment, err := h.adjustments.GetAdjustment(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(adjustment, w)
+}
@@ -0,0 +1,194 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/auth"
+	"avito-intership/balance"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"avito-intership/server/middleware"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+type adjustmentHandlerSuite struct {
+	suite.Suite
+
+	adjustments   *mocks.AdjustmentUseCase
+	testingServer *httptest.Server
+}
+
+func (suite *adjustmentHandlerSuite) SetupSuite() {
+	adjustments := new(mocks.AdjustmentUseCase)
+
+	router := mux.NewRouter()
+	router.Use(middleware.WithPrincipal(&models.Principal{Service: "alice", Scopes: []string{auth.ScopeOperator}}))
+	RegisterAdminEndpoints(router.PathPrefix("/api/v1/admin").Subrouter(), new(mocks.UseCase),
+		new(mocks.ApprovalUseCase), adjustments)
+
+	suite.testingServer = httptest.NewServer(router)
+	suite.adjustments = adjustments
+}
+
+func (suite *adjustmentHandlerSuite) TearDownSuite() {
+	defer suite.testingServer.Close()
+}
+
+func (suite *adjustmentHandlerSuite) TestAdjust() {
+	suite.adjustments.On("Adjust", mock.MatchedBy(func(a *models.Adjustment) bool {
+		return a.UserId == 1 && a.Amount == -100 && a.Operator == "alice" &&
+			a.Reason == balance.ReasonChargeback && a.Comment == "bank chargeback"
+	})).Return(&models.Adjustment{Id: 1, UserId: 1, Amount: -100, Status: balance.StatusCompleted}, nil)
+
+	data := url.Values{}
+	data.Set("user_id", "1")
+	data.Set("amount", "-100")
+	data.Set("reason", balance.ReasonChargeback)
+	data.Set("comment", "bank chargeback")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/adjustments", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.Adjustment
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(int64(1), responseBody.Id)
+}
+
+func (suite *adjustmentHandlerSuite) TestAdjust_ApprovalRequired() {
+	operationId := int64(7)
+	suite.adjustments.On("Adjust", mock.MatchedBy(func(a *models.Adjustment) bool {
+		return a.UserId == 2
+	})).Return(&models.Adjustment{Id: 2, Status: balance.StatusPending, OperationId: &operationId},
+		&balance.ApprovalRequiredError{Operation: &models.PendingOperation{Id: operationId}})
+
+	data := url.Values{}
+	data.Set("user_id", "2")
+	data.Set("amount", "500000")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/adjustments", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody AdjustmentStatus
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusAccepted, response.StatusCode)
+	suite.Equal(apperror.ApprovalRequired, responseBody.Code)
+	suite.Equal(operationId, responseBody.Operation.Id)
+	suite.Equal(int64(2), responseBody.Adjustment.Id)
+}
+
+func (suite *adjustmentHandlerSuite) TestAdjust_Errors() {
+	cases := []struct {
+		userId int64
+		err    error
+		status int
+	}{
+		{userId: 3, err: balance.ErrBadAdjustment, status: http.StatusBadRequest},
+		{userId: 4, err: balance.ErrTooLowBalance, status: http.StatusConflict},
+	}
+
+	for _, c := range cases {
+		suite.Run(c.err.Error(), func() {
+			userId := c.userId
+			suite.adjustments.On("Adjust", mock.MatchedBy(func(a *models.Adjustment) bool {
+				return a.UserId == userId
+			})).Return(nil, c.err)
+
+			data := url.Values{}
+			data.Set("user_id", fmt.Sprint(c.userId))
+			data.Set("amount", "-10")
+
+			response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/adjustments", suite.testingServer.URL), data)
+			suite.NoError(err, "request should not produce error")
+			defer response.Body.Close()
+
+			suite.Equal(c.status, response.StatusCode)
+		})
+	}
+}
+
+func (suite *adjustmentHandlerSuite) TestAdjust_BadAmount() {
+	data := url.Values{}
+	data.Set("user_id", "1")
+	data.Set("amount", "ten")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/adjustments", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *adjustmentHandlerSuite) TestAdjust_NoOperator() {
+	adjustments := new(mocks.AdjustmentUseCase)
+	router := mux.NewRouter()
+	router.Use(middleware.WithPrincipal(&models.Principal{Service: "billing", Scopes: []string{auth.ScopeCredit}}))
+	RegisterAdminEndpoints(router.PathPrefix("/api/v1/admin").Subrouter(), new(mocks.UseCase),
+		new(mocks.ApprovalUseCase), adjustments)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	data := url.Values{}
+	data.Set("user_id", "1")
+	data.Set("amount", "-100")
+	data.Set("operator", "alice")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/adjustments", server.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusForbidden, response.StatusCode)
+	adjustments.AssertNotCalled(suite.T(), "Adjust", mock.Anything)
+}
+
+func (suite *adjustmentHandlerSuite) TestGetAdjustments_Filter() {
+	suite.adjustments.On("GetAdjustments", &models.AdjustmentFilter{UserId: 5, Operator: "alice",
+		Reason: balance.ReasonGoodwill}).Return([]*models.Adjustment{{Id: 1}, {Id: 2}}, nil)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/admin/adjustments?user_id=5&operator=alice&reason=%s",
+		suite.testingServer.URL, balance.ReasonGoodwill))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody []*models.Adjustment
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Len(responseBody, 2)
+}
+
+func (suite *adjustmentHandlerSuite) TestGetAdjustments_BadReason() {
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/admin/adjustments?reason=gift", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *adjustmentHandlerSuite) TestGetAdjustment_NotFound() {
+	suite.adjustments.On("GetAdjustment", int64(404)).Return(nil, balance.ErrAdjustmentNotFound)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/admin/adjustments/404", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusNotFound, response.StatusCode)
+}
+
+func TestAdjustmentHandler(t *testing.T) {
+	suite.Run(t, new(adjustmentHandlerSuite))
+}
@@ -0,0 +1,39 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/problem"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// GrantBonusEndpoint начисляет пользователю бонусы, сгорающие через days дней
+func (h Handler) GrantBonusEndpoint(w http.ResponseWriter, r *http.Request) {
+	userId, err := strconv.ParseInt(r.FormValue("user"), 10, 64)
+	if err != nil || userId <= 0 {
+		problem.Write(w, apperror.BadArgument("user"))
+		return
+	}
+
+	amount, err := strconv.ParseFloat(r.FormValue("amount"), 32)
+	if err != nil {
+		problem.Write(w, apperror.BadArgument("amount"))
+		return
+	}
+
+	days, err := strconv.Atoi(r.FormValue("days"))
+	if err != nil {
+		problem.Write(w, apperror.BadArgument("days"))
+		return
+	}
+
+	bonus, err := h.useCase.GrantBonus(userId, float32(amount), days)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(bonus)
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/auth"
+	"avito-intership/balance"
+	"avito-intership/problem"
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type ApprovalHandler struct {
+	Handler
+	approvals balance.ApprovalUseCase
+}
+
+func NewApprovalHandler(useCase balance.UseCase, approvals balance.ApprovalUseCase) *ApprovalHandler {
+	return &ApprovalHandler{
+		Handler:   Handler{useCase: useCase},
+		approvals: approvals,
+	}
+}
+
+func (h ApprovalHandler) writeJSON(value interface{}, w http.ResponseWriter) {
+	w.Header().Add("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		problem.Write(w, err)
+	}
+}
+
+func (h ApprovalHandler) writeApprovalError(err error, w http.ResponseWriter) {
+	var limitErr *balance.LimitExceededError
+	if errors.As(err, &limitErr) {
+		h.writeLimitExceeded(limitErr, w)
+		return
+	}
+
+	problem.Write(w, err)
+}
+
+func (h ApprovalHandler) parseId(r *http.Request, w http.ResponseWriter) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil || id <= 0 {
+		problem.Write(w, apperror.BadArgument("id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+// GetOperationsEndpoint по умолчанию возвращает операции, ожидающие одобрения
+func (h ApprovalHandler) GetOperationsEndpoint(w http.ResponseWriter, r *http.Request) {
+	status := r.FormValue("status")
+	switch status {
+	case "":
+		status = balance.StatusPending
+	case "all":
+		status = ""
+	case balance.StatusPending, balance.StatusCompleted, balance.StatusRejected, balance.StatusExpired:
+	default:
+		problem.Write(w, apperror.BadArgument("status"))
+		return
+	}
+
+	operations, err := h.approvals.GetOperations(status)
+	if err != nil {
+		h.writeApprovalError(err, w)
+		return
+	}
+
+	h.writeJSON(operations, w)
+}
+
+func (h ApprovalHandler) GetOperationEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	operation, err := h.approvals.GetOperation(id)
+	if err != nil {
+		h.writeApprovalError(err, w)
+		return
+	}
+
+	h.writeJSON(operation, w)
+}
+
+func (h ApprovalHandler) ApproveEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	checker, err := auth.Operator(r.Context())
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	operation, err := h.approvals.Approve(id, checker, r.FormValue("note"))
+	if err != nil {
+		h.writeApprovalError(err, w)
+		return
+	}
+
+	h.writeJSON(operation, w)
+}
+
+func (h ApprovalHandler) RejectEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	checker, err := auth.Operator(r.Context())
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	operation, err := h.approvals.Reject(id, checker, r.FormValue("note"))
+	if err != nil {
+		h.writeApprovalError(err, w)
+		return
+	}
+
+	h.writeJSON(operation, w)
+}
@@ -0,0 +1,180 @@
+package http
+
+import (
+	"avito-intership/auth"
+	"avito-intership/balance"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"avito-intership/server/middleware"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type approvalHandlerSuite struct {
+	suite.Suite
+
+	approvals     *mocks.ApprovalUseCase
+	testingServer *httptest.Server
+}
+
+func (suite *approvalHandlerSuite) SetupSuite() {
+	approvals := new(mocks.ApprovalUseCase)
+
+	router := mux.NewRouter()
+	router.Use(middleware.WithPrincipal(&models.Principal{Service: "bob", Scopes: []string{auth.ScopeOperator}}))
+	RegisterAdminEndpoints(router.PathPrefix("/api/v1/admin").Subrouter(), new(mocks.UseCase), approvals,
+		new(mocks.AdjustmentUseCase))
+
+	suite.testingServer = httptest.NewServer(router)
+	suite.approvals = approvals
+}
+
+func (suite *approvalHandlerSuite) TearDownSuite() {
+	defer suite.testingServer.Close()
+}
+
+func (suite *approvalHandlerSuite) TestGetOperations_PendingByDefault() {
+	suite.approvals.On("GetOperations", balance.StatusPending).
+		Return([]*models.PendingOperation{{Id: 1, Status: balance.StatusPending}}, nil)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/admin/operations", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody []*models.PendingOperation
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Len(responseBody, 1)
+}
+
+func (suite *approvalHandlerSuite) TestGetOperations_BadStatus() {
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/admin/operations?status=done", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *approvalHandlerSuite) TestGetOperation_NotFound() {
+	suite.approvals.On("GetOperation", int64(404)).Return(nil, balance.ErrOperationNotFound)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/admin/operations/404", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusNotFound, response.StatusCode)
+}
+
+func (suite *approvalHandlerSuite) TestApprove() {
+	checker := "bob"
+	now := time.Now()
+	suite.approvals.On("Approve", int64(1), "bob", "verified").Return(&models.PendingOperation{Id: 1,
+		Status: balance.StatusCompleted, Checker: &checker, DecidedAt: &now}, nil)
+
+	// Проверяющий определяется ключом, имя из параметров запроса не учитывается
+	data := url.Values{}
+	data.Set("checker", "alice")
+	data.Set("note", "verified")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/operations/1/approve", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.PendingOperation
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(balance.StatusCompleted, responseBody.Status)
+	suite.Equal(checker, *responseBody.Checker)
+}
+
+func (suite *approvalHandlerSuite) TestApprove_Errors() {
+	cases := []struct {
+		id     int64
+		err    error
+		status int
+	}{
+		{id: 2, err: balance.ErrTooLowBalance, status: http.StatusConflict},
+		{id: 3, err: balance.ErrOperationExpired, status: http.StatusConflict},
+		{id: 4, err: balance.ErrOperationDecided, status: http.StatusConflict},
+		{id: 5, err: balance.ErrNoChecker, status: http.StatusBadRequest},
+		{id: 6, err: balance.ErrSameOperator, status: http.StatusForbidden},
+	}
+
+	for _, c := range cases {
+		suite.Run(c.err.Error(), func() {
+			suite.approvals.On("Approve", c.id, "bob", "").Return(nil, c.err)
+
+			response, err := http.PostForm(
+				fmt.Sprintf("%s/api/v1/admin/operations/%d/approve", suite.testingServer.URL, c.id), url.Values{})
+			suite.NoError(err, "request should not produce error")
+			defer response.Body.Close()
+
+			suite.Equal(c.status, response.StatusCode)
+		})
+	}
+}
+
+func (suite *approvalHandlerSuite) TestReject() {
+	suite.approvals.On("Reject", int64(6), "bob", "suspicious").
+		Return(&models.PendingOperation{Id: 6, Status: balance.StatusRejected}, nil)
+
+	data := url.Values{}
+	data.Set("note", "suspicious")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/operations/6/reject", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+}
+
+func (suite *approvalHandlerSuite) TestDecide_NoOperator() {
+	cases := []struct {
+		principal *models.Principal
+		status    int
+	}{
+		{principal: nil, status: http.StatusUnauthorized},
+		{principal: &models.Principal{UserId: 1, Scopes: auth.UserScopes}, status: http.StatusForbidden},
+		{principal: &models.Principal{Service: "billing", Scopes: []string{auth.ScopeDebit}}, status: http.StatusForbidden},
+	}
+
+	for _, c := range cases {
+		approvals := new(mocks.ApprovalUseCase)
+		router := mux.NewRouter()
+		router.Use(middleware.WithPrincipal(c.principal))
+		RegisterAdminEndpoints(router.PathPrefix("/api/v1/admin").Subrouter(), new(mocks.UseCase), approvals,
+			new(mocks.AdjustmentUseCase))
+		server := httptest.NewServer(router)
+
+		for _, action := range []string{"approve", "reject"} {
+			data := url.Values{}
+			data.Set("checker", "alice")
+
+			response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/operations/1/%s", server.URL, action), data)
+			suite.NoError(err, "request should not produce error")
+			response.Body.Close()
+
+			suite.Equal(c.status, response.StatusCode)
+		}
+
+		server.Close()
+		approvals.AssertNotCalled(suite.T(), "Approve", mock.Anything, mock.Anything, mock.Anything)
+		approvals.AssertNotCalled(suite.T(), "Reject", mock.Anything, mock.Anything, mock.Anything)
+	}
+}
+
+func TestApprovalHandler(t *testing.T) {
+	suite.Run(t, new(approvalHandlerSuite))
+}
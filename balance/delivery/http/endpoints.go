@@ -1,13 +1,19 @@
 package http
 
 import (
+	"avito-intership/apperror"
+	"avito-intership/auth"
 	"avito-intership/balance"
 	"avito-intership/exchange"
+	"avito-intership/models"
+	"avito-intership/problem"
 	"encoding/json"
+	"errors"
 	"github.com/gorilla/mux"
-	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 )
 
 type Handler struct {
@@ -20,10 +26,24 @@ func NewHandler(useCase balance.UseCase) *Handler {
 	}
 }
 
+type Rate struct {
+	Value float32 `json:"value"`
+	// Курс с учетом спреда
+	Applied   float32   `json:"applied"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// Возраст курса в секундах
+	Age   int64 `json:"age"`
+	Stale bool  `json:"stale"`
+}
+
 type Balance struct {
 	Id     int64   `json:"id"`
 	Amount float32 `json:"amount"`
-	Error  *string `json:"error"`
+	// Бонусы, которые можно потратить только на услуги
+	Bonus          float32    `json:"bonus"`
+	BonusExpiresAt *time.Time `json:"bonus_expires_at"`
+	Currency       string     `json:"currency"`
+	Rate           *Rate      `json:"rate"`
 }
 
 type StatusMessage struct {
@@ -31,6 +51,44 @@ type StatusMessage struct {
 	Message *string `json:"message"`
 }
 
+type TransferStatus struct {
+	StatusMessage
+	Payouts []*models.Payout `json:"payouts"`
+}
+
+type ConversionStatus struct {
+	StatusMessage
+	Conversion *models.Conversion `json:"conversion"`
+}
+
+// HeldStatus - ответ на перевод, отправленный на ручную проверку
+type HeldStatus struct {
+	StatusMessage
+	Code     apperror.Code `json:"code"`
+	ReviewId int64         `json:"review_id"`
+}
+
+// PendingStatus - ответ на операцию, ожидающую одобрения оператором
+type PendingStatus struct {
+	StatusMessage
+	Code      apperror.Code            `json:"code"`
+	Operation *models.PendingOperation `json:"operation"`
+}
+
+// LimitProblem дополняет ошибку превышения лимита самим лимитом и моментом его сброса
+type LimitProblem struct {
+	*problem.Problem
+	Limit    *models.Limit `json:"limit"`
+	ResetsAt time.Time     `json:"resets_at"`
+}
+
+// ConversionProblem - ошибка конвертации вместе с результатом в рублях, который удалось получить
+type ConversionProblem struct {
+	*problem.Problem
+	Balance      *Balance              `json:"balance,omitempty"`
+	Transactions []*models.Transaction `json:"transactions,omitempty"`
+}
+
 func (h Handler) writeStatus(success bool, message *string, w *http.ResponseWriter) {
 	status := StatusMessage{
 		Success: success,
@@ -41,15 +99,26 @@ func (h Handler) writeStatus(success bool, message *string, w *http.ResponseWrit
 	_ = json.NewEncoder(*w).Encode(status)
 }
 
+// authorize проверяет право вызывающего на операцию со счетом userId и отвечает ошибкой, если права нет
+func (h Handler) authorize(scope string, userId int64, r *http.Request, w http.ResponseWriter) bool {
+	if err := auth.Authorize(r.Context(), scope, userId); err != nil {
+		problem.Write(w, err)
+		return false
+	}
+
+	return true
+}
+
 func (h Handler) GetBalanceEndpoint(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil || id <= 0 {
-		log.Println(err)
-		w.WriteHeader(http.StatusBadRequest)
-		message := "Bad id argument"
-		h.writeStatus(false, &message, &w)
+		problem.Write(w, apperror.BadArgument("id"))
+		return
+	}
+
+	if !h.authorize(auth.ScopeBalanceRead, id, r, w) {
 		return
 	}
 
@@ -58,27 +127,45 @@ func (h Handler) GetBalanceEndpoint(w http.ResponseWriter, r *http.Request) {
 		currency = exchange.RUB
 	}
 
-	amount, err := h.useCase.GetBalance(id, currency)
+	// Неизвестная валюта отклоняется до обращения к источнику курсов
+	if !exchange.IsSupported(currency) {
+		problem.Write(w, apperror.BadArgument("currency"))
+		return
+	}
 
-	balanceResponse := Balance{id, amount, nil}
+	userBalance, err := h.useCase.GetBalance(id, currency)
+	if err != nil && err != balance.ErrConversion {
+		problem.Write(w, err)
+		return
+	}
+
+	balanceResponse := Balance{
+		Id:             id,
+		Amount:         userBalance.Amount,
+		Bonus:          userBalance.Bonus,
+		BonusExpiresAt: userBalance.BonusExpiresAt,
+		Currency:       userBalance.Currency,
+	}
+	if userBalance.Rate != nil {
+		balanceResponse.Rate = &Rate{
+			Value:     userBalance.Rate.Value,
+			Applied:   userBalance.AppliedRate,
+			UpdatedAt: userBalance.Rate.UpdatedAt,
+			Age:       int64(userBalance.Rate.Age().Seconds()),
+			Stale:     userBalance.Rate.Stale,
+		}
+	}
+	// Баланс в рублях передается вместе с ошибкой, чтобы клиент мог показать его без повторного запроса
 	if err == balance.ErrConversion {
-		errMessage := err.Error()
-		balanceResponse.Error = &errMessage
-	} else if err != nil {
-		log.Println(err)
-		w.WriteHeader(http.StatusInternalServerError)
-		message := "Server error"
-		h.writeStatus(false, &message, &w)
+		p := problem.New(err)
+		problem.WriteProblem(w, p, ConversionProblem{Problem: p, Balance: &balanceResponse})
 		return
 	}
 
 	w.Header().Add("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(balanceResponse)
 	if err != nil {
-		log.Println(err)
-		w.WriteHeader(http.StatusInternalServerError)
-		message := "Server error"
-		h.writeStatus(false, &message, &w)
+		problem.Write(w, err)
 	}
 }
 
@@ -87,19 +174,13 @@ func (h Handler) ChangeBalanceEndpoint(w http.ResponseWriter, r *http.Request) {
 
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil || id <= 0 {
-		log.Println(err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		message := "Bad id argument"
-		h.writeStatus(false, &message, &w)
+		problem.Write(w, apperror.BadArgument("id"))
 		return
 	}
 
 	amount, err := strconv.ParseFloat(r.FormValue("amount"), 32)
 	if err != nil {
-		log.Println(err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		message := "Bad amount argument"
-		h.writeStatus(false, &message, &w)
+		problem.Write(w, apperror.BadArgument("amount"))
 		return
 	}
 
@@ -107,25 +188,53 @@ func (h Handler) ChangeBalanceEndpoint(w http.ResponseWriter, r *http.Request) {
 	if amount < 0 {
 		product, err = strconv.ParseInt(r.FormValue("product"), 10, 32)
 		if err != nil || product < 0 {
-			log.Println(err.Error())
-			w.WriteHeader(http.StatusBadRequest)
-			message := "Bad product argument"
-			h.writeStatus(false, &message, &w)
+			problem.Write(w, apperror.BadArgument("product"))
 			return
 		}
 	}
 
-	err = h.useCase.ChangeBalance(id, float32(amount), product)
-	if err == balance.ErrTooLowBalance {
-		log.Println(err.Error())
-		w.WriteHeader(http.StatusConflict)
-		message := err.Error()
-		h.writeStatus(false, &message, &w)
+	h.changeBalance(id, float32(amount), product, r.FormValue("currency"), r, w)
+}
+
+// changeBalance изменяет баланс и отвечает одинаково для /api/v1 и /api/v2.
+// Списание, в том числе оплата услуги, требует отдельного права, которое выдается только биллингу
+func (h Handler) changeBalance(id int64, amount float32, product int64, currency string, r *http.Request,
+	w http.ResponseWriter) {
+	scope := auth.ScopeCredit
+	if amount < 0 {
+		scope = auth.ScopeDebit
+	}
+	if !h.authorize(scope, id, r, w) {
+		return
+	}
+
+	// amount может быть указан в иностранной валюте, тогда баланс изменяется на эквивалент в рублях
+	if currency == "" {
+		currency = exchange.RUB
+	}
+
+	if !exchange.IsSupported(currency) {
+		problem.Write(w, apperror.BadArgument("currency"))
+		return
+	}
+
+	var conversion *models.Conversion
+	var err error
+	if currency == exchange.RUB {
+		err = h.useCase.ChangeBalance(id, amount, product)
+	} else {
+		conversion, err = h.useCase.ChangeBalanceInCurrency(id, amount, product, currency)
+	}
+
+	var limitErr *balance.LimitExceededError
+	if errors.As(err, &limitErr) {
+		h.writeLimitExceeded(limitErr, w)
 	} else if err != nil {
-		log.Println(err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
-		message := "Server error"
-		h.writeStatus(false, &message, &w)
+		problem.Write(w, err)
+	} else if conversion != nil {
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(w).Encode(ConversionStatus{StatusMessage{Success: true}, conversion})
 	} else {
 		w.WriteHeader(http.StatusOK)
 		h.writeStatus(true, nil, &w)
@@ -135,42 +244,166 @@ func (h Handler) ChangeBalanceEndpoint(w http.ResponseWriter, r *http.Request) {
 func (h Handler) TransferMoneyEndpoint(w http.ResponseWriter, r *http.Request) {
 	srcId, err := strconv.ParseInt(r.FormValue("src"), 10, 64)
 	if err != nil || srcId <= 0 {
-		log.Println(err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		message := "Bad src argument"
-		h.writeStatus(false, &message, &w)
+		problem.Write(w, apperror.BadArgument("src"))
 		return
 	}
 
 	dstId, err := strconv.ParseInt(r.FormValue("dst"), 10, 64)
 	if err != nil || dstId <= 0 {
-		log.Println(err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		message := "Bad dst argument"
-		h.writeStatus(false, &message, &w)
+		problem.Write(w, apperror.BadArgument("dst"))
+		return
+	}
+
+	amount, err := strconv.ParseFloat(r.FormValue("amount"), 32)
+	if err != nil || amount <= 0 {
+		problem.Write(w, apperror.BadArgument("amount"))
+		return
+	}
+
+	commission, ok := h.parseCommission(r, w)
+	if !ok {
+		return
+	}
+
+	h.transferMoney(srcId, dstId, float32(amount), commission, r, w)
+}
+
+func (h Handler) transferMoney(srcId int64, dstId int64, amount float32, commission *models.Commission,
+	r *http.Request, w http.ResponseWriter) {
+	if !h.authorize(auth.ScopeTransfer, srcId, r, w) {
+		return
+	}
+
+	if commission == nil {
+		err := h.useCase.TransferMoney(srcId, dstId, amount)
+		h.writeTransferStatus(nil, err, w)
+		return
+	}
+
+	payouts, err := h.useCase.TransferMoneyWithCommission(srcId, dstId, amount, commission)
+	h.writeTransferStatus(payouts, err, w)
+}
+
+func (h Handler) SplitPaymentEndpoint(w http.ResponseWriter, r *http.Request) {
+	srcId, err := strconv.ParseInt(r.FormValue("src"), 10, 64)
+	if err != nil || srcId <= 0 {
+		problem.Write(w, apperror.BadArgument("src"))
 		return
 	}
 
 	amount, err := strconv.ParseFloat(r.FormValue("amount"), 32)
 	if err != nil || amount <= 0 {
-		log.Println(err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		message := "Bad amount argument"
-		h.writeStatus(false, &message, &w)
+		problem.Write(w, apperror.BadArgument("amount"))
+		return
+	}
+
+	shares, err := parseShares(r.FormValue("shares"))
+	if err != nil {
+		problem.Write(w, apperror.BadArgument("shares"))
 		return
 	}
 
-	err = h.useCase.TransferMoney(srcId, dstId, float32(amount))
-	if err == balance.ErrTooLowBalance {
-		log.Println(err.Error())
-		w.WriteHeader(http.StatusConflict)
+	commission, ok := h.parseCommission(r, w)
+	if !ok {
+		return
+	}
+
+	if !h.authorize(auth.ScopeTransfer, srcId, r, w) {
+		return
+	}
+
+	payouts, err := h.useCase.SplitPayment(srcId, float32(amount), shares, commission)
+	h.writeTransferStatus(payouts, err, w)
+}
+
+// parseShares разбирает доли получателей в формате "id:доля,id:доля"
+func parseShares(value string) ([]*models.Share, error) {
+	shares := make([]*models.Share, 0)
+	for _, item := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(item), ":")
+		if len(parts) != 2 {
+			return nil, balance.ErrBadShares
+		}
+
+		userId, err := strconv.ParseInt(parts[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		share, err := strconv.ParseFloat(parts[1], 32)
+		if err != nil {
+			return nil, err
+		}
+
+		shares = append(shares, &models.Share{UserId: userId, Share: float32(share)})
+	}
+
+	return shares, nil
+}
+
+// parseCommission читает необязательные параметры комиссии, nil означает перевод без комиссии
+func (h Handler) parseCommission(r *http.Request, w http.ResponseWriter) (*models.Commission, bool) {
+	names := []string{"fee_percent", "fee_min", "fee_fixed"}
+	values := make([]float32, len(names))
+	present := false
+
+	for i, name := range names {
+		raw := r.FormValue(name)
+		if raw == "" {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(raw, 32)
+		if err != nil {
+			problem.Write(w, apperror.BadArgument(name))
+			return nil, false
+		}
+
+		values[i] = float32(value)
+		present = true
+	}
+
+	if !present {
+		return nil, true
+	}
+
+	return &models.Commission{Percent: values[0], Min: values[1], Fixed: values[2]}, true
+}
+
+// writeLimitExceeded отвечает 429 с моментом сброса лимита в теле и в заголовке Retry-After
+func (h Handler) writeLimitExceeded(err *balance.LimitExceededError, w http.ResponseWriter) {
+	p := problem.New(err)
+	problem.WriteProblem(w, p, LimitProblem{p, err.Limit, err.ResetsAt})
+}
+
+// writeApprovalRequired отвечает 202: операция принята, но будет выполнена только после одобрения
+func (h Handler) writeApprovalRequired(err *balance.ApprovalRequiredError, w http.ResponseWriter) {
+	message := err.Error()
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_ = json.NewEncoder(w).Encode(PendingStatus{StatusMessage{Success: false, Message: &message},
+		apperror.ApprovalRequired, err.Operation})
+}
+
+func (h Handler) writeTransferStatus(payouts []*models.Payout, err error, w http.ResponseWriter) {
+	var limitErr *balance.LimitExceededError
+	var heldErr *balance.TransferHeldError
+	var approvalErr *balance.ApprovalRequiredError
+	if errors.As(err, &limitErr) {
+		h.writeLimitExceeded(limitErr, w)
+	} else if errors.As(err, &approvalErr) {
+		h.writeApprovalRequired(approvalErr, w)
+	} else if errors.As(err, &heldErr) {
 		message := err.Error()
-		h.writeStatus(false, &message, &w)
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		_ = json.NewEncoder(w).Encode(HeldStatus{StatusMessage{Success: false, Message: &message},
+			apperror.TransferHeld, heldErr.Review.Id})
 	} else if err != nil {
-		log.Println(err.Error())
-		w.WriteHeader(http.StatusInternalServerError)
-		message := "Server error"
-		h.writeStatus(false, &message, &w)
+		problem.Write(w, err)
+	} else if payouts != nil {
+		w.Header().Add("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(TransferStatus{StatusMessage{Success: true}, payouts})
 	} else {
 		h.writeStatus(true, nil, &w)
 	}
@@ -181,28 +414,23 @@ func (h Handler) GetHistoryEndpoint(w http.ResponseWriter, r *http.Request) {
 
 	id, err := strconv.ParseInt(vars["id"], 10, 64)
 	if err != nil || id <= 0 {
-		log.Println(err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		message := "Bad id argument"
-		h.writeStatus(false, &message, &w)
+		problem.Write(w, apperror.BadArgument("id"))
+		return
+	}
+
+	if !h.authorize(auth.ScopeBalanceRead, id, r, w) {
 		return
 	}
 
 	page, err := strconv.ParseInt(r.FormValue("page"), 10, 64)
 	if err != nil || page <= 0 {
-		log.Println(err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		message := "Bad page argument"
-		h.writeStatus(false, &message, &w)
+		problem.Write(w, apperror.BadArgument("page"))
 		return
 	}
 
 	perPage, err := strconv.ParseInt(r.FormValue("per_page"), 10, 64)
 	if err != nil || perPage <= 0 {
-		log.Println(err.Error())
-		w.WriteHeader(http.StatusBadRequest)
-		message := "Bad per_page argument"
-		h.writeStatus(false, &message, &w)
+		problem.Write(w, apperror.BadArgument("per_page"))
 		return
 	}
 
@@ -218,21 +446,31 @@ func (h Handler) GetHistoryEndpoint(w http.ResponseWriter, r *http.Request) {
 		desc = true
 	}
 
-	transactions, err := h.useCase.GetHistory(id, page, perPage, sort, desc)
+	currency := r.FormValue("currency")
+	if currency == "" {
+		currency = exchange.RUB
+	}
+
+	if !exchange.IsSupported(currency) {
+		problem.Write(w, apperror.BadArgument("currency"))
+		return
+	}
+
+	// При ошибке конвертации операции передаются вместе с ошибкой, у части из них нет поля converted
+	transactions, err := h.useCase.GetHistory(id, page, perPage, sort, desc, currency)
+	if err == balance.ErrConversion {
+		p := problem.New(err)
+		problem.WriteProblem(w, p, ConversionProblem{Problem: p, Transactions: transactions})
+		return
+	}
 	if err != nil {
-		log.Println(err)
-		w.WriteHeader(http.StatusInternalServerError)
-		message := "Server error"
-		h.writeStatus(false, &message, &w)
+		problem.Write(w, err)
 		return
 	}
 
 	w.Header().Add("Content-Type", "application/json")
 	err = json.NewEncoder(w).Encode(transactions)
 	if err != nil {
-		log.Println(err)
-		w.WriteHeader(http.StatusInternalServerError)
-		message := "Server error"
-		h.writeStatus(false, &message, &w)
+		problem.Write(w, err)
 	}
 }
@@ -1,13 +1,18 @@
 package http
 
 import (
+	"avito-intership/apperror"
+	"avito-intership/auth"
 	"avito-intership/balance"
 	"avito-intership/mocks"
 	"avito-intership/models"
+	"avito-intership/problem"
+	"avito-intership/server/middleware"
 	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"net/http"
 	"net/http/httptest"
@@ -28,6 +33,8 @@ func (suite *balanceHandlerSuite) SetupSuite() {
 	handler := NewHandler(useCase)
 
 	router := mux.NewRouter()
+	router.Use(middleware.WithPrincipal(&models.Principal{Service: auth.BillingService,
+		Scopes: []string{auth.ScopeBalanceRead, auth.ScopeTransfer, auth.ScopeCredit, auth.ScopeDebit}}))
 	RegisterEndpoints(router, useCase)
 	testingServer := httptest.NewServer(router)
 
@@ -52,7 +59,7 @@ func (suite *balanceHandlerSuite) TestGetHistory() {
 		{UserId:1, Amount:1, Time:txTime, TargetId:1, Type:"fill"},
 	}
 
-	suite.useCase.On("GetHistory", id, page, perPage, sort, desc).Return(transactions, nil)
+	suite.useCase.On("GetHistory", id, page, perPage, sort, desc, "RUB").Return(transactions, nil)
 
 	response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/%d/history?page=%d&per_page=%d",
 		suite.testingServer.URL, id, page, perPage))
@@ -71,12 +78,50 @@ func (suite *balanceHandlerSuite) TestGetHistory() {
 	suite.Equal(len(transactions), len(responseBody))
 }
 
+func (suite *balanceHandlerSuite) TestGetHistory_Currency() {
+	var id int64 = 2
+	var page int64 = 1
+	var perPage int64 = 5
+	currency := "USD"
+
+	txTime := time.Now()
+	transactions := []*models.Transaction{
+		{UserId: id, Amount: 100, Time: txTime, Type: "fill",
+			Converted: &models.Conversion{Amount: 1.25, From: "RUB", Currency: currency}},
+	}
+
+	suite.useCase.On("GetHistory", id, page, perPage, balance.SortDate, false, currency).Return(transactions, nil)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/%d/history?page=%d&per_page=%d&currency=%s",
+		suite.testingServer.URL, id, page, perPage, currency))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody []*models.Transaction
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(transactions[0].Converted.Amount, responseBody[0].Converted.Amount)
+	suite.Equal(currency, responseBody[0].Converted.Currency)
+}
+
+func (suite *balanceHandlerSuite) TestGetHistory_UnsupportedCurrency() {
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/%d/history?page=1&per_page=5&currency=XYZ",
+		suite.testingServer.URL, 3))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
 func (suite *balanceHandlerSuite) TestGetBalanceHandler_Ok() {
 	var id int64 = 1
 	var amount float32 = 100
 	currency := "RUB"
 
-	suite.useCase.On("GetBalance", id, currency).Return(amount, nil)
+	suite.useCase.On("GetBalance", id, currency).
+		Return(&models.Balance{UserId: id, Amount: amount, Currency: currency}, nil)
 
 	response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/%d?currency=%s",
 		suite.testingServer.URL, id, currency))
@@ -90,6 +135,73 @@ func (suite *balanceHandlerSuite) TestGetBalanceHandler_Ok() {
 	suite.Equal(http.StatusOK, response.StatusCode)
 	suite.Equal(responseBody.Amount, amount)
 	suite.Equal(responseBody.Id, id)
+	suite.Nil(responseBody.Rate)
+}
+
+func (suite *balanceHandlerSuite) TestGetBalanceHandler_StaleRate() {
+	var id int64 = 2
+	var amount float32 = 10
+	currency := "USD"
+	rate := &models.Rate{
+		Currency:  currency,
+		Value:     10,
+		UpdatedAt: time.Now().Add(-2 * time.Hour),
+		Stale:     true,
+	}
+
+	suite.useCase.On("GetBalance", id, currency).
+		Return(&models.Balance{UserId: id, Amount: amount, Currency: currency, Rate: rate}, nil)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/%d?currency=%s",
+		suite.testingServer.URL, id, currency))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody Balance
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(amount, responseBody.Amount)
+	suite.Equal(currency, responseBody.Currency)
+	suite.NotNil(responseBody.Rate)
+	suite.True(responseBody.Rate.Stale)
+	suite.GreaterOrEqual(responseBody.Rate.Age, int64(2*time.Hour/time.Second))
+}
+
+func (suite *balanceHandlerSuite) TestGetBalanceHandler_ConversionFailed() {
+	var id int64 = 3
+	var amount float32 = 10
+
+	suite.useCase.On("GetBalance", id, "EUR").
+		Return(&models.Balance{UserId: id, Amount: amount, Currency: "RUB"}, balance.ErrConversion)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/%d?currency=EUR", suite.testingServer.URL, id))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody ConversionProblem
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusServiceUnavailable, response.StatusCode)
+	suite.Equal(apperror.ConversionFailed, responseBody.Code)
+	suite.True(responseBody.Retryable)
+	suite.Equal(amount, responseBody.Balance.Amount)
+	suite.Equal("RUB", responseBody.Balance.Currency)
+}
+
+func (suite *balanceHandlerSuite) TestGetBalanceHandler_UnsupportedCurrency() {
+	var id int64 = 3
+	currency := "XYZ"
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/%d?currency=%s",
+		suite.testingServer.URL, id, currency))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+	suite.useCase.AssertNotCalled(suite.T(), "GetBalance", id, currency)
 }
 
 func (suite *balanceHandlerSuite) TestChangeBalanceHandler_Ok() {
@@ -122,6 +234,54 @@ func (suite *balanceHandlerSuite) TestChangeBalanceHandler_LowBalance() {
 	suite.Equal(http.StatusConflict, response.StatusCode)
 }
 
+func (suite *balanceHandlerSuite) TestChangeBalanceHandler_Currency() {
+	var id int64 = 4
+	var amount float32 = -12.5
+	var product int64 = 3
+	currency := "USD"
+	conversion := &models.Conversion{
+		Amount:      -1015,
+		From:        currency,
+		Currency:    "RUB",
+		Rate:        &models.Rate{Base: "RUB", Currency: currency, Value: 80},
+		AppliedRate: 81.2,
+		Fee:         15,
+	}
+
+	suite.useCase.On("ChangeBalanceInCurrency", id, amount, product, currency).Return(conversion, nil)
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/balance/%d?amount=%f&product=%d&currency=%s",
+		suite.testingServer.URL, id, amount, product, currency), "", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody ConversionStatus
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.True(responseBody.Success)
+	suite.Equal(conversion.Amount, responseBody.Conversion.Amount)
+	suite.Equal(conversion.AppliedRate, responseBody.Conversion.AppliedRate)
+	suite.Equal(conversion.Fee, responseBody.Conversion.Fee)
+}
+
+func (suite *balanceHandlerSuite) TestChangeBalanceHandler_RateUnavailable() {
+	var id int64 = 5
+	var amount float32 = -10
+	var product int64 = 3
+	currency := "EUR"
+
+	suite.useCase.On("ChangeBalanceInCurrency", id, amount, product, currency).Return(nil, balance.ErrRateUnavailable)
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/balance/%d?amount=%f&product=%d&currency=%s",
+		suite.testingServer.URL, id, amount, product, currency), "", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusServiceUnavailable, response.StatusCode)
+}
+
 func (suite *balanceHandlerSuite) TestTransferMoneyHandler_Ok() {
 	var src int64 = 1
 	var dst int64 = 2
@@ -152,6 +312,255 @@ func (suite *balanceHandlerSuite) TestTransferMoneyHandler_LowBalance() {
 	suite.Equal(http.StatusConflict, response.StatusCode)
 }
 
+func (suite *balanceHandlerSuite) TestTransferMoneyHandler_LimitExceeded() {
+	var src int64 = 11
+	var dst int64 = 12
+	var amount float32 = 100
+	var maxCount int64 = 20
+	resetsAt := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+	limitErr := &balance.LimitExceededError{
+		Limit:    &models.Limit{Id: 1, Operation: balance.TransferOperation, Period: "hour", MaxCount: &maxCount},
+		ResetsAt: resetsAt,
+	}
+
+	suite.useCase.On("TransferMoney", src, dst, amount).Return(limitErr)
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/transfer?src=%d&dst=%d&amount=%f",
+		suite.testingServer.URL, src, dst, amount), "", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody LimitProblem
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusTooManyRequests, response.StatusCode)
+	suite.Equal(problem.ContentType, response.Header.Get("Content-Type"))
+	suite.NotEmpty(response.Header.Get("Retry-After"))
+	suite.Equal(apperror.LimitExceeded, responseBody.Code)
+	suite.True(responseBody.Retryable)
+	suite.True(resetsAt.Equal(responseBody.ResetsAt))
+	suite.Equal(maxCount, *responseBody.Limit.MaxCount)
+}
+
+func (suite *balanceHandlerSuite) TestTransferMoneyHandler_Held() {
+	var src int64 = 21
+	var dst int64 = 22
+	var amount float32 = 5000
+
+	suite.useCase.On("TransferMoney", src, dst, amount).
+		Return(&balance.TransferHeldError{Review: &models.TransferReview{Id: 7, SrcId: src, DstId: dst, Amount: amount}})
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/transfer?src=%d&dst=%d&amount=%f",
+		suite.testingServer.URL, src, dst, amount), "", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody HeldStatus
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusAccepted, response.StatusCode)
+	suite.False(responseBody.Success)
+	suite.Equal(apperror.TransferHeld, responseBody.Code)
+	suite.Equal(int64(7), responseBody.ReviewId)
+}
+
+func (suite *balanceHandlerSuite) TestTransferMoneyHandler_Blocked() {
+	var src int64 = 23
+	var dst int64 = 24
+	var amount float32 = 50000
+
+	suite.useCase.On("TransferMoney", src, dst, amount).Return(balance.ErrTransferBlocked)
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/transfer?src=%d&dst=%d&amount=%f",
+		suite.testingServer.URL, src, dst, amount), "", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusForbidden, response.StatusCode)
+}
+
+func (suite *balanceHandlerSuite) TestTransferMoneyHandler_ApprovalRequired() {
+	var src int64 = 25
+	var dst int64 = 26
+	var amount float32 = 200000
+	operation := &models.PendingOperation{Id: 9, Type: balance.PendingTransfer, UserId: src, Amount: amount,
+		Status: balance.StatusPending, ExpiresAt: time.Now().Add(time.Hour)}
+
+	suite.useCase.On("TransferMoney", src, dst, amount).Return(&balance.ApprovalRequiredError{Operation: operation})
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/transfer?src=%d&dst=%d&amount=%f",
+		suite.testingServer.URL, src, dst, amount), "", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody PendingStatus
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusAccepted, response.StatusCode)
+	suite.False(responseBody.Success)
+	suite.Equal(apperror.ApprovalRequired, responseBody.Code)
+	suite.Equal(int64(9), responseBody.Operation.Id)
+	suite.Equal(balance.StatusPending, responseBody.Operation.Status)
+}
+
+func (suite *balanceHandlerSuite) TestTransferMoneyHandler_Commission() {
+	var src int64 = 6
+	var dst int64 = 7
+	var amount float32 = 100
+	commission := &models.Commission{Percent: 1, Min: 5}
+	payouts := []*models.Payout{{UserId: dst, Amount: 95, Fee: 5}}
+
+	suite.useCase.On("TransferMoneyWithCommission", src, dst, amount, commission).Return(payouts, nil)
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/transfer?src=%d&dst=%d&amount=%f&fee_percent=1&fee_min=5",
+		suite.testingServer.URL, src, dst, amount), "", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody TransferStatus
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(payouts, responseBody.Payouts)
+}
+
+func (suite *balanceHandlerSuite) TestSplitPaymentHandler() {
+	var src int64 = 8
+	var amount float32 = 100
+	shares := []*models.Share{{UserId: 9, Share: 70}, {UserId: 10, Share: 30}}
+	payouts := []*models.Payout{{UserId: 9, Amount: 70}, {UserId: 10, Amount: 30}}
+
+	suite.useCase.On("SplitPayment", src, amount, shares, (*models.Commission)(nil)).Return(payouts, nil)
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/split?src=%d&amount=%f&shares=9:70,10:30",
+		suite.testingServer.URL, src, amount), "", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.useCase.AssertCalled(suite.T(), "SplitPayment", src, amount, shares, (*models.Commission)(nil))
+}
+
+func (suite *balanceHandlerSuite) TestSplitPaymentHandler_BadShares() {
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/split?src=1&amount=100&shares=9-70",
+		suite.testingServer.URL), "", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *balanceHandlerSuite) TestGetBalanceHandler_Bonus() {
+	var id int64 = 11
+	expiresAt := time.Date(2021, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	suite.useCase.On("GetBalance", id, "RUB").Return(&models.Balance{
+		UserId: id, Amount: 100, Bonus: 50, BonusExpiresAt: &expiresAt, Currency: "RUB",
+	}, nil)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/%d", suite.testingServer.URL, id))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody Balance
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(float32(100), responseBody.Amount)
+	suite.Equal(float32(50), responseBody.Bonus)
+	suite.True(expiresAt.Equal(*responseBody.BonusExpiresAt))
+}
+
+func (suite *balanceHandlerSuite) TestGrantBonusHandler() {
+	var id int64 = 12
+	bonus := &models.Bonus{Id: 1, UserId: id, Amount: 500}
+	suite.useCase.On("GrantBonus", id, float32(500), 30).Return(bonus, nil)
+
+	router := mux.NewRouter()
+	RegisterAdminEndpoints(router.PathPrefix("/api/v1/admin").Subrouter(), suite.useCase, new(mocks.ApprovalUseCase),
+		new(mocks.AdjustmentUseCase))
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/admin/bonuses?user=%d&amount=500&days=30", server.URL, id),
+		"", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.Bonus
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(bonus.Amount, responseBody.Amount)
+}
+
+func (suite *balanceHandlerSuite) TestUserToken() {
+	var userId int64 = 21
+	suite.useCase.On("GetBalance", userId, "RUB").Return(&models.Balance{UserId: userId, Currency: "RUB"}, nil)
+	suite.useCase.On("TransferMoney", userId, int64(22), float32(10)).Return(nil)
+
+	router := mux.NewRouter()
+	router.Use(middleware.WithPrincipal(&models.Principal{UserId: userId, Scopes: auth.UserScopes}))
+	RegisterEndpoints(router, suite.useCase)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	cases := []struct {
+		method string
+		path   string
+		status int
+	}{
+		{http.MethodGet, "/api/v1/balance/21", http.StatusOK},
+		{http.MethodPost, "/api/v1/transfer?src=21&dst=22&amount=10", http.StatusOK},
+		{http.MethodGet, "/api/v1/balance/22", http.StatusForbidden},
+		{http.MethodGet, "/api/v1/balance/22/history?page=1&per_page=10", http.StatusForbidden},
+		{http.MethodPost, "/api/v1/transfer?src=22&dst=21&amount=10", http.StatusForbidden},
+		{http.MethodPost, "/api/v1/split?src=22&amount=10&shares=21:1", http.StatusForbidden},
+		{http.MethodPost, "/api/v1/balance/21?amount=10", http.StatusForbidden},
+		{http.MethodPost, "/api/v1/balance/21?amount=-10&product=1", http.StatusForbidden},
+	}
+
+	for _, c := range cases {
+		request, err := http.NewRequest(c.method, server.URL+c.path, nil)
+		suite.NoError(err, "creating request should not produce error")
+
+		response, err := http.DefaultClient.Do(request)
+		suite.NoError(err, "request should not produce error")
+		response.Body.Close()
+
+		suite.Equal(c.status, response.StatusCode, c.path)
+	}
+	suite.useCase.AssertNotCalled(suite.T(), "GetBalance", int64(22), mock.Anything)
+	suite.useCase.AssertNotCalled(suite.T(), "ChangeBalance", userId, mock.Anything, mock.Anything)
+}
+
+func (suite *balanceHandlerSuite) TestChangeBalanceHandler_DebitOnlyForBilling() {
+	router := mux.NewRouter()
+	router.Use(middleware.WithPrincipal(&models.Principal{Service: "marketplace",
+		Scopes: []string{auth.ScopeBalanceRead, auth.ScopeCredit}}))
+	RegisterEndpoints(router, suite.useCase)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/balance/23?amount=-10&product=1", server.URL), "", nil)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody problem.Problem
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusForbidden, response.StatusCode)
+	suite.Equal(apperror.PermissionDenied, responseBody.Code)
+	suite.useCase.AssertNotCalled(suite.T(), "ChangeBalance", int64(23), mock.Anything, mock.Anything)
+}
+
 func TestBalanceHandler(t *testing.T) {
 	suite.Run(t, new(balanceHandlerSuite))
 }
\ No newline at end of file
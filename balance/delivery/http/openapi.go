@@ -0,0 +1,144 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/problem"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// openAPISpec описывает /api/v2 и используется для проверки запросов, поэтому описание и проверка не расходятся
+//
+//go:embed openapi.yaml
+var openAPISpec []byte
+
+// FieldError - ошибка в одном поле запроса; Field - путь к полю через точку, In - "path", "query" или "body"
+type FieldError struct {
+	Field   string `json:"field"`
+	In      string `json:"in"`
+	Message string `json:"message"`
+}
+
+// ValidationProblem перечисляет поля, не прошедшие проверку
+type ValidationProblem struct {
+	*problem.Problem
+	Errors []*FieldError `json:"errors"`
+}
+
+var errSchemaMismatch = apperror.New(apperror.InvalidArgument, "Request doesn't match the API schema")
+
+// Validator проверяет запросы к /api/v2 по описанию OpenAPI
+type Validator struct {
+	spec *openapi3.T
+}
+
+func NewValidator() (*Validator, error) {
+	spec, err := openapi3.NewLoader().LoadFromData(openAPISpec)
+	if err != nil {
+		return nil, err
+	}
+
+	err = spec.Validate(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	return &Validator{spec: spec}, nil
+}
+
+// Handler проверяет запрос по операции method пути path из описания и только затем передает его next.
+// Маршрут ищется при регистрации, поэтому метод, отсутствующий в описании, не зарегистрируется
+func (v *Validator) Handler(path string, method string, next http.HandlerFunc) http.HandlerFunc {
+	pathItem := v.spec.Paths.Find(path)
+	if pathItem == nil || pathItem.GetOperation(method) == nil {
+		log.Fatalf("%s %s is not described in openapi.yaml", method, path)
+	}
+
+	route := &routers.Route{
+		Spec:      v.spec,
+		Server:    v.spec.Servers[0],
+		Path:      path,
+		PathItem:  pathItem,
+		Method:    method,
+		Operation: pathItem.GetOperation(method),
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		err := openapi3filter.ValidateRequest(r.Context(), &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: mux.Vars(r),
+			Route:      route,
+			// Вызывающий уже определен middleware.Authenticate, описание схем нужно только клиентам
+			Options: &openapi3filter.Options{MultiError: true,
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+		})
+		if err != nil {
+			writeValidationErrors(fieldErrors(err, "", ""), w)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func (v *Validator) SpecYAMLEndpoint(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/yaml")
+	_, _ = w.Write(openAPISpec)
+}
+
+func (v *Validator) SpecJSONEndpoint(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v.spec)
+}
+
+func writeValidationErrors(errs []*FieldError, w http.ResponseWriter) {
+	p := problem.New(errSchemaMismatch)
+	problem.WriteProblem(w, p, ValidationProblem{p, errs})
+}
+
+// fieldErrors раскладывает ошибку проверки на ошибки отдельных полей;
+// field и in - поле, к которому относится ошибка, если она сама его не содержит
+func fieldErrors(err error, field string, in string) []*FieldError {
+	switch e := err.(type) {
+	case openapi3.MultiError:
+		result := make([]*FieldError, 0, len(e))
+		for _, inner := range e {
+			result = append(result, fieldErrors(inner, field, in)...)
+		}
+		return result
+	case *openapi3filter.RequestError:
+		switch {
+		case e.Parameter != nil:
+			field, in = e.Parameter.Name, e.Parameter.In
+		case e.RequestBody != nil:
+			in = "body"
+		}
+
+		if e.Err == nil {
+			return []*FieldError{{Field: field, In: in, Message: e.Reason}}
+		}
+		return fieldErrors(e.Err, field, in)
+	case *openapi3.SchemaError:
+		path := e.JSONPointer()
+		// Ошибка лишнего свойства относится к объекту, имя свойства есть только в тексте ошибки
+		var property string
+		if _, scanErr := fmt.Sscanf(e.Reason, "property %q is unsupported", &property); scanErr == nil {
+			path = append(path, property)
+		}
+		if len(path) > 0 {
+			field = strings.Join(path, ".")
+		}
+		return []*FieldError{{Field: field, In: in, Message: e.Reason}}
+	}
+
+	return []*FieldError{{Field: field, In: in, Message: err.Error()}}
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"avito-intership/balance"
 	"github.com/gorilla/mux"
+	"log"
 	"net/http"
 )
 
@@ -15,7 +16,58 @@ func RegisterEndpoints(router *mux.Router, uc balance.UseCase) {
 		Methods(http.MethodOptions, http.MethodPost)
 	router.HandleFunc("/api/v1/transfer", handler.TransferMoneyEndpoint).
 		Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/api/v1/split", handler.SplitPaymentEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
 	router.HandleFunc("/api/v1/balance/{id:[0-9]+}/history", handler.GetHistoryEndpoint).
 		Methods(http.MethodGet, http.MethodOptions)
 }
 
+
+// RegisterAdminEndpoints регистрирует служебные методы, router - подмаршрутизатор /api/v1/admin
+func RegisterAdminEndpoints(router *mux.Router, uc balance.UseCase, approvals balance.ApprovalUseCase,
+	adjustments balance.AdjustmentUseCase) {
+	handler := NewHandler(uc)
+	approvalHandler := NewApprovalHandler(uc, approvals)
+	adjustmentHandler := NewAdjustmentHandler(uc, adjustments)
+
+	router.HandleFunc("/bonuses", handler.GrantBonusEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/operations", approvalHandler.GetOperationsEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/operations/{id:[0-9]+}", approvalHandler.GetOperationEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/operations/{id:[0-9]+}/approve", approvalHandler.ApproveEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/operations/{id:[0-9]+}/reject", approvalHandler.RejectEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/adjustments", adjustmentHandler.GetAdjustmentsEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/adjustments", adjustmentHandler.AdjustEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/adjustments/{id:[0-9]+}", adjustmentHandler.GetAdjustmentEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+}
+
+// RegisterV2Endpoints регистрирует /api/v2; каждый запрос проверяется по openapi.yaml до вызова обработчика
+func RegisterV2Endpoints(router *mux.Router, uc balance.UseCase) {
+	validator, err := NewValidator()
+	if err != nil {
+		log.Fatal(err)
+	}
+	handler := NewV2Handler(uc)
+
+	router.HandleFunc("/api/v2/openapi.yaml", validator.SpecYAMLEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/api/v2/openapi.json", validator.SpecJSONEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/api/v2/balance/{id}", validator.Handler("/balance/{id}", http.MethodGet,
+		handler.GetBalanceEndpoint)).Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/api/v2/balance/{id}", validator.Handler("/balance/{id}", http.MethodPost,
+		handler.ChangeBalanceEndpoint)).Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/api/v2/balance/{id}/history", validator.Handler("/balance/{id}/history", http.MethodGet,
+		handler.GetHistoryEndpoint)).Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/api/v2/transfer", validator.Handler("/transfer", http.MethodPost,
+		handler.TransferMoneyEndpoint)).Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/api/v2/split", validator.Handler("/split", http.MethodPost,
+		handler.SplitPaymentEndpoint)).Methods(http.MethodOptions, http.MethodPost)
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"avito-intership/auth"
+	"avito-intership/balance"
+	"avito-intership/models"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+// Тела запросов /api/v2; типы и обязательность полей проверяет Validator до разбора
+type ChangeBalanceRequest struct {
+	Amount float32 `json:"amount"`
+	// Обязателен для списания
+	ProductId *int64 `json:"product_id"`
+	Currency  string `json:"currency"`
+}
+
+type TransferRequest struct {
+	SrcUserId  int64              `json:"src_user_id"`
+	DstUserId  int64              `json:"dst_user_id"`
+	Amount     float32            `json:"amount"`
+	Commission *models.Commission `json:"commission"`
+}
+
+type SplitRequest struct {
+	SrcUserId  int64              `json:"src_user_id"`
+	Amount     float32            `json:"amount"`
+	Shares     []*models.Share    `json:"shares"`
+	Commission *models.Commission `json:"commission"`
+}
+
+// V2Handler принимает параметры операций в теле запроса, чтобы суммы не попадали в строку запроса и журналы доступа.
+// Ответы совпадают с /api/v1
+type V2Handler struct {
+	Handler
+}
+
+func NewV2Handler(useCase balance.UseCase) *V2Handler {
+	return &V2Handler{
+		Handler: Handler{useCase: useCase},
+	}
+}
+
+func (h V2Handler) decode(value interface{}, r *http.Request, w http.ResponseWriter) bool {
+	err := json.NewDecoder(r.Body).Decode(value)
+	if err != nil {
+		writeValidationErrors([]*FieldError{{In: "body", Message: err.Error()}}, w)
+		return false
+	}
+
+	return true
+}
+
+func (h V2Handler) ChangeBalanceEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		writeValidationErrors([]*FieldError{{Field: "id", In: "path", Message: err.Error()}}, w)
+		return
+	}
+
+	var request ChangeBalanceRequest
+	if !h.decode(&request, r, w) {
+		return
+	}
+
+	product := balance.RefillId
+	if request.Amount < 0 {
+		if request.ProductId == nil {
+			writeValidationErrors([]*FieldError{{Field: "product_id", In: "body",
+				Message: "product_id is required for debit"}}, w)
+			return
+		}
+		product = *request.ProductId
+	}
+
+	h.changeBalance(id, request.Amount, product, request.Currency, r, w)
+}
+
+func (h V2Handler) TransferMoneyEndpoint(w http.ResponseWriter, r *http.Request) {
+	var request TransferRequest
+	if !h.decode(&request, r, w) {
+		return
+	}
+
+	h.transferMoney(request.SrcUserId, request.DstUserId, request.Amount, request.Commission, r, w)
+}
+
+func (h V2Handler) SplitPaymentEndpoint(w http.ResponseWriter, r *http.Request) {
+	var request SplitRequest
+	if !h.decode(&request, r, w) {
+		return
+	}
+
+	if !h.authorize(auth.ScopeTransfer, request.SrcUserId, r, w) {
+		return
+	}
+
+	payouts, err := h.useCase.SplitPayment(request.SrcUserId, request.Amount, request.Shares, request.Commission)
+	h.writeTransferStatus(payouts, err, w)
+}
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/auth"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"avito-intership/server/middleware"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type v2HandlerSuite struct {
+	suite.Suite
+
+	useCase       *mocks.UseCase
+	testingServer *httptest.Server
+}
+
+func (suite *v2HandlerSuite) SetupSuite() {
+	useCase := new(mocks.UseCase)
+
+	router := mux.NewRouter()
+	router.Use(middleware.WithPrincipal(&models.Principal{Service: auth.BillingService,
+		Scopes: []string{auth.ScopeBalanceRead, auth.ScopeTransfer, auth.ScopeCredit, auth.ScopeDebit}}))
+	RegisterV2Endpoints(router, useCase)
+
+	suite.testingServer = httptest.NewServer(router)
+	suite.useCase = useCase
+}
+
+func (suite *v2HandlerSuite) TearDownSuite() {
+	defer suite.testingServer.Close()
+}
+
+func (suite *v2HandlerSuite) post(path string, body string) *http.Response {
+	response, err := http.Post(fmt.Sprintf("%s/api/v2%s", suite.testingServer.URL, path), "application/json",
+		bytes.NewBufferString(body))
+	suite.NoError(err, "request should not produce error")
+
+	return response
+}
+
+func (suite *v2HandlerSuite) validationErrors(response *http.Response) []*FieldError {
+	defer response.Body.Close()
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+
+	var responseBody ValidationProblem
+	err := json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+	suite.Equal(apperror.InvalidArgument, responseBody.Code)
+
+	return responseBody.Errors
+}
+
+func (suite *v2HandlerSuite) TestTransfer() {
+	commission := &models.Commission{Percent: 2, Min: 1}
+	payouts := []*models.Payout{{UserId: 2, Amount: 98, Fee: 2}}
+	suite.useCase.On("TransferMoneyWithCommission", int64(1), int64(2), float32(100), commission).
+		Return(payouts, nil)
+
+	response := suite.post("/transfer",
+		`{"src_user_id": 1, "dst_user_id": 2, "amount": 100, "commission": {"percent": 2, "min": 1}}`)
+	defer response.Body.Close()
+
+	var responseBody TransferStatus
+	err := json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(payouts, responseBody.Payouts)
+}
+
+func (suite *v2HandlerSuite) TestTransfer_FieldErrors() {
+	errs := suite.validationErrors(suite.post("/transfer",
+		`{"src_user_id": "1", "dst_user_id": 2, "commission": {"percent": 150}, "note": "x"}`))
+
+	fields := make(map[string]string)
+	for _, e := range errs {
+		suite.Equal("body", e.In)
+		fields[e.Field] = e.Message
+	}
+	suite.Contains(fields, "src_user_id")
+	suite.Contains(fields, "amount")
+	suite.Contains(fields, "commission.percent")
+	suite.Contains(fields, "note")
+}
+
+func (suite *v2HandlerSuite) TestSplit_BadShare() {
+	errs := suite.validationErrors(suite.post("/split",
+		`{"src_user_id": 1, "amount": 100, "shares": [{"user_id": 2, "share": 0}]}`))
+
+	suite.Len(errs, 1)
+	suite.Equal("shares.0.share", errs[0].Field)
+}
+
+func (suite *v2HandlerSuite) TestSplit() {
+	shares := []*models.Share{{UserId: 3, Share: 1}, {UserId: 4, Share: 1}}
+	payouts := []*models.Payout{{UserId: 3, Amount: 50}, {UserId: 4, Amount: 50}}
+	suite.useCase.On("SplitPayment", int64(5), float32(100), shares, (*models.Commission)(nil)).
+		Return(payouts, nil)
+
+	response := suite.post("/split",
+		`{"src_user_id": 5, "amount": 100, "shares": [{"user_id": 3, "share": 1}, {"user_id": 4, "share": 1}]}`)
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+}
+
+func (suite *v2HandlerSuite) TestChangeBalance() {
+	suite.useCase.On("ChangeBalance", int64(6), float32(-10), int64(3)).Return(nil)
+
+	response := suite.post("/balance/6", `{"amount": -10, "product_id": 3}`)
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+}
+
+func (suite *v2HandlerSuite) TestChangeBalance_DebitWithoutProduct() {
+	errs := suite.validationErrors(suite.post("/balance/7", `{"amount": -10}`))
+
+	suite.Len(errs, 1)
+	suite.Equal("product_id", errs[0].Field)
+	suite.Equal("body", errs[0].In)
+}
+
+func (suite *v2HandlerSuite) TestChangeBalance_BadId() {
+	errs := suite.validationErrors(suite.post("/balance/0", `{"amount": 10}`))
+
+	suite.Len(errs, 1)
+	suite.Equal("id", errs[0].Field)
+	suite.Equal("path", errs[0].In)
+}
+
+func (suite *v2HandlerSuite) TestGetHistory_BadPage() {
+	response, err := http.Get(fmt.Sprintf("%s/api/v2/balance/1/history?page=abc&per_page=5",
+		suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+
+	errs := suite.validationErrors(response)
+	suite.Len(errs, 1)
+	suite.Equal("page", errs[0].Field)
+	suite.Equal("query", errs[0].In)
+}
+
+func (suite *v2HandlerSuite) TestSpec() {
+	response, err := http.Get(fmt.Sprintf("%s/api/v2/openapi.yaml", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	suite.NoError(err, "reading should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(openAPISpec, body)
+}
+
+func TestV2Handler(t *testing.T) {
+	suite.Run(t, new(v2HandlerSuite))
+}
@@ -1,8 +1,85 @@
 package balance
 
-import "errors"
+import (
+	"avito-intership/apperror"
+	"avito-intership/models"
+	"fmt"
+	"math"
+	"time"
+)
 
 var (
-	ErrTooLowBalance = errors.New("balance can't be lower than 0")
-	ErrConversion    = errors.New("conversion wasn't completed, amount returned in RUB")
+	ErrTooLowBalance = apperror.New(apperror.InsufficientFunds, "balance can't be lower than 0")
+	ErrConversion    = apperror.NewRetryable(apperror.ConversionFailed,
+		"conversion wasn't completed, amount returned in RUB")
+	ErrRateUnavailable = apperror.NewRetryable(apperror.Unavailable, "exchange rate is unavailable, try again later")
+	ErrBadCommission   = apperror.WithParam(apperror.InvalidArgument, "commission",
+		"commission must be either a percentage or a fixed fee and less than the amount")
+	ErrBadBonus  = apperror.New(apperror.InvalidArgument, "bonus must have a positive amount and lifetime")
+	ErrBadShares = apperror.WithParam(apperror.InvalidArgument, "shares",
+		"shares must be positive and give every recipient a non-zero amount")
+	ErrTransferBlocked = apperror.New(apperror.PermissionDenied, "transfer is blocked by fraud rules")
+
+	ErrOperationNotFound = apperror.New(apperror.NotFound, "pending operation not found")
+	ErrOperationDecided  = apperror.New(apperror.Conflict, "pending operation is already decided")
+	ErrOperationExpired  = apperror.New(apperror.Conflict, "pending operation is expired")
+	ErrNoChecker         = apperror.WithParam(apperror.InvalidArgument, "checker", "checker must be specified")
+	ErrSameOperator      = apperror.New(apperror.PermissionDenied, "operation must be decided by another operator")
+
+	ErrAdjustmentNotFound = apperror.New(apperror.NotFound, "adjustment not found")
+	ErrBadAdjustment      = apperror.New(apperror.InvalidArgument,
+		"adjustment must have a non-zero amount, operator, known reason and comment")
 )
+
+// LimitExceededError возвращается, если операция превысила бы лимит счета
+type LimitExceededError struct {
+	Limit *models.Limit
+	// Начало следующего периода, с которого лимит снова доступен
+	ResetsAt time.Time
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("%s limit per %s is exceeded, resets at %s",
+		e.Limit.Operation, e.Limit.Period, e.ResetsAt.Format(time.RFC3339))
+}
+
+func (e *LimitExceededError) AppError() *apperror.Error {
+	return apperror.NewRetryable(apperror.LimitExceeded, e.Error())
+}
+
+// RetryAfter возвращает количество секунд до сброса лимита
+func (e *LimitExceededError) RetryAfter() int64 {
+	seconds := int64(math.Ceil(time.Until(e.ResetsAt).Seconds()))
+	if seconds < 0 {
+		return 0
+	}
+
+	return seconds
+}
+
+// TransferHeldError возвращается, если перевод не выполнен, а отправлен на ручную проверку
+type TransferHeldError struct {
+	Review *models.TransferReview
+}
+
+func (e *TransferHeldError) Error() string {
+	return fmt.Sprintf("transfer is held for manual review %d", e.Review.Id)
+}
+
+func (e *TransferHeldError) AppError() *apperror.Error {
+	return apperror.New(apperror.TransferHeld, e.Error())
+}
+
+// ApprovalRequiredError возвращается, если операция превышает порог и ожидает одобрения оператором
+type ApprovalRequiredError struct {
+	Operation *models.PendingOperation
+}
+
+func (e *ApprovalRequiredError) Error() string {
+	return fmt.Sprintf("operation %d is pending approval until %s", e.Operation.Id,
+		e.Operation.ExpiresAt.Format(time.RFC3339))
+}
+
+func (e *ApprovalRequiredError) AppError() *apperror.Error {
+	return apperror.New(apperror.ApprovalRequired, e.Error())
+}
@@ -1,23 +1,121 @@
 package balance
 
-import "avito-intership/models"
+import (
+	"avito-intership/models"
+	"time"
+)
 
 const RefillId int64 = 0
 
+// Служебные счета платформы имеют отрицательные id, чтобы не пересекаться со счетами пользователей
+const (
+	// FxFeeAccountId - счет, на который зачисляется доход от спреда при конвертации
+	FxFeeAccountId int64 = -1
+	// EscrowAccountId - счет, на котором хранятся средства оплаченных, но не закрытых сделок
+	EscrowAccountId int64 = -2
+	// RevenueAccountId - счет, на который зачисляются комиссии за переводы
+	RevenueAccountId int64 = -3
+)
+
 const (
 	WithdrawType string = "product"
 	TransferType string = "transfer"
 	RefillType   string = "fill"
+	FeeType      string = "fee"
+	BonusType    string = "bonus"
+	// BonusExpiredType - списание сгоревших бонусов
+	BonusExpiredType string = "bonus_expired"
+	// CashbackType - начисление кэшбэка за покупку, target_id - id правила
+	CashbackType string = "cashback"
+	// AdjustmentType - корректировка баланса оператором, target_id - id корректировки
+	AdjustmentType string = "adjustment"
 )
 
+// Коды причин корректировок баланса
+const (
+	ReasonDuplicateCharge string = "duplicate_charge"
+	ReasonFailedService   string = "failed_service"
+	ReasonChargeback      string = "chargeback"
+	ReasonGoodwill        string = "goodwill"
+	ReasonFraudRecovery   string = "fraud_recovery"
+	ReasonCorrection      string = "correction"
+)
+
+var adjustmentReasons = map[string]bool{
+	ReasonDuplicateCharge: true,
+	ReasonFailedService:   true,
+	ReasonChargeback:      true,
+	ReasonGoodwill:        true,
+	ReasonFraudRecovery:   true,
+	ReasonCorrection:      true,
+}
+
+func IsAdjustmentReason(reason string) bool {
+	return adjustmentReasons[reason]
+}
+
+// Операции, на которые устанавливаются лимиты
+const (
+	TransferOperation string = "transfer"
+	SpendOperation    string = "spend"
+)
+
+// Состояния операций. Операции, ожидающие одобрения, и отклоненные операции не влияют на баланс
+const (
+	StatusPending   string = "pending"
+	StatusCompleted string = "completed"
+	StatusRejected  string = "rejected"
+	StatusExpired   string = "expired"
+)
+
+// Операции, которые выше порога требуют одобрения
+const (
+	PendingTransfer string = "transfer"
+	// PendingOperatorAdjustment - корректировка оператором, target_id - id корректировки
+	PendingOperatorAdjustment string = "operator_adjustment"
+)
+
+// DefaultTier - уровень счетов, для которых уровень не назначен
+const DefaultTier = "default"
+
 const (
 	SortAmount = iota
 	SortDate   = iota
 )
 
+// Списания и переводы проверяют лимиты счета и при их превышении возвращают *LimitExceededError
 type Repository interface {
 	ChangeBalance(userId int64, amount float32, productId int64) error
+	// ChangeBalanceWithFee изменяет баланс и в той же транзакции зачисляет fee на счет FxFeeAccountId
+	ChangeBalanceWithFee(userId int64, amount float32, productId int64, fee float32) error
+	// GetBalance возвращает основной баланс без бонусов
 	GetBalance(userId int64) (float32, error)
+	// GrantBonus начисляет бонусы, сгорающие в момент expiresAt
+	GrantBonus(userId int64, amount float32, expiresAt time.Time) (*models.Bonus, error)
+	// GetBonuses возвращает несгоревшие бонусы пользователя, ближайшие к сгоранию идут первыми
+	GetBonuses(userId int64, at time.Time) ([]*models.Bonus, error)
+	// ExpireBonuses списывает остатки бонусов, сгоревших к моменту at, и возвращает их количество
+	ExpireBonuses(at time.Time) (int64, error)
 	TransferMoney(srcUserId int64, dstUserId int64, amount float32) error
+	// TransferMoneyWithFee в одной транзакции переводит деньги получателям payouts и зачисляет их комиссии
+	// на счет RevenueAccountId
+	TransferMoneyWithFee(srcUserId int64, payouts []*models.Payout) error
 	GetHistory(userId int64, page int64, perPage int64, sort int, desc bool) ([]*models.Transaction, error)
+	// CreatePending сохраняет операцию и записывает ее в историю в состоянии pending без изменения баланса
+	CreatePending(operation *models.PendingOperation) (*models.PendingOperation, error)
+	GetPending(id int64) (*models.PendingOperation, error)
+	// GetPendingOperations возвращает операции в состоянии status, пустой status - все операции
+	GetPendingOperations(status string) ([]*models.PendingOperation, error)
+	// ApprovePending в одной транзакции выполняет операцию и заменяет ее записи в истории выполненными;
+	// если выполнить операцию не удалось, она остается ожидающей одобрения
+	ApprovePending(id int64, checker string, note *string) (*models.PendingOperation, error)
+	RejectPending(id int64, checker string, note *string) (*models.PendingOperation, error)
+	// ExpirePending переводит в состояние expired операции, не одобренные к моменту at, и возвращает их количество
+	ExpirePending(at time.Time) (int64, error)
+	// CreateAdjustment записывает корректировку и в той же транзакции применяет ее. Если указан expiresAt,
+	// корректировка не применяется, а создается операция, ожидающая одобрения до expiresAt
+	CreateAdjustment(adjustment *models.Adjustment, expiresAt *time.Time) (*models.Adjustment, error)
+	GetAdjustment(id int64) (*models.Adjustment, error)
+	// GetAdjustments возвращает корректировки, начиная с последних
+	GetAdjustments(filter *models.AdjustmentFilter) ([]*models.Adjustment, error)
 }
@@ -0,0 +1,132 @@
+package postgres
+
+import (
+	"avito-intership/balance"
+	"avito-intership/models"
+	"database/sql"
+	"time"
+)
+
+// Состояние корректировки берется из ее записи в истории, операция одобрения - из очереди одобрения
+const adjustmentQuery = `SELECT a.id, a.user_id, a.amount, a.operator, a.reason, a.comment, a.created_at,
+		t.status, o.id
+	FROM adjustments a
+	LEFT JOIN transactions t ON t.user_id = a.user_id AND t.type = 'adjustment' AND t.target_id = a.id
+	LEFT JOIN pending_operations o ON o.type = 'operator_adjustment' AND o.target_id = a.id`
+
+func scanAdjustment(row scanner) (*models.Adjustment, error) {
+	var adjustment models.Adjustment
+	var status sql.NullString
+	var operationId sql.NullInt64
+
+	err := row.Scan(&adjustment.Id, &adjustment.UserId, &adjustment.Amount, &adjustment.Operator, &adjustment.Reason,
+		&adjustment.Comment, &adjustment.CreatedAt, &status, &operationId)
+	if err == sql.ErrNoRows {
+		return nil, balance.ErrAdjustmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	adjustment.Status = status.String
+	if operationId.Valid {
+		adjustment.OperationId = &operationId.Int64
+	}
+
+	return &adjustment, nil
+}
+
+// adjust применяет корректировку оператора. В отличие от списаний она не тратит бонусы и не учитывается
+// в лимитах, но не может сделать баланс отрицательным
+func (r BalanceRepository) adjust(userId int64, amount float32, adjustmentId int64, tx *sql.Tx) error {
+	var currentAmount float32
+	row := tx.QueryRow("SELECT amount FROM balances WHERE id = $1 FOR UPDATE", userId)
+	err := row.Scan(&currentAmount)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	if currentAmount+amount < 0 {
+		return balance.ErrTooLowBalance
+	}
+
+	return r.credit(userId, amount, adjustmentId, balance.AdjustmentType, tx)
+}
+
+func (r BalanceRepository) CreateAdjustment(adjustment *models.Adjustment, expiresAt *time.Time) (*models.Adjustment, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	created := *adjustment
+	err = tx.QueryRow(
+		`INSERT INTO adjustments (user_id, amount, operator, reason, comment)
+		VALUES ($1, $2, $3, $4::adjustment_reason, $5)
+		RETURNING id, created_at`,
+		adjustment.UserId, adjustment.Amount, adjustment.Operator, adjustment.Reason, adjustment.Comment).
+		Scan(&created.Id, &created.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt == nil {
+		err = r.adjust(created.UserId, created.Amount, created.Id, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		created.Status = balance.StatusCompleted
+		return &created, nil
+	}
+
+	operation, err := r.createPending(&models.PendingOperation{Type: balance.PendingOperatorAdjustment,
+		UserId: created.UserId, TargetId: created.Id, Amount: created.Amount, Maker: &created.Operator,
+		ExpiresAt: *expiresAt}, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	created.Status = balance.StatusPending
+	created.OperationId = &operation.Id
+	return &created, nil
+}
+
+func (r BalanceRepository) GetAdjustment(id int64) (*models.Adjustment, error) {
+	return scanAdjustment(r.db.QueryRow(adjustmentQuery+" WHERE a.id = $1", id))
+}
+
+func (r BalanceRepository) GetAdjustments(filter *models.AdjustmentFilter) ([]*models.Adjustment, error) {
+	rows, err := r.db.Query(adjustmentQuery+`
+		WHERE ($1 = 0 OR a.user_id = $1) AND ($2 = '' OR a.operator = $2) AND ($3 = '' OR a.reason::text = $3)
+		ORDER BY a.created_at DESC, a.id DESC`,
+		filter.UserId, filter.Operator, filter.Reason)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	adjustments := make([]*models.Adjustment, 0)
+	for rows.Next() {
+		adjustment, err := scanAdjustment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		adjustments = append(adjustments, adjustment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return adjustments, nil
+}
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"avito-intership/balance"
+	"avito-intership/models"
+	"time"
+)
+
+func (suite *balanceRepositorySuite) TestCreateAdjustment_Immediate() {
+	suite.curId += 1
+	id := suite.curId
+
+	adjustment, err := suite.repository.CreateAdjustment(&models.Adjustment{UserId: id, Amount: smallAmount,
+		Operator: "alice", Reason: balance.ReasonGoodwill, Comment: "compensation"}, nil)
+	suite.NoError(err, "creating adjustment should not produce error")
+	suite.Equal(balance.StatusCompleted, adjustment.Status)
+
+	amount, err := suite.repository.GetBalance(id)
+	suite.NoError(err, "getting balance should not produce error")
+	suite.Equal(smallAmount, amount)
+
+	history, err := suite.repository.GetHistory(id, 1, 10, balance.SortDate, false)
+	suite.NoError(err, "getting history should not produce error")
+	suite.Len(history, 1)
+	suite.Equal(balance.AdjustmentType, history[0].Type)
+
+	stored, err := suite.repository.GetAdjustment(adjustment.Id)
+	suite.NoError(err, "getting adjustment should not produce error")
+	suite.Equal("alice", stored.Operator)
+	suite.Equal(balance.ReasonGoodwill, stored.Reason)
+	suite.Equal("compensation", stored.Comment)
+	suite.Equal(balance.StatusCompleted, stored.Status)
+	suite.Nil(stored.OperationId)
+}
+
+func (suite *balanceRepositorySuite) TestCreateAdjustment_TooLowBalance() {
+	suite.curId += 1
+	id := suite.curId
+
+	_, err := suite.repository.CreateAdjustment(&models.Adjustment{UserId: id, Amount: -smallAmount,
+		Operator: "alice", Reason: balance.ReasonChargeback, Comment: "chargeback"}, nil)
+	suite.Equal(balance.ErrTooLowBalance, err)
+
+	adjustments, err := suite.repository.GetAdjustments(&models.AdjustmentFilter{UserId: id})
+	suite.NoError(err, "getting adjustments should not produce error")
+	suite.Len(adjustments, 0, "failed adjustment is not recorded")
+}
+
+func (suite *balanceRepositorySuite) TestCreateAdjustment_Pending() {
+	suite.curId += 1
+	id := suite.curId
+
+	expiresAt := time.Now().Add(time.Hour)
+	adjustment, err := suite.repository.CreateAdjustment(&models.Adjustment{UserId: id, Amount: bigAmount,
+		Operator: "alice", Reason: balance.ReasonFailedService, Comment: "refund"}, &expiresAt)
+	suite.NoError(err, "creating adjustment should not produce error")
+	suite.Equal(balance.StatusPending, adjustment.Status)
+	suite.NotNil(adjustment.OperationId)
+
+	amount, err := suite.repository.GetBalance(id)
+	suite.NoError(err, "getting balance should not produce error")
+	suite.Equal(float32(0), amount, "pending adjustment does not change balance")
+
+	_, err = suite.repository.ApprovePending(*adjustment.OperationId, "alice", nil)
+	suite.Equal(balance.ErrSameOperator, err)
+
+	_, err = suite.repository.ApprovePending(*adjustment.OperationId, "bob", nil)
+	suite.NoError(err, "approving adjustment should not produce error")
+
+	amount, err = suite.repository.GetBalance(id)
+	suite.NoError(err, "getting balance should not produce error")
+	suite.Equal(bigAmount, amount)
+
+	stored, err := suite.repository.GetAdjustment(adjustment.Id)
+	suite.NoError(err, "getting adjustment should not produce error")
+	suite.Equal(balance.StatusCompleted, stored.Status)
+	suite.Equal(*adjustment.OperationId, *stored.OperationId)
+}
+
+func (suite *balanceRepositorySuite) TestGetAdjustments_Filter() {
+	suite.curId += 1
+	id := suite.curId
+
+	for _, operator := range []string{"alice", "bob"} {
+		_, err := suite.repository.CreateAdjustment(&models.Adjustment{UserId: id, Amount: smallAmount,
+			Operator: operator, Reason: balance.ReasonCorrection, Comment: "fix"}, nil)
+		suite.NoError(err, "creating adjustment should not produce error")
+	}
+
+	adjustments, err := suite.repository.GetAdjustments(&models.AdjustmentFilter{UserId: id})
+	suite.NoError(err, "getting adjustments should not produce error")
+	suite.Len(adjustments, 2)
+
+	adjustments, err = suite.repository.GetAdjustments(&models.AdjustmentFilter{UserId: id, Operator: "bob"})
+	suite.NoError(err, "getting adjustments should not produce error")
+	suite.Len(adjustments, 1)
+	suite.Equal("bob", adjustments[0].Operator)
+
+	adjustments, err = suite.repository.GetAdjustments(&models.AdjustmentFilter{UserId: id,
+		Reason: balance.ReasonGoodwill})
+	suite.NoError(err, "getting adjustments should not produce error")
+	suite.Len(adjustments, 0)
+
+	_, err = suite.repository.GetAdjustment(-1)
+	suite.Equal(balance.ErrAdjustmentNotFound, err)
+}
@@ -3,7 +3,10 @@ package postgres
 import (
 	"avito-intership/balance"
 	"avito-intership/models"
+	outboxPostgres "avito-intership/outbox/repository/postgres"
+	streamPostgres "avito-intership/stream/repository/postgres"
 	"database/sql"
+	"math"
 	"time"
 )
 
@@ -16,22 +19,49 @@ func NewBalanceRepository(dbConn *sql.DB) *BalanceRepository {
 }
 
 type Transaction struct {
-	Id       int64
-	UserId   int64
-	Amount   float32
-	TargetId int64
-	Type     string
-	Time     time.Time
+	Id        int64
+	UserId    int64
+	Amount    float32
+	TargetId  int64
+	Type      string
+	Time      time.Time
+	Fee       float32
+	Bonus     float32
+	Reference sql.NullString
+	LinkedId  sql.NullInt64
+	// Пустое состояние при записи означает выполненную операцию
+	Status      string
+	OperationId sql.NullInt64
+	Product     sql.NullString
 }
 
 func transactionToModel(transaction Transaction) *models.Transaction {
-	return &models.Transaction{
+	result := &models.Transaction{
+		Id:       transaction.Id,
 		UserId:   transaction.UserId,
 		Amount:   transaction.Amount,
 		TargetId: transaction.TargetId,
 		Type:     transaction.Type,
 		Time:     transaction.Time,
+		Fee:      transaction.Fee,
+		Bonus:    transaction.Bonus,
+		Status:   transaction.Status,
 	}
+
+	if transaction.Reference.Valid {
+		result.Reference = &transaction.Reference.String
+	}
+	if transaction.LinkedId.Valid {
+		result.LinkedId = &transaction.LinkedId.Int64
+	}
+	if transaction.OperationId.Valid {
+		result.OperationId = &transaction.OperationId.Int64
+	}
+	if transaction.Product.Valid {
+		result.ProductName = &transaction.Product.String
+	}
+
+	return result
 }
 
 func (r BalanceRepository) GetBalance(userId int64) (float32, error) {
@@ -59,12 +89,44 @@ func (r BalanceRepository) GetBalance(userId int64) (float32, error) {
 	return currentAmount, nil
 }
 
-func (r BalanceRepository) insertTransaction(userId int64, amount float32, target int64, trType string, tx *sql.Tx) error {
-	_, err := tx.Exec(`INSERT INTO transactions (user_id, amount, target_id, type) VALUES ($1, $2, $3, $4)`,
-		userId, amount, target, trType)
+func (r BalanceRepository) insertTransaction(t Transaction, tx *sql.Tx) error {
+	_, err := r.insertTransactionId(t, tx)
 	return err
 }
 
+// insertTransactionId записывает операцию и возвращает ее id, чтобы на нее можно было сослаться
+func (r BalanceRepository) insertTransactionId(t Transaction, tx *sql.Tx) (int64, error) {
+	status := t.Status
+	if status == "" {
+		status = balance.StatusCompleted
+	}
+
+	t.Status = status
+	err := tx.QueryRow(
+		`INSERT INTO transactions (user_id, amount, target_id, type, fee, bonus, reference, linked_id, status, operation_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9::transaction_status, $10) RETURNING id, date`,
+		t.UserId, t.Amount, t.TargetId, t.Type, t.Fee, t.Bonus, t.Reference, t.LinkedId, status, t.OperationId).
+		Scan(&t.Id, &t.Time)
+	if err != nil {
+		return 0, err
+	}
+
+	// Операции, ожидающие одобрения, баланс не меняют, событие записывается при их выполнении
+	if status == balance.StatusCompleted {
+		err = outboxPostgres.AppendTransaction(tx, transactionToModel(t))
+		if err != nil {
+			return 0, err
+		}
+
+		err = streamPostgres.NotifyTransaction(tx, transactionToModel(t))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return t.Id, nil
+}
+
 func (r BalanceRepository) ChangeBalance(userId int64, amount float32, productId int64) error {
 	tx, err := r.db.Begin()
 	if err != nil {
@@ -79,23 +141,63 @@ func (r BalanceRepository) ChangeBalance(userId int64, amount float32, productId
 		}
 	}()
 
+	err = r.changeBalance(userId, amount, productId, tx)
+	return err
+}
+
+func (r BalanceRepository) ChangeBalanceWithFee(userId int64, amount float32, productId int64, fee float32) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	err = r.changeBalance(userId, amount, productId, tx)
+	if err != nil {
+		return err
+	}
+
+	if fee > 0 {
+		err = r.credit(balance.FxFeeAccountId, fee, userId, balance.FeeType, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r BalanceRepository) changeBalance(userId int64, amount float32, productId int64, tx *sql.Tx) error {
 	var currentAmount float32
 	row := tx.QueryRow("SELECT amount FROM balances WHERE id = $1 FOR UPDATE", userId)
-	err = row.Scan(&currentAmount)
+	err := row.Scan(&currentAmount)
 	if err != nil && err != sql.ErrNoRows {
 		return err
 	}
 
-	if currentAmount+amount < 0 {
-		err = balance.ErrTooLowBalance
-		return err
+	var bonus float32
+	if amount < 0 {
+		err = r.checkLimits(userId, balance.SpendOperation, -amount, 1, tx)
+		if err != nil {
+			return err
+		}
+
+		// Списание на услуги в первую очередь оплачивается бонусами
+		bonus, err = r.spendBonus(userId, -amount, tx)
+		if err != nil {
+			return err
+		}
 	}
-	// Если счета у пользователя нет, то создаем его, иначе обновляем
-	_, err = tx.Exec(
-		`INSERT INTO balances(id, amount) VALUES ($1, $2) 
-		ON CONFLICT(id) DO UPDATE SET amount = balances.amount + EXCLUDED.amount`, userId, amount)
-	if err != nil {
-		return err
+
+	if currentAmount+amount+bonus < 0 {
+		return balance.ErrTooLowBalance
 	}
 
 	var txType string
@@ -105,14 +207,391 @@ func (r BalanceRepository) ChangeBalance(userId int64, amount float32, productId
 		txType = balance.RefillType
 	}
 
-	err = r.insertTransaction(userId, amount, productId, txType, tx)
+	_, err = tx.Exec(
+		`INSERT INTO balances(id, amount) VALUES ($1, $2) 
+		ON CONFLICT(id) DO UPDATE SET amount = balances.amount + EXCLUDED.amount`, userId, amount+bonus)
+	if err != nil {
+		return err
+	}
+
+	purchaseId, err := r.insertTransactionId(Transaction{UserId: userId, Amount: amount, TargetId: productId,
+		Type: txType, Bonus: -bonus}, tx)
+	if err != nil {
+		return err
+	}
+
+	if txType == balance.WithdrawType {
+		return r.applyCashback(userId, -amount, productId, purchaseId, tx)
+	}
+
+	return nil
+}
+
+// applyCashback начисляет кэшбэк за покупку purchaseId на сумму spent по всем подходящим активным правилам.
+// Вызывается после блокировки строки баланса, поэтому параллельные покупки пользователя не превысят месячный лимит
+func (r BalanceRepository) applyCashback(userId int64, spent float32, productId int64, purchaseId int64,
+	tx *sql.Tx) error {
+	rows, err := tx.Query(
+		`SELECT id, percent, monthly_cap FROM cashback_rules
+		WHERE active AND (product_id IS NULL OR product_id = $1) ORDER BY id`, productId)
+	if err != nil {
+		return err
+	}
+
+	type rule struct {
+		id         int64
+		percent    float64
+		monthlyCap sql.NullFloat64
+	}
+
+	rules := make([]rule, 0)
+	for rows.Next() {
+		var cr rule
+		if err = rows.Scan(&cr.id, &cr.percent, &cr.monthlyCap); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		rules = append(rules, cr)
+	}
+	if err = rows.Close(); err != nil {
+		return err
+	}
+
+	for _, cr := range rules {
+		cashback := int64(math.Round(float64(toKopecks(float64(spent))) * cr.percent / 100))
+
+		if cr.monthlyCap.Valid {
+			var earned float64
+			err = tx.QueryRow(
+				`SELECT COALESCE(SUM(amount), 0) FROM transactions
+				WHERE user_id = $1 AND type = $2 AND target_id = $3 AND date >= date_trunc('month', NOW())`,
+				userId, balance.CashbackType, cr.id).Scan(&earned)
+			if err != nil {
+				return err
+			}
+
+			if left := toKopecks(cr.monthlyCap.Float64) - toKopecks(earned); cashback > left {
+				cashback = left
+			}
+		}
+
+		if cashback <= 0 {
+			continue
+		}
+
+		_, err = tx.Exec("UPDATE balances SET amount = amount + $1 WHERE id = $2", fromKopecks(cashback), userId)
+		if err != nil {
+			return err
+		}
+
+		err = r.insertTransaction(Transaction{UserId: userId, Amount: fromKopecks(cashback), TargetId: cr.id,
+			Type: balance.CashbackType, LinkedId: sql.NullInt64{Int64: purchaseId, Valid: true}}, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// spendBonus списывает до amount с несгоревших бонусов, начиная с ближайших к сгоранию, и возвращает списанную сумму.
+// Если основного баланса не хватит на остаток, вызывающий откатывает транзакцию вместе со списанием бонусов
+func (r BalanceRepository) spendBonus(userId int64, amount float32, tx *sql.Tx) (float32, error) {
+	rows, err := tx.Query(
+		`SELECT id, amount FROM bonuses WHERE user_id = $1 AND amount > 0 AND expires_at > NOW()
+		ORDER BY expires_at, id FOR UPDATE`, userId)
+	if err != nil {
+		return 0, err
+	}
+
+	type lot struct {
+		id      int64
+		kopecks int64
+	}
+
+	lots := make([]lot, 0)
+	for rows.Next() {
+		var l lot
+		var lotAmount float64
+		if err = rows.Scan(&l.id, &lotAmount); err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+		l.kopecks = toKopecks(lotAmount)
+		lots = append(lots, l)
+	}
+	if err = rows.Close(); err != nil {
+		return 0, err
+	}
+
+	// Считаем в копейках, чтобы остатки бонусов не накапливали ошибку округления
+	need := toKopecks(float64(amount))
+	var spent int64
+	for _, l := range lots {
+		if need == 0 {
+			break
+		}
+
+		take := l.kopecks
+		if take > need {
+			take = need
+		}
+
+		_, err = tx.Exec("UPDATE bonuses SET amount = amount - $1 WHERE id = $2", fromKopecks(take), l.id)
+		if err != nil {
+			return 0, err
+		}
+
+		need -= take
+		spent += take
+	}
+
+	return fromKopecks(spent), nil
+}
+
+// checkLimits проверяет, что операция на amount рублей, состоящая из count операций, не превысит лимитов счета
+// на операцию operation. Использованная часть лимита считается по таблице transactions за текущий календарный
+// период, поэтому вызывать нужно после блокировки строки баланса - иначе параллельные операции превысят лимит
+func (r BalanceRepository) checkLimits(userId int64, operation string, amount float32, count int64, tx *sql.Tx) error {
+	// На служебные счета лимиты не распространяются
+	if userId <= 0 {
+		return nil
+	}
+
+	txType := balance.TransferType
+	if operation == balance.SpendOperation {
+		txType = balance.WithdrawType
+	}
+
+	rows, err := tx.Query(
+		`SELECT l.id, l.user_id, l.tier, l.operation, l.period, l.max_amount, l.max_count,
+			COALESCE(SUM(-t.amount), 0), COUNT(t.id),
+			date_trunc(l.period::text, NOW()) + ('1 ' || l.period::text)::interval
+		FROM account_limits l
+		LEFT JOIN transactions t ON t.user_id = $1 AND t.type = $3::transaction_type AND t.amount < 0
+			AND t.status = 'completed' AND t.date >= date_trunc(l.period::text, NOW())
+		WHERE l.operation = $2::limit_operation AND (l.user_id = $1 OR l.tier =
+			COALESCE((SELECT tier FROM account_tiers WHERE user_id = $1), $4))
+		GROUP BY l.id`, userId, operation, txType, balance.DefaultTier)
 	if err != nil {
 		return err
 	}
+	defer rows.Close()
+
+	// Если превышено несколько лимитов, сообщаем о том, который сбросится позже
+	var exceeded *balance.LimitExceededError
+	for rows.Next() {
+		var limit models.Limit
+		var limitUserId sql.NullInt64
+		var tier sql.NullString
+		var maxAmount sql.NullFloat64
+		var maxCount sql.NullInt64
+		var usedAmount float64
+		var usedCount int64
+		var resetsAt time.Time
+
+		err = rows.Scan(&limit.Id, &limitUserId, &tier, &limit.Operation, &limit.Period, &maxAmount, &maxCount,
+			&usedAmount, &usedCount, &resetsAt)
+		if err != nil {
+			return err
+		}
+
+		amountExceeded := maxAmount.Valid && toKopecks(usedAmount)+toKopecks(float64(amount)) > toKopecks(maxAmount.Float64)
+		countExceeded := maxCount.Valid && usedCount+count > maxCount.Int64
+		if !amountExceeded && !countExceeded {
+			continue
+		}
+
+		if limitUserId.Valid {
+			limit.UserId = &limitUserId.Int64
+		}
+		if tier.Valid {
+			limit.Tier = &tier.String
+		}
+		if maxAmount.Valid {
+			value := float32(maxAmount.Float64)
+			limit.MaxAmount = &value
+		}
+		if maxCount.Valid {
+			limit.MaxCount = &maxCount.Int64
+		}
+
+		if exceeded == nil || resetsAt.After(exceeded.ResetsAt) {
+			exceeded = &balance.LimitExceededError{Limit: &limit, ResetsAt: resetsAt}
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+	if exceeded != nil {
+		return exceeded
+	}
 
 	return nil
 }
 
+func toKopecks(amount float64) int64 {
+	return int64(math.Round(amount * 100))
+}
+
+func fromKopecks(kopecks int64) float32 {
+	return float32(float64(kopecks) / 100)
+}
+
+func (r BalanceRepository) GrantBonus(userId int64, amount float32, expiresAt time.Time) (*models.Bonus, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	// Бонусы привязаны к счету, поэтому создаем его, если его еще нет
+	_, err = tx.Exec("INSERT INTO balances(id, amount) VALUES ($1, 0) ON CONFLICT(id) DO NOTHING", userId)
+	if err != nil {
+		return nil, err
+	}
+
+	bonus := models.Bonus{UserId: userId}
+	row := tx.QueryRow(
+		`INSERT INTO bonuses (user_id, amount, expires_at) VALUES ($1, $2, $3)
+		RETURNING id, amount, expires_at, created_at`, userId, amount, expiresAt)
+	err = row.Scan(&bonus.Id, &bonus.Amount, &bonus.ExpiresAt, &bonus.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.insertTransaction(Transaction{UserId: userId, Amount: bonus.Amount, TargetId: bonus.Id,
+		Type: balance.BonusType, Bonus: bonus.Amount}, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bonus, nil
+}
+
+func (r BalanceRepository) GetBonuses(userId int64, at time.Time) ([]*models.Bonus, error) {
+	rows, err := r.db.Query(
+		`SELECT id, user_id, amount, expires_at, created_at FROM bonuses
+		WHERE user_id = $1 AND amount > 0 AND expires_at > $2 ORDER BY expires_at, id`, userId, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bonuses := make([]*models.Bonus, 0)
+	for rows.Next() {
+		var bonus models.Bonus
+		err = rows.Scan(&bonus.Id, &bonus.UserId, &bonus.Amount, &bonus.ExpiresAt, &bonus.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		bonuses = append(bonuses, &bonus)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return bonuses, nil
+}
+
+func (r BalanceRepository) ExpireBonuses(at time.Time) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	// Обнуляем остатки и записываем их списание в историю одним запросом
+	rows, err := tx.Query(
+		`WITH expired AS (
+			SELECT id, user_id, amount FROM bonuses WHERE amount > 0 AND expires_at <= $1 FOR UPDATE
+		), cleared AS (
+			UPDATE bonuses b SET amount = 0 FROM expired e WHERE b.id = e.id
+		)
+		INSERT INTO transactions (user_id, amount, target_id, type, bonus)
+		SELECT user_id, -amount, id, $2::transaction_type, -amount FROM expired
+		RETURNING id, user_id, amount, target_id, type, bonus, status, date`, at, balance.BonusExpiredType)
+	if err != nil {
+		return 0, err
+	}
+
+	expired := make([]Transaction, 0)
+	for rows.Next() {
+		var t Transaction
+		err = rows.Scan(&t.Id, &t.UserId, &t.Amount, &t.TargetId, &t.Type, &t.Bonus, &t.Status, &t.Time)
+		if err != nil {
+			_ = rows.Close()
+			return 0, err
+		}
+
+		expired = append(expired, t)
+	}
+	_ = rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, t := range expired {
+		err = outboxPostgres.AppendTransaction(tx, transactionToModel(t))
+		if err != nil {
+			return 0, err
+		}
+
+		err = streamPostgres.NotifyTransaction(tx, transactionToModel(t))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return int64(len(expired)), nil
+}
+
+// credit зачисляет amount на счет accountId и записывает операцию
+func (r BalanceRepository) credit(accountId int64, amount float32, target int64, trType string, tx *sql.Tx) error {
+	return r.creditTransaction(Transaction{UserId: accountId, Amount: amount, TargetId: target, Type: trType}, tx)
+}
+
+// Credit зачисляет amount на счет accountId в транзакции tx, открытой другим репозиторием, и записывает
+// операцию с событием; reference может быть пустым
+func Credit(tx *sql.Tx, accountId int64, amount float32, target int64, trType string, reference string) error {
+	t := Transaction{UserId: accountId, Amount: amount, TargetId: target, Type: trType}
+	if reference != "" {
+		t.Reference = sql.NullString{String: reference, Valid: true}
+	}
+
+	return BalanceRepository{}.creditTransaction(t, tx)
+}
+
+func (r BalanceRepository) creditTransaction(t Transaction, tx *sql.Tx) error {
+	// Если счета нет, то создаем его, иначе обновляем
+	_, err := tx.Exec(
+		`INSERT INTO balances(id, amount) VALUES ($1, $2) 
+		ON CONFLICT(id) DO UPDATE SET amount = balances.amount + EXCLUDED.amount`, t.UserId, t.Amount)
+	if err != nil {
+		return err
+	}
+
+	return r.insertTransaction(t, tx)
+}
+
 /* Перевод денег от пользователя srcUserId пользователю dstUserId
    amount - положительное количество переводимых денег */
 func (r BalanceRepository) TransferMoney(srcUserId int64, dstUserId int64, amount float32) error {
@@ -129,16 +608,32 @@ func (r BalanceRepository) TransferMoney(srcUserId int64, dstUserId int64, amoun
 		}
 	}()
 
+	err = r.transferMoney(srcUserId, dstUserId, amount, tx)
+	return err
+}
+
+// Transfer выполняет перевод в транзакции tx, открытой другим репозиторием, чтобы перевод и
+// связанное с ним изменение, например статуса сделки, применялись вместе
+func Transfer(tx *sql.Tx, srcUserId int64, dstUserId int64, amount float32) error {
+	// Операции внутри транзакции не используют соединение репозитория
+	return BalanceRepository{}.transferMoney(srcUserId, dstUserId, amount, tx)
+}
+
+func (r BalanceRepository) transferMoney(srcUserId int64, dstUserId int64, amount float32, tx *sql.Tx) error {
 	// Проверяем, что у пользователя srcUserId достаточно денег для перевода
 	var currentAmount float32
 	row := tx.QueryRow("SELECT amount FROM balances WHERE id = $1 FOR UPDATE", srcUserId)
-	err = row.Scan(&currentAmount)
+	err := row.Scan(&currentAmount)
 	if err != nil && err != sql.ErrNoRows {
 		return err
 	}
 
 	if currentAmount-amount < 0 {
-		err = balance.ErrTooLowBalance
+		return balance.ErrTooLowBalance
+	}
+
+	err = r.checkLimits(srcUserId, balance.TransferOperation, amount, 1, tx)
+	if err != nil {
 		return err
 	}
 
@@ -155,12 +650,12 @@ func (r BalanceRepository) TransferMoney(srcUserId int64, dstUserId int64, amoun
 		return err
 	}
 
-	err = r.insertTransaction(srcUserId, -amount, dstUserId, balance.TransferType, tx)
+	err = r.insertTransaction(Transaction{UserId: srcUserId, Amount: -amount, TargetId: dstUserId, Type: balance.TransferType}, tx)
 	if err != nil {
 		return err
 	}
 
-	err = r.insertTransaction(dstUserId, amount, srcUserId, balance.TransferType, tx)
+	err = r.insertTransaction(Transaction{UserId: dstUserId, Amount: amount, TargetId: srcUserId, Type: balance.TransferType}, tx)
 	if err != nil {
 		return err
 	}
@@ -168,6 +663,82 @@ func (r BalanceRepository) TransferMoney(srcUserId int64, dstUserId int64, amoun
 	return nil
 }
 
+func (r BalanceRepository) TransferMoneyWithFee(srcUserId int64, payouts []*models.Payout) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	err = r.transferMoneyWithFee(srcUserId, payouts, tx)
+	return err
+}
+
+func (r BalanceRepository) transferMoneyWithFee(srcUserId int64, payouts []*models.Payout, tx *sql.Tx) error {
+	var total, fee float32
+	for _, payout := range payouts {
+		total += payout.Amount + payout.Fee
+		fee += payout.Fee
+	}
+
+	var currentAmount float32
+	row := tx.QueryRow("SELECT amount FROM balances WHERE id = $1 FOR UPDATE", srcUserId)
+	err := row.Scan(&currentAmount)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	if currentAmount-total < 0 {
+		return balance.ErrTooLowBalance
+	}
+
+	// Каждый получатель разделенного платежа считается отдельным переводом
+	err = r.checkLimits(srcUserId, balance.TransferOperation, total, int64(len(payouts)), tx)
+	if err != nil {
+		return err
+	}
+
+	for _, payout := range payouts {
+		_, err = tx.Exec("UPDATE balances SET amount = amount - $1 WHERE id = $2", payout.Amount+payout.Fee, srcUserId)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(
+			`INSERT INTO balances(id, amount) VALUES ($1, $2) 
+			ON CONFLICT(id) DO UPDATE SET amount = balances.amount + EXCLUDED.amount`, payout.UserId, payout.Amount)
+		if err != nil {
+			return err
+		}
+
+		// Комиссия отображается в операциях и отправителя, и получателя
+		err = r.insertTransaction(Transaction{UserId: srcUserId, Amount: -(payout.Amount + payout.Fee),
+			TargetId: payout.UserId, Type: balance.TransferType, Fee: payout.Fee}, tx)
+		if err != nil {
+			return err
+		}
+
+		err = r.insertTransaction(Transaction{UserId: payout.UserId, Amount: payout.Amount,
+			TargetId: srcUserId, Type: balance.TransferType, Fee: payout.Fee}, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	if fee > 0 {
+		return r.credit(balance.RevenueAccountId, fee, srcUserId, balance.FeeType, tx)
+	}
+
+	return nil
+}
+
 func (r BalanceRepository) GetHistory(userId int64, page int64, perPage int64, sort int, desc bool) ([]*models.Transaction, error) {
 	tx, err := r.db.Begin()
 	if err != nil {
@@ -182,13 +753,16 @@ func (r BalanceRepository) GetHistory(userId int64, page int64, perPage int64, s
 		}
 	}()
 
-	orderColumn := "date"
+	orderColumn := "t.date"
 	if sort == balance.SortAmount {
-		orderColumn = "amount"
+		orderColumn = "t.amount"
 	}
 
-	query := `SELECT user_id, amount, target_id, type, date 
-				FROM transactions WHERE user_id = $1 ORDER BY ` + orderColumn
+	query := `SELECT t.id, t.user_id, t.amount, t.target_id, t.type, t.date, t.fee, t.bonus, t.reference, t.linked_id,
+					t.status, t.operation_id, p.name
+				FROM transactions t
+				LEFT JOIN products p ON t.type = 'product' AND p.id = t.target_id
+				WHERE t.user_id = $1 ORDER BY ` + orderColumn
 	if desc {
 		query += " DESC"
 	}
@@ -202,7 +776,8 @@ func (r BalanceRepository) GetHistory(userId int64, page int64, perPage int64, s
 	transactions := make([]*models.Transaction, 0)
 	for rows.Next() {
 		var tx Transaction
-		err = rows.Scan(&tx.UserId, &tx.Amount, &tx.TargetId, &tx.Type, &tx.Time)
+		err = rows.Scan(&tx.Id, &tx.UserId, &tx.Amount, &tx.TargetId, &tx.Type, &tx.Time, &tx.Fee, &tx.Bonus,
+			&tx.Reference, &tx.LinkedId, &tx.Status, &tx.OperationId, &tx.Product)
 		if err != nil {
 			return nil, err
 		}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"log"
 	"testing"
+	"time"
 )
 
 const (
@@ -153,6 +154,247 @@ func (suite *balanceRepositorySuite) TestTransferMoney_TooLowBalance() {
 }
 
 
+func (suite *balanceRepositorySuite) TestChangeBalanceWithFee() {
+	suite.curId += 1
+	id := suite.curId
+	var product int64 = 1
+	var fee float32 = 15
+
+	feeBefore, err := suite.repository.GetBalance(balance.FxFeeAccountId)
+	suite.NoError(err, "getting fee account balance should not produce error")
+
+	err = suite.repository.ChangeBalance(id, bigAmount, 0)
+	suite.NoError(err, "positive changing balance should not produce error")
+
+	err = suite.repository.ChangeBalanceWithFee(id, -smallAmount, product, fee)
+	suite.NoError(err, "changing balance with fee should not produce error")
+
+	amount, err := suite.repository.GetBalance(id)
+	suite.NoError(err, "getting balance should not produce error")
+	suite.Equal(bigAmount-smallAmount, amount)
+
+	feeAfter, err := suite.repository.GetBalance(balance.FxFeeAccountId)
+	suite.NoError(err, "getting fee account balance should not produce error")
+	suite.Equal(feeBefore+fee, feeAfter)
+}
+
+
+func (suite *balanceRepositorySuite) TestTransferMoneyWithFee() {
+	suite.curId += 1
+	srcId := suite.curId
+	suite.curId += 1
+	firstId := suite.curId
+	suite.curId += 1
+	secondId := suite.curId
+
+	revenueBefore, err := suite.repository.GetBalance(balance.RevenueAccountId)
+	suite.NoError(err, "getting revenue account balance should not produce error")
+
+	err = suite.repository.ChangeBalance(srcId, smallAmount, 0)
+	suite.NoError(err, "positive changing balance should not produce error")
+
+	payouts := []*models.Payout{{UserId: firstId, Amount: 63, Fee: 7}, {UserId: secondId, Amount: 27, Fee: 3}}
+	err = suite.repository.TransferMoneyWithFee(srcId, payouts)
+	suite.NoError(err, "transfer with fee should not produce error")
+
+	amount, err := suite.repository.GetBalance(srcId)
+	suite.NoError(err, "getting balance should not produce error")
+	suite.Equal(smallAmount-100, amount)
+
+	amount, err = suite.repository.GetBalance(firstId)
+	suite.NoError(err, "getting balance should not produce error")
+	suite.Equal(float32(63), amount)
+
+	revenueAfter, err := suite.repository.GetBalance(balance.RevenueAccountId)
+	suite.NoError(err, "getting revenue account balance should not produce error")
+	suite.Equal(revenueBefore+10, revenueAfter)
+
+	history, err := suite.repository.GetHistory(firstId, 1, 10, balance.SortDate, false)
+	suite.NoError(err, "getting history should not produce error")
+	suite.Equal(float32(7), history[0].Fee, "fee is shown to the recipient")
+}
+
+func (suite *balanceRepositorySuite) TestTransferMoneyWithFee_TooLowBalance() {
+	suite.curId += 1
+	srcId := suite.curId
+
+	err := suite.repository.TransferMoneyWithFee(srcId, []*models.Payout{{UserId: 1, Amount: 10, Fee: 1}})
+	suite.Equal(balance.ErrTooLowBalance, err)
+}
+
+
+func (suite *balanceRepositorySuite) TestChangeBalance_BonusSpentFirst() {
+	suite.curId += 1
+	id := suite.curId
+	var product int64 = 1
+
+	err := suite.repository.ChangeBalance(id, smallAmount, 0)
+	suite.NoError(err, "positive changing balance should not produce error")
+	_, err = suite.repository.GrantBonus(id, 30, time.Now().Add(time.Hour))
+	suite.NoError(err, "granting bonus should not produce error")
+
+	err = suite.repository.ChangeBalance(id, -halfAmount, product)
+	suite.NoError(err, "withdraw should not produce error")
+
+	amount, err := suite.repository.GetBalance(id)
+	suite.NoError(err, "getting balance should not produce error")
+	suite.Equal(smallAmount-halfAmount+30, amount, "bonus should be spent before the main balance")
+
+	bonuses, err := suite.repository.GetBonuses(id, time.Now())
+	suite.NoError(err, "getting bonuses should not produce error")
+	suite.Len(bonuses, 0)
+
+	history, err := suite.repository.GetHistory(id, 1, 10, balance.SortDate, true)
+	suite.NoError(err, "getting history should not produce error")
+	suite.Equal(-halfAmount, history[0].Amount)
+	suite.Equal(float32(-30), history[0].Bonus)
+}
+
+func (suite *balanceRepositorySuite) TestChangeBalance_BonusAndMainTooLow() {
+	suite.curId += 1
+	id := suite.curId
+
+	_, err := suite.repository.GrantBonus(id, 30, time.Now().Add(time.Hour))
+	suite.NoError(err, "granting bonus should not produce error")
+
+	err = suite.repository.ChangeBalance(id, -halfAmount, 1)
+	suite.Equal(balance.ErrTooLowBalance, err)
+
+	bonuses, err := suite.repository.GetBonuses(id, time.Now())
+	suite.NoError(err, "getting bonuses should not produce error")
+	suite.Equal(float32(30), bonuses[0].Amount, "bonus should be kept when withdraw fails")
+}
+
+func (suite *balanceRepositorySuite) TestTransferMoney_BonusNotTransferable() {
+	suite.curId += 1
+	srcId := suite.curId
+	suite.curId += 1
+	dstId := suite.curId
+
+	_, err := suite.repository.GrantBonus(srcId, smallAmount, time.Now().Add(time.Hour))
+	suite.NoError(err, "granting bonus should not produce error")
+
+	err = suite.repository.TransferMoney(srcId, dstId, halfAmount)
+	suite.Equal(balance.ErrTooLowBalance, err)
+}
+
+func (suite *balanceRepositorySuite) TestExpireBonuses() {
+	suite.curId += 1
+	id := suite.curId
+
+	_, err := suite.repository.GrantBonus(id, 30, time.Now().Add(time.Hour))
+	suite.NoError(err, "granting bonus should not produce error")
+
+	expired, err := suite.repository.ExpireBonuses(time.Now().Add(2 * time.Hour))
+	suite.NoError(err, "expiring bonuses should not produce error")
+	suite.GreaterOrEqual(expired, int64(1))
+
+	bonuses, err := suite.repository.GetBonuses(id, time.Now())
+	suite.NoError(err, "getting bonuses should not produce error")
+	suite.Len(bonuses, 0)
+
+	history, err := suite.repository.GetHistory(id, 1, 10, balance.SortDate, true)
+	suite.NoError(err, "getting history should not produce error")
+	suite.Equal(balance.BonusExpiredType, history[0].Type)
+	suite.Equal(float32(-30), history[0].Bonus)
+}
+
+
+func (suite *balanceRepositorySuite) TestChangeBalance_Cashback() {
+	suite.curId += 1
+	id := suite.curId
+
+	var productId, ruleId int64
+	err := suite.db.QueryRow(
+		"INSERT INTO products (name, price, currency) VALUES ('Cashback', 100, 'RUB') RETURNING id").Scan(&productId)
+	suite.Require().NoError(err)
+	err = suite.db.QueryRow(
+		"INSERT INTO cashback_rules (product_id, percent, monthly_cap) VALUES ($1, 5, 8) RETURNING id",
+		productId).Scan(&ruleId)
+	suite.Require().NoError(err)
+	defer suite.db.Exec("UPDATE cashback_rules SET active = FALSE WHERE id = $1", ruleId)
+
+	err = suite.repository.ChangeBalance(id, 1000, balance.RefillId)
+	suite.NoError(err, "positive changing balance should not produce error")
+
+	err = suite.repository.ChangeBalance(id, -100, productId)
+	suite.NoError(err, "purchase should not produce error")
+
+	history, err := suite.repository.GetHistory(id, 1, 10, balance.SortDate, true)
+	suite.NoError(err, "getting history should not produce error")
+	var purchase, cashback *models.Transaction
+	for _, t := range history {
+		switch t.Type {
+		case balance.WithdrawType:
+			purchase = t
+		case balance.CashbackType:
+			cashback = t
+		}
+	}
+	suite.Require().NotNil(cashback, "purchase should trigger cashback")
+	suite.Equal(float32(5), cashback.Amount)
+	suite.Equal(ruleId, cashback.TargetId)
+	suite.Equal(purchase.Id, *cashback.LinkedId)
+
+	// Второе начисление ограничено месячным лимитом 8
+	err = suite.repository.ChangeBalance(id, -100, productId)
+	suite.NoError(err, "purchase should not produce error")
+
+	amount, err := suite.repository.GetBalance(id)
+	suite.NoError(err, "getting balance should not produce error")
+	suite.Equal(float32(1000-200+8), amount)
+}
+
+func (suite *balanceRepositorySuite) TestTransferMoney_CountLimit() {
+	suite.curId += 1
+	srcId := suite.curId
+	suite.curId += 1
+	dstId := suite.curId
+
+	_, err := suite.db.Exec(
+		"INSERT INTO account_limits (user_id, operation, period, max_count) VALUES ($1, 'transfer', 'hour', 2)", srcId)
+	suite.Require().NoError(err)
+
+	err = suite.repository.ChangeBalance(srcId, smallAmount, balance.RefillId)
+	suite.NoError(err, "positive changing balance should not produce error")
+
+	for i := 0; i < 2; i++ {
+		err = suite.repository.TransferMoney(srcId, dstId, 1)
+		suite.NoError(err, "transfer within limit should not produce error")
+	}
+
+	err = suite.repository.TransferMoney(srcId, dstId, 1)
+	limitErr, ok := err.(*balance.LimitExceededError)
+	suite.Require().True(ok, "third transfer should exceed the limit")
+	suite.Equal("hour", limitErr.Limit.Period)
+	suite.True(limitErr.ResetsAt.After(time.Now()))
+
+	amount, err := suite.repository.GetBalance(srcId)
+	suite.NoError(err, "getting balance should not produce error")
+	suite.Equal(smallAmount-2, amount)
+}
+
+func (suite *balanceRepositorySuite) TestChangeBalance_TierAmountLimit() {
+	suite.curId += 1
+	id := suite.curId
+
+	_, err := suite.db.Exec("INSERT INTO account_tiers (user_id, tier) VALUES ($1, 'restricted')", id)
+	suite.Require().NoError(err)
+	_, err = suite.db.Exec(
+		"INSERT INTO account_limits (tier, operation, period, max_amount) VALUES ('restricted', 'spend', 'day', 10)")
+	suite.Require().NoError(err)
+
+	err = suite.repository.ChangeBalance(id, smallAmount, balance.RefillId)
+	suite.NoError(err, "positive changing balance should not produce error")
+
+	err = suite.repository.ChangeBalance(id, -6, 1)
+	suite.NoError(err, "spending within limit should not produce error")
+
+	err = suite.repository.ChangeBalance(id, -6, 1)
+	_, ok := err.(*balance.LimitExceededError)
+	suite.True(ok, "spending over the tier limit should fail")
+}
+
 func (suite *balanceRepositorySuite) TearDownSuite() {
 	err := utils.DropTable(suite.db, []string{"balances"})
 	if err != nil {
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"avito-intership/balance"
+	"avito-intership/models"
+	"avito-intership/outbox"
+	"encoding/json"
+	"time"
+)
+
+// userEvents возвращает события пользователя из outbox в порядке записи
+func (suite *balanceRepositorySuite) userEvents(userId int64) []*models.Event {
+	rows, err := suite.db.Query(
+		"SELECT id, type, schema_version, user_id, payload FROM outbox_events WHERE user_id = $1 ORDER BY id", userId)
+	suite.Require().NoError(err, "getting events should not produce error")
+	defer rows.Close()
+
+	events := make([]*models.Event, 0)
+	for rows.Next() {
+		var event models.Event
+		var payload []byte
+		suite.Require().NoError(rows.Scan(&event.Id, &event.Type, &event.SchemaVersion, &event.UserId, &payload))
+		event.Payload = payload
+		events = append(events, &event)
+	}
+
+	return events
+}
+
+func (suite *balanceRepositorySuite) TestEvents_ChangeBalanceAndTransfer() {
+	suite.curId += 1
+	srcId := suite.curId
+	suite.curId += 1
+	dstId := suite.curId
+
+	err := suite.repository.ChangeBalance(srcId, bigAmount, balance.RefillId)
+	suite.NoError(err, "positive changing balance should not produce error")
+
+	err = suite.repository.TransferMoney(srcId, dstId, smallAmount)
+	suite.NoError(err, "transferring money should not produce error")
+
+	events := suite.userEvents(srcId)
+	suite.Len(events, 2)
+	suite.Equal(outbox.EventBalanceCredited, events[0].Type)
+	suite.Equal(outbox.EventTransferCompleted, events[1].Type)
+	suite.Equal(outbox.SchemaVersion, events[1].SchemaVersion)
+
+	events = suite.userEvents(dstId)
+	suite.Len(events, 1)
+
+	var transaction models.Transaction
+	err = json.Unmarshal(events[0].Payload, &transaction)
+	suite.NoError(err, "payload should be a transaction")
+	suite.Equal(smallAmount, transaction.Amount)
+	suite.Equal(srcId, transaction.TargetId)
+	suite.Equal(balance.StatusCompleted, transaction.Status)
+}
+
+func (suite *balanceRepositorySuite) TestEvents_FailedTransfer() {
+	suite.curId += 1
+	srcId := suite.curId
+	suite.curId += 1
+	dstId := suite.curId
+
+	err := suite.repository.TransferMoney(srcId, dstId, smallAmount)
+	suite.Equal(balance.ErrTooLowBalance, err)
+
+	suite.Len(suite.userEvents(srcId), 0, "rolled back operation should not leave events")
+	suite.Len(suite.userEvents(dstId), 0, "rolled back operation should not leave events")
+}
+
+func (suite *balanceRepositorySuite) TestEvents_PendingOperation() {
+	suite.curId += 1
+	srcId := suite.curId
+	suite.curId += 1
+	dstId := suite.curId
+
+	err := suite.repository.ChangeBalance(srcId, bigAmount, balance.RefillId)
+	suite.NoError(err, "positive changing balance should not produce error")
+
+	operation := suite.createPendingTransfer(srcId, dstId, smallAmount, time.Now().Add(time.Hour))
+	suite.Len(suite.userEvents(dstId), 0, "pending operation does not change balance")
+
+	_, err = suite.repository.ApprovePending(operation.Id, "alice", nil)
+	suite.NoError(err, "approving operation should not produce error")
+	suite.Len(suite.userEvents(dstId), 1)
+}
@@ -0,0 +1,358 @@
+package postgres
+
+import (
+	"avito-intership/balance"
+	"avito-intership/models"
+	"database/sql"
+	"time"
+)
+
+const pendingColumns = "id, type, user_id, target_id, amount, fee, status, maker, checker, note, created_at, expires_at, decided_at"
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+type querier interface {
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+}
+
+func scanPending(row scanner) (*models.PendingOperation, error) {
+	var operation models.PendingOperation
+	var maker, checker, note sql.NullString
+	var decidedAt sql.NullTime
+
+	err := row.Scan(&operation.Id, &operation.Type, &operation.UserId, &operation.TargetId, &operation.Amount,
+		&operation.Fee, &operation.Status, &maker, &checker, &note, &operation.CreatedAt, &operation.ExpiresAt, &decidedAt)
+	if err == sql.ErrNoRows {
+		return nil, balance.ErrOperationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if maker.Valid {
+		operation.Maker = &maker.String
+	}
+	if checker.Valid {
+		operation.Checker = &checker.String
+	}
+	if note.Valid {
+		operation.Note = &note.String
+	}
+	if decidedAt.Valid {
+		operation.DecidedAt = &decidedAt.Time
+	}
+
+	return &operation, nil
+}
+
+func (r BalanceRepository) loadPayouts(operation *models.PendingOperation, q querier) error {
+	if operation.Type != balance.PendingTransfer {
+		return nil
+	}
+
+	rows, err := q.Query("SELECT user_id, amount, fee FROM pending_payouts WHERE operation_id = $1", operation.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	operation.Payouts = make([]*models.Payout, 0)
+	for rows.Next() {
+		var payout models.Payout
+		err = rows.Scan(&payout.UserId, &payout.Amount, &payout.Fee)
+		if err != nil {
+			return err
+		}
+
+		operation.Payouts = append(operation.Payouts, &payout)
+	}
+
+	return rows.Err()
+}
+
+// ensureAccount создает пустой счет, чтобы на него можно было записать операцию в истории
+func (r BalanceRepository) ensureAccount(userId int64, tx *sql.Tx) error {
+	_, err := tx.Exec("INSERT INTO balances(id, amount) VALUES ($1, 0) ON CONFLICT(id) DO NOTHING", userId)
+	return err
+}
+
+func (r BalanceRepository) CreatePending(operation *models.PendingOperation) (*models.PendingOperation, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	created, err := r.createPending(operation, tx)
+	return created, err
+}
+
+func (r BalanceRepository) createPending(operation *models.PendingOperation, tx *sql.Tx) (*models.PendingOperation, error) {
+	row := tx.QueryRow(
+		`INSERT INTO pending_operations (type, user_id, target_id, amount, fee, maker, expires_at)
+		VALUES ($1::pending_operation_type, $2, $3, $4, $5, $6, $7)
+		RETURNING `+pendingColumns,
+		operation.Type, operation.UserId, operation.TargetId, operation.Amount, operation.Fee, operation.Maker,
+		operation.ExpiresAt)
+	created, err := scanPending(row)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.ensureAccount(operation.UserId, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// В истории операция записывается так же, как будет записана после выполнения, но в состоянии pending
+	operationId := sql.NullInt64{Int64: created.Id, Valid: true}
+	if operation.Type == balance.PendingOperatorAdjustment {
+		err = r.insertTransaction(Transaction{UserId: operation.UserId, Amount: operation.Amount,
+			TargetId: operation.TargetId, Type: balance.AdjustmentType, Status: balance.StatusPending,
+			OperationId: operationId}, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		return created, nil
+	}
+
+	for _, payout := range operation.Payouts {
+		_, err = tx.Exec("INSERT INTO pending_payouts (operation_id, user_id, amount, fee) VALUES ($1, $2, $3, $4)",
+			created.Id, payout.UserId, payout.Amount, payout.Fee)
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.ensureAccount(payout.UserId, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.insertTransaction(Transaction{UserId: operation.UserId, Amount: -(payout.Amount + payout.Fee),
+			TargetId: payout.UserId, Type: balance.TransferType, Fee: payout.Fee, Status: balance.StatusPending,
+			OperationId: operationId}, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.insertTransaction(Transaction{UserId: payout.UserId, Amount: payout.Amount,
+			TargetId: operation.UserId, Type: balance.TransferType, Fee: payout.Fee, Status: balance.StatusPending,
+			OperationId: operationId}, tx)
+		if err != nil {
+			return nil, err
+		}
+	}
+	created.Payouts = operation.Payouts
+
+	return created, nil
+}
+
+func (r BalanceRepository) GetPending(id int64) (*models.PendingOperation, error) {
+	operation, err := scanPending(r.db.QueryRow("SELECT "+pendingColumns+" FROM pending_operations WHERE id = $1", id))
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.loadPayouts(operation, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
+
+func (r BalanceRepository) GetPendingOperations(status string) ([]*models.PendingOperation, error) {
+	rows, err := r.db.Query(
+		`SELECT `+pendingColumns+` FROM pending_operations
+		WHERE $1 = '' OR status::text = $1 ORDER BY created_at, id`, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	operations := make([]*models.PendingOperation, 0)
+	for rows.Next() {
+		operation, err := scanPending(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		operations = append(operations, operation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, operation := range operations {
+		err = r.loadPayouts(operation, r.db)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return operations, nil
+}
+
+// lockPending блокирует операцию до конца транзакции и проверяет, что решение по ней еще не принято
+// и принимает его не автор операции
+func (r BalanceRepository) lockPending(id int64, checker string, tx *sql.Tx) (*models.PendingOperation, error) {
+	operation, err := scanPending(tx.QueryRow(
+		"SELECT "+pendingColumns+" FROM pending_operations WHERE id = $1 FOR UPDATE", id))
+	if err != nil {
+		return nil, err
+	}
+
+	if operation.Status != balance.StatusPending {
+		return nil, balance.ErrOperationDecided
+	}
+	if operation.Maker != nil && *operation.Maker == checker {
+		return nil, balance.ErrSameOperator
+	}
+	if !operation.ExpiresAt.After(time.Now()) {
+		return nil, balance.ErrOperationExpired
+	}
+
+	err = r.loadPayouts(operation, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
+
+func (r BalanceRepository) decidePending(operation *models.PendingOperation, status string, checker string,
+	note *string, tx *sql.Tx) (*models.PendingOperation, error) {
+	decided, err := scanPending(tx.QueryRow(
+		`UPDATE pending_operations SET status = $2::transaction_status, checker = $3, note = $4, decided_at = NOW()
+		WHERE id = $1
+		RETURNING `+pendingColumns,
+		operation.Id, status, checker, note))
+	if err != nil {
+		return nil, err
+	}
+	decided.Payouts = operation.Payouts
+
+	return decided, nil
+}
+
+func (r BalanceRepository) ApprovePending(id int64, checker string, note *string) (*models.PendingOperation, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	operation, err := r.lockPending(id, checker, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Записи в состоянии pending заменяются записями, которые делает сама операция
+	_, err = tx.Exec("DELETE FROM transactions WHERE operation_id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+
+	if operation.Type == balance.PendingOperatorAdjustment {
+		err = r.adjust(operation.UserId, operation.Amount, operation.TargetId, tx)
+	} else {
+		err = r.transferMoneyWithFee(operation.UserId, operation.Payouts, tx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	approved, err := r.decidePending(operation, balance.StatusCompleted, checker, note, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return approved, nil
+}
+
+func (r BalanceRepository) RejectPending(id int64, checker string, note *string) (*models.PendingOperation, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	operation, err := r.lockPending(id, checker, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec("UPDATE transactions SET status = 'rejected' WHERE operation_id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+
+	rejected, err := r.decidePending(operation, balance.StatusRejected, checker, note, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return rejected, nil
+}
+
+func (r BalanceRepository) ExpirePending(at time.Time) (int64, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	result, err := tx.Exec(
+		`UPDATE pending_operations SET status = 'expired', decided_at = NOW()
+		WHERE status = 'pending' AND expires_at <= $1`, at)
+	if err != nil {
+		return 0, err
+	}
+
+	expired, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(
+		`UPDATE transactions t SET status = 'expired'
+		FROM pending_operations o
+		WHERE t.operation_id = o.id AND o.status = 'expired' AND t.status = 'pending'`)
+	if err != nil {
+		return 0, err
+	}
+
+	return expired, nil
+}
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"avito-intership/balance"
+	"avito-intership/models"
+	"time"
+)
+
+func (suite *balanceRepositorySuite) createPendingTransfer(srcId int64, dstId int64, amount float32,
+	expiresAt time.Time) *models.PendingOperation {
+	operation, err := suite.repository.CreatePending(&models.PendingOperation{Type: balance.PendingTransfer,
+		UserId: srcId, Amount: amount, Payouts: []*models.Payout{{UserId: dstId, Amount: amount}},
+		ExpiresAt: expiresAt})
+	suite.NoError(err, "creating pending operation should not produce error")
+
+	return operation
+}
+
+func (suite *balanceRepositorySuite) TestPendingTransfer_Approve() {
+	suite.curId += 1
+	srcId := suite.curId
+	suite.curId += 1
+	dstId := suite.curId
+
+	err := suite.repository.ChangeBalance(srcId, bigAmount, balance.RefillId)
+	suite.NoError(err, "positive changing balance should not produce error")
+
+	operation := suite.createPendingTransfer(srcId, dstId, smallAmount, time.Now().Add(time.Hour))
+	suite.Equal(balance.StatusPending, operation.Status)
+
+	amount, err := suite.repository.GetBalance(srcId)
+	suite.NoError(err, "getting balance should not produce error")
+	suite.Equal(bigAmount, amount, "pending transfer does not change balance")
+
+	history, err := suite.repository.GetHistory(dstId, 1, 10, balance.SortDate, false)
+	suite.NoError(err, "getting history should not produce error")
+	suite.Len(history, 1)
+	suite.Equal(balance.StatusPending, history[0].Status)
+	suite.Equal(operation.Id, *history[0].OperationId)
+
+	approved, err := suite.repository.ApprovePending(operation.Id, "alice", nil)
+	suite.NoError(err, "approving operation should not produce error")
+	suite.Equal(balance.StatusCompleted, approved.Status)
+	suite.Equal("alice", *approved.Checker)
+
+	amount, err = suite.repository.GetBalance(dstId)
+	suite.NoError(err, "getting balance should not produce error")
+	suite.Equal(smallAmount, amount)
+
+	history, err = suite.repository.GetHistory(dstId, 1, 10, balance.SortDate, false)
+	suite.NoError(err, "getting history should not produce error")
+	suite.Len(history, 1, "pending record is replaced by the completed one")
+	suite.Equal(balance.StatusCompleted, history[0].Status)
+
+	_, err = suite.repository.ApprovePending(operation.Id, "bob", nil)
+	suite.Equal(balance.ErrOperationDecided, err)
+}
+
+func (suite *balanceRepositorySuite) TestPendingTransfer_SameOperator() {
+	suite.curId += 1
+	srcId := suite.curId
+	suite.curId += 1
+	dstId := suite.curId
+
+	// Перевод, одобренный оператором alice после проверки антифрода, она же одобрить не может
+	maker := "alice"
+	operation, err := suite.repository.CreatePending(&models.PendingOperation{Type: balance.PendingTransfer,
+		UserId: srcId, Amount: smallAmount, Payouts: []*models.Payout{{UserId: dstId, Amount: smallAmount}},
+		Maker: &maker, ExpiresAt: time.Now().Add(time.Hour)})
+	suite.NoError(err, "creating pending operation should not produce error")
+	suite.Equal(maker, *operation.Maker)
+
+	_, err = suite.repository.ApprovePending(operation.Id, "alice", nil)
+	suite.Equal(balance.ErrSameOperator, err)
+
+	_, err = suite.repository.RejectPending(operation.Id, "alice", nil)
+	suite.Equal(balance.ErrSameOperator, err)
+
+	pending, err := suite.repository.GetPending(operation.Id)
+	suite.NoError(err, "getting pending operation should not produce error")
+	suite.Equal(balance.StatusPending, pending.Status)
+}
+
+func (suite *balanceRepositorySuite) TestPendingTransfer_ApproveTooLowBalance() {
+	suite.curId += 1
+	srcId := suite.curId
+	suite.curId += 1
+	dstId := suite.curId
+
+	operation := suite.createPendingTransfer(srcId, dstId, smallAmount, time.Now().Add(time.Hour))
+
+	_, err := suite.repository.ApprovePending(operation.Id, "alice", nil)
+	suite.Equal(balance.ErrTooLowBalance, err)
+
+	pending, err := suite.repository.GetPending(operation.Id)
+	suite.NoError(err, "getting pending operation should not produce error")
+	suite.Equal(balance.StatusPending, pending.Status, "failed approval leaves operation pending")
+	suite.Len(pending.Payouts, 1)
+}
+
+func (suite *balanceRepositorySuite) TestPendingAdjustment_Reject() {
+	suite.curId += 1
+	id := suite.curId
+
+	expiresAt := time.Now().Add(time.Hour)
+	adjustment, err := suite.repository.CreateAdjustment(&models.Adjustment{UserId: id, Amount: bigAmount,
+		Operator: "alice", Reason: balance.ReasonFailedService, Comment: "refund"}, &expiresAt)
+	suite.NoError(err, "creating adjustment should not produce error")
+
+	note := "no reason given"
+	rejected, err := suite.repository.RejectPending(*adjustment.OperationId, "bob", &note)
+	suite.NoError(err, "rejecting operation should not produce error")
+	suite.Equal(balance.StatusRejected, rejected.Status)
+	suite.Equal(note, *rejected.Note)
+
+	amount, err := suite.repository.GetBalance(id)
+	suite.NoError(err, "getting balance should not produce error")
+	suite.Equal(float32(0), amount)
+
+	history, err := suite.repository.GetHistory(id, 1, 10, balance.SortDate, false)
+	suite.NoError(err, "getting history should not produce error")
+	suite.Equal(balance.StatusRejected, history[0].Status)
+}
+
+func (suite *balanceRepositorySuite) TestExpirePending() {
+	suite.curId += 1
+	srcId := suite.curId
+	suite.curId += 1
+	dstId := suite.curId
+
+	err := suite.repository.ChangeBalance(srcId, bigAmount, balance.RefillId)
+	suite.NoError(err, "positive changing balance should not produce error")
+
+	operation := suite.createPendingTransfer(srcId, dstId, smallAmount, time.Now().Add(-time.Minute))
+
+	_, err = suite.repository.ApprovePending(operation.Id, "alice", nil)
+	suite.Equal(balance.ErrOperationExpired, err)
+
+	expired, err := suite.repository.ExpirePending(time.Now())
+	suite.NoError(err, "expiring operations should not produce error")
+	suite.GreaterOrEqual(expired, int64(1))
+
+	expiredOperations, err := suite.repository.GetPendingOperations(balance.StatusExpired)
+	suite.NoError(err, "getting operations should not produce error")
+	suite.Equal(operation.Id, expiredOperations[len(expiredOperations)-1].Id)
+
+	history, err := suite.repository.GetHistory(dstId, 1, 10, balance.SortDate, false)
+	suite.NoError(err, "getting history should not produce error")
+	suite.Equal(balance.StatusExpired, history[0].Status)
+}
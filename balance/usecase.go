@@ -2,9 +2,41 @@ package balance
 
 import "avito-intership/models"
 
+// Переводы на сумму выше порога не выполняются сразу, а возвращают *ApprovalRequiredError с операцией,
+// ожидающей одобрения
 type UseCase interface {
 	ChangeBalance(userId int64, amount float32, productId int64) error
-	GetBalance(userId int64, currency string) (float32, error)
+	// ChangeBalanceInCurrency изменяет баланс на сумму amount в валюте currency по курсу с учетом спреда
+	ChangeBalanceInCurrency(userId int64, amount float32, productId int64, currency string) (*models.Conversion, error)
+	GetBalance(userId int64, currency string) (*models.Balance, error)
+	// GrantBonus начисляет бонусы, которые можно потратить только на услуги в течение days дней
+	GrantBonus(userId int64, amount float32, days int) (*models.Bonus, error)
+	ExpireBonuses() error
 	TransferMoney(srcUserId int64, dstUserId int64, amount float32) error
-	GetHistory(userId int64, page int64, perPage int64, sort int, desc bool) ([]*models.Transaction, error)
-}
\ No newline at end of file
+	// TransferMoneyWithCommission удерживает commission из переводимой суммы amount
+	TransferMoneyWithCommission(srcUserId int64, dstUserId int64, amount float32, commission *models.Commission) ([]*models.Payout, error)
+	// SplitPayment делит платеж amount между получателями пропорционально долям, commission может быть nil
+	SplitPayment(srcUserId int64, amount float32, shares []*models.Share, commission *models.Commission) ([]*models.Payout, error)
+	// TransferReviewed выполняет перевод, одобренный оператором maker после ручной проверки. Перевод выше порога
+	// ждет одобрения операции, maker записывается ее создателем, поэтому одобрить ее может только другой оператор
+	TransferReviewed(review *models.TransferReview, maker string) error
+	GetHistory(userId int64, page int64, perPage int64, sort int, desc bool, currency string) ([]*models.Transaction, error)
+}
+
+// ApprovalUseCase - одобрение операций выше порога вторым оператором
+type ApprovalUseCase interface {
+	GetOperations(status string) ([]*models.PendingOperation, error)
+	GetOperation(id int64) (*models.PendingOperation, error)
+	Approve(id int64, checker string, note string) (*models.PendingOperation, error)
+	Reject(id int64, checker string, note string) (*models.PendingOperation, error)
+	// ExpireOperations отклоняет операции, не одобренные за отведенное время
+	ExpireOperations() error
+}
+
+// AdjustmentUseCase - корректировки баланса операторами поддержки
+type AdjustmentUseCase interface {
+	// Adjust применяет корректировку, а выше порога одобрения возвращает *ApprovalRequiredError
+	Adjust(adjustment *models.Adjustment) (*models.Adjustment, error)
+	GetAdjustment(id int64) (*models.Adjustment, error)
+	GetAdjustments(filter *models.AdjustmentFilter) ([]*models.Adjustment, error)
+}
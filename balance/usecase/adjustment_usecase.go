@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"strings"
+	"time"
+)
+
+type AdjustmentUseCase struct {
+	balanceRepo       balance.Repository
+	approvalThreshold float32
+}
+
+func NewAdjustmentUseCase(repo balance.Repository, approvalThreshold float32) *AdjustmentUseCase {
+	return &AdjustmentUseCase{
+		balanceRepo:       repo,
+		approvalThreshold: approvalThreshold,
+	}
+}
+
+// Adjust при сумме выше порога возвращает записанную корректировку вместе с *balance.ApprovalRequiredError
+func (u AdjustmentUseCase) Adjust(adjustment *models.Adjustment) (*models.Adjustment, error) {
+	adjustment.Amount, _ = exchange.Round(exchange.Decimal(adjustment.Amount), exchange.RUB).Float32()
+	adjustment.Operator = strings.TrimSpace(adjustment.Operator)
+	adjustment.Comment = strings.TrimSpace(adjustment.Comment)
+	if adjustment.UserId <= 0 || adjustment.Amount == 0 || adjustment.Operator == "" || adjustment.Comment == "" ||
+		!balance.IsAdjustmentReason(adjustment.Reason) {
+		return nil, balance.ErrBadAdjustment
+	}
+
+	if !exceedsThreshold(adjustment.Amount, u.approvalThreshold) {
+		return u.balanceRepo.CreateAdjustment(adjustment, nil)
+	}
+
+	expiresAt := time.Now().Add(pendingOperationLifetime)
+	created, err := u.balanceRepo.CreateAdjustment(adjustment, &expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	operation, err := u.balanceRepo.GetPending(*created.OperationId)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, &balance.ApprovalRequiredError{Operation: operation}
+}
+
+func (u AdjustmentUseCase) GetAdjustment(id int64) (*models.Adjustment, error) {
+	return u.balanceRepo.GetAdjustment(id)
+}
+
+func (u AdjustmentUseCase) GetAdjustments(filter *models.AdjustmentFilter) ([]*models.Adjustment, error) {
+	return u.balanceRepo.GetAdjustments(filter)
+}
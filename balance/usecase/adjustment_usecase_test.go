@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type adjustmentUseCaseSuite struct {
+	suite.Suite
+	repository  *mocks.Repository
+	adjustments balance.AdjustmentUseCase
+}
+
+func (suite *adjustmentUseCaseSuite) SetupTest() {
+	repository := new(mocks.Repository)
+
+	suite.repository = repository
+	suite.adjustments = NewAdjustmentUseCase(repository, 1000)
+}
+
+func (suite *adjustmentUseCaseSuite) TestAdjust_Validation() {
+	cases := []struct {
+		name       string
+		adjustment models.Adjustment
+	}{
+		{"bad user", models.Adjustment{UserId: 0, Amount: 10, Operator: "alice", Reason: balance.ReasonGoodwill, Comment: "c"}},
+		{"zero amount", models.Adjustment{UserId: 1, Amount: 0.001, Operator: "alice", Reason: balance.ReasonGoodwill, Comment: "c"}},
+		{"no operator", models.Adjustment{UserId: 1, Amount: 10, Operator: " ", Reason: balance.ReasonGoodwill, Comment: "c"}},
+		{"no comment", models.Adjustment{UserId: 1, Amount: 10, Operator: "alice", Reason: balance.ReasonGoodwill}},
+		{"bad reason", models.Adjustment{UserId: 1, Amount: 10, Operator: "alice", Reason: "gift", Comment: "c"}},
+	}
+
+	for _, c := range cases {
+		suite.Run(c.name, func() {
+			adjustment := c.adjustment
+			_, err := suite.adjustments.Adjust(&adjustment)
+			suite.Equal(balance.ErrBadAdjustment, err)
+		})
+	}
+
+	suite.repository.AssertNotCalled(suite.T(), "CreateAdjustment", mock.Anything, mock.Anything)
+}
+
+func (suite *adjustmentUseCaseSuite) TestAdjust_Immediate() {
+	suite.repository.On("CreateAdjustment", mock.MatchedBy(func(a *models.Adjustment) bool {
+		return a.UserId == 1 && a.Amount == -10.5 && a.Operator == "alice" && a.Comment == "double charge"
+	}), (*time.Time)(nil)).Return(&models.Adjustment{Id: 3, Status: balance.StatusCompleted}, nil)
+
+	adjustment, err := suite.adjustments.Adjust(&models.Adjustment{UserId: 1, Amount: -10.5, Operator: " alice ",
+		Reason: balance.ReasonDuplicateCharge, Comment: "double charge "})
+
+	suite.NoError(err)
+	suite.Equal(int64(3), adjustment.Id)
+	suite.repository.AssertNotCalled(suite.T(), "GetPending", mock.Anything)
+}
+
+func (suite *adjustmentUseCaseSuite) TestAdjust_AboveThreshold() {
+	operationId := int64(9)
+	suite.repository.On("CreateAdjustment", mock.Anything, mock.MatchedBy(func(at *time.Time) bool {
+		return at != nil && at.After(time.Now())
+	})).Return(&models.Adjustment{Id: 4, Status: balance.StatusPending, OperationId: &operationId}, nil)
+	suite.repository.On("GetPending", operationId).
+		Return(&models.PendingOperation{Id: operationId, Type: balance.PendingOperatorAdjustment}, nil)
+
+	adjustment, err := suite.adjustments.Adjust(&models.Adjustment{UserId: 1, Amount: 5000, Operator: "alice",
+		Reason: balance.ReasonGoodwill, Comment: "compensation"})
+
+	var approvalErr *balance.ApprovalRequiredError
+	suite.True(errors.As(err, &approvalErr))
+	suite.Equal(operationId, approvalErr.Operation.Id)
+	suite.Equal(int64(4), adjustment.Id)
+}
+
+func TestAdjustmentUseCase(t *testing.T) {
+	suite.Run(t, new(adjustmentUseCaseSuite))
+}
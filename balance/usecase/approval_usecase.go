@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/models"
+	"log"
+	"strings"
+	"time"
+)
+
+type ApprovalUseCase struct {
+	balanceRepo balance.Repository
+}
+
+func NewApprovalUseCase(repo balance.Repository) *ApprovalUseCase {
+	return &ApprovalUseCase{
+		balanceRepo: repo,
+	}
+}
+
+func (u ApprovalUseCase) GetOperations(status string) ([]*models.PendingOperation, error) {
+	return u.balanceRepo.GetPendingOperations(status)
+}
+
+func (u ApprovalUseCase) GetOperation(id int64) (*models.PendingOperation, error) {
+	return u.balanceRepo.GetPending(id)
+}
+
+func (u ApprovalUseCase) Approve(id int64, checker string, note string) (*models.PendingOperation, error) {
+	checker, notePtr, err := decision(checker, note)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.balanceRepo.ApprovePending(id, checker, notePtr)
+}
+
+func (u ApprovalUseCase) Reject(id int64, checker string, note string) (*models.PendingOperation, error) {
+	checker, notePtr, err := decision(checker, note)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.balanceRepo.RejectPending(id, checker, notePtr)
+}
+
+func (u ApprovalUseCase) ExpireOperations() error {
+	expired, err := u.balanceRepo.ExpirePending(time.Now())
+	if err != nil {
+		return err
+	}
+
+	if expired > 0 {
+		log.Printf("%d pending operations expired", expired)
+	}
+
+	return nil
+}
+
+func decision(checker string, note string) (string, *string, error) {
+	checker = strings.TrimSpace(checker)
+	if checker == "" {
+		return "", nil, balance.ErrNoChecker
+	}
+
+	if note = strings.TrimSpace(note); note != "" {
+		return checker, &note, nil
+	}
+
+	return checker, nil, nil
+}
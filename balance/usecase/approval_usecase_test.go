@@ -0,0 +1,154 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type approvalUseCaseSuite struct {
+	suite.Suite
+	repository *mocks.Repository
+	exchanger  *mocks.Exchanger
+	useCase    balance.UseCase
+	approvals  balance.ApprovalUseCase
+}
+
+func (suite *approvalUseCaseSuite) SetupTest() {
+	repository := new(mocks.Repository)
+	exchanger := new(mocks.Exchanger)
+
+	suite.repository = repository
+	suite.exchanger = exchanger
+	suite.useCase = NewBalanceUseCase(repository, exchanger, 1000)
+	suite.approvals = NewApprovalUseCase(repository)
+}
+
+func (suite *approvalUseCaseSuite) TestTransferMoney_BelowThreshold() {
+	suite.repository.On("TransferMoney", int64(1), int64(2), float32(1000)).Return(nil)
+
+	err := suite.useCase.TransferMoney(1, 2, 1000)
+
+	suite.NoError(err)
+	suite.repository.AssertNotCalled(suite.T(), "CreatePending", mock.Anything)
+}
+
+func (suite *approvalUseCaseSuite) TestTransferMoney_AboveThreshold() {
+	suite.repository.On("CreatePending", mock.MatchedBy(func(o *models.PendingOperation) bool {
+		return o.Type == balance.PendingTransfer && o.UserId == 1 && o.Amount == 1500 && len(o.Payouts) == 1 &&
+			o.Payouts[0].UserId == 2 && o.Payouts[0].Amount == 1500 && o.ExpiresAt.After(time.Now())
+	})).Return(&models.PendingOperation{Id: 7, Status: balance.StatusPending}, nil)
+
+	err := suite.useCase.TransferMoney(1, 2, 1500)
+
+	var approvalErr *balance.ApprovalRequiredError
+	suite.True(errors.As(err, &approvalErr))
+	suite.Equal(int64(7), approvalErr.Operation.Id)
+	suite.repository.AssertNotCalled(suite.T(), "TransferMoney", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *approvalUseCaseSuite) TestTransferMoneyWithCommission_AboveThreshold() {
+	suite.repository.On("CreatePending", mock.MatchedBy(func(o *models.PendingOperation) bool {
+		return len(o.Payouts) == 1 && o.Payouts[0].Amount == 1980 && o.Payouts[0].Fee == 20
+	})).Return(&models.PendingOperation{Id: 8}, nil)
+
+	_, err := suite.useCase.TransferMoneyWithCommission(1, 2, 2000, &models.Commission{Percent: 1})
+
+	var approvalErr *balance.ApprovalRequiredError
+	suite.True(errors.As(err, &approvalErr))
+	suite.repository.AssertNotCalled(suite.T(), "TransferMoneyWithFee", mock.Anything, mock.Anything)
+}
+
+func (suite *approvalUseCaseSuite) TestTransferReviewed_MakerIsReviewer() {
+	reviews := []*models.TransferReview{
+		{SrcId: 1, DstId: 2, Amount: 1500},
+		{SrcId: 1, DstId: 2, Amount: 2000, Commission: &models.Commission{Percent: 1}},
+		{SrcId: 1, DstId: 2, Amount: 3000, Shares: []*models.Share{{UserId: 2, Share: 70}, {UserId: 3, Share: 30}}},
+	}
+	suite.repository.On("CreatePending", mock.MatchedBy(func(o *models.PendingOperation) bool {
+		return o.Type == balance.PendingTransfer && o.Maker != nil && *o.Maker == "alice"
+	})).Return(&models.PendingOperation{Id: 9, Status: balance.StatusPending}, nil)
+
+	for _, review := range reviews {
+		err := suite.useCase.TransferReviewed(review, "alice")
+
+		var approvalErr *balance.ApprovalRequiredError
+		suite.True(errors.As(err, &approvalErr))
+	}
+
+	suite.repository.AssertNumberOfCalls(suite.T(), "CreatePending", len(reviews))
+}
+
+func (suite *approvalUseCaseSuite) TestTransferReviewed_BelowThreshold() {
+	suite.repository.On("TransferMoney", int64(1), int64(2), float32(500)).Return(nil)
+
+	err := suite.useCase.TransferReviewed(&models.TransferReview{SrcId: 1, DstId: 2, Amount: 500}, "alice")
+
+	suite.NoError(err)
+	suite.repository.AssertNotCalled(suite.T(), "CreatePending", mock.Anything)
+}
+
+func (suite *approvalUseCaseSuite) TestChangeBalance_NotHeld() {
+	suite.repository.On("ChangeBalance", int64(1), float32(-5000), int64(3)).Return(nil)
+
+	err := suite.useCase.ChangeBalance(1, -5000, 3)
+
+	suite.NoError(err, "purchases and credits above the threshold are not held")
+	suite.repository.AssertNotCalled(suite.T(), "CreatePending", mock.Anything)
+}
+
+func (suite *approvalUseCaseSuite) TestChangeBalanceInCurrency_NotHeld() {
+	suite.exchanger.On("Exchange", float32(20), "USD", "sell").
+		Return(&models.Conversion{Amount: 1400, Currency: "RUB", Fee: 20}, nil)
+	suite.repository.On("ChangeBalanceWithFee", int64(1), float32(1400), int64(0), float32(20)).Return(nil)
+
+	_, err := suite.useCase.ChangeBalanceInCurrency(1, 20, 0, "USD")
+
+	suite.NoError(err)
+	suite.repository.AssertNotCalled(suite.T(), "CreatePending", mock.Anything)
+}
+
+func (suite *approvalUseCaseSuite) TestApprove() {
+	note := "verified"
+	suite.repository.On("ApprovePending", int64(1), "alice", &note).
+		Return(&models.PendingOperation{Id: 1, Status: balance.StatusCompleted}, nil)
+
+	operation, err := suite.approvals.Approve(1, " alice ", " verified ")
+
+	suite.NoError(err)
+	suite.Equal(balance.StatusCompleted, operation.Status)
+}
+
+func (suite *approvalUseCaseSuite) TestReject_NoChecker() {
+	_, err := suite.approvals.Reject(1, " ", "note")
+
+	suite.Equal(balance.ErrNoChecker, err)
+	suite.repository.AssertNotCalled(suite.T(), "RejectPending", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *approvalUseCaseSuite) TestReject() {
+	suite.repository.On("RejectPending", int64(2), "bob", (*string)(nil)).
+		Return(&models.PendingOperation{Id: 2, Status: balance.StatusRejected}, nil)
+
+	operation, err := suite.approvals.Reject(2, "bob", "")
+
+	suite.NoError(err)
+	suite.Equal(balance.StatusRejected, operation.Status)
+}
+
+func (suite *approvalUseCaseSuite) TestExpireOperations() {
+	suite.repository.On("ExpirePending", mock.Anything).Return(int64(3), nil)
+
+	err := suite.approvals.ExpireOperations()
+
+	suite.NoError(err)
+}
+
+func TestApprovalUseCase(t *testing.T) {
+	suite.Run(t, new(approvalUseCaseSuite))
+}
@@ -5,24 +5,76 @@ import (
 	"avito-intership/exchange"
 	"avito-intership/models"
 	"log"
+	"math"
+	"math/big"
+	"time"
 )
 
+// DefaultApprovalThreshold - сумма в рублях, выше которой переводы и корректировки операторами требуют одобрения
+const DefaultApprovalThreshold float32 = 100000
+
+// pendingOperationLifetime - время, за которое операцию нужно одобрить, иначе она отклоняется
+const pendingOperationLifetime = 24 * time.Hour
+
 type BalanceUseCase struct {
 	balanceRepo balance.Repository
 	exchanger   exchange.Exchanger
+	// Нулевой порог отключает одобрение операций
+	approvalThreshold float32
 }
 
-func NewBalanceUseCase(repo balance.Repository, exchanger exchange.Exchanger) *BalanceUseCase {
+func NewBalanceUseCase(repo balance.Repository, exchanger exchange.Exchanger, approvalThreshold float32) *BalanceUseCase {
 	return &BalanceUseCase{
-		balanceRepo: repo,
-		exchanger:   exchanger,
+		balanceRepo:       repo,
+		exchanger:         exchanger,
+		approvalThreshold: approvalThreshold,
+	}
+}
+
+func (u BalanceUseCase) needsApproval(amount float32) bool {
+	return exceedsThreshold(amount, u.approvalThreshold)
+}
+
+func exceedsThreshold(amount float32, threshold float32) bool {
+	return threshold > 0 && float32(math.Abs(float64(amount))) > threshold
+}
+
+// hold откладывает операцию до одобрения оператором
+func (u BalanceUseCase) hold(operation *models.PendingOperation) error {
+	operation.ExpiresAt = time.Now().Add(pendingOperationLifetime)
+	created, err := u.balanceRepo.CreatePending(operation)
+	if err != nil {
+		return err
 	}
+
+	return &balance.ApprovalRequiredError{Operation: created}
 }
 
-func (u BalanceUseCase) GetBalance(userId int64, currency string) (float32, error) {
+func (u BalanceUseCase) GetBalance(userId int64, currency string) (*models.Balance, error) {
 	amount, err := u.balanceRepo.GetBalance(userId)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+
+	bonuses, err := u.balanceRepo.GetBonuses(userId, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	bonus := new(big.Rat)
+	for _, b := range bonuses {
+		bonus.Add(bonus, exchange.Decimal(b.Amount))
+	}
+	bonusAmount, _ := bonus.Float32()
+
+	result := &models.Balance{
+		UserId:   userId,
+		Amount:   amount,
+		Bonus:    bonusAmount,
+		Currency: exchange.RUB,
+	}
+	if len(bonuses) > 0 {
+		result.BonusExpiresAt = &bonuses[0].ExpiresAt
 	}
 
 	if currency != exchange.RUB {
@@ -30,30 +82,168 @@ func (u BalanceUseCase) GetBalance(userId int64, currency string) (float32, erro
 		if err != nil {
 			// В случае ошибки конвертации возращаем пользователю баланс в рублях
 			log.Println(err)
-			return amount, balance.ErrConversion
+			return result, balance.ErrConversion
+		}
+
+		convertedBonus := &models.Conversion{}
+		if bonusAmount > 0 {
+			convertedBonus, err = u.exchanger.ConvertRubles(bonusAmount, currency)
+			if err != nil {
+				log.Println(err)
+				return result, balance.ErrConversion
+			}
 		}
 
-		return converted, nil
+		result.Amount = converted.Amount
+		result.Bonus = convertedBonus.Amount
+		result.Currency = converted.Currency
+		result.Rate = converted.Rate
+		result.AppliedRate = converted.AppliedRate
+	}
+
+	return result, nil
+}
+
+func (u BalanceUseCase) GrantBonus(userId int64, amount float32, days int) (*models.Bonus, error) {
+	amount, _ = exchange.Round(exchange.Decimal(amount), exchange.RUB).Float32()
+	if userId <= 0 || amount <= 0 || days <= 0 {
+		return nil, balance.ErrBadBonus
+	}
+
+	return u.balanceRepo.GrantBonus(userId, amount, time.Now().AddDate(0, 0, days))
+}
+
+func (u BalanceUseCase) ExpireBonuses() error {
+	expired, err := u.balanceRepo.ExpireBonuses(time.Now())
+	if err != nil {
+		return err
 	}
 
-	return amount, nil
+	if expired > 0 {
+		log.Printf("%d bonuses expired", expired)
+	}
+
+	return nil
 }
 
+// ChangeBalance выполняется сразу при любой сумме: это зачисления и покупки сервисов, а одобрения оператором
+// требуют только переводы и ручные корректировки
 func (u BalanceUseCase) ChangeBalance(userId int64, amount float32, productId int64) error {
 	err := u.balanceRepo.ChangeBalance(userId, amount, productId)
 	return err
 }
 
+func (u BalanceUseCase) ChangeBalanceInCurrency(userId int64, amount float32, productId int64, currency string) (*models.Conversion, error) {
+	if currency == exchange.RUB {
+		return nil, u.ChangeBalance(userId, amount, productId)
+	}
+
+	// Списание означает покупку валюты пользователем, зачисление - продажу
+	direction := exchange.SellDirection
+	if amount < 0 {
+		direction = exchange.BuyDirection
+	}
+
+	conversion, err := u.exchanger.Exchange(float32(math.Abs(float64(amount))), currency, direction)
+	if err != nil {
+		log.Println(err)
+		return nil, balance.ErrRateUnavailable
+	}
+
+	if amount < 0 {
+		conversion.Amount = -conversion.Amount
+	}
+
+	err = u.balanceRepo.ChangeBalanceWithFee(userId, conversion.Amount, productId, conversion.Fee)
+	if err != nil {
+		return nil, err
+	}
+
+	return conversion, nil
+}
+
 func (u BalanceUseCase) TransferMoney(srcUserId int64, dstUserId int64, amount float32) error {
+	return u.transferMoney(srcUserId, dstUserId, amount, nil)
+}
+
+// transferMoney записывает maker создателем операции, если перевод выше порога ждет одобрения
+func (u BalanceUseCase) transferMoney(srcUserId int64, dstUserId int64, amount float32, maker *string) error {
+	if u.needsApproval(amount) {
+		return u.hold(&models.PendingOperation{Type: balance.PendingTransfer, UserId: srcUserId, Amount: amount,
+			Payouts: []*models.Payout{{UserId: dstUserId, Amount: amount}}, Maker: maker})
+	}
+
 	err := u.balanceRepo.TransferMoney(srcUserId, dstUserId, amount)
 	return err
 }
 
-func (u BalanceUseCase) GetHistory(userId int64, page int64, perPage int64, sort int, desc bool) ([]*models.Transaction, error) {
+func (u BalanceUseCase) TransferMoneyWithCommission(srcUserId int64, dstUserId int64, amount float32,
+	commission *models.Commission) ([]*models.Payout, error) {
+	return u.SplitPayment(srcUserId, amount, []*models.Share{{UserId: dstUserId, Share: 1}}, commission)
+}
+
+func (u BalanceUseCase) SplitPayment(srcUserId int64, amount float32, shares []*models.Share,
+	commission *models.Commission) ([]*models.Payout, error) {
+	return u.splitPayment(srcUserId, amount, shares, commission, nil)
+}
+
+func (u BalanceUseCase) TransferReviewed(review *models.TransferReview, maker string) error {
+	var err error
+	switch {
+	case len(review.Shares) > 0:
+		_, err = u.splitPayment(review.SrcId, review.Amount, review.Shares, review.Commission, &maker)
+	case review.Commission != nil:
+		_, err = u.splitPayment(review.SrcId, review.Amount, []*models.Share{{UserId: review.DstId, Share: 1}},
+			review.Commission, &maker)
+	default:
+		err = u.transferMoney(review.SrcId, review.DstId, review.Amount, &maker)
+	}
+
+	return err
+}
+
+func (u BalanceUseCase) splitPayment(srcUserId int64, amount float32, shares []*models.Share,
+	commission *models.Commission, maker *string) ([]*models.Payout, error) {
+	result, err := payouts(srcUserId, amount, shares, commission)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.needsApproval(amount) {
+		return nil, u.hold(&models.PendingOperation{Type: balance.PendingTransfer, UserId: srcUserId, Amount: amount,
+			Payouts: result, Maker: maker})
+	}
+
+	err = u.balanceRepo.TransferMoneyWithFee(srcUserId, result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (u BalanceUseCase) GetHistory(userId int64, page int64, perPage int64, sort int, desc bool, currency string) ([]*models.Transaction, error) {
 	transactions, err := u.balanceRepo.GetHistory(userId, page, perPage, sort, desc)
 	if err != nil {
 		return nil, err
 	}
 
-	return transactions, nil
+	if currency == exchange.RUB {
+		return transactions, nil
+	}
+
+	// Каждая операция конвертируется по курсу на дату ее совершения
+	err = nil
+	for _, transaction := range transactions {
+		converted, convErr := u.exchanger.ConvertAt(transaction.Amount, exchange.RUB, currency, transaction.Time)
+		if convErr != nil {
+			log.Println(convErr)
+			err = balance.ErrConversion
+			continue
+		}
+
+		transaction.Converted = converted
+	}
+
+	return transactions, err
 }
@@ -2,9 +2,14 @@ package usecase
 
 import (
 	"avito-intership/balance"
+	"avito-intership/exchange"
 	"avito-intership/mocks"
+	"avito-intership/models"
+	"errors"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"testing"
+	"time"
 )
 
 type balanceUseCaseSuite struct {
@@ -17,7 +22,7 @@ type balanceUseCaseSuite struct {
 func (suite *balanceUseCaseSuite) SetupTest() {
 	repository := new(mocks.Repository)
 	exchanger := new(mocks.Exchanger)
-	useCase := NewBalanceUseCase(repository, exchanger)
+	useCase := NewBalanceUseCase(repository, exchanger, 0)
 
 	suite.repository = repository
 	suite.exchanger = exchanger
@@ -30,11 +35,13 @@ func (suite *balanceUseCaseSuite) TestGetBalance_RUB() {
 	currency := "RUB"
 
 	suite.repository.On("GetBalance", id).Return(amount, nil)
+	suite.repository.On("GetBonuses", id, mock.Anything).Return([]*models.Bonus{}, nil)
 
 	result, err := suite.useCase.GetBalance(id, currency)
 
 	suite.Nil(err, "no error when return amount")
-	suite.Equal(amount, result, "result and amount should be equal")
+	suite.Equal(amount, result.Amount, "result and amount should be equal")
+	suite.Nil(result.Rate, "no rate for rubles")
 }
 
 func (suite *balanceUseCaseSuite) TestGetBalance_USD() {
@@ -43,13 +50,109 @@ func (suite *balanceUseCaseSuite) TestGetBalance_USD() {
 	var converted float32 = 10
 	currency := "USD"
 
-	suite.exchanger.On("ConvertRubles", amount, currency).Return(converted, nil)
+	rate := &models.Rate{Currency: currency, Value: 10}
+
+	suite.exchanger.On("ConvertRubles", amount, currency).
+		Return(&models.Conversion{Amount: converted, Currency: currency, Rate: rate}, nil)
 	suite.repository.On("GetBalance", id).Return(amount, nil)
+	suite.repository.On("GetBonuses", id, mock.Anything).Return([]*models.Bonus{}, nil)
 
 	result, err := suite.useCase.GetBalance(id, currency)
 
 	suite.Nil(err, "no error when return amount")
-	suite.Equal(converted, result, "result and amount should be equal")
+	suite.Equal(converted, result.Amount, "result and amount should be equal")
+	suite.Equal(currency, result.Currency)
+	suite.Equal(rate, result.Rate)
+}
+
+func (suite *balanceUseCaseSuite) TestGetBalance_ConversionError() {
+	var id int64 = 1
+	var amount float32 = 100
+	currency := "USD"
+
+	suite.exchanger.On("ConvertRubles", amount, currency).Return(nil, errors.New("upstream error"))
+	suite.repository.On("GetBalance", id).Return(amount, nil)
+	suite.repository.On("GetBonuses", id, mock.Anything).Return([]*models.Bonus{}, nil)
+
+	result, err := suite.useCase.GetBalance(id, currency)
+
+	suite.Equal(balance.ErrConversion, err, "conversion error expected")
+	suite.Equal(amount, result.Amount, "amount returned in rubles")
+	suite.Equal("RUB", result.Currency)
+}
+
+func (suite *balanceUseCaseSuite) TestGetBalance_Bonus() {
+	var id int64 = 1
+	var amount float32 = 100
+	soon := time.Now().Add(24 * time.Hour)
+	bonuses := []*models.Bonus{
+		{Id: 1, UserId: id, Amount: 20.1, ExpiresAt: soon},
+		{Id: 2, UserId: id, Amount: 30.2, ExpiresAt: soon.Add(24 * time.Hour)},
+	}
+
+	suite.repository.On("GetBalance", id).Return(amount, nil)
+	suite.repository.On("GetBonuses", id, mock.Anything).Return(bonuses, nil)
+
+	result, err := suite.useCase.GetBalance(id, "RUB")
+
+	suite.NoError(err)
+	suite.Equal(amount, result.Amount, "bonus is not included in the main balance")
+	suite.Equal(float32(50.3), result.Bonus)
+	suite.Equal(soon, *result.BonusExpiresAt)
+}
+
+func (suite *balanceUseCaseSuite) TestGetBalance_BonusUSD() {
+	var id int64 = 1
+	var amount float32 = 100
+	var bonus float32 = 50
+	currency := "USD"
+	rate := &models.Rate{Currency: currency, Value: 10}
+
+	suite.repository.On("GetBalance", id).Return(amount, nil)
+	suite.repository.On("GetBonuses", id, mock.Anything).
+		Return([]*models.Bonus{{Id: 1, UserId: id, Amount: bonus, ExpiresAt: time.Now().Add(time.Hour)}}, nil)
+	suite.exchanger.On("ConvertRubles", amount, currency).
+		Return(&models.Conversion{Amount: 10, Currency: currency, Rate: rate}, nil)
+	suite.exchanger.On("ConvertRubles", bonus, currency).
+		Return(&models.Conversion{Amount: 5, Currency: currency, Rate: rate}, nil)
+
+	result, err := suite.useCase.GetBalance(id, currency)
+
+	suite.NoError(err)
+	suite.Equal(float32(10), result.Amount)
+	suite.Equal(float32(5), result.Bonus)
+}
+
+func (suite *balanceUseCaseSuite) TestGrantBonus() {
+	var id int64 = 1
+	bonus := &models.Bonus{Id: 1, UserId: id, Amount: 500}
+	suite.repository.On("GrantBonus", id, float32(500), mock.MatchedBy(func(expiresAt time.Time) bool {
+		return expiresAt.After(time.Now().AddDate(0, 0, 29)) && expiresAt.Before(time.Now().AddDate(0, 0, 31))
+	})).Return(bonus, nil)
+
+	result, err := suite.useCase.GrantBonus(id, 500, 30)
+
+	suite.NoError(err)
+	suite.Equal(bonus, result)
+}
+
+func (suite *balanceUseCaseSuite) TestGrantBonus_Invalid() {
+	_, err := suite.useCase.GrantBonus(1, 500, 0)
+	suite.Equal(balance.ErrBadBonus, err)
+
+	_, err = suite.useCase.GrantBonus(1, -1, 30)
+	suite.Equal(balance.ErrBadBonus, err)
+
+	suite.repository.AssertNotCalled(suite.T(), "GrantBonus", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *balanceUseCaseSuite) TestExpireBonuses() {
+	suite.repository.On("ExpireBonuses", mock.Anything).Return(int64(2), nil)
+
+	err := suite.useCase.ExpireBonuses()
+
+	suite.NoError(err)
+	suite.repository.AssertCalled(suite.T(), "ExpireBonuses", mock.Anything)
 }
 
 func (suite *balanceUseCaseSuite) TestChangeBalance_Add() {
@@ -88,6 +191,54 @@ func (suite *balanceUseCaseSuite) TestChangeBalance_TooLowBalance() {
 	suite.Equal(balance.ErrTooLowBalance, err, "too low balance error expected")
 }
 
+func (suite *balanceUseCaseSuite) TestChangeBalanceInCurrency_Buy() {
+	var id int64 = 1
+	var productId int64 = 5
+	conversion := &models.Conversion{Amount: 1015, From: "USD", Currency: "RUB", AppliedRate: 81.2, Fee: 15}
+
+	suite.exchanger.On("Exchange", float32(12.5), "USD", exchange.BuyDirection).Return(conversion, nil)
+	suite.repository.On("ChangeBalanceWithFee", id, float32(-1015), productId, float32(15)).Return(nil)
+
+	result, err := suite.useCase.ChangeBalanceInCurrency(id, -12.5, productId, "USD")
+
+	suite.NoError(err)
+	suite.Equal(float32(-1015), result.Amount, "charge is debited in rubles")
+	suite.Equal(float32(15), result.Fee)
+}
+
+func (suite *balanceUseCaseSuite) TestChangeBalanceInCurrency_Sell() {
+	var id int64 = 1
+	conversion := &models.Conversion{Amount: 980, From: "USD", Currency: "RUB", AppliedRate: 78.4, Fee: 20}
+
+	suite.exchanger.On("Exchange", float32(12.5), "USD", exchange.SellDirection).Return(conversion, nil)
+	suite.repository.On("ChangeBalanceWithFee", id, float32(980), int64(0), float32(20)).Return(nil)
+
+	result, err := suite.useCase.ChangeBalanceInCurrency(id, 12.5, 0, "USD")
+
+	suite.NoError(err)
+	suite.Equal(float32(980), result.Amount)
+}
+
+func (suite *balanceUseCaseSuite) TestChangeBalanceInCurrency_RateUnavailable() {
+	suite.exchanger.On("Exchange", float32(10), "USD", exchange.BuyDirection).Return(nil, errors.New("upstream error"))
+
+	_, err := suite.useCase.ChangeBalanceInCurrency(1, -10, 1, "USD")
+
+	suite.Equal(balance.ErrRateUnavailable, err)
+	suite.repository.AssertNotCalled(suite.T(), "ChangeBalanceWithFee", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *balanceUseCaseSuite) TestChangeBalanceInCurrency_TooLowBalance() {
+	conversion := &models.Conversion{Amount: 800, From: "USD", Currency: "RUB", Fee: 8}
+
+	suite.exchanger.On("Exchange", float32(10), "USD", exchange.BuyDirection).Return(conversion, nil)
+	suite.repository.On("ChangeBalanceWithFee", int64(1), float32(-800), int64(1), float32(8)).Return(balance.ErrTooLowBalance)
+
+	_, err := suite.useCase.ChangeBalanceInCurrency(1, -10, 1, "USD")
+
+	suite.Equal(balance.ErrTooLowBalance, err)
+}
+
 func (suite *balanceUseCaseSuite) TestTransferBalance_Positive() {
 	var src int64 = 1
 	var dst int64 = 2
@@ -112,6 +263,84 @@ func (suite *balanceUseCaseSuite) TestTransferBalance_TooLowBalance() {
 	suite.Equal(balance.ErrTooLowBalance, err, "too low balance error expected")
 }
 
+func (suite *balanceUseCaseSuite) TestTransferMoneyWithCommission() {
+	expected := []*models.Payout{{UserId: 2, Amount: 95, Fee: 5}}
+	suite.repository.On("TransferMoneyWithFee", int64(1), expected).Return(nil)
+
+	result, err := suite.useCase.TransferMoneyWithCommission(1, 2, 100, &models.Commission{Percent: 1, Min: 5})
+
+	suite.NoError(err)
+	suite.Equal(expected, result)
+}
+
+func (suite *balanceUseCaseSuite) TestSplitPayment_TooLowBalance() {
+	suite.repository.On("TransferMoneyWithFee", int64(1), mock.Anything).Return(balance.ErrTooLowBalance)
+
+	_, err := suite.useCase.SplitPayment(1, 100, []*models.Share{{UserId: 2, Share: 1}, {UserId: 3, Share: 1}}, nil)
+
+	suite.Equal(balance.ErrTooLowBalance, err)
+}
+
+func (suite *balanceUseCaseSuite) TestSplitPayment_BadCommission() {
+	_, err := suite.useCase.SplitPayment(1, 100, []*models.Share{{UserId: 2, Share: 1}},
+		&models.Commission{Percent: 1, Fixed: 1})
+
+	suite.Equal(balance.ErrBadCommission, err)
+	suite.repository.AssertNotCalled(suite.T(), "TransferMoneyWithFee", mock.Anything, mock.Anything)
+}
+
+func (suite *balanceUseCaseSuite) TestGetHistory_RUB() {
+	var id int64 = 1
+	transactions := []*models.Transaction{{UserId: id, Amount: 100, Type: balance.RefillType}}
+
+	suite.repository.On("GetHistory", id, int64(1), int64(10), balance.SortDate, false).Return(transactions, nil)
+
+	result, err := suite.useCase.GetHistory(id, 1, 10, balance.SortDate, false, "RUB")
+
+	suite.NoError(err)
+	suite.Nil(result[0].Converted)
+	suite.exchanger.AssertNotCalled(suite.T(), "ConvertAt", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *balanceUseCaseSuite) TestGetHistory_HistoricalRates() {
+	var id int64 = 1
+	currency := "USD"
+	monday := time.Date(2021, 11, 15, 12, 0, 0, 0, time.UTC)
+	friday := time.Date(2021, 11, 19, 12, 0, 0, 0, time.UTC)
+	transactions := []*models.Transaction{
+		{UserId: id, Amount: 100, Type: balance.RefillType, Time: monday},
+		{UserId: id, Amount: -50, Type: balance.WithdrawType, Time: friday},
+	}
+
+	suite.repository.On("GetHistory", id, int64(1), int64(10), balance.SortDate, false).Return(transactions, nil)
+	suite.exchanger.On("ConvertAt", float32(100), "RUB", currency, monday).
+		Return(&models.Conversion{Amount: 1.37, From: "RUB", Currency: currency}, nil)
+	suite.exchanger.On("ConvertAt", float32(-50), "RUB", currency, friday).
+		Return(&models.Conversion{Amount: -0.69, From: "RUB", Currency: currency}, nil)
+
+	result, err := suite.useCase.GetHistory(id, 1, 10, balance.SortDate, false, currency)
+
+	suite.NoError(err)
+	suite.Equal(float32(1.37), result[0].Converted.Amount)
+	suite.Equal(float32(-0.69), result[1].Converted.Amount)
+}
+
+func (suite *balanceUseCaseSuite) TestGetHistory_ConversionError() {
+	var id int64 = 1
+	currency := "USD"
+	txTime := time.Date(2021, 11, 15, 12, 0, 0, 0, time.UTC)
+	transactions := []*models.Transaction{{UserId: id, Amount: 100, Type: balance.RefillType, Time: txTime}}
+
+	suite.repository.On("GetHistory", id, int64(1), int64(10), balance.SortDate, false).Return(transactions, nil)
+	suite.exchanger.On("ConvertAt", float32(100), "RUB", currency, txTime).Return(nil, errors.New("upstream error"))
+
+	result, err := suite.useCase.GetHistory(id, 1, 10, balance.SortDate, false, currency)
+
+	suite.Equal(balance.ErrConversion, err)
+	suite.Len(result, 1, "transactions are returned in rubles")
+	suite.Nil(result[0].Converted)
+}
+
 func TestBalanceUseCase(t *testing.T) {
 	suite.Run(t, new(balanceUseCaseSuite))
 }
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"math/big"
+	"sort"
+)
+
+// kopecks округляет сумму в рублях и переводит ее в целое число копеек
+func kopecks(amount *big.Rat) *big.Int {
+	rounded := exchange.Round(amount, exchange.RUB)
+	return new(big.Int).Quo(new(big.Int).Mul(rounded.Num(), big.NewInt(100)), rounded.Denom())
+}
+
+func rubles(kopecks *big.Int) float32 {
+	value, _ := new(big.Rat).SetFrac(kopecks, big.NewInt(100)).Float32()
+	return value
+}
+
+// fee вычисляет комиссию в копейках с суммы amount, nil commission означает перевод без комиссии
+func fee(amount *big.Int, commission *models.Commission) (*big.Int, error) {
+	if commission == nil {
+		return new(big.Int), nil
+	}
+
+	c := commission
+	if c.Percent < 0 || c.Percent >= 100 || c.Min < 0 || c.Fixed < 0 ||
+		(c.Percent > 0 && c.Fixed > 0) || (c.Min > 0 && c.Percent == 0) {
+		return nil, balance.ErrBadCommission
+	}
+
+	result := kopecks(exchange.Decimal(c.Fixed))
+	if c.Percent > 0 {
+		// amount в копейках, процент от суммы в рублях равен amount * percent / 10000
+		percent := new(big.Rat).Mul(new(big.Rat).SetInt(amount), exchange.Decimal(c.Percent))
+		result = kopecks(percent.Quo(percent, big.NewRat(10000, 1)))
+
+		if min := kopecks(exchange.Decimal(c.Min)); result.Cmp(min) < 0 {
+			result = min
+		}
+	}
+
+	if result.Cmp(amount) >= 0 {
+		return nil, balance.ErrBadCommission
+	}
+
+	return result, nil
+}
+
+// allocate делит total копеек пропорционально весам методом наибольшего остатка,
+// сумма частей всегда равна total
+func allocate(total *big.Int, weights []*big.Rat) []*big.Int {
+	sum := new(big.Rat)
+	for _, weight := range weights {
+		sum.Add(sum, weight)
+	}
+
+	parts := make([]*big.Int, len(weights))
+	remainders := make([]*big.Rat, len(weights))
+	left := new(big.Int).Set(total)
+	for i, weight := range weights {
+		exact := new(big.Rat).Mul(new(big.Rat).SetInt(total), weight)
+		exact.Quo(exact, sum)
+
+		parts[i] = new(big.Int).Quo(exact.Num(), exact.Denom())
+		remainders[i] = exact.Sub(exact, new(big.Rat).SetInt(parts[i]))
+		left.Sub(left, parts[i])
+	}
+
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return remainders[order[a]].Cmp(remainders[order[b]]) > 0
+	})
+
+	for i := 0; left.Sign() > 0; i++ {
+		parts[order[i]].Add(parts[order[i]], big.NewInt(1))
+		left.Sub(left, big.NewInt(1))
+	}
+
+	return parts
+}
+
+// payouts делит платеж между получателями, комиссия каждого получателя пропорциональна его части платежа
+func payouts(srcUserId int64, amount float32, shares []*models.Share, commission *models.Commission) ([]*models.Payout, error) {
+	if len(shares) == 0 {
+		return nil, balance.ErrBadShares
+	}
+
+	seen := make(map[int64]bool, len(shares))
+	weights := make([]*big.Rat, len(shares))
+	for i, share := range shares {
+		if share.UserId <= 0 || share.UserId == srcUserId || share.Share <= 0 || seen[share.UserId] {
+			return nil, balance.ErrBadShares
+		}
+		seen[share.UserId] = true
+		weights[i] = exchange.Decimal(share.Share)
+	}
+
+	total := kopecks(exchange.Decimal(amount))
+	totalFee, err := fee(total, commission)
+	if err != nil {
+		return nil, err
+	}
+
+	gross := allocate(total, weights)
+	grossWeights := make([]*big.Rat, len(gross))
+	for i, part := range gross {
+		if part.Sign() <= 0 {
+			return nil, balance.ErrBadShares
+		}
+		grossWeights[i] = new(big.Rat).SetInt(part)
+	}
+	fees := allocate(totalFee, grossWeights)
+
+	result := make([]*models.Payout, len(shares))
+	for i, share := range shares {
+		result[i] = &models.Payout{
+			UserId: share.UserId,
+			Amount: rubles(new(big.Int).Sub(gross[i], fees[i])),
+			Fee:    rubles(fees[i]),
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPayouts(t *testing.T) {
+	cases := []struct {
+		name       string
+		amount     float32
+		shares     []*models.Share
+		commission *models.Commission
+		expected   []*models.Payout
+	}{
+		{name: "no commission", amount: 100, shares: []*models.Share{{UserId: 2, Share: 1}},
+			expected: []*models.Payout{{UserId: 2, Amount: 100, Fee: 0}}},
+		{name: "percent", amount: 1000, shares: []*models.Share{{UserId: 2, Share: 1}},
+			commission: &models.Commission{Percent: 1.5},
+			expected:   []*models.Payout{{UserId: 2, Amount: 985, Fee: 15}}},
+		{name: "percent below minimum", amount: 100, shares: []*models.Share{{UserId: 2, Share: 1}},
+			commission: &models.Commission{Percent: 1, Min: 5},
+			expected:   []*models.Payout{{UserId: 2, Amount: 95, Fee: 5}}},
+		{name: "percent is rounded", amount: 10.01, shares: []*models.Share{{UserId: 2, Share: 1}},
+			commission: &models.Commission{Percent: 5},
+			expected:   []*models.Payout{{UserId: 2, Amount: 9.51, Fee: 0.5}}},
+		{name: "fixed", amount: 100, shares: []*models.Share{{UserId: 2, Share: 1}},
+			commission: &models.Commission{Fixed: 10},
+			expected:   []*models.Payout{{UserId: 2, Amount: 90, Fee: 10}}},
+		{name: "split", amount: 100, shares: []*models.Share{{UserId: 2, Share: 70}, {UserId: 3, Share: 30}},
+			commission: &models.Commission{Percent: 10},
+			expected:   []*models.Payout{{UserId: 2, Amount: 63, Fee: 7}, {UserId: 3, Amount: 27, Fee: 3}}},
+		{name: "split remainder", amount: 100, shares: []*models.Share{{UserId: 2, Share: 1}, {UserId: 3, Share: 1}, {UserId: 4, Share: 1}},
+			commission: &models.Commission{Fixed: 1},
+			expected: []*models.Payout{
+				{UserId: 2, Amount: 33, Fee: 0.34},
+				{UserId: 3, Amount: 33, Fee: 0.33},
+				{UserId: 4, Amount: 33, Fee: 0.33},
+			}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			result, err := payouts(1, c.amount, c.shares, c.commission)
+
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, result)
+		})
+	}
+}
+
+func TestPayouts_Invalid(t *testing.T) {
+	cases := []struct {
+		name       string
+		amount     float32
+		shares     []*models.Share
+		commission *models.Commission
+		err        error
+	}{
+		{name: "percent and fixed", amount: 100, shares: []*models.Share{{UserId: 2, Share: 1}},
+			commission: &models.Commission{Percent: 1, Fixed: 1}, err: balance.ErrBadCommission},
+		{name: "minimum without percent", amount: 100, shares: []*models.Share{{UserId: 2, Share: 1}},
+			commission: &models.Commission{Min: 1}, err: balance.ErrBadCommission},
+		{name: "fee exceeds amount", amount: 10, shares: []*models.Share{{UserId: 2, Share: 1}},
+			commission: &models.Commission{Fixed: 10}, err: balance.ErrBadCommission},
+		{name: "no recipients", amount: 10, shares: []*models.Share{}, err: balance.ErrBadShares},
+		{name: "transfer to self", amount: 10, shares: []*models.Share{{UserId: 1, Share: 1}}, err: balance.ErrBadShares},
+		{name: "duplicate recipient", amount: 10, shares: []*models.Share{{UserId: 2, Share: 1}, {UserId: 2, Share: 1}},
+			err: balance.ErrBadShares},
+		{name: "zero payout", amount: 0.01, shares: []*models.Share{{UserId: 2, Share: 1}, {UserId: 3, Share: 1}},
+			err: balance.ErrBadShares},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			_, err := payouts(1, c.amount, c.shares, c.commission)
+
+			assert.Equal(t, c.err, err)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"context"
+	"log"
+	"time"
+)
+
+const bonusExpireCheckTime = time.Hour
+
+type BonusExpirer struct {
+	useCase  balance.UseCase
+	interval time.Duration
+}
+
+func NewBonusExpirer(useCase balance.UseCase) *BonusExpirer {
+	return &BonusExpirer{
+		useCase:  useCase,
+		interval: bonusExpireCheckTime,
+	}
+}
+
+// Run периодически списывает сгоревшие бонусы до отмены ctx
+func (e *BonusExpirer) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.useCase.ExpireBonuses(); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+const pendingExpireCheckTime = 5 * time.Minute
+
+type PendingExpirer struct {
+	useCase  balance.ApprovalUseCase
+	interval time.Duration
+}
+
+func NewPendingExpirer(useCase balance.ApprovalUseCase) *PendingExpirer {
+	return &PendingExpirer{
+		useCase:  useCase,
+		interval: pendingExpireCheckTime,
+	}
+}
+
+// Run периодически отклоняет просроченные операции, ожидающие одобрения, до отмены ctx
+func (e *PendingExpirer) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := e.useCase.ExpireOperations(); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
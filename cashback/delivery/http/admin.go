@@ -0,0 +1,175 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/cashback"
+	"avito-intership/models"
+	"avito-intership/problem"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type AdminHandler struct {
+	useCase cashback.CashbackUseCase
+}
+
+func NewAdminHandler(useCase cashback.CashbackUseCase) *AdminHandler {
+	return &AdminHandler{
+		useCase: useCase,
+	}
+}
+
+type StatusMessage struct {
+	Success bool    `json:"success"`
+	Message *string `json:"message"`
+}
+
+func (h AdminHandler) writeStatus(success bool, message *string, w *http.ResponseWriter) {
+	status := StatusMessage{
+		Success: success,
+		Message: message,
+	}
+
+	(*w).Header().Add("Content-Type", "application/json")
+	_ = json.NewEncoder(*w).Encode(status)
+}
+
+func (h AdminHandler) writeJSON(value interface{}, w http.ResponseWriter) {
+	w.Header().Add("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		problem.Write(w, err)
+	}
+}
+
+func (h AdminHandler) parseId(r *http.Request, w http.ResponseWriter) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil || id <= 0 {
+		problem.Write(w, apperror.BadArgument("id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+// parseRule читает правило; без product_id правило действует на любые покупки, без monthly_cap - без лимита,
+// active по умолчанию true
+func (h AdminHandler) parseRule(r *http.Request, w http.ResponseWriter) (*models.CashbackRule, bool) {
+	percent, err := strconv.ParseFloat(r.FormValue("percent"), 32)
+	if err != nil {
+		problem.Write(w, apperror.BadArgument("percent"))
+		return nil, false
+	}
+
+	rule := &models.CashbackRule{
+		Percent: float32(percent),
+		Active:  true,
+	}
+
+	if productId := r.FormValue("product_id"); productId != "" {
+		id, err := strconv.ParseInt(productId, 10, 64)
+		if err != nil {
+			problem.Write(w, apperror.BadArgument("product_id"))
+			return nil, false
+		}
+		rule.ProductId = &id
+	}
+
+	if monthlyCap := r.FormValue("monthly_cap"); monthlyCap != "" {
+		value, err := strconv.ParseFloat(monthlyCap, 32)
+		if err != nil {
+			problem.Write(w, apperror.BadArgument("monthly_cap"))
+			return nil, false
+		}
+		capValue := float32(value)
+		rule.MonthlyCap = &capValue
+	}
+
+	if active := r.FormValue("active"); active != "" {
+		rule.Active, err = strconv.ParseBool(active)
+		if err != nil {
+			problem.Write(w, apperror.BadArgument("active"))
+			return nil, false
+		}
+	}
+
+	return rule, true
+}
+
+func (h AdminHandler) GetRulesEndpoint(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.useCase.GetRules()
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(rules, w)
+}
+
+func (h AdminHandler) GetRuleEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	rule, err := h.useCase.GetRule(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(rule, w)
+}
+
+func (h AdminHandler) CreateRuleEndpoint(w http.ResponseWriter, r *http.Request) {
+	rule, ok := h.parseRule(r, w)
+	if !ok {
+		return
+	}
+
+	created, err := h.useCase.CreateRule(rule)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(created, w)
+}
+
+func (h AdminHandler) UpdateRuleEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	rule, ok := h.parseRule(r, w)
+	if !ok {
+		return
+	}
+	rule.Id = id
+
+	updated, err := h.useCase.UpdateRule(rule)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(updated, w)
+}
+
+func (h AdminHandler) DeactivateRuleEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	err := h.useCase.DeactivateRule(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeStatus(true, nil, &w)
+}
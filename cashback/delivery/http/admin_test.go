@@ -0,0 +1,105 @@
+package http
+
+import (
+	"avito-intership/cashback"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+type cashbackHandlerSuite struct {
+	suite.Suite
+
+	useCase       *mocks.CashbackUseCase
+	testingServer *httptest.Server
+}
+
+func (suite *cashbackHandlerSuite) SetupSuite() {
+	useCase := new(mocks.CashbackUseCase)
+
+	router := mux.NewRouter()
+	RegisterAdminEndpoints(router.PathPrefix("/api/v1/admin").Subrouter(), useCase)
+
+	suite.testingServer = httptest.NewServer(router)
+	suite.useCase = useCase
+}
+
+func (suite *cashbackHandlerSuite) TearDownSuite() {
+	defer suite.testingServer.Close()
+}
+
+func (suite *cashbackHandlerSuite) TestCreateRule() {
+	var productId int64 = 12
+	var monthlyCap float32 = 500
+	created := &models.CashbackRule{Id: 1, ProductId: &productId, Percent: 5, MonthlyCap: &monthlyCap, Active: true}
+	suite.useCase.On("CreateRule", mock.MatchedBy(func(r *models.CashbackRule) bool {
+		return r.ProductId != nil && *r.ProductId == 12 && r.Percent == 5 &&
+			r.MonthlyCap != nil && *r.MonthlyCap == 500 && r.Active
+	})).Return(created, nil)
+
+	data := url.Values{}
+	data.Set("product_id", "12")
+	data.Set("percent", "5")
+	data.Set("monthly_cap", "500")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/cashback", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.CashbackRule
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(created, &responseBody)
+}
+
+func (suite *cashbackHandlerSuite) TestCreateRule_BadPercent() {
+	data := url.Values{}
+	data.Set("percent", "five")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/cashback", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *cashbackHandlerSuite) TestCreateRule_Invalid() {
+	suite.useCase.On("CreateRule", mock.MatchedBy(func(r *models.CashbackRule) bool {
+		return r.Percent == 150
+	})).Return(nil, cashback.ErrBadRule)
+
+	data := url.Values{}
+	data.Set("percent", "150")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/cashback", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *cashbackHandlerSuite) TestDeactivateRule_NotFound() {
+	suite.useCase.On("DeactivateRule", int64(404)).Return(cashback.ErrRuleNotFound)
+
+	request, _ := http.NewRequest(http.MethodDelete,
+		fmt.Sprintf("%s/api/v1/admin/cashback/404", suite.testingServer.URL), nil)
+	response, err := http.DefaultClient.Do(request)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusNotFound, response.StatusCode)
+}
+
+func TestCashbackHandler(t *testing.T) {
+	suite.Run(t, new(cashbackHandlerSuite))
+}
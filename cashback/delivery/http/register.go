@@ -0,0 +1,23 @@
+package http
+
+import (
+	"avito-intership/cashback"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// RegisterAdminEndpoints регистрирует управление правилами кэшбэка, router - подмаршрутизатор /api/v1/admin
+func RegisterAdminEndpoints(router *mux.Router, uc cashback.CashbackUseCase) {
+	handler := NewAdminHandler(uc)
+
+	router.HandleFunc("/cashback", handler.GetRulesEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/cashback", handler.CreateRuleEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/cashback/{id:[0-9]+}", handler.GetRuleEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/cashback/{id:[0-9]+}", handler.UpdateRuleEndpoint).
+		Methods(http.MethodOptions, http.MethodPut)
+	router.HandleFunc("/cashback/{id:[0-9]+}", handler.DeactivateRuleEndpoint).
+		Methods(http.MethodOptions, http.MethodDelete)
+}
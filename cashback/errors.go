@@ -0,0 +1,9 @@
+package cashback
+
+import "avito-intership/apperror"
+
+var (
+	ErrRuleNotFound = apperror.New(apperror.NotFound, "cashback rule not found")
+	ErrBadRule      = apperror.New(apperror.InvalidArgument,
+		"cashback percent must be in (0, 100] and monthly cap must be positive")
+)
@@ -0,0 +1,12 @@
+package cashback
+
+import "avito-intership/models"
+
+// Правила применяются репозиторием баланса в транзакции покупки, здесь только управление ими
+type CashbackRepository interface {
+	CreateRule(rule *models.CashbackRule) (*models.CashbackRule, error)
+	UpdateRule(rule *models.CashbackRule) (*models.CashbackRule, error)
+	DeactivateRule(id int64) error
+	GetRule(id int64) (*models.CashbackRule, error)
+	GetRules() ([]*models.CashbackRule, error)
+}
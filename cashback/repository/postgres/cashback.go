@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"avito-intership/cashback"
+	"avito-intership/models"
+	"database/sql"
+)
+
+type CashbackRepository struct {
+	db *sql.DB
+}
+
+func NewCashbackRepository(dbConn *sql.DB) *CashbackRepository {
+	return &CashbackRepository{dbConn}
+}
+
+const ruleColumns = "id, product_id, percent, monthly_cap, active, created_at"
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRule(row scanner) (*models.CashbackRule, error) {
+	var rule models.CashbackRule
+	var productId sql.NullInt64
+	var monthlyCap sql.NullFloat64
+
+	err := row.Scan(&rule.Id, &productId, &rule.Percent, &monthlyCap, &rule.Active, &rule.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, cashback.ErrRuleNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if productId.Valid {
+		rule.ProductId = &productId.Int64
+	}
+	if monthlyCap.Valid {
+		value := float32(monthlyCap.Float64)
+		rule.MonthlyCap = &value
+	}
+
+	return &rule, nil
+}
+
+func (r CashbackRepository) CreateRule(rule *models.CashbackRule) (*models.CashbackRule, error) {
+	row := r.db.QueryRow(
+		`INSERT INTO cashback_rules (product_id, percent, monthly_cap, active) VALUES ($1, $2, $3, $4)
+		RETURNING `+ruleColumns,
+		rule.ProductId, rule.Percent, rule.MonthlyCap, rule.Active)
+	return scanRule(row)
+}
+
+func (r CashbackRepository) UpdateRule(rule *models.CashbackRule) (*models.CashbackRule, error) {
+	row := r.db.QueryRow(
+		`UPDATE cashback_rules SET product_id = $1, percent = $2, monthly_cap = $3, active = $4 WHERE id = $5
+		RETURNING `+ruleColumns,
+		rule.ProductId, rule.Percent, rule.MonthlyCap, rule.Active, rule.Id)
+	return scanRule(row)
+}
+
+// DeactivateRule отключает правило; запись сохраняется, на нее ссылаются начисления кэшбэка
+func (r CashbackRepository) DeactivateRule(id int64) error {
+	result, err := r.db.Exec("UPDATE cashback_rules SET active = FALSE WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return cashback.ErrRuleNotFound
+	}
+
+	return nil
+}
+
+func (r CashbackRepository) GetRule(id int64) (*models.CashbackRule, error) {
+	row := r.db.QueryRow("SELECT "+ruleColumns+" FROM cashback_rules WHERE id = $1", id)
+	return scanRule(row)
+}
+
+func (r CashbackRepository) GetRules() ([]*models.CashbackRule, error) {
+	rows, err := r.db.Query("SELECT " + ruleColumns + " FROM cashback_rules ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := make([]*models.CashbackRule, 0)
+	for rows.Next() {
+		rule, err := scanRule(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, rule)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"avito-intership/cashback"
+	"avito-intership/models"
+	"avito-intership/utils"
+	"database/sql"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/suite"
+	"log"
+	"testing"
+)
+
+type cashbackRepositorySuite struct {
+	suite.Suite
+
+	db       *sql.DB
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+
+	repository cashback.CashbackRepository
+}
+
+func (suite *cashbackRepositorySuite) SetupSuite() {
+	db, pool, resource := utils.DockerDBUp()
+	err := utils.InitTable(db, "../../../init.sql")
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	suite.repository = NewCashbackRepository(db)
+	suite.db = db
+	suite.pool = pool
+	suite.resource = resource
+}
+
+func (suite *cashbackRepositorySuite) TestCreateRule() {
+	var monthlyCap float32 = 500
+	created, err := suite.repository.CreateRule(&models.CashbackRule{Percent: 5, MonthlyCap: &monthlyCap, Active: true})
+	suite.NoError(err, "creating rule should not produce error")
+	suite.Nil(created.ProductId)
+
+	stored, err := suite.repository.GetRule(created.Id)
+	suite.NoError(err, "getting rule should not produce error")
+	suite.Equal(float32(5), stored.Percent)
+	suite.Equal(monthlyCap, *stored.MonthlyCap)
+	suite.True(stored.Active)
+}
+
+func (suite *cashbackRepositorySuite) TestUpdateRule() {
+	created, err := suite.repository.CreateRule(&models.CashbackRule{Percent: 5, Active: true})
+	suite.NoError(err, "creating rule should not produce error")
+
+	created.Percent = 7.5
+	updated, err := suite.repository.UpdateRule(created)
+	suite.NoError(err, "updating rule should not produce error")
+	suite.Equal(float32(7.5), updated.Percent)
+	suite.Nil(updated.MonthlyCap)
+}
+
+func (suite *cashbackRepositorySuite) TestDeactivateRule() {
+	created, err := suite.repository.CreateRule(&models.CashbackRule{Percent: 3, Active: true})
+	suite.NoError(err, "creating rule should not produce error")
+
+	err = suite.repository.DeactivateRule(created.Id)
+	suite.NoError(err, "deactivating rule should not produce error")
+
+	stored, err := suite.repository.GetRule(created.Id)
+	suite.NoError(err, "deactivated rule should be kept")
+	suite.False(stored.Active)
+}
+
+func (suite *cashbackRepositorySuite) TestDeactivateRule_NotFound() {
+	err := suite.repository.DeactivateRule(100500)
+
+	suite.Equal(cashback.ErrRuleNotFound, err)
+}
+
+func (suite *cashbackRepositorySuite) TearDownSuite() {
+	err := utils.DropTable(suite.db, []string{"cashback_rules"})
+	if err != nil {
+		log.Println(err)
+	}
+
+	if err := suite.pool.Purge(suite.resource); err != nil {
+		log.Fatalf("Could not purge resource: %s", err)
+	}
+}
+
+func TestCashbackRepository(t *testing.T) {
+	suite.Run(t, new(cashbackRepositorySuite))
+}
@@ -0,0 +1,11 @@
+package cashback
+
+import "avito-intership/models"
+
+type CashbackUseCase interface {
+	CreateRule(rule *models.CashbackRule) (*models.CashbackRule, error)
+	UpdateRule(rule *models.CashbackRule) (*models.CashbackRule, error)
+	DeactivateRule(id int64) error
+	GetRule(id int64) (*models.CashbackRule, error)
+	GetRules() ([]*models.CashbackRule, error)
+}
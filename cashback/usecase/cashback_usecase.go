@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"avito-intership/cashback"
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"avito-intership/product"
+)
+
+type CashbackUseCase struct {
+	repository cashback.CashbackRepository
+	products   product.ProductRepository
+}
+
+func NewCashbackUseCase(repository cashback.CashbackRepository, products product.ProductRepository) *CashbackUseCase {
+	return &CashbackUseCase{
+		repository: repository,
+		products:   products,
+	}
+}
+
+// validateRule проверяет правило и округляет лимит до копеек
+func (u CashbackUseCase) validateRule(rule *models.CashbackRule) error {
+	if rule.Percent <= 0 || rule.Percent > 100 {
+		return cashback.ErrBadRule
+	}
+
+	if rule.MonthlyCap != nil {
+		monthlyCap, _ := exchange.Round(exchange.Decimal(*rule.MonthlyCap), exchange.RUB).Float32()
+		if monthlyCap <= 0 {
+			return cashback.ErrBadRule
+		}
+		rule.MonthlyCap = &monthlyCap
+	}
+
+	if rule.ProductId != nil {
+		if _, err := u.products.GetProduct(*rule.ProductId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (u CashbackUseCase) CreateRule(rule *models.CashbackRule) (*models.CashbackRule, error) {
+	if err := u.validateRule(rule); err != nil {
+		return nil, err
+	}
+
+	return u.repository.CreateRule(rule)
+}
+
+func (u CashbackUseCase) UpdateRule(rule *models.CashbackRule) (*models.CashbackRule, error) {
+	if err := u.validateRule(rule); err != nil {
+		return nil, err
+	}
+
+	return u.repository.UpdateRule(rule)
+}
+
+func (u CashbackUseCase) DeactivateRule(id int64) error {
+	return u.repository.DeactivateRule(id)
+}
+
+func (u CashbackUseCase) GetRule(id int64) (*models.CashbackRule, error) {
+	return u.repository.GetRule(id)
+}
+
+func (u CashbackUseCase) GetRules() ([]*models.CashbackRule, error) {
+	return u.repository.GetRules()
+}
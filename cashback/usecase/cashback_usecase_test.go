@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"avito-intership/cashback"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"avito-intership/product"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type cashbackUseCaseSuite struct {
+	suite.Suite
+	repository *mocks.CashbackRepository
+	products   *mocks.ProductRepository
+	useCase    cashback.CashbackUseCase
+}
+
+func (suite *cashbackUseCaseSuite) SetupTest() {
+	repository := new(mocks.CashbackRepository)
+	products := new(mocks.ProductRepository)
+
+	suite.repository = repository
+	suite.products = products
+	suite.useCase = NewCashbackUseCase(repository, products)
+}
+
+func (suite *cashbackUseCaseSuite) TestCreateRule_Ok() {
+	var productId int64 = 12
+	var monthlyCap float32 = 499.999
+	rule := &models.CashbackRule{ProductId: &productId, Percent: 5, MonthlyCap: &monthlyCap, Active: true}
+	created := &models.CashbackRule{Id: 1, ProductId: &productId, Percent: 5, Active: true}
+
+	suite.products.On("GetProduct", productId).Return(&models.Product{Id: productId}, nil)
+	suite.repository.On("CreateRule", mock.MatchedBy(func(r *models.CashbackRule) bool {
+		return *r.MonthlyCap == 500 && r.Percent == 5
+	})).Return(created, nil)
+
+	result, err := suite.useCase.CreateRule(rule)
+
+	suite.NoError(err)
+	suite.Equal(created, result)
+}
+
+func (suite *cashbackUseCaseSuite) TestCreateRule_AnyProduct() {
+	rule := &models.CashbackRule{Percent: 1, Active: true}
+	suite.repository.On("CreateRule", rule).Return(&models.CashbackRule{Id: 2, Percent: 1, Active: true}, nil)
+
+	_, err := suite.useCase.CreateRule(rule)
+
+	suite.NoError(err)
+	suite.products.AssertNotCalled(suite.T(), "GetProduct", mock.Anything)
+}
+
+func (suite *cashbackUseCaseSuite) TestCreateRule_Invalid() {
+	var unknownProduct int64 = 404
+	var zeroCap float32 = 0.001
+	cases := []struct {
+		name string
+		rule *models.CashbackRule
+		err  error
+	}{
+		{name: "zero percent", rule: &models.CashbackRule{Percent: 0}, err: cashback.ErrBadRule},
+		{name: "percent above 100", rule: &models.CashbackRule{Percent: 101}, err: cashback.ErrBadRule},
+		{name: "cap below kopeck", rule: &models.CashbackRule{Percent: 5, MonthlyCap: &zeroCap}, err: cashback.ErrBadRule},
+		{name: "unknown product", rule: &models.CashbackRule{Percent: 5, ProductId: &unknownProduct},
+			err: product.ErrProductNotFound},
+	}
+	suite.products.On("GetProduct", unknownProduct).Return(nil, product.ErrProductNotFound)
+
+	for _, c := range cases {
+		suite.Run(c.name, func() {
+			_, err := suite.useCase.CreateRule(c.rule)
+
+			suite.Equal(c.err, err)
+		})
+	}
+	suite.repository.AssertNotCalled(suite.T(), "CreateRule", mock.Anything)
+}
+
+func TestCashbackUseCase(t *testing.T) {
+	suite.Run(t, new(cashbackUseCaseSuite))
+}
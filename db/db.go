@@ -10,6 +10,9 @@ import (
 
 var db *sql.DB
 
+// connInfo нужен соединениям вне пула, например для LISTEN
+var connInfo string
+
 func init() {
 	dbPort, err := strconv.Atoi(os.Getenv("DB_PORT"))
 	if err != nil {
@@ -21,11 +24,11 @@ func init() {
 	user := os.Getenv("DB_USER")
 	password := os.Getenv("DB_PSW")
 
-	psqlInfo := fmt.Sprintf("host=%s port=%d user=%s "+
+	connInfo = fmt.Sprintf("host=%s port=%d user=%s "+
 		"password=%s dbname=%s sslmode=disable",
 		host, dbPort, user, password, dbName)
 
-	db, err = sql.Open("postgres", psqlInfo)
+	db, err = sql.Open("postgres", connInfo)
 	if err != nil {
 		panic(err)
 	}
@@ -39,3 +42,7 @@ func init() {
 func GetDB() *sql.DB {
 	return db
 }
+
+func GetConnInfo() string {
+	return connInfo
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/auth"
+	"avito-intership/escrow"
+	"avito-intership/models"
+	"avito-intership/problem"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	useCase escrow.DealUseCase
+}
+
+func NewHandler(useCase escrow.DealUseCase) *Handler {
+	return &Handler{
+		useCase: useCase,
+	}
+}
+
+func (h Handler) writeDeal(deal *models.Deal, w http.ResponseWriter) {
+	w.Header().Add("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(deal)
+	if err != nil {
+		problem.Write(w, err)
+	}
+}
+
+func (h Handler) parseId(r *http.Request, w http.ResponseWriter) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil || id <= 0 {
+		problem.Write(w, apperror.BadArgument("id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (h Handler) CreateDealEndpoint(w http.ResponseWriter, r *http.Request) {
+	buyer, err := strconv.ParseInt(r.FormValue("buyer"), 10, 64)
+	if err != nil {
+		problem.Write(w, apperror.BadArgument("buyer"))
+		return
+	}
+
+	seller, err := strconv.ParseInt(r.FormValue("seller"), 10, 64)
+	if err != nil {
+		problem.Write(w, apperror.BadArgument("seller"))
+		return
+	}
+
+	amount, err := strconv.ParseFloat(r.FormValue("amount"), 32)
+	if err != nil {
+		problem.Write(w, apperror.BadArgument("amount"))
+		return
+	}
+
+	// Сделку может создать любая из ее сторон
+	if err := auth.AuthorizeAny(r.Context(), auth.ScopeDeals, buyer, seller); err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	var releaseAfter int64
+	if value := r.FormValue("release_after"); value != "" {
+		releaseAfter, err = strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			problem.Write(w, apperror.BadArgument("release_after"))
+			return
+		}
+	}
+
+	deal, err := h.useCase.CreateDeal(buyer, seller, float32(amount), releaseAfter)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeDeal(deal, w)
+}
+
+// getDeal возвращает сделку, если вызывающий - сервис или пользователь из parties
+func (h Handler) getDeal(r *http.Request, w http.ResponseWriter, parties func(deal *models.Deal) []int64) (*models.Deal, bool) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return nil, false
+	}
+
+	deal, err := h.useCase.GetDeal(id)
+	if err != nil {
+		problem.Write(w, err)
+		return nil, false
+	}
+
+	if err = auth.AuthorizeAny(r.Context(), auth.ScopeDeals, parties(deal)...); err != nil {
+		problem.Write(w, err)
+		return nil, false
+	}
+
+	return deal, true
+}
+
+func buyer(deal *models.Deal) []int64 {
+	return []int64{deal.BuyerId}
+}
+
+func buyerOrSeller(deal *models.Deal) []int64 {
+	return []int64{deal.BuyerId, deal.SellerId}
+}
+
+func (h Handler) GetDealEndpoint(w http.ResponseWriter, r *http.Request) {
+	deal, ok := h.getDeal(r, w, buyerOrSeller)
+	if !ok {
+		return
+	}
+
+	h.writeDeal(deal, w)
+}
+
+// transitionEndpoint возвращает обработчик, выполняющий над сделкой операцию action от имени одной из parties
+func (h Handler) transitionEndpoint(action func(id int64) (*models.Deal, error),
+	parties func(deal *models.Deal) []int64) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		deal, ok := h.getDeal(r, w, parties)
+		if !ok {
+			return
+		}
+
+		deal, err := action(deal.Id)
+		if err != nil {
+			problem.Write(w, err)
+			return
+		}
+
+		h.writeDeal(deal, w)
+	}
+}
+
+// FundDealEndpoint - покупатель оплачивает сделку
+func (h Handler) FundDealEndpoint(w http.ResponseWriter, r *http.Request) {
+	h.transitionEndpoint(h.useCase.Fund, buyer)(w, r)
+}
+
+// ConfirmDealEndpoint - покупатель подтверждает получение, деньги уходят продавцу
+func (h Handler) ConfirmDealEndpoint(w http.ResponseWriter, r *http.Request) {
+	h.transitionEndpoint(h.useCase.Release, buyer)(w, r)
+}
+
+// DisputeDealEndpoint - спор по сделке, деньги возвращаются покупателю. Продавец может вернуть деньги сам
+func (h Handler) DisputeDealEndpoint(w http.ResponseWriter, r *http.Request) {
+	h.transitionEndpoint(h.useCase.Refund, buyerOrSeller)(w, r)
+}
@@ -0,0 +1,170 @@
+package http
+
+import (
+	"avito-intership/auth"
+	"avito-intership/balance"
+	"avito-intership/escrow"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"avito-intership/server/middleware"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type dealHandlerSuite struct {
+	suite.Suite
+
+	useCase       *mocks.DealUseCase
+	testingServer *httptest.Server
+}
+
+func (suite *dealHandlerSuite) SetupSuite() {
+	useCase := new(mocks.DealUseCase)
+
+	router := mux.NewRouter()
+	router.Use(middleware.WithPrincipal(&models.Principal{Service: "marketplace",
+		Scopes: []string{auth.ScopeDeals}}))
+	RegisterEndpoints(router, useCase)
+
+	suite.testingServer = httptest.NewServer(router)
+	suite.useCase = useCase
+}
+
+func (suite *dealHandlerSuite) TearDownSuite() {
+	defer suite.testingServer.Close()
+}
+
+func (suite *dealHandlerSuite) TestCreateDeal() {
+	created := &models.Deal{Id: 1, BuyerId: 1, SellerId: 2, Amount: 100, Status: escrow.DealCreated, ReleaseAfter: 3600}
+	suite.useCase.On("CreateDeal", int64(1), int64(2), float32(100), int64(3600)).Return(created, nil)
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/deals?buyer=1&seller=2&amount=100&release_after=3600",
+		suite.testingServer.URL), "", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.Deal
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(*created, responseBody)
+}
+
+func (suite *dealHandlerSuite) TestCreateDeal_BadArguments() {
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/deals?buyer=1&amount=100", suite.testingServer.URL),
+		"", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *dealHandlerSuite) TestTransitions() {
+	cases := []struct {
+		name   string
+		path   string
+		method string
+		result *models.Deal
+		err    error
+		status int
+	}{
+		{name: "fund", path: "fund", method: "Fund",
+			result: &models.Deal{Id: 10, Status: escrow.DealFunded}, status: http.StatusOK},
+		{name: "fund with low balance", path: "fund", method: "Fund",
+			err: balance.ErrTooLowBalance, status: http.StatusConflict},
+		{name: "confirm", path: "confirm", method: "Release",
+			result: &models.Deal{Id: 10, Status: escrow.DealReleased}, status: http.StatusOK},
+		{name: "dispute closed deal", path: "dispute", method: "Refund",
+			err: escrow.ErrBadTransition, status: http.StatusConflict},
+	}
+
+	for i, c := range cases {
+		suite.Run(c.name, func() {
+			id := int64(100 + i)
+			suite.useCase.On("GetDeal", id).Return(&models.Deal{Id: id, BuyerId: 1, SellerId: 2}, nil)
+			suite.useCase.On(c.method, id).Return(c.result, c.err)
+
+			response, err := http.Post(fmt.Sprintf("%s/api/v1/deals/%d/%s", suite.testingServer.URL, id, c.path),
+				"", bytes.NewBuffer([]byte{}))
+			suite.NoError(err, "request should not produce error")
+			defer response.Body.Close()
+
+			suite.Equal(c.status, response.StatusCode)
+			suite.useCase.AssertCalled(suite.T(), c.method, id)
+		})
+	}
+}
+
+func (suite *dealHandlerSuite) TestTransitions_UnknownDeal() {
+	suite.useCase.On("GetDeal", int64(200)).Return(nil, escrow.ErrDealNotFound)
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/deals/200/confirm", suite.testingServer.URL),
+		"", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusNotFound, response.StatusCode)
+	suite.useCase.AssertNotCalled(suite.T(), "Release", int64(200))
+}
+
+// TestUserParties проверяет, что пользователь работает только со сделками, в которых он участвует
+func (suite *dealHandlerSuite) TestUserParties() {
+	useCase := new(mocks.DealUseCase)
+	router := mux.NewRouter()
+	router.Use(middleware.WithPrincipal(&models.Principal{UserId: 2, Scopes: auth.UserScopes}))
+	RegisterEndpoints(router, useCase)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	deal := &models.Deal{Id: 1, BuyerId: 1, SellerId: 2, Amount: 100, Status: escrow.DealFunded}
+	foreign := &models.Deal{Id: 2, BuyerId: 1, SellerId: 3, Amount: 100, Status: escrow.DealFunded}
+	useCase.On("GetDeal", deal.Id).Return(deal, nil)
+	useCase.On("GetDeal", foreign.Id).Return(foreign, nil)
+	useCase.On("CreateDeal", int64(1), int64(2), float32(100), int64(0)).Return(deal, nil)
+	useCase.On("Refund", deal.Id).Return(deal, nil)
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		status int
+	}{
+		{name: "seller creates deal", method: http.MethodPost, path: "/api/v1/deals?buyer=1&seller=2&amount=100",
+			status: http.StatusOK},
+		{name: "foreign deal creation", method: http.MethodPost, path: "/api/v1/deals?buyer=1&seller=3&amount=100",
+			status: http.StatusForbidden},
+		{name: "own deal", method: http.MethodGet, path: "/api/v1/deals/1", status: http.StatusOK},
+		{name: "foreign deal", method: http.MethodGet, path: "/api/v1/deals/2", status: http.StatusForbidden},
+		{name: "seller can't fund", method: http.MethodPost, path: "/api/v1/deals/1/fund", status: http.StatusForbidden},
+		{name: "seller can't confirm", method: http.MethodPost, path: "/api/v1/deals/1/confirm",
+			status: http.StatusForbidden},
+		{name: "seller refunds", method: http.MethodPost, path: "/api/v1/deals/1/dispute", status: http.StatusOK},
+	}
+
+	for _, c := range cases {
+		suite.Run(c.name, func() {
+			request, err := http.NewRequest(c.method, server.URL+c.path, nil)
+			suite.NoError(err)
+
+			response, err := http.DefaultClient.Do(request)
+			suite.NoError(err, "request should not produce error")
+			defer response.Body.Close()
+
+			suite.Equal(c.status, response.StatusCode)
+		})
+	}
+	useCase.AssertNotCalled(suite.T(), "Fund", mock.Anything)
+	useCase.AssertNotCalled(suite.T(), "Release", mock.Anything)
+}
+
+func TestDealHandler(t *testing.T) {
+	suite.Run(t, new(dealHandlerSuite))
+}
@@ -0,0 +1,22 @@
+package http
+
+import (
+	"avito-intership/escrow"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+func RegisterEndpoints(router *mux.Router, uc escrow.DealUseCase) {
+	handler := NewHandler(uc)
+
+	router.HandleFunc("/api/v1/deals", handler.CreateDealEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/api/v1/deals/{id:[0-9]+}", handler.GetDealEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/api/v1/deals/{id:[0-9]+}/fund", handler.FundDealEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/api/v1/deals/{id:[0-9]+}/confirm", handler.ConfirmDealEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/api/v1/deals/{id:[0-9]+}/dispute", handler.DisputeDealEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+}
@@ -0,0 +1,14 @@
+package escrow
+
+import "avito-intership/apperror"
+
+var (
+	ErrDealNotFound = apperror.New(apperror.NotFound, "deal not found")
+	ErrBadDeal      = apperror.New(apperror.InvalidArgument,
+		"deal must have different buyer and seller and a positive amount")
+	ErrBadTransition = apperror.New(apperror.Conflict, "operation is not allowed in the current deal status")
+	ErrDealTooLarge  = apperror.WithParam(apperror.InvalidArgument, "amount",
+		"deal amount exceeds the approval threshold, use a transfer approved by an operator")
+	ErrFundingHeld = apperror.New(apperror.PermissionDenied,
+		"deal funding requires a manual fraud review and can't be completed")
+)
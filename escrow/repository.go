@@ -0,0 +1,38 @@
+package escrow
+
+import (
+	"avito-intership/balance"
+	"avito-intership/models"
+	"time"
+)
+
+// Статусы сделки: created -> funded -> released | refunded
+const (
+	DealCreated  string = "created"
+	DealFunded   string = "funded"
+	DealReleased string = "released"
+	DealRefunded string = "refunded"
+)
+
+type DealRepository interface {
+	CreateDeal(deal *models.Deal) (*models.Deal, error)
+	GetDeal(id int64) (*models.Deal, error)
+	// Transition переводит сделку из статуса from в статус to и в той же транзакции переводит сумму сделки
+	// между счетами, которые возвращает Accounts; ErrBadTransition - если сделка в другом статусе
+	Transition(id int64, from string, to string) (*models.Deal, error)
+	// GetDueDeals возвращает оплаченные сделки, срок подтверждения которых истек к моменту at
+	GetDueDeals(at time.Time) ([]*models.Deal, error)
+}
+
+// Accounts возвращает счета, между которыми переводится сумма сделки при переходе в статус to:
+// оплата списывает деньги покупателя на счет эскроу, закрытие сделки выплачивает их продавцу или покупателю
+func Accounts(deal *models.Deal, to string) (int64, int64) {
+	switch to {
+	case DealFunded:
+		return deal.BuyerId, balance.EscrowAccountId
+	case DealReleased:
+		return balance.EscrowAccountId, deal.SellerId
+	default:
+		return balance.EscrowAccountId, deal.BuyerId
+	}
+}
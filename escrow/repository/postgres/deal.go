@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	balancePostgres "avito-intership/balance/repository/postgres"
+	"avito-intership/escrow"
+	"avito-intership/models"
+	"database/sql"
+	"time"
+)
+
+type DealRepository struct {
+	db *sql.DB
+}
+
+func NewDealRepository(dbConn *sql.DB) *DealRepository {
+	return &DealRepository{dbConn}
+}
+
+const dealColumns = "id, buyer_id, seller_id, amount, status, release_after, created_at, funded_at, closed_at"
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanDeal(row scanner) (*models.Deal, error) {
+	var deal models.Deal
+	var fundedAt, closedAt sql.NullTime
+
+	err := row.Scan(&deal.Id, &deal.BuyerId, &deal.SellerId, &deal.Amount, &deal.Status, &deal.ReleaseAfter,
+		&deal.CreatedAt, &fundedAt, &closedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if fundedAt.Valid {
+		deal.FundedAt = &fundedAt.Time
+	}
+	if closedAt.Valid {
+		deal.ClosedAt = &closedAt.Time
+	}
+
+	return &deal, nil
+}
+
+func (r DealRepository) CreateDeal(deal *models.Deal) (*models.Deal, error) {
+	row := r.db.QueryRow(
+		`INSERT INTO deals (buyer_id, seller_id, amount, release_after) VALUES ($1, $2, $3, $4)
+		RETURNING `+dealColumns,
+		deal.BuyerId, deal.SellerId, deal.Amount, deal.ReleaseAfter)
+	return scanDeal(row)
+}
+
+func (r DealRepository) GetDeal(id int64) (*models.Deal, error) {
+	row := r.db.QueryRow("SELECT "+dealColumns+" FROM deals WHERE id = $1", id)
+	deal, err := scanDeal(row)
+	if err == sql.ErrNoRows {
+		return nil, escrow.ErrDealNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return deal, nil
+}
+
+// Transition меняет статус только если сделка находится в статусе from, поэтому из двух одновременных
+// операций над сделкой выполнится одна. Если перевод не удался, смена статуса откатывается вместе с ним
+func (r DealRepository) Transition(id int64, from string, to string) (*models.Deal, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	row := tx.QueryRow(
+		`UPDATE deals SET status = $3::deal_status,
+			funded_at = CASE WHEN $3::deal_status = 'funded' THEN NOW() ELSE funded_at END,
+			closed_at = CASE WHEN $3::deal_status IN ('released', 'refunded') THEN NOW() ELSE NULL END
+		WHERE id = $1 AND status = $2::deal_status
+		RETURNING `+dealColumns,
+		id, from, to)
+	deal, err := scanDeal(row)
+	if err == sql.ErrNoRows {
+		// Отличаем несуществующую сделку от сделки в другом статусе
+		if _, err = r.GetDeal(id); err != nil {
+			return nil, err
+		}
+		err = escrow.ErrBadTransition
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	src, dst := escrow.Accounts(deal, to)
+	err = balancePostgres.Transfer(tx, src, dst, deal.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return deal, nil
+}
+
+func (r DealRepository) GetDueDeals(at time.Time) ([]*models.Deal, error) {
+	rows, err := r.db.Query(
+		`SELECT `+dealColumns+` FROM deals
+		WHERE status = 'funded' AND funded_at + release_after * INTERVAL '1 second' <= $1
+		ORDER BY funded_at`, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deals := make([]*models.Deal, 0)
+	for rows.Next() {
+		deal, err := scanDeal(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deals = append(deals, deal)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deals, nil
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"avito-intership/balance"
+	"avito-intership/escrow"
+	"avito-intership/models"
+	"avito-intership/utils"
+	"database/sql"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/suite"
+	"log"
+	"testing"
+	"time"
+)
+
+type dealRepositorySuite struct {
+	suite.Suite
+
+	db       *sql.DB
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+
+	repository escrow.DealRepository
+}
+
+func (suite *dealRepositorySuite) SetupSuite() {
+	db, pool, resource := utils.DockerDBUp()
+	err := utils.InitTable(db, "../../../init.sql")
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	suite.repository = NewDealRepository(db)
+	suite.db = db
+	suite.pool = pool
+	suite.resource = resource
+}
+
+func (suite *dealRepositorySuite) balance(userId int64) float32 {
+	var amount float32
+	err := suite.db.QueryRow("SELECT amount FROM balances WHERE id = $1", userId).Scan(&amount)
+	if err != nil && err != sql.ErrNoRows {
+		suite.FailNow(err.Error())
+	}
+
+	return amount
+}
+
+func (suite *dealRepositorySuite) fill(userId int64, amount float32) {
+	_, err := suite.db.Exec(
+		`INSERT INTO balances(id, amount) VALUES ($1, $2)
+		ON CONFLICT(id) DO UPDATE SET amount = balances.amount + EXCLUDED.amount`, userId, amount)
+	suite.NoError(err, "filling balance should not produce error")
+}
+
+func (suite *dealRepositorySuite) TestTransition() {
+	suite.fill(1, 100)
+	escrowBefore := suite.balance(balance.EscrowAccountId)
+
+	created, err := suite.repository.CreateDeal(&models.Deal{BuyerId: 1, SellerId: 2, Amount: 100, ReleaseAfter: 60})
+	suite.NoError(err, "creating deal should not produce error")
+	suite.Equal(escrow.DealCreated, created.Status)
+
+	funded, err := suite.repository.Transition(created.Id, escrow.DealCreated, escrow.DealFunded)
+	suite.NoError(err, "funding deal should not produce error")
+	suite.Equal(escrow.DealFunded, funded.Status)
+	suite.NotNil(funded.FundedAt)
+	suite.Equal(float32(0), suite.balance(1))
+	suite.Equal(escrowBefore+100, suite.balance(balance.EscrowAccountId))
+
+	_, err = suite.repository.Transition(created.Id, escrow.DealCreated, escrow.DealFunded)
+	suite.Equal(escrow.ErrBadTransition, err, "deal can be funded only once")
+
+	released, err := suite.repository.Transition(created.Id, escrow.DealFunded, escrow.DealReleased)
+	suite.NoError(err, "releasing deal should not produce error")
+	suite.NotNil(released.ClosedAt)
+	suite.Equal(float32(100), suite.balance(2))
+	suite.Equal(escrowBefore, suite.balance(balance.EscrowAccountId))
+}
+
+func (suite *dealRepositorySuite) TestTransition_TooLowBalance() {
+	created, err := suite.repository.CreateDeal(&models.Deal{BuyerId: 5, SellerId: 6, Amount: 100, ReleaseAfter: 60})
+	suite.NoError(err, "creating deal should not produce error")
+
+	_, err = suite.repository.Transition(created.Id, escrow.DealCreated, escrow.DealFunded)
+	suite.Equal(balance.ErrTooLowBalance, err)
+
+	deal, err := suite.repository.GetDeal(created.Id)
+	suite.NoError(err, "getting deal should not produce error")
+	suite.Equal(escrow.DealCreated, deal.Status, "status change is rolled back with the transfer")
+	suite.Nil(deal.FundedAt)
+}
+
+func (suite *dealRepositorySuite) TestTransition_NotFound() {
+	_, err := suite.repository.Transition(100500, escrow.DealCreated, escrow.DealFunded)
+
+	suite.Equal(escrow.ErrDealNotFound, err)
+}
+
+func (suite *dealRepositorySuite) TestGetDueDeals() {
+	suite.fill(3, 50)
+	created, err := suite.repository.CreateDeal(&models.Deal{BuyerId: 3, SellerId: 4, Amount: 50, ReleaseAfter: 3600})
+	suite.NoError(err, "creating deal should not produce error")
+	_, err = suite.repository.Transition(created.Id, escrow.DealCreated, escrow.DealFunded)
+	suite.NoError(err, "funding deal should not produce error")
+
+	due, err := suite.repository.GetDueDeals(time.Now())
+	suite.NoError(err, "getting due deals should not produce error")
+	for _, deal := range due {
+		suite.NotEqual(created.Id, deal.Id, "deal is not due yet")
+	}
+
+	due, err = suite.repository.GetDueDeals(time.Now().Add(2 * time.Hour))
+	suite.NoError(err, "getting due deals should not produce error")
+	ids := make([]int64, 0, len(due))
+	for _, deal := range due {
+		ids = append(ids, deal.Id)
+	}
+	suite.Contains(ids, created.Id)
+}
+
+func (suite *dealRepositorySuite) TearDownSuite() {
+	err := utils.DropTable(suite.db, []string{"deals", "transactions", "balances"})
+	if err != nil {
+		log.Println(err)
+	}
+
+	if err := suite.pool.Purge(suite.resource); err != nil {
+		log.Fatalf("Could not purge resource: %s", err)
+	}
+}
+
+func TestDealRepository(t *testing.T) {
+	suite.Run(t, new(dealRepositorySuite))
+}
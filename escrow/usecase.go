@@ -0,0 +1,16 @@
+package escrow
+
+import "avito-intership/models"
+
+type DealUseCase interface {
+	CreateDeal(buyerId int64, sellerId int64, amount float32, releaseAfter int64) (*models.Deal, error)
+	GetDeal(id int64) (*models.Deal, error)
+	// Fund переводит сумму сделки с баланса покупателя на счет эскроу
+	Fund(id int64) (*models.Deal, error)
+	// Release переводит сумму сделки продавцу
+	Release(id int64) (*models.Deal, error)
+	// Refund возвращает сумму сделки покупателю
+	Refund(id int64) (*models.Deal, error)
+	// ReleaseDue закрывает в пользу продавца сделки, которые покупатель не подтвердил и не оспорил в срок
+	ReleaseDue() error
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/escrow"
+	"avito-intership/exchange"
+	"avito-intership/fraud"
+	"avito-intership/models"
+	"log"
+	"time"
+)
+
+// Если покупатель не подтвердил получение и не открыл спор, сделка закрывается в пользу продавца
+const defaultReleaseAfter = 7 * 24 * time.Hour
+
+type DealUseCase struct {
+	deals  escrow.DealRepository
+	engine fraud.Evaluator
+	// Нулевой порог отключает ограничение суммы сделки
+	approvalThreshold float32
+}
+
+func NewDealUseCase(deals escrow.DealRepository, engine fraud.Evaluator, approvalThreshold float32) *DealUseCase {
+	return &DealUseCase{
+		deals:             deals,
+		engine:            engine,
+		approvalThreshold: approvalThreshold,
+	}
+}
+
+// Оплата сделки не может ждать решения оператора: одобренный позже перевод не оплатил бы сделку.
+// Поэтому сделки выше порога одобрения не принимаются, а оплата, которую антифрод отправил бы на проверку,
+// отклоняется
+func (u DealUseCase) exceedsThreshold(amount float32) bool {
+	return u.approvalThreshold > 0 && amount > u.approvalThreshold
+}
+
+func (u DealUseCase) CreateDeal(buyerId int64, sellerId int64, amount float32, releaseAfter int64) (*models.Deal, error) {
+	amount, _ = exchange.Round(exchange.Decimal(amount), exchange.RUB).Float32()
+	if buyerId <= 0 || sellerId <= 0 || buyerId == sellerId || amount <= 0 || releaseAfter < 0 {
+		return nil, escrow.ErrBadDeal
+	}
+
+	if u.exceedsThreshold(amount) {
+		return nil, escrow.ErrDealTooLarge
+	}
+
+	if releaseAfter == 0 {
+		releaseAfter = int64(defaultReleaseAfter / time.Second)
+	}
+
+	return u.deals.CreateDeal(&models.Deal{
+		BuyerId:      buyerId,
+		SellerId:     sellerId,
+		Amount:       amount,
+		ReleaseAfter: releaseAfter,
+	})
+}
+
+func (u DealUseCase) GetDeal(id int64) (*models.Deal, error) {
+	return u.deals.GetDeal(id)
+}
+
+// Fund проверяет оплату правилами антифрода как перевод покупателя на счет эскроу
+func (u DealUseCase) Fund(id int64) (*models.Deal, error) {
+	deal, err := u.deals.GetDeal(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if deal.Status != escrow.DealCreated {
+		return nil, escrow.ErrBadTransition
+	}
+
+	if u.exceedsThreshold(deal.Amount) {
+		return nil, escrow.ErrDealTooLarge
+	}
+
+	// Деньги идут на служебный счет сделки, но получает их продавец, поэтому оплата проверяется как перевод ему
+	decision, err := u.engine.Evaluate(fraud.Transfer{SrcId: deal.BuyerId, DstId: deal.SellerId, Amount: deal.Amount})
+	if err != nil {
+		return nil, err
+	}
+
+	switch decision.Verdict {
+	case fraud.Block:
+		log.Printf("deal %d funding blocked by %s: %s", id, decision.Rule, decision.Reason)
+		return nil, balance.ErrTransferBlocked
+	case fraud.Review:
+		log.Printf("deal %d funding held by %s: %s", id, decision.Rule, decision.Reason)
+		return nil, escrow.ErrFundingHeld
+	}
+
+	return u.deals.Transition(id, escrow.DealCreated, escrow.DealFunded)
+}
+
+func (u DealUseCase) Release(id int64) (*models.Deal, error) {
+	return u.deals.Transition(id, escrow.DealFunded, escrow.DealReleased)
+}
+
+func (u DealUseCase) Refund(id int64) (*models.Deal, error) {
+	return u.deals.Transition(id, escrow.DealFunded, escrow.DealRefunded)
+}
+
+func (u DealUseCase) ReleaseDue() error {
+	deals, err := u.deals.GetDueDeals(time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, deal := range deals {
+		// Сделка могла быть подтверждена или оспорена после выборки
+		_, err = u.Release(deal.Id)
+		if err != nil && err != escrow.ErrBadTransition {
+			log.Printf("deal %d: auto-release failed: %v", deal.Id, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/escrow"
+	"avito-intership/fraud"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type dealUseCaseSuite struct {
+	suite.Suite
+	deals   *mocks.DealRepository
+	engine  *mocks.Evaluator
+	useCase escrow.DealUseCase
+}
+
+func (suite *dealUseCaseSuite) SetupTest() {
+	deals := new(mocks.DealRepository)
+	engine := new(mocks.Evaluator)
+
+	suite.deals = deals
+	suite.engine = engine
+	suite.useCase = NewDealUseCase(deals, engine, 1000)
+}
+
+func (suite *dealUseCaseSuite) TestCreateDeal() {
+	created := &models.Deal{Id: 1, BuyerId: 1, SellerId: 2, Amount: 100.5, Status: escrow.DealCreated}
+	suite.deals.On("CreateDeal", &models.Deal{
+		BuyerId:      1,
+		SellerId:     2,
+		Amount:       100.5,
+		ReleaseAfter: int64(defaultReleaseAfter / time.Second),
+	}).Return(created, nil)
+
+	deal, err := suite.useCase.CreateDeal(1, 2, 100.5, 0)
+
+	suite.NoError(err)
+	suite.Equal(created, deal)
+}
+
+func (suite *dealUseCaseSuite) TestCreateDeal_Invalid() {
+	cases := []struct {
+		name   string
+		buyer  int64
+		seller int64
+		amount float32
+	}{
+		{name: "same user", buyer: 1, seller: 1, amount: 10},
+		{name: "zero amount", buyer: 1, seller: 2, amount: 0},
+		{name: "platform account", buyer: balance.EscrowAccountId, seller: 2, amount: 10},
+	}
+
+	for _, c := range cases {
+		suite.Run(c.name, func() {
+			_, err := suite.useCase.CreateDeal(c.buyer, c.seller, c.amount, 0)
+
+			suite.Equal(escrow.ErrBadDeal, err)
+		})
+	}
+	suite.deals.AssertNotCalled(suite.T(), "CreateDeal", mock.Anything)
+}
+
+func (suite *dealUseCaseSuite) TestCreateDeal_AboveThreshold() {
+	_, err := suite.useCase.CreateDeal(1, 2, 1000.01, 0)
+
+	suite.Equal(escrow.ErrDealTooLarge, err)
+	suite.deals.AssertNotCalled(suite.T(), "CreateDeal", mock.Anything)
+}
+
+func (suite *dealUseCaseSuite) TestFund() {
+	created := &models.Deal{Id: 1, BuyerId: 1, SellerId: 2, Amount: 100, Status: escrow.DealCreated}
+	funded := &models.Deal{Id: 1, BuyerId: 1, SellerId: 2, Amount: 100, Status: escrow.DealFunded}
+	suite.deals.On("GetDeal", created.Id).Return(created, nil)
+	suite.engine.On("Evaluate", fraud.Transfer{SrcId: 1, DstId: 2, Amount: 100}).
+		Return(&fraud.Decision{Verdict: fraud.Allow}, nil)
+	suite.deals.On("Transition", funded.Id, escrow.DealCreated, escrow.DealFunded).Return(funded, nil)
+
+	deal, err := suite.useCase.Fund(funded.Id)
+
+	suite.NoError(err)
+	suite.Equal(escrow.DealFunded, deal.Status)
+}
+
+func (suite *dealUseCaseSuite) TestFund_TooLowBalance() {
+	created := &models.Deal{Id: 1, BuyerId: 1, SellerId: 2, Amount: 100, Status: escrow.DealCreated}
+	suite.deals.On("GetDeal", created.Id).Return(created, nil)
+	suite.engine.On("Evaluate", mock.Anything).Return(&fraud.Decision{Verdict: fraud.Allow}, nil)
+	suite.deals.On("Transition", created.Id, escrow.DealCreated, escrow.DealFunded).
+		Return(nil, balance.ErrTooLowBalance)
+
+	_, err := suite.useCase.Fund(created.Id)
+
+	suite.Equal(balance.ErrTooLowBalance, err)
+}
+
+func (suite *dealUseCaseSuite) TestFund_Fraud() {
+	cases := []struct {
+		name    string
+		verdict string
+		err     error
+	}{
+		{name: "blocked", verdict: fraud.Block, err: balance.ErrTransferBlocked},
+		{name: "held for review", verdict: fraud.Review, err: escrow.ErrFundingHeld},
+	}
+
+	for _, c := range cases {
+		suite.Run(c.name, func() {
+			deals := new(mocks.DealRepository)
+			engine := new(mocks.Evaluator)
+			deals.On("GetDeal", int64(1)).
+				Return(&models.Deal{Id: 1, BuyerId: 1, SellerId: 2, Amount: 100, Status: escrow.DealCreated}, nil)
+			engine.On("Evaluate", mock.Anything).Return(&fraud.Decision{Verdict: c.verdict, Rule: "rule"}, nil)
+
+			_, err := NewDealUseCase(deals, engine, 1000).Fund(1)
+
+			suite.Equal(c.err, err)
+			deals.AssertNotCalled(suite.T(), "Transition", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func (suite *dealUseCaseSuite) TestFund_AboveThreshold() {
+	// Сделка создана до того, как порог был снижен
+	suite.deals.On("GetDeal", int64(1)).
+		Return(&models.Deal{Id: 1, BuyerId: 1, SellerId: 2, Amount: 5000, Status: escrow.DealCreated}, nil)
+
+	_, err := suite.useCase.Fund(1)
+
+	suite.Equal(escrow.ErrDealTooLarge, err)
+	suite.engine.AssertNotCalled(suite.T(), "Evaluate", mock.Anything)
+	suite.deals.AssertNotCalled(suite.T(), "Transition", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *dealUseCaseSuite) TestFund_NotCreated() {
+	suite.deals.On("GetDeal", int64(1)).
+		Return(&models.Deal{Id: 1, BuyerId: 1, SellerId: 2, Amount: 100, Status: escrow.DealFunded}, nil)
+
+	_, err := suite.useCase.Fund(1)
+
+	suite.Equal(escrow.ErrBadTransition, err)
+	suite.engine.AssertNotCalled(suite.T(), "Evaluate", mock.Anything)
+}
+
+func (suite *dealUseCaseSuite) TestRelease() {
+	released := &models.Deal{Id: 2, BuyerId: 1, SellerId: 2, Amount: 100, Status: escrow.DealReleased}
+	suite.deals.On("Transition", released.Id, escrow.DealFunded, escrow.DealReleased).Return(released, nil)
+
+	deal, err := suite.useCase.Release(released.Id)
+
+	suite.NoError(err)
+	suite.Equal(released, deal)
+}
+
+func (suite *dealUseCaseSuite) TestRefund() {
+	refunded := &models.Deal{Id: 3, BuyerId: 1, SellerId: 2, Amount: 100, Status: escrow.DealRefunded}
+	suite.deals.On("Transition", refunded.Id, escrow.DealFunded, escrow.DealRefunded).Return(refunded, nil)
+
+	deal, err := suite.useCase.Refund(refunded.Id)
+
+	suite.NoError(err)
+	suite.Equal(refunded, deal)
+}
+
+func (suite *dealUseCaseSuite) TestRefund_NotFunded() {
+	suite.deals.On("Transition", int64(4), escrow.DealFunded, escrow.DealRefunded).Return(nil, escrow.ErrBadTransition)
+
+	_, err := suite.useCase.Refund(4)
+
+	suite.Equal(escrow.ErrBadTransition, err)
+}
+
+func (suite *dealUseCaseSuite) TestReleaseDue() {
+	due := []*models.Deal{
+		{Id: 5, BuyerId: 1, SellerId: 2, Amount: 10, Status: escrow.DealFunded},
+		{Id: 6, BuyerId: 1, SellerId: 3, Amount: 20, Status: escrow.DealFunded},
+	}
+	suite.deals.On("GetDueDeals", mock.Anything).Return(due, nil)
+	suite.deals.On("Transition", int64(5), escrow.DealFunded, escrow.DealReleased).Return(due[0], nil)
+	suite.deals.On("Transition", int64(6), escrow.DealFunded, escrow.DealReleased).Return(nil, escrow.ErrBadTransition)
+
+	err := suite.useCase.ReleaseDue()
+
+	suite.NoError(err)
+	suite.deals.AssertNumberOfCalls(suite.T(), "Transition", 2)
+}
+
+func (suite *dealUseCaseSuite) TestReleaseDue_Error() {
+	dbErr := errors.New("db error")
+	suite.deals.On("GetDueDeals", mock.Anything).Return(nil, dbErr)
+
+	err := suite.useCase.ReleaseDue()
+
+	suite.Equal(dbErr, err)
+}
+
+func TestDealUseCase(t *testing.T) {
+	suite.Run(t, new(dealUseCaseSuite))
+}
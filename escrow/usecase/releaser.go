@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"avito-intership/escrow"
+	"context"
+	"log"
+	"time"
+)
+
+const releaseCheckTime = time.Minute
+
+type Releaser struct {
+	useCase  escrow.DealUseCase
+	interval time.Duration
+}
+
+func NewReleaser(useCase escrow.DealUseCase) *Releaser {
+	return &Releaser{
+		useCase:  useCase,
+		interval: releaseCheckTime,
+	}
+}
+
+// Run периодически закрывает просроченные сделки до отмены ctx
+func (r *Releaser) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.useCase.ReleaseDue(); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package exchange
+
+import "avito-intership/models"
+
+// Валюты стандарта ISO 4217, курсы которых предоставляет источник
+var currencies = []*models.Currency{
+	{Code: "AED", Name: "UAE Dirham", MinorUnits: 2},
+	{Code: "AFN", Name: "Afghan Afghani", MinorUnits: 2},
+	{Code: "ALL", Name: "Albanian Lek", MinorUnits: 2},
+	{Code: "AMD", Name: "Armenian Dram", MinorUnits: 2},
+	{Code: "ANG", Name: "Netherlands Antillean Guilder", MinorUnits: 2},
+	{Code: "AOA", Name: "Angolan Kwanza", MinorUnits: 2},
+	{Code: "ARS", Name: "Argentine Peso", MinorUnits: 2},
+	{Code: "AUD", Name: "Australian Dollar", MinorUnits: 2},
+	{Code: "AWG", Name: "Aruban Florin", MinorUnits: 2},
+	{Code: "AZN", Name: "Azerbaijani Manat", MinorUnits: 2},
+	{Code: "BAM", Name: "Bosnia-Herzegovina Convertible Mark", MinorUnits: 2},
+	{Code: "BBD", Name: "Barbadian Dollar", MinorUnits: 2},
+	{Code: "BDT", Name: "Bangladeshi Taka", MinorUnits: 2},
+	{Code: "BGN", Name: "Bulgarian Lev", MinorUnits: 2},
+	{Code: "BHD", Name: "Bahraini Dinar", MinorUnits: 3},
+	{Code: "BIF", Name: "Burundian Franc", MinorUnits: 0},
+	{Code: "BMD", Name: "Bermudan Dollar", MinorUnits: 2},
+	{Code: "BND", Name: "Brunei Dollar", MinorUnits: 2},
+	{Code: "BOB", Name: "Bolivian Boliviano", MinorUnits: 2},
+	{Code: "BRL", Name: "Brazilian Real", MinorUnits: 2},
+	{Code: "BSD", Name: "Bahamian Dollar", MinorUnits: 2},
+	{Code: "BTN", Name: "Bhutanese Ngultrum", MinorUnits: 2},
+	{Code: "BWP", Name: "Botswanan Pula", MinorUnits: 2},
+	{Code: "BYN", Name: "Belarusian Ruble", MinorUnits: 2},
+	{Code: "BZD", Name: "Belize Dollar", MinorUnits: 2},
+	{Code: "CAD", Name: "Canadian Dollar", MinorUnits: 2},
+	{Code: "CDF", Name: "Congolese Franc", MinorUnits: 2},
+	{Code: "CHF", Name: "Swiss Franc", MinorUnits: 2},
+	{Code: "CLP", Name: "Chilean Peso", MinorUnits: 0},
+	{Code: "CNY", Name: "Chinese Yuan", MinorUnits: 2},
+	{Code: "COP", Name: "Colombian Peso", MinorUnits: 2},
+	{Code: "CRC", Name: "Costa Rican Colon", MinorUnits: 2},
+	{Code: "CUP", Name: "Cuban Peso", MinorUnits: 2},
+	{Code: "CVE", Name: "Cape Verdean Escudo", MinorUnits: 2},
+	{Code: "CZK", Name: "Czech Koruna", MinorUnits: 2},
+	{Code: "DJF", Name: "Djiboutian Franc", MinorUnits: 0},
+	{Code: "DKK", Name: "Danish Krone", MinorUnits: 2},
+	{Code: "DOP", Name: "Dominican Peso", MinorUnits: 2},
+	{Code: "DZD", Name: "Algerian Dinar", MinorUnits: 2},
+	{Code: "EGP", Name: "Egyptian Pound", MinorUnits: 2},
+	{Code: "ERN", Name: "Eritrean Nakfa", MinorUnits: 2},
+	{Code: "ETB", Name: "Ethiopian Birr", MinorUnits: 2},
+	{Code: "EUR", Name: "Euro", MinorUnits: 2},
+	{Code: "FJD", Name: "Fijian Dollar", MinorUnits: 2},
+	{Code: "FKP", Name: "Falkland Islands Pound", MinorUnits: 2},
+	{Code: "GBP", Name: "British Pound Sterling", MinorUnits: 2},
+	{Code: "GEL", Name: "Georgian Lari", MinorUnits: 2},
+	{Code: "GHS", Name: "Ghanaian Cedi", MinorUnits: 2},
+	{Code: "GIP", Name: "Gibraltar Pound", MinorUnits: 2},
+	{Code: "GMD", Name: "Gambian Dalasi", MinorUnits: 2},
+	{Code: "GNF", Name: "Guinean Franc", MinorUnits: 0},
+	{Code: "GTQ", Name: "Guatemalan Quetzal", MinorUnits: 2},
+	{Code: "GYD", Name: "Guyanaese Dollar", MinorUnits: 2},
+	{Code: "HKD", Name: "Hong Kong Dollar", MinorUnits: 2},
+	{Code: "HNL", Name: "Honduran Lempira", MinorUnits: 2},
+	{Code: "HTG", Name: "Haitian Gourde", MinorUnits: 2},
+	{Code: "HUF", Name: "Hungarian Forint", MinorUnits: 2},
+	{Code: "IDR", Name: "Indonesian Rupiah", MinorUnits: 2},
+	{Code: "ILS", Name: "Israeli New Shekel", MinorUnits: 2},
+	{Code: "INR", Name: "Indian Rupee", MinorUnits: 2},
+	{Code: "IQD", Name: "Iraqi Dinar", MinorUnits: 3},
+	{Code: "IRR", Name: "Iranian Rial", MinorUnits: 2},
+	{Code: "ISK", Name: "Icelandic Krona", MinorUnits: 0},
+	{Code: "JMD", Name: "Jamaican Dollar", MinorUnits: 2},
+	{Code: "JOD", Name: "Jordanian Dinar", MinorUnits: 3},
+	{Code: "JPY", Name: "Japanese Yen", MinorUnits: 0},
+	{Code: "KES", Name: "Kenyan Shilling", MinorUnits: 2},
+	{Code: "KGS", Name: "Kyrgystani Som", MinorUnits: 2},
+	{Code: "KHR", Name: "Cambodian Riel", MinorUnits: 2},
+	{Code: "KMF", Name: "Comorian Franc", MinorUnits: 0},
+	{Code: "KPW", Name: "North Korean Won", MinorUnits: 2},
+	{Code: "KRW", Name: "South Korean Won", MinorUnits: 0},
+	{Code: "KWD", Name: "Kuwaiti Dinar", MinorUnits: 3},
+	{Code: "KYD", Name: "Cayman Islands Dollar", MinorUnits: 2},
+	{Code: "KZT", Name: "Kazakhstani Tenge", MinorUnits: 2},
+	{Code: "LAK", Name: "Laotian Kip", MinorUnits: 2},
+	{Code: "LBP", Name: "Lebanese Pound", MinorUnits: 2},
+	{Code: "LKR", Name: "Sri Lankan Rupee", MinorUnits: 2},
+	{Code: "LRD", Name: "Liberian Dollar", MinorUnits: 2},
+	{Code: "LSL", Name: "Lesotho Loti", MinorUnits: 2},
+	{Code: "LYD", Name: "Libyan Dinar", MinorUnits: 3},
+	{Code: "MAD", Name: "Moroccan Dirham", MinorUnits: 2},
+	{Code: "MDL", Name: "Moldovan Leu", MinorUnits: 2},
+	{Code: "MGA", Name: "Malagasy Ariary", MinorUnits: 2},
+	{Code: "MKD", Name: "Macedonian Denar", MinorUnits: 2},
+	{Code: "MMK", Name: "Myanma Kyat", MinorUnits: 2},
+	{Code: "MNT", Name: "Mongolian Tugrik", MinorUnits: 2},
+	{Code: "MOP", Name: "Macanese Pataca", MinorUnits: 2},
+	{Code: "MRU", Name: "Mauritanian Ouguiya", MinorUnits: 2},
+	{Code: "MUR", Name: "Mauritian Rupee", MinorUnits: 2},
+	{Code: "MVR", Name: "Maldivian Rufiyaa", MinorUnits: 2},
+	{Code: "MWK", Name: "Malawian Kwacha", MinorUnits: 2},
+	{Code: "MXN", Name: "Mexican Peso", MinorUnits: 2},
+	{Code: "MYR", Name: "Malaysian Ringgit", MinorUnits: 2},
+	{Code: "MZN", Name: "Mozambican Metical", MinorUnits: 2},
+	{Code: "NAD", Name: "Namibian Dollar", MinorUnits: 2},
+	{Code: "NGN", Name: "Nigerian Naira", MinorUnits: 2},
+	{Code: "NIO", Name: "Nicaraguan Cordoba", MinorUnits: 2},
+	{Code: "NOK", Name: "Norwegian Krone", MinorUnits: 2},
+	{Code: "NPR", Name: "Nepalese Rupee", MinorUnits: 2},
+	{Code: "NZD", Name: "New Zealand Dollar", MinorUnits: 2},
+	{Code: "OMR", Name: "Omani Rial", MinorUnits: 3},
+	{Code: "PAB", Name: "Panamanian Balboa", MinorUnits: 2},
+	{Code: "PEN", Name: "Peruvian Nuevo Sol", MinorUnits: 2},
+	{Code: "PGK", Name: "Papua New Guinean Kina", MinorUnits: 2},
+	{Code: "PHP", Name: "Philippine Peso", MinorUnits: 2},
+	{Code: "PKR", Name: "Pakistani Rupee", MinorUnits: 2},
+	{Code: "PLN", Name: "Polish Zloty", MinorUnits: 2},
+	{Code: "PYG", Name: "Paraguayan Guarani", MinorUnits: 0},
+	{Code: "QAR", Name: "Qatari Rial", MinorUnits: 2},
+	{Code: "RON", Name: "Romanian Leu", MinorUnits: 2},
+	{Code: "RSD", Name: "Serbian Dinar", MinorUnits: 2},
+	{Code: "RUB", Name: "Russian Ruble", MinorUnits: 2},
+	{Code: "RWF", Name: "Rwandan Franc", MinorUnits: 0},
+	{Code: "SAR", Name: "Saudi Riyal", MinorUnits: 2},
+	{Code: "SBD", Name: "Solomon Islands Dollar", MinorUnits: 2},
+	{Code: "SCR", Name: "Seychellois Rupee", MinorUnits: 2},
+	{Code: "SDG", Name: "Sudanese Pound", MinorUnits: 2},
+	{Code: "SEK", Name: "Swedish Krona", MinorUnits: 2},
+	{Code: "SGD", Name: "Singapore Dollar", MinorUnits: 2},
+	{Code: "SHP", Name: "Saint Helena Pound", MinorUnits: 2},
+	{Code: "SLL", Name: "Sierra Leonean Leone", MinorUnits: 2},
+	{Code: "SOS", Name: "Somali Shilling", MinorUnits: 2},
+	{Code: "SRD", Name: "Surinamese Dollar", MinorUnits: 2},
+	{Code: "SYP", Name: "Syrian Pound", MinorUnits: 2},
+	{Code: "SZL", Name: "Swazi Lilangeni", MinorUnits: 2},
+	{Code: "THB", Name: "Thai Baht", MinorUnits: 2},
+	{Code: "TJS", Name: "Tajikistani Somoni", MinorUnits: 2},
+	{Code: "TMT", Name: "Turkmenistani Manat", MinorUnits: 2},
+	{Code: "TND", Name: "Tunisian Dinar", MinorUnits: 3},
+	{Code: "TOP", Name: "Tongan Pa'anga", MinorUnits: 2},
+	{Code: "TRY", Name: "Turkish Lira", MinorUnits: 2},
+	{Code: "TTD", Name: "Trinidad and Tobago Dollar", MinorUnits: 2},
+	{Code: "TWD", Name: "New Taiwan Dollar", MinorUnits: 2},
+	{Code: "TZS", Name: "Tanzanian Shilling", MinorUnits: 2},
+	{Code: "UAH", Name: "Ukrainian Hryvnia", MinorUnits: 2},
+	{Code: "UGX", Name: "Ugandan Shilling", MinorUnits: 0},
+	{Code: "USD", Name: "United States Dollar", MinorUnits: 2},
+	{Code: "UYU", Name: "Uruguayan Peso", MinorUnits: 2},
+	{Code: "UZS", Name: "Uzbekistan Som", MinorUnits: 2},
+	{Code: "VES", Name: "Venezuelan Bolivar", MinorUnits: 2},
+	{Code: "VND", Name: "Vietnamese Dong", MinorUnits: 0},
+	{Code: "VUV", Name: "Vanuatu Vatu", MinorUnits: 0},
+	{Code: "WST", Name: "Samoan Tala", MinorUnits: 2},
+	{Code: "XAF", Name: "CFA Franc BEAC", MinorUnits: 0},
+	{Code: "XCD", Name: "East Caribbean Dollar", MinorUnits: 2},
+	{Code: "XOF", Name: "CFA Franc BCEAO", MinorUnits: 0},
+	{Code: "XPF", Name: "CFP Franc", MinorUnits: 0},
+	{Code: "YER", Name: "Yemeni Rial", MinorUnits: 2},
+	{Code: "ZAR", Name: "South African Rand", MinorUnits: 2},
+	{Code: "ZMW", Name: "Zambian Kwacha", MinorUnits: 2},
+}
+
+var currenciesByCode = make(map[string]*models.Currency, len(currencies))
+
+func init() {
+	for _, currency := range currencies {
+		currenciesByCode[currency.Code] = currency
+	}
+}
+
+func Currencies() []*models.Currency {
+	return currencies
+}
+
+func IsSupported(currency string) bool {
+	_, ok := currenciesByCode[currency]
+	return ok
+}
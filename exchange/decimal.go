@@ -0,0 +1,66 @@
+package exchange
+
+import (
+	"avito-intership/models"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Точность неизвестных валют
+const defaultMinorUnits = 2
+
+func MinorUnits(currency string) int {
+	if c, ok := currenciesByCode[currency]; ok {
+		return c.MinorUnits
+	}
+
+	return defaultMinorUnits
+}
+
+// Decimal переводит float32 в точную десятичную дробь по его кратчайшей десятичной записи
+func Decimal(value float32) *big.Rat {
+	rat, _ := new(big.Rat).SetString(strconv.FormatFloat(float64(value), 'f', -1, 32))
+	return rat
+}
+
+// RateValue возвращает точное значение курса, а если его нет - десятичную запись Value
+func RateValue(rate *models.Rate) *big.Rat {
+	if rate.Exact != nil {
+		return new(big.Rat).Set(rate.Exact)
+	}
+
+	return Decimal(rate.Value)
+}
+
+// SetRateValue записывает в курс точное значение и его округление
+func SetRateValue(rate *models.Rate, value *big.Rat) {
+	rate.Exact = value
+	rate.Value, _ = value.Float32()
+}
+
+func ParseDecimal(value string) (*big.Rat, error) {
+	rat, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return nil, fmt.Errorf("bad decimal value %q", value)
+	}
+
+	return rat, nil
+}
+
+// Round округляет значение до точности валюты, половины округляются от нуля
+func Round(value *big.Rat, currency string) *big.Rat {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(MinorUnits(currency))), nil)
+
+	scaled := new(big.Rat).Mul(value, new(big.Rat).SetInt(scale))
+	num := new(big.Int).Abs(scaled.Num())
+	quo, rem := new(big.Int).QuoRem(num, scaled.Denom(), new(big.Int))
+	if new(big.Int).Mul(rem, big.NewInt(2)).Cmp(scaled.Denom()) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	if scaled.Sign() < 0 {
+		quo.Neg(quo)
+	}
+
+	return new(big.Rat).SetFrac(quo, scale)
+}
@@ -0,0 +1,58 @@
+package exchange
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	cases := []struct {
+		value    string
+		currency string
+		expected string
+	}{
+		{value: "1.234", currency: "USD", expected: "1.23"},
+		{value: "1.235", currency: "USD", expected: "1.24"},
+		{value: "-1.235", currency: "USD", expected: "-1.24"},
+		{value: "1.5", currency: "JPY", expected: "2"},
+		{value: "1.49", currency: "JPY", expected: "1"},
+		{value: "-2.5", currency: "JPY", expected: "-3"},
+		{value: "0.1235", currency: "BHD", expected: "0.124"},
+		{value: "0.1234", currency: "BHD", expected: "0.123"},
+		{value: "1/3", currency: "RUB", expected: "0.33"},
+		{value: "0", currency: "RUB", expected: "0"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.value+" "+c.currency, func(t *testing.T) {
+			value, ok := new(big.Rat).SetString(c.value)
+			assert.True(t, ok)
+			expected, _ := new(big.Rat).SetString(c.expected)
+
+			assert.Equal(t, expected.String(), Round(value, c.currency).String())
+		})
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	cases := []struct {
+		value    float32
+		expected string
+	}{
+		{value: 0.1, expected: "1/10"},
+		{value: 73.5, expected: "147/2"},
+		{value: -10.005, expected: "-2001/200"},
+		{value: 0, expected: "0/1"},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.expected, Decimal(c.value).String())
+	}
+}
+
+func TestMinorUnits(t *testing.T) {
+	assert.Equal(t, 0, MinorUnits("JPY"))
+	assert.Equal(t, 3, MinorUnits("BHD"))
+	assert.Equal(t, 2, MinorUnits("USD"))
+}
@@ -0,0 +1,186 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"avito-intership/problem"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type AdminHandler struct {
+	Handler
+	overrides exchange.OverrideUseCase
+}
+
+func NewAdminHandler(exchanger exchange.Exchanger, overrides exchange.OverrideUseCase) *AdminHandler {
+	return &AdminHandler{
+		Handler:   Handler{exchanger: exchanger},
+		overrides: overrides,
+	}
+}
+
+func (h AdminHandler) writeJSON(value interface{}, w http.ResponseWriter) {
+	w.Header().Add("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		problem.Write(w, err)
+	}
+}
+
+// parseOverride читает курс и период действия; valid_from по умолчанию - текущий момент,
+// отсутствие valid_to означает бессрочное действие
+func (h AdminHandler) parseOverride(r *http.Request, w http.ResponseWriter) (*models.RateOverride, bool) {
+	rate, err := strconv.ParseFloat(r.FormValue("rate"), 32)
+	if err != nil || rate <= 0 {
+		problem.Write(w, apperror.BadArgument("rate"))
+		return nil, false
+	}
+
+	override := &models.RateOverride{
+		Rate:      float32(rate),
+		ValidFrom: time.Now(),
+	}
+
+	if validFrom := r.FormValue("valid_from"); validFrom != "" {
+		override.ValidFrom, err = time.Parse(time.RFC3339, validFrom)
+		if err != nil {
+			problem.Write(w, apperror.BadArgument("valid_from"))
+			return nil, false
+		}
+	}
+
+	if validTo := r.FormValue("valid_to"); validTo != "" {
+		to, err := time.Parse(time.RFC3339, validTo)
+		if err != nil {
+			problem.Write(w, apperror.BadArgument("valid_to"))
+			return nil, false
+		}
+		override.ValidTo = &to
+	}
+
+	return override, true
+}
+
+func (h AdminHandler) parseId(r *http.Request, w http.ResponseWriter) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil || id <= 0 {
+		problem.Write(w, apperror.BadArgument("id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (h AdminHandler) GetOverridesEndpoint(w http.ResponseWriter, r *http.Request) {
+	overrides, err := h.overrides.GetOverrides(r.FormValue("currency"))
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(overrides, w)
+}
+
+func (h AdminHandler) CreateOverrideEndpoint(w http.ResponseWriter, r *http.Request) {
+	override, ok := h.parseOverride(r, w)
+	if !ok {
+		return
+	}
+	override.Currency = r.FormValue("currency")
+
+	created, err := h.overrides.CreateOverride(override, r.FormValue("operator"), r.FormValue("reason"))
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(created, w)
+}
+
+func (h AdminHandler) UpdateOverrideEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	override, ok := h.parseOverride(r, w)
+	if !ok {
+		return
+	}
+	override.Id = id
+
+	updated, err := h.overrides.UpdateOverride(override, r.FormValue("operator"), r.FormValue("reason"))
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(updated, w)
+}
+
+func (h AdminHandler) RevokeOverrideEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	err := h.overrides.RevokeOverride(id, r.FormValue("operator"), r.FormValue("reason"))
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeStatus(true, nil, &w)
+}
+
+func (h AdminHandler) GetOverrideLogEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	changes, err := h.overrides.GetOverrideLog(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(changes, w)
+}
+
+func (h AdminHandler) GetSpreadsEndpoint(w http.ResponseWriter, r *http.Request) {
+	spreads, err := h.exchanger.Spreads()
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(spreads, w)
+}
+
+func (h AdminHandler) SetSpreadEndpoint(w http.ResponseWriter, r *http.Request) {
+	percent, err := strconv.ParseFloat(r.FormValue("percent"), 32)
+	if err != nil {
+		problem.Write(w, apperror.BadArgument("percent"))
+		return
+	}
+
+	spread := &models.Spread{
+		Currency:  mux.Vars(r)["currency"],
+		Direction: r.FormValue("direction"),
+		Percent:   float32(percent),
+	}
+
+	err = h.exchanger.SetSpread(spread)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeStatus(true, nil, &w)
+}
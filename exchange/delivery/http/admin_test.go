@@ -0,0 +1,165 @@
+package http
+
+import (
+	"avito-intership/exchange"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type adminHandlerSuite struct {
+	suite.Suite
+
+	exchanger     *mocks.Exchanger
+	overrides     *mocks.OverrideUseCase
+	testingServer *httptest.Server
+}
+
+func (suite *adminHandlerSuite) SetupSuite() {
+	exchanger := new(mocks.Exchanger)
+	overrides := new(mocks.OverrideUseCase)
+
+	router := mux.NewRouter()
+	RegisterAdminEndpoints(router.PathPrefix("/api/v1/admin").Subrouter(), exchanger, overrides)
+
+	suite.testingServer = httptest.NewServer(router)
+	suite.exchanger = exchanger
+	suite.overrides = overrides
+}
+
+func (suite *adminHandlerSuite) TearDownSuite() {
+	defer suite.testingServer.Close()
+}
+
+func (suite *adminHandlerSuite) TestCreateOverride() {
+	validTo := time.Date(2021, 11, 19, 18, 0, 0, 0, time.UTC)
+	created := &models.RateOverride{Id: 1, Currency: "USD", Rate: 90, ValidTo: &validTo}
+
+	suite.overrides.On("CreateOverride", mock.MatchedBy(func(o *models.RateOverride) bool {
+		return o.Currency == "USD" && o.Rate == 90 && o.ValidTo != nil && o.ValidTo.Equal(validTo)
+	}), "finance", "upstream outage").Return(created, nil)
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/rates/overrides", suite.testingServer.URL),
+		url.Values{
+			"currency": {"USD"},
+			"rate":     {"90.00"},
+			"valid_to": {validTo.Format(time.RFC3339)},
+			"operator": {"finance"},
+			"reason":   {"upstream outage"},
+		})
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.RateOverride
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(created.Id, responseBody.Id)
+}
+
+func (suite *adminHandlerSuite) TestCreateOverride_BadRate() {
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/rates/overrides", suite.testingServer.URL),
+		url.Values{"currency": {"USD"}, "rate": {"-1"}, "operator": {"finance"}, "reason": {"test"}})
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *adminHandlerSuite) TestRevokeOverride_NotFound() {
+	var id int64 = 42
+	suite.overrides.On("RevokeOverride", id, "finance", "mistake").Return(exchange.ErrOverrideNotFound)
+
+	request, _ := http.NewRequest(http.MethodDelete,
+		fmt.Sprintf("%s/api/v1/admin/rates/overrides/%d?operator=finance&reason=mistake", suite.testingServer.URL, id), nil)
+	response, err := http.DefaultClient.Do(request)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusNotFound, response.StatusCode)
+}
+
+func (suite *adminHandlerSuite) TestGetOverrideLog() {
+	var id int64 = 1
+	changes := []*models.RateOverrideChange{
+		{OverrideId: id, Action: exchange.OverrideCreateAction, Operator: "finance", Reason: "outage", Rate: 90},
+	}
+	suite.overrides.On("GetOverrideLog", id).Return(changes, nil)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/admin/rates/overrides/%d/log", suite.testingServer.URL, id))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody []*models.RateOverrideChange
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(changes[0].Operator, responseBody[0].Operator)
+	suite.Equal(changes[0].Reason, responseBody[0].Reason)
+}
+
+func (suite *adminHandlerSuite) TestGetSpreads() {
+	spreads := []*models.Spread{{Currency: "USD", Direction: exchange.BuyDirection, Percent: 1.5}}
+	suite.exchanger.On("Spreads").Return(spreads, nil)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/admin/rates/spreads", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody []*models.Spread
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(spreads, responseBody)
+}
+
+func (suite *adminHandlerSuite) TestSetSpread() {
+	spread := &models.Spread{Currency: "EUR", Direction: exchange.SellDirection, Percent: 2}
+	suite.exchanger.On("SetSpread", spread).Return(nil)
+
+	data := url.Values{}
+	data.Set("direction", exchange.SellDirection)
+	data.Set("percent", "2")
+
+	request, _ := http.NewRequest(http.MethodPut,
+		fmt.Sprintf("%s/api/v1/admin/rates/spreads/EUR?%s", suite.testingServer.URL, data.Encode()), nil)
+	response, err := http.DefaultClient.Do(request)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.exchanger.AssertCalled(suite.T(), "SetSpread", spread)
+}
+
+func (suite *adminHandlerSuite) TestSetSpread_Invalid() {
+	spread := &models.Spread{Currency: "GBP", Direction: "hold", Percent: 2}
+	suite.exchanger.On("SetSpread", spread).Return(exchange.ErrBadSpread)
+
+	data := url.Values{}
+	data.Set("direction", "hold")
+	data.Set("percent", "2")
+
+	request, _ := http.NewRequest(http.MethodPut,
+		fmt.Sprintf("%s/api/v1/admin/rates/spreads/GBP?%s", suite.testingServer.URL, data.Encode()), nil)
+	response, err := http.DefaultClient.Do(request)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func TestAdminHandler(t *testing.T) {
+	suite.Run(t, new(adminHandlerSuite))
+}
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"avito-intership/problem"
+	"encoding/json"
+	"net/http"
+)
+
+type Handler struct {
+	exchanger exchange.Exchanger
+}
+
+func NewHandler(exchanger exchange.Exchanger) *Handler {
+	return &Handler{
+		exchanger: exchanger,
+	}
+}
+
+type Rates struct {
+	Base  string         `json:"base"`
+	Rates []*models.Rate `json:"rates"`
+}
+
+type StatusMessage struct {
+	Success bool    `json:"success"`
+	Message *string `json:"message"`
+}
+
+func (h Handler) writeStatus(success bool, message *string, w *http.ResponseWriter) {
+	status := StatusMessage{
+		Success: success,
+		Message: message,
+	}
+
+	(*w).Header().Add("Content-Type", "application/json")
+	_ = json.NewEncoder(*w).Encode(status)
+}
+
+func (h Handler) GetCurrenciesEndpoint(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(h.exchanger.Currencies())
+	if err != nil {
+		problem.Write(w, err)
+	}
+}
+
+func (h Handler) GetRatesEndpoint(w http.ResponseWriter, r *http.Request) {
+	base := r.FormValue("base")
+	if base == "" {
+		base = exchange.RUB
+	}
+
+	if !exchange.IsSupported(base) {
+		problem.Write(w, apperror.BadArgument("base"))
+		return
+	}
+
+	rates, err := h.exchanger.Rates(base)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	err = json.NewEncoder(w).Encode(Rates{base, rates})
+	if err != nil {
+		problem.Write(w, err)
+	}
+}
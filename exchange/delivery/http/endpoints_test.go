@@ -0,0 +1,88 @@
+package http
+
+import (
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type exchangeHandlerSuite struct {
+	suite.Suite
+
+	exchanger     *mocks.Exchanger
+	testingServer *httptest.Server
+}
+
+func (suite *exchangeHandlerSuite) SetupSuite() {
+	exchanger := new(mocks.Exchanger)
+
+	router := mux.NewRouter()
+	RegisterEndpoints(router, exchanger)
+
+	suite.testingServer = httptest.NewServer(router)
+	suite.exchanger = exchanger
+}
+
+func (suite *exchangeHandlerSuite) TearDownSuite() {
+	defer suite.testingServer.Close()
+}
+
+func (suite *exchangeHandlerSuite) TestGetCurrencies() {
+	currencies := []*models.Currency{
+		{Code: "JPY", Name: "Japanese Yen", MinorUnits: 0},
+		{Code: "RUB", Name: "Russian Ruble", MinorUnits: 2},
+	}
+	suite.exchanger.On("Currencies").Return(currencies)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/currencies", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody []*models.Currency
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(currencies, responseBody)
+}
+
+func (suite *exchangeHandlerSuite) TestGetRates() {
+	base := "USD"
+	updatedAt := time.Date(2021, 11, 18, 2, 0, 0, 0, time.UTC)
+	rates := []*models.Rate{
+		{Base: base, Currency: "EUR", Value: 0.88, UpdatedAt: updatedAt, FetchedAt: updatedAt, Source: "test"},
+	}
+	suite.exchanger.On("Rates", base).Return(rates, nil)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/rates?base=%s", suite.testingServer.URL, base))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody Rates
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(base, responseBody.Base)
+	suite.Equal(rates, responseBody.Rates)
+}
+
+func (suite *exchangeHandlerSuite) TestGetRates_UnsupportedBase() {
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/rates?base=XYZ", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+	suite.exchanger.AssertNotCalled(suite.T(), "Rates", "XYZ")
+}
+
+func TestExchangeHandler(t *testing.T) {
+	suite.Run(t, new(exchangeHandlerSuite))
+}
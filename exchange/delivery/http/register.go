@@ -0,0 +1,36 @@
+package http
+
+import (
+	"avito-intership/exchange"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+func RegisterEndpoints(router *mux.Router, exchanger exchange.Exchanger) {
+	handler := NewHandler(exchanger)
+
+	router.HandleFunc("/api/v1/currencies", handler.GetCurrenciesEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/api/v1/rates", handler.GetRatesEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+}
+
+// RegisterAdminEndpoints регистрирует служебные методы, router - подмаршрутизатор /api/v1/admin
+func RegisterAdminEndpoints(router *mux.Router, exchanger exchange.Exchanger, overrides exchange.OverrideUseCase) {
+	handler := NewAdminHandler(exchanger, overrides)
+
+	router.HandleFunc("/rates/overrides", handler.GetOverridesEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/rates/overrides", handler.CreateOverrideEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/rates/overrides/{id:[0-9]+}", handler.UpdateOverrideEndpoint).
+		Methods(http.MethodOptions, http.MethodPut)
+	router.HandleFunc("/rates/overrides/{id:[0-9]+}", handler.RevokeOverrideEndpoint).
+		Methods(http.MethodOptions, http.MethodDelete)
+	router.HandleFunc("/rates/overrides/{id:[0-9]+}/log", handler.GetOverrideLogEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/rates/spreads", handler.GetSpreadsEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/rates/spreads/{currency:[A-Z]{3}}", handler.SetSpreadEndpoint).
+		Methods(http.MethodOptions, http.MethodPut)
+}
@@ -0,0 +1,16 @@
+package exchange
+
+import "avito-intership/apperror"
+
+var (
+	ErrCircuitOpen = apperror.NewRetryable(apperror.Unavailable,
+		"exchange rates source is unavailable, try again later")
+	ErrUnsupportedCurrency = apperror.WithParam(apperror.InvalidArgument, "currency", "currency is not supported")
+	ErrOverrideNotFound    = apperror.New(apperror.NotFound, "rate override not found")
+	ErrBadOverridePeriod   = apperror.New(apperror.InvalidArgument, "override must end after it starts")
+	ErrNoOperator          = apperror.New(apperror.InvalidArgument, "operator and reason are required")
+	ErrRateNotFound        = apperror.New(apperror.NotFound, "rate not found")
+	ErrSpreadNotFound      = apperror.New(apperror.NotFound, "spread not found")
+	ErrBadSpread           = apperror.New(apperror.InvalidArgument,
+		"spread must be a percent in [0, 100) for buy or sell direction")
+)
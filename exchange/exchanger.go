@@ -1,7 +1,21 @@
 package exchange
 
+import (
+	"avito-intership/models"
+	"time"
+)
+
 const RUB string = "RUB"
 
 type Exchanger interface {
-	ConvertRubles(amount float32, currency string) (float32, error)
+	ConvertRubles(amount float32, currency string) (*models.Conversion, error)
+	Convert(amount float32, from string, to string) (*models.Conversion, error)
+	// ConvertAt конвертирует по курсам, действовавшим в момент at
+	ConvertAt(amount float32, from string, to string, at time.Time) (*models.Conversion, error)
+	// Exchange конвертирует amount валюты currency в рубли по курсу с учетом спреда направления direction
+	Exchange(amount float32, currency string, direction string) (*models.Conversion, error)
+	Currencies() []*models.Currency
+	Rates(base string) ([]*models.Rate, error)
+	Spreads() ([]*models.Spread, error)
+	SetSpread(spread *models.Spread) error
 }
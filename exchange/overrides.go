@@ -0,0 +1,32 @@
+package exchange
+
+import (
+	"avito-intership/models"
+	"time"
+)
+
+const (
+	OverrideCreateAction string = "create"
+	OverrideUpdateAction string = "update"
+	OverrideRevokeAction string = "revoke"
+)
+
+const OverrideSource string = "override"
+
+type OverrideRepository interface {
+	CreateOverride(override *models.RateOverride, operator string, reason string) (*models.RateOverride, error)
+	UpdateOverride(override *models.RateOverride, operator string, reason string) (*models.RateOverride, error)
+	RevokeOverride(id int64, operator string, reason string) error
+	GetOverride(id int64) (*models.RateOverride, error)
+	GetOverrides(currency string) ([]*models.RateOverride, error)
+	GetActiveOverrides(at time.Time) ([]*models.RateOverride, error)
+	GetOverrideLog(id int64) ([]*models.RateOverrideChange, error)
+}
+
+type OverrideUseCase interface {
+	CreateOverride(override *models.RateOverride, operator string, reason string) (*models.RateOverride, error)
+	UpdateOverride(override *models.RateOverride, operator string, reason string) (*models.RateOverride, error)
+	RevokeOverride(id int64, operator string, reason string) error
+	GetOverrides(currency string) ([]*models.RateOverride, error)
+	GetOverrideLog(id int64) ([]*models.RateOverrideChange, error)
+}
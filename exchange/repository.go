@@ -1,5 +1,22 @@
 package exchange
 
+import (
+	"avito-intership/models"
+	"time"
+)
+
+// RateRepository реализуют и источник курсов, и кеширующий репозиторий, который используют сервисы
 type RateRepository interface {
-	GetRubleRate(currency string) (float32, error)
+	GetRubleRate(currency string) (*models.Rate, error)
+	// GetRubleRates возвращает все известные реализации курсы: источник запрашивает их заново,
+	// а кеширующий репозиторий отдает только закешированные, не обращаясь к источнику
+	GetRubleRates() ([]*models.Rate, error)
+	// GetRubleRateAt возвращает курс, действовавший в день date
+	GetRubleRateAt(currency string, date time.Time) (*models.Rate, error)
+}
+
+// RateHistoryRepository хранит полученные курсы по дням
+type RateHistoryRepository interface {
+	SaveRate(rate *models.Rate, date time.Time) error
+	GetRate(currency string, date time.Time) (*models.Rate, error)
 }
@@ -1,6 +1,8 @@
 package cache
 
 import (
+	"avito-intership/exchange"
+	"avito-intership/models"
 	"context"
 	"fmt"
 	"github.com/go-redis/redis/v8"
@@ -10,11 +12,25 @@ import (
 	"time"
 )
 
-const rateExpireTime = time.Hour
+// Курс хранится дольше, чем считается свежим, чтобы его можно было отдать при недоступности источника
+const rateKeepTime = 7 * 24 * time.Hour
+
+const (
+	ratePrefix    = "rate:"
+	currenciesKey = "rates"
+)
+
+const (
+	valueField     = "value"
+	exactField     = "exact"
+	updatedAtField = "updated_at"
+	fetchedAtField = "fetched_at"
+	sourceField    = "source"
+)
 
 type RedisCache struct {
 	client *redis.Client
-	ctx context.Context
+	ctx    context.Context
 }
 
 func NewRedisCache() *RedisCache {
@@ -30,24 +46,76 @@ func NewRedisCache() *RedisCache {
 
 	return &RedisCache{
 		client: client,
-		ctx: context.Background(),
+		ctx:    context.Background(),
 	}
 }
 
-func (c *RedisCache) GetRubleRate(currency string) (float32, error) {
-	val, err := c.client.Get(c.ctx, currency).Float32()
+func (c *RedisCache) GetRubleRate(currency string) (*models.Rate, error) {
+	fields, err := c.client.HGetAll(c.ctx, ratePrefix+currency).Result()
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, redis.Nil
 	}
 
-	return val, err
-}
+	value, err := strconv.ParseFloat(fields[valueField], 32)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAt, err := strconv.ParseInt(fields[updatedAtField], 10, 64)
+	if err != nil {
+		return nil, err
+	}
 
-func (c *RedisCache) SetRate(currency string, rate float32) error {
-	status := c.client.Set(c.ctx, currency, rate, rateExpireTime)
-	if status.Err() != nil {
-		return status.Err()
+	fetchedAt, err := strconv.ParseInt(fields[fetchedAtField], 10, 64)
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
-}
\ No newline at end of file
+	rate := &models.Rate{
+		Base:      exchange.RUB,
+		Currency:  currency,
+		Value:     float32(value),
+		UpdatedAt: time.Unix(updatedAt, 0),
+		FetchedAt: time.Unix(fetchedAt, 0),
+		Source:    fields[sourceField],
+	}
+	if exact, ok := fields[exactField]; ok {
+		rate.Exact, err = exchange.ParseDecimal(exact)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rate, nil
+}
+
+func (c *RedisCache) SetRate(rate *models.Rate) error {
+	key := ratePrefix + rate.Currency
+
+	_, err := c.client.TxPipelined(c.ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(c.ctx, key,
+			valueField, rate.Value,
+			updatedAtField, rate.UpdatedAt.Unix(),
+			fetchedAtField, rate.FetchedAt.Unix(),
+			sourceField, rate.Source)
+		// Точный курс хранится дробью, так как кросс-курс может не иметь конечной десятичной записи
+		if rate.Exact != nil {
+			pipe.HSet(c.ctx, key, exactField, rate.Exact.RatString())
+		} else {
+			pipe.HDel(c.ctx, key, exactField)
+		}
+		pipe.Expire(c.ctx, key, rateKeepTime)
+		pipe.SAdd(c.ctx, currenciesKey, rate.Currency)
+		return nil
+	})
+
+	return err
+}
+
+// Currencies возвращает валюты, курсы которых когда-либо запрашивались
+func (c *RedisCache) Currencies() ([]string, error) {
+	return c.client.SMembers(c.ctx, currenciesKey).Result()
+}
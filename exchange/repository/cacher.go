@@ -1,6 +1,9 @@
 package repository
 
+import "avito-intership/models"
+
 type Cacher interface {
-	GetRubleRate(currency string) (float32, error)
-	SetRate(currency string, rate float32) error
+	GetRubleRate(currency string) (*models.Rate, error)
+	SetRate(rate *models.Rate) error
+	Currencies() ([]string, error)
 }
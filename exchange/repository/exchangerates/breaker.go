@@ -0,0 +1,122 @@
+package exchangerates
+
+import (
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"sync"
+	"time"
+)
+
+const (
+	breakerFailureThreshold = 5
+	breakerCooldown         = 30 * time.Second
+)
+
+const (
+	breakerClosed = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// CircuitBreaker перестает обращаться к источнику после нескольких ошибок подряд
+// и пробует снова после паузы
+type CircuitBreaker struct {
+	netRepo exchange.RateRepository
+
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    int
+	failures int
+	openedAt time.Time
+}
+
+func NewCircuitBreaker(netRepo exchange.RateRepository) *CircuitBreaker {
+	return &CircuitBreaker{
+		netRepo:   netRepo,
+		threshold: breakerFailureThreshold,
+		cooldown:  breakerCooldown,
+		now:       time.Now,
+		state:     breakerClosed,
+	}
+}
+
+func (b *CircuitBreaker) GetRubleRate(currency string) (*models.Rate, error) {
+	if !b.allow() {
+		return nil, exchange.ErrCircuitOpen
+	}
+
+	rate, err := b.netRepo.GetRubleRate(currency)
+	b.report(err)
+	if err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+func (b *CircuitBreaker) GetRubleRates() ([]*models.Rate, error) {
+	if !b.allow() {
+		return nil, exchange.ErrCircuitOpen
+	}
+
+	rates, err := b.netRepo.GetRubleRates()
+	b.report(err)
+	if err != nil {
+		return nil, err
+	}
+
+	return rates, nil
+}
+
+func (b *CircuitBreaker) GetRubleRateAt(currency string, date time.Time) (*models.Rate, error) {
+	if !b.allow() {
+		return nil, exchange.ErrCircuitOpen
+	}
+
+	rate, err := b.netRepo.GetRubleRateAt(currency, date)
+	b.report(err)
+	if err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+func (b *CircuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		// Пропускаем один пробный запрос, остальные отклоняем до его завершения
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+func (b *CircuitBreaker) report(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil {
+		b.state = breakerClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.state = breakerOpen
+		b.openedAt = b.now()
+	}
+}
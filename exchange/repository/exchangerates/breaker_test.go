@@ -0,0 +1,84 @@
+package exchangerates
+
+import (
+	"avito-intership/exchange"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"errors"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type circuitBreakerSuite struct {
+	suite.Suite
+	netRepo *mocks.RateRepository
+	breaker *CircuitBreaker
+	now     time.Time
+}
+
+func (suite *circuitBreakerSuite) SetupTest() {
+	netRepo := new(mocks.RateRepository)
+	breaker := NewCircuitBreaker(netRepo)
+
+	suite.now = time.Now()
+	breaker.now = func() time.Time { return suite.now }
+
+	suite.netRepo = netRepo
+	suite.breaker = breaker
+}
+
+func (suite *circuitBreakerSuite) trip(currency string) {
+	for i := 0; i < breakerFailureThreshold; i++ {
+		_, err := suite.breaker.GetRubleRate(currency)
+		suite.Error(err)
+		suite.NotEqual(exchange.ErrCircuitOpen, err, "upstream error expected before circuit opens")
+	}
+}
+
+func (suite *circuitBreakerSuite) TestOpensAfterFailures() {
+	currency := "USD"
+	suite.netRepo.On("GetRubleRate", currency).Return(nil, errors.New("upstream error"))
+
+	suite.trip(currency)
+
+	_, err := suite.breaker.GetRubleRate(currency)
+
+	suite.Equal(exchange.ErrCircuitOpen, err)
+	suite.netRepo.AssertNumberOfCalls(suite.T(), "GetRubleRate", breakerFailureThreshold)
+}
+
+func (suite *circuitBreakerSuite) TestProbeAfterCooldown() {
+	currency := "USD"
+	rate := &models.Rate{Currency: currency, Value: 75}
+	suite.netRepo.On("GetRubleRate", currency).Return(nil, errors.New("upstream error")).Times(breakerFailureThreshold)
+	suite.netRepo.On("GetRubleRate", currency).Return(rate, nil)
+
+	suite.trip(currency)
+	suite.now = suite.now.Add(breakerCooldown)
+
+	result, err := suite.breaker.GetRubleRate(currency)
+	suite.NoError(err, "probe should reach upstream after cooldown")
+	suite.Equal(rate, result)
+
+	_, err = suite.breaker.GetRubleRate(currency)
+	suite.NoError(err, "circuit should be closed after successful probe")
+}
+
+func (suite *circuitBreakerSuite) TestFailedProbeReopens() {
+	currency := "USD"
+	suite.netRepo.On("GetRubleRate", currency).Return(nil, errors.New("upstream error"))
+
+	suite.trip(currency)
+	suite.now = suite.now.Add(breakerCooldown)
+
+	_, err := suite.breaker.GetRubleRate(currency)
+	suite.NotEqual(exchange.ErrCircuitOpen, err, "probe should reach upstream")
+
+	_, err = suite.breaker.GetRubleRate(currency)
+	suite.Equal(exchange.ErrCircuitOpen, err)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	suite.Run(t, new(circuitBreakerSuite))
+}
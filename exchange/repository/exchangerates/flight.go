@@ -0,0 +1,47 @@
+package exchangerates
+
+import (
+	"avito-intership/models"
+	"sync"
+)
+
+type flightCall struct {
+	wg   sync.WaitGroup
+	rate *models.Rate
+	err  error
+}
+
+// flightGroup объединяет одновременные запросы курса одной валюты в один запрос к источнику
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+func newFlightGroup() *flightGroup {
+	return &flightGroup{
+		calls: make(map[string]*flightCall),
+	}
+}
+
+func (g *flightGroup) do(currency string, fetch func() (*models.Rate, error)) (*models.Rate, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[currency]; ok {
+		g.mu.Unlock()
+		call.wg.Wait()
+		return call.rate, call.err
+	}
+
+	call := &flightCall{}
+	call.wg.Add(1)
+	g.calls[currency] = call
+	g.mu.Unlock()
+
+	call.rate, call.err = fetch()
+	call.wg.Done()
+
+	g.mu.Lock()
+	delete(g.calls, currency)
+	g.mu.Unlock()
+
+	return call.rate, call.err
+}
@@ -0,0 +1,71 @@
+package exchangerates
+
+import (
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"log"
+	"time"
+)
+
+// RecordingRepository сохраняет каждый полученный от источника курс в историю
+// и отдает исторические курсы из нее, догружая недостающие дни у источника
+type RecordingRepository struct {
+	netRepo exchange.RateRepository
+	history exchange.RateHistoryRepository
+}
+
+func NewRecordingRepository(netRepo exchange.RateRepository, history exchange.RateHistoryRepository) *RecordingRepository {
+	return &RecordingRepository{
+		netRepo: netRepo,
+		history: history,
+	}
+}
+
+func (r *RecordingRepository) save(rate *models.Rate, date time.Time) {
+	if err := r.history.SaveRate(rate, date); err != nil {
+		log.Printf("saving %s rate to history: %v", rate.Currency, err)
+	}
+}
+
+func (r *RecordingRepository) GetRubleRate(currency string) (*models.Rate, error) {
+	rate, err := r.netRepo.GetRubleRate(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	r.save(rate, rate.UpdatedAt)
+
+	return rate, nil
+}
+
+func (r *RecordingRepository) GetRubleRates() ([]*models.Rate, error) {
+	rates, err := r.netRepo.GetRubleRates()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rate := range rates {
+		r.save(rate, rate.UpdatedAt)
+	}
+
+	return rates, nil
+}
+
+func (r *RecordingRepository) GetRubleRateAt(currency string, date time.Time) (*models.Rate, error) {
+	rate, err := r.history.GetRate(currency, date)
+	if err == nil {
+		return rate, nil
+	}
+	if err != exchange.ErrRateNotFound {
+		log.Println(err)
+	}
+
+	rate, err = r.netRepo.GetRubleRateAt(currency, date)
+	if err != nil {
+		return nil, err
+	}
+
+	r.save(rate, date)
+
+	return rate, nil
+}
@@ -0,0 +1,80 @@
+package exchangerates
+
+import (
+	"avito-intership/exchange"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"errors"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type recordingRepositorySuite struct {
+	suite.Suite
+	netRepo    *mocks.RateRepository
+	history    *mocks.RateHistoryRepository
+	repository *RecordingRepository
+}
+
+func (suite *recordingRepositorySuite) SetupTest() {
+	netRepo := new(mocks.RateRepository)
+	history := new(mocks.RateHistoryRepository)
+
+	suite.netRepo = netRepo
+	suite.history = history
+	suite.repository = NewRecordingRepository(netRepo, history)
+}
+
+func (suite *recordingRepositorySuite) TestGetRubleRate_Saved() {
+	rate := &models.Rate{Currency: "USD", Value: 75, UpdatedAt: time.Now()}
+	suite.netRepo.On("GetRubleRate", "USD").Return(rate, nil)
+	suite.history.On("SaveRate", rate, rate.UpdatedAt).Return(nil)
+
+	result, err := suite.repository.GetRubleRate("USD")
+
+	suite.NoError(err)
+	suite.Equal(rate, result)
+	suite.history.AssertCalled(suite.T(), "SaveRate", rate, rate.UpdatedAt)
+}
+
+func (suite *recordingRepositorySuite) TestGetRubleRate_SaveErrorIgnored() {
+	rate := &models.Rate{Currency: "USD", Value: 75, UpdatedAt: time.Now()}
+	suite.netRepo.On("GetRubleRate", "USD").Return(rate, nil)
+	suite.history.On("SaveRate", rate, rate.UpdatedAt).Return(errors.New("db is down"))
+
+	result, err := suite.repository.GetRubleRate("USD")
+
+	suite.NoError(err, "history failure should not break conversion")
+	suite.Equal(rate, result)
+}
+
+func (suite *recordingRepositorySuite) TestGetRubleRateAt_Stored() {
+	date := time.Date(2021, 11, 15, 0, 0, 0, 0, time.UTC)
+	stored := &models.Rate{Currency: "USD", Value: 72.5}
+	suite.history.On("GetRate", "USD", date).Return(stored, nil)
+
+	result, err := suite.repository.GetRubleRateAt("USD", date)
+
+	suite.NoError(err)
+	suite.Equal(stored, result)
+	suite.netRepo.AssertNotCalled(suite.T(), "GetRubleRateAt", "USD", date)
+}
+
+func (suite *recordingRepositorySuite) TestGetRubleRateAt_Backfill() {
+	date := time.Date(2021, 11, 15, 0, 0, 0, 0, time.UTC)
+	fetched := &models.Rate{Currency: "USD", Value: 72.5}
+	suite.history.On("GetRate", "USD", date).Return(nil, exchange.ErrRateNotFound)
+	suite.netRepo.On("GetRubleRateAt", "USD", date).Return(fetched, nil)
+	suite.history.On("SaveRate", fetched, date).Return(nil)
+
+	result, err := suite.repository.GetRubleRateAt("USD", date)
+
+	suite.NoError(err)
+	suite.Equal(fetched, result)
+	suite.history.AssertCalled(suite.T(), "SaveRate", fetched, date)
+}
+
+func TestRecordingRepository(t *testing.T) {
+	suite.Run(t, new(recordingRepositorySuite))
+}
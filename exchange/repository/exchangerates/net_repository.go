@@ -2,16 +2,23 @@ package exchangerates
 
 import (
 	"avito-intership/exchange"
+	"avito-intership/models"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 )
 
 const (
 	baseUrl        string = "http://api.exchangeratesapi.io/v1/"
 	latestEndpoint string = "latest"
-	apiKeyEnd      string = "EXCHANGE_KEY"
+	// Исторические курсы запрашиваются по адресу с датой вместо latest
+	historicalLayout string = "2006-01-02"
+	apiKeyEnd        string = "EXCHANGE_KEY"
+	source           string = "exchangeratesapi.io"
 )
 
 type netRepository struct {
@@ -32,37 +39,133 @@ type apiError struct {
 }
 
 type apiResponse struct {
-	Success   bool               `json:"success"`
-	Timestamp uint64             `json:"timestamp"`
-	Base      string             `json:"base"`
-	Date      string             `json:"date"`
-	Rates     map[string]float32 `json:"rates"`
-	Error     apiError           `json:"error"`
+	Success   bool                   `json:"success"`
+	Timestamp uint64                 `json:"timestamp"`
+	Base      string                 `json:"base"`
+	Date      string                 `json:"date"`
+	Rates     map[string]json.Number `json:"rates"`
+	Error     apiError               `json:"error"`
 }
 
-func (r *netRepository) GetRubleRate(currency string) (float32, error) {
-	queryString := fmt.Sprintf("?access_key=%s&symbols=%s,%s", r.apiKey, exchange.RUB, currency)
-	response, err := http.Get(baseUrl + latestEndpoint + queryString)
+func (r *netRepository) GetRubleRate(currency string) (*models.Rate, error) {
+	response, err := r.request(latestEndpoint, exchange.RUB, currency)
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+
+	return response.rubleRate(currency)
+}
+
+func (r *netRepository) GetRubleRateAt(currency string, date time.Time) (*models.Rate, error) {
+	response, err := r.request(date.UTC().Format(historicalLayout), exchange.RUB, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.rubleRate(currency)
+}
+
+// GetRubleRates запрашивает у источника последние курсы всех валют
+func (r *netRepository) GetRubleRates() ([]*models.Rate, error) {
+	response, err := r.request(latestEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make([]*models.Rate, 0, len(response.Rates))
+	for currency := range response.Rates {
+		if currency == exchange.RUB || !exchange.IsSupported(currency) {
+			continue
+		}
+
+		rate, err := response.rubleRate(currency)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
+
+// request запрашивает курсы указанных валют, без указания валют - курсы всех валют;
+// endpoint - latest для актуальных курсов или дата для исторических
+func (r *netRepository) request(endpoint string, symbols ...string) (*apiResponse, error) {
+	queryString := fmt.Sprintf("?access_key=%s", r.apiKey)
+	if len(symbols) > 0 {
+		queryString += "&symbols=" + strings.Join(symbols, ",")
+	}
+
+	response, err := http.Get(baseUrl + endpoint + queryString)
+	if err != nil {
+		return nil, err
 	}
 	defer response.Body.Close()
 
 	var responseBody apiResponse
 	err = json.NewDecoder(response.Body).Decode(&responseBody)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
 	if !responseBody.Success {
-		return 0, fmt.Errorf(responseBody.Error.Info)
+		return nil, fmt.Errorf(responseBody.Error.Info)
+	}
+
+	return &responseBody, nil
+}
+
+func (r *apiResponse) rubleRate(currency string) (*models.Rate, error) {
+	rate, err := r.crossRate(exchange.RUB, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.Rate{
+		Base:      exchange.RUB,
+		Currency:  currency,
+		UpdatedAt: time.Unix(int64(r.Timestamp), 0),
+		FetchedAt: time.Now(),
+		Source:    source,
+	}
+	exchange.SetRateValue(result, rate)
+
+	return result, nil
+}
+
+// Базовый тариф exhangerateapi не позволяет указать базовую валюту для получения курса,
+// поэтому цена currency в base вычисляется через курсы обеих валют к базовой валюте ответа
+func (r *apiResponse) crossRate(base string, currency string) (*big.Rat, error) {
+	baseRate, err := r.rate(base)
+	if err != nil {
+		return nil, err
+	}
+
+	currencyRate, err := r.rate(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Rat).Quo(baseRate, currencyRate), nil
+}
+
+func (r *apiResponse) rate(currency string) (*big.Rat, error) {
+	if currency == r.Base {
+		return big.NewRat(1, 1), nil
 	}
-	// Базовый тариф exhangerateapi не позволяет указать базовую валюту для получения курса,
-	// поэтому для получения курса получаются курс евро к рублю и курс требуемой валюты к евро
-	rublesInEur := responseBody.Rates[exchange.RUB]
-	eurCurrencyRate := responseBody.Rates[currency]
 
-	rubblesCurrencyRate := rublesInEur / eurCurrencyRate
+	value, ok := r.Rates[currency]
+	if !ok {
+		return nil, fmt.Errorf("no %s rate in response", currency)
+	}
+
+	rate, err := exchange.ParseDecimal(value.String())
+	if err != nil {
+		return nil, err
+	}
+	if rate.Sign() <= 0 {
+		return nil, fmt.Errorf("bad %s rate %s", currency, value)
+	}
 
-	return rubblesCurrencyRate, nil
+	return rate, nil
 }
@@ -0,0 +1,47 @@
+package exchangerates
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestApiResponse_CrossRate(t *testing.T) {
+	response := apiResponse{
+		Base: "EUR",
+		Rates: map[string]json.Number{
+			"RUB": "83.2",
+			"USD": "1.132",
+			"JPY": "128",
+			"BAD": "0",
+		},
+	}
+
+	cases := []struct {
+		name     string
+		base     string
+		currency string
+		expected string
+		err      bool
+	}{
+		{name: "through response base", base: "RUB", currency: "USD", expected: "20800/283"},
+		{name: "response base currency", base: "RUB", currency: "EUR", expected: "416/5"},
+		{name: "to response base", base: "EUR", currency: "RUB", expected: "5/416"},
+		{name: "zero minor units currency", base: "RUB", currency: "JPY", expected: "13/20"},
+		{name: "missing currency", base: "RUB", currency: "XYZ", err: true},
+		{name: "zero rate", base: "RUB", currency: "BAD", err: true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			rate, err := response.crossRate(c.base, c.currency)
+			if c.err {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, c.expected, rate.String())
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package exchangerates
+
+import (
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"sync"
+	"time"
+)
+
+// Время, в течение которого закешированный список назначенных курсов считается актуальным.
+// Изменения через этот же экземпляр применяются сразу, изменения с других реплик - не позже чем через это время
+const overrideCacheTime = 5 * time.Second
+
+// OverrideCache хранит в памяти все назначенные курсы, чтобы конвертация не обращалась к базе на каждый запрос;
+// кеш сбрасывается при любом изменении назначений
+type OverrideCache struct {
+	exchange.OverrideRepository
+	mu        sync.Mutex
+	overrides []*models.RateOverride
+	expiresAt time.Time
+	now       func() time.Time
+}
+
+func NewOverrideCache(overrides exchange.OverrideRepository) *OverrideCache {
+	return &OverrideCache{
+		OverrideRepository: overrides,
+		now:                time.Now,
+	}
+}
+
+func (c *OverrideCache) invalidate() {
+	c.mu.Lock()
+	c.overrides = nil
+	c.mu.Unlock()
+}
+
+func (c *OverrideCache) load() ([]*models.RateOverride, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if c.overrides != nil && now.Before(c.expiresAt) {
+		return c.overrides, nil
+	}
+
+	overrides, err := c.OverrideRepository.GetOverrides("")
+	if err != nil {
+		return nil, err
+	}
+	if overrides == nil {
+		overrides = []*models.RateOverride{}
+	}
+
+	c.overrides = overrides
+	c.expiresAt = now.Add(overrideCacheTime)
+	return overrides, nil
+}
+
+// GetActiveOverrides сохраняет порядок хранилища: более поздние назначения идут первыми
+func (c *OverrideCache) GetActiveOverrides(at time.Time) ([]*models.RateOverride, error) {
+	overrides, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+
+	active := make([]*models.RateOverride, 0, len(overrides))
+	for _, override := range overrides {
+		if override.IsActive(at) {
+			active = append(active, override)
+		}
+	}
+
+	return active, nil
+}
+
+func (c *OverrideCache) CreateOverride(override *models.RateOverride, operator string, reason string) (*models.RateOverride, error) {
+	defer c.invalidate()
+	return c.OverrideRepository.CreateOverride(override, operator, reason)
+}
+
+func (c *OverrideCache) UpdateOverride(override *models.RateOverride, operator string, reason string) (*models.RateOverride, error) {
+	defer c.invalidate()
+	return c.OverrideRepository.UpdateOverride(override, operator, reason)
+}
+
+func (c *OverrideCache) RevokeOverride(id int64, operator string, reason string) error {
+	defer c.invalidate()
+	return c.OverrideRepository.RevokeOverride(id, operator, reason)
+}
@@ -0,0 +1,95 @@
+package exchangerates
+
+import (
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type overrideCacheSuite struct {
+	suite.Suite
+	overrides *mocks.OverrideRepository
+	cache     *OverrideCache
+	now       time.Time
+}
+
+func (suite *overrideCacheSuite) SetupTest() {
+	overrides := new(mocks.OverrideRepository)
+
+	suite.overrides = overrides
+	suite.now = time.Date(2022, 3, 1, 12, 0, 0, 0, time.UTC)
+	suite.cache = NewOverrideCache(overrides)
+	suite.cache.now = func() time.Time { return suite.now }
+}
+
+func (suite *overrideCacheSuite) TestGetActiveOverrides_Cached() {
+	validTo := suite.now.Add(-time.Hour)
+	active := &models.RateOverride{Id: 2, Currency: "USD", Rate: 90, ValidFrom: suite.now.Add(-time.Hour)}
+	expired := &models.RateOverride{Id: 1, Currency: "USD", Rate: 85, ValidFrom: suite.now.Add(-2 * time.Hour), ValidTo: &validTo}
+	suite.overrides.On("GetOverrides", "").Return([]*models.RateOverride{active, expired}, nil).Once()
+
+	first, err := suite.cache.GetActiveOverrides(suite.now)
+	suite.NoError(err)
+	second, err := suite.cache.GetActiveOverrides(suite.now)
+	suite.NoError(err)
+	past, err := suite.cache.GetActiveOverrides(suite.now.Add(-90 * time.Minute))
+	suite.NoError(err)
+
+	suite.Equal([]*models.RateOverride{active}, first)
+	suite.Equal(first, second)
+	suite.Equal([]*models.RateOverride{expired}, past)
+	suite.overrides.AssertNumberOfCalls(suite.T(), "GetOverrides", 1)
+}
+
+func (suite *overrideCacheSuite) TestGetActiveOverrides_Expired() {
+	suite.overrides.On("GetOverrides", "").Return([]*models.RateOverride{}, nil)
+
+	_, _ = suite.cache.GetActiveOverrides(suite.now)
+	suite.now = suite.now.Add(overrideCacheTime)
+	_, _ = suite.cache.GetActiveOverrides(suite.now)
+
+	suite.overrides.AssertNumberOfCalls(suite.T(), "GetOverrides", 2)
+}
+
+func (suite *overrideCacheSuite) TestGetActiveOverrides_Error() {
+	loadErr := errors.New("db error")
+	suite.overrides.On("GetOverrides", "").Return(nil, loadErr).Once()
+	suite.overrides.On("GetOverrides", "").Return([]*models.RateOverride{}, nil).Once()
+
+	_, err := suite.cache.GetActiveOverrides(suite.now)
+	suite.Equal(loadErr, err)
+
+	_, err = suite.cache.GetActiveOverrides(suite.now)
+	suite.NoError(err, "failed load is not cached")
+}
+
+func (suite *overrideCacheSuite) TestWriteInvalidates() {
+	override := &models.RateOverride{Currency: "USD", Rate: 90, ValidFrom: suite.now}
+	suite.overrides.On("GetOverrides", "").Return([]*models.RateOverride{}, nil)
+	suite.overrides.On("CreateOverride", override, "admin", "reason").Return(override, nil)
+	suite.overrides.On("UpdateOverride", override, "admin", "reason").Return(override, nil)
+	suite.overrides.On("RevokeOverride", int64(1), "admin", "reason").Return(nil)
+
+	writes := []func(){
+		func() { _, _ = suite.cache.CreateOverride(override, "admin", "reason") },
+		func() { _, _ = suite.cache.UpdateOverride(override, "admin", "reason") },
+		func() { _ = suite.cache.RevokeOverride(1, "admin", "reason") },
+	}
+
+	_, _ = suite.cache.GetActiveOverrides(suite.now)
+	for _, write := range writes {
+		write()
+		_, _ = suite.cache.GetActiveOverrides(suite.now)
+	}
+
+	suite.overrides.AssertNumberOfCalls(suite.T(), "GetOverrides", 1+len(writes))
+	suite.overrides.AssertNotCalled(suite.T(), "GetActiveOverrides", mock.Anything)
+}
+
+func TestOverrideCache(t *testing.T) {
+	suite.Run(t, new(overrideCacheSuite))
+}
@@ -0,0 +1,91 @@
+package exchangerates
+
+import (
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"log"
+	"time"
+)
+
+// OverridingRepository отдает назначенные вручную курсы раньше кеша и источника
+type OverridingRepository struct {
+	next      exchange.RateRepository
+	overrides exchange.OverrideRepository
+}
+
+func NewOverridingRepository(next exchange.RateRepository, overrides exchange.OverrideRepository) *OverridingRepository {
+	return &OverridingRepository{
+		next:      next,
+		overrides: overrides,
+	}
+}
+
+func overrideToRate(override *models.RateOverride, now time.Time) *models.Rate {
+	return &models.Rate{
+		Base:      exchange.RUB,
+		Currency:  override.Currency,
+		Value:     override.Rate,
+		UpdatedAt: override.ValidFrom,
+		FetchedAt: now,
+		Source:    exchange.OverrideSource,
+	}
+}
+
+// active возвращает действующие назначенные курсы по валютам;
+// недоступность хранилища назначений не должна мешать конвертации, поэтому ошибка только логируется
+func (r *OverridingRepository) active(now time.Time) map[string]*models.RateOverride {
+	overrides, err := r.overrides.GetActiveOverrides(now)
+	if err != nil {
+		log.Println(err)
+		return nil
+	}
+
+	active := make(map[string]*models.RateOverride, len(overrides))
+	for _, override := range overrides {
+		// При пересечении периодов действует более позднее назначение
+		if _, ok := active[override.Currency]; !ok {
+			active[override.Currency] = override
+		}
+	}
+
+	return active
+}
+
+func (r *OverridingRepository) GetRubleRate(currency string) (*models.Rate, error) {
+	now := time.Now()
+	if override, ok := r.active(now)[currency]; ok {
+		return overrideToRate(override, now), nil
+	}
+
+	return r.next.GetRubleRate(currency)
+}
+
+func (r *OverridingRepository) GetRubleRateAt(currency string, date time.Time) (*models.Rate, error) {
+	if override, ok := r.active(date)[currency]; ok {
+		return overrideToRate(override, time.Now()), nil
+	}
+
+	return r.next.GetRubleRateAt(currency, date)
+}
+
+func (r *OverridingRepository) GetRubleRates() ([]*models.Rate, error) {
+	rates, err := r.next.GetRubleRates()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	active := r.active(now)
+
+	result := make([]*models.Rate, 0, len(rates)+len(active))
+	for _, rate := range rates {
+		if _, ok := active[rate.Currency]; !ok {
+			result = append(result, rate)
+		}
+	}
+	for _, override := range active {
+		result = append(result, overrideToRate(override, now))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,84 @@
+package exchangerates
+
+import (
+	"avito-intership/exchange"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type overridingRepositorySuite struct {
+	suite.Suite
+	next       *mocks.RateRepository
+	overrides  *mocks.OverrideRepository
+	repository *OverridingRepository
+}
+
+func (suite *overridingRepositorySuite) SetupTest() {
+	next := new(mocks.RateRepository)
+	overrides := new(mocks.OverrideRepository)
+
+	suite.next = next
+	suite.overrides = overrides
+	suite.repository = NewOverridingRepository(next, overrides)
+}
+
+func (suite *overridingRepositorySuite) TestGetRubleRate_Override() {
+	override := &models.RateOverride{Id: 2, Currency: "USD", Rate: 90, ValidFrom: time.Now().Add(-time.Hour)}
+	older := &models.RateOverride{Id: 1, Currency: "USD", Rate: 85, ValidFrom: time.Now().Add(-2 * time.Hour)}
+	suite.overrides.On("GetActiveOverrides", mock.Anything).Return([]*models.RateOverride{override, older}, nil)
+
+	rate, err := suite.repository.GetRubleRate("USD")
+
+	suite.NoError(err)
+	suite.Equal(override.Rate, rate.Value)
+	suite.Equal(exchange.OverrideSource, rate.Source)
+	suite.next.AssertNotCalled(suite.T(), "GetRubleRate", "USD")
+}
+
+func (suite *overridingRepositorySuite) TestGetRubleRate_NoOverride() {
+	fetched := &models.Rate{Currency: "USD", Value: 75}
+	suite.overrides.On("GetActiveOverrides", mock.Anything).
+		Return([]*models.RateOverride{{Currency: "EUR", Rate: 95}}, nil)
+	suite.next.On("GetRubleRate", "USD").Return(fetched, nil)
+
+	rate, err := suite.repository.GetRubleRate("USD")
+
+	suite.NoError(err)
+	suite.Equal(fetched, rate)
+}
+
+func (suite *overridingRepositorySuite) TestGetRubleRate_OverrideStoreError() {
+	fetched := &models.Rate{Currency: "USD", Value: 75}
+	suite.overrides.On("GetActiveOverrides", mock.Anything).Return(nil, errors.New("db is down"))
+	suite.next.On("GetRubleRate", "USD").Return(fetched, nil)
+
+	rate, err := suite.repository.GetRubleRate("USD")
+
+	suite.NoError(err, "override store failure should not break conversion")
+	suite.Equal(fetched, rate)
+}
+
+func (suite *overridingRepositorySuite) TestGetRubleRates() {
+	rates := []*models.Rate{{Currency: "USD", Value: 75}, {Currency: "EUR", Value: 85}}
+	suite.next.On("GetRubleRates").Return(rates, nil)
+	suite.overrides.On("GetActiveOverrides", mock.Anything).
+		Return([]*models.RateOverride{{Currency: "USD", Rate: 90}}, nil)
+
+	result, err := suite.repository.GetRubleRates()
+
+	suite.NoError(err)
+	values := make(map[string]float32)
+	for _, rate := range result {
+		values[rate.Currency] = rate.Value
+	}
+	suite.Equal(map[string]float32{"USD": 90, "EUR": 85}, values)
+}
+
+func TestOverridingRepository(t *testing.T) {
+	suite.Run(t, new(overridingRepositorySuite))
+}
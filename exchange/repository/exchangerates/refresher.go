@@ -0,0 +1,45 @@
+package exchangerates
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Курсы обновляются чаще, чем устаревают, чтобы запрос пользователя не ждал ответа источника
+const rateRefreshTime = 45 * time.Minute
+
+type Refresher struct {
+	repo     *Repository
+	interval time.Duration
+}
+
+func NewRefresher(repo *Repository) *Refresher {
+	return &Refresher{
+		repo:     repo,
+		interval: rateRefreshTime,
+	}
+}
+
+// Run обновляет курсы при запуске, чтобы первые запросы не ждали источник, а затем периодически до отмены ctx
+func (r *Refresher) Run(ctx context.Context) {
+	r.refresh()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.refresh()
+		}
+	}
+}
+
+func (r *Refresher) refresh() {
+	if err := r.repo.Refresh(); err != nil {
+		log.Println(err)
+	}
+}
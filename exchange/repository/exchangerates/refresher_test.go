@@ -0,0 +1,80 @@
+package exchangerates
+
+import (
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type refresherSuite struct {
+	suite.Suite
+	netRepo   *mocks.RateRepository
+	cache     *mocks.Cacher
+	refreshes chan struct{}
+	refresher *Refresher
+}
+
+func (suite *refresherSuite) SetupTest() {
+	suite.netRepo = new(mocks.RateRepository)
+	suite.cache = new(mocks.Cacher)
+	suite.refreshes = make(chan struct{}, 10)
+	suite.refresher = NewRefresher(NewExchangeRepository(suite.netRepo, suite.cache))
+
+	rate := &models.Rate{Currency: "USD", Value: 70, FetchedAt: time.Now()}
+	suite.netRepo.On("GetRubleRates").Run(func(mock.Arguments) {
+		select {
+		case suite.refreshes <- struct{}{}:
+		default:
+		}
+	}).Return([]*models.Rate{rate}, nil)
+	suite.cache.On("SetRate", rate).Return(nil)
+}
+
+// run запускает обновление и возвращает функцию, которая останавливает его и дожидается завершения
+func (suite *refresherSuite) run() func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		suite.refresher.Run(ctx)
+		close(done)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func (suite *refresherSuite) waitRefresh() {
+	select {
+	case <-suite.refreshes:
+	case <-time.After(time.Second):
+		suite.FailNow("rates are not refreshed")
+	}
+}
+
+func (suite *refresherSuite) TestRun_RefreshesOnStart() {
+	suite.refresher.interval = time.Hour
+	stop := suite.run()
+	defer stop()
+
+	suite.waitRefresh()
+}
+
+func (suite *refresherSuite) TestRun_RefreshesPeriodically() {
+	suite.refresher.interval = 10 * time.Millisecond
+	stop := suite.run()
+	defer stop()
+
+	for i := 0; i < 3; i++ {
+		suite.waitRefresh()
+	}
+}
+
+func TestRefresher(t *testing.T) {
+	suite.Run(t, new(refresherSuite))
+}
@@ -3,36 +3,104 @@ package exchangerates
 import (
 	"avito-intership/exchange"
 	"avito-intership/exchange/repository"
+	"avito-intership/models"
 	"log"
+	"time"
 )
 
+// Время, в течение которого закешированный курс считается свежим
+const rateExpireTime = time.Hour
+
 type Repository struct {
 	netRepo exchange.RateRepository
 	cache   repository.Cacher
+	flights *flightGroup
 }
 
 func NewExchangeRepository(netRepo exchange.RateRepository, cache repository.Cacher) *Repository {
 	return &Repository{
 		netRepo: netRepo,
 		cache:   cache,
+		flights: newFlightGroup(),
 	}
 }
 
-func (r *Repository) GetRubleRate(currency string) (float32, error) {
-	rate, err := r.cache.GetRubleRate(currency)
-	if err == nil {
-		return rate, nil
+func (r *Repository) GetRubleRate(currency string) (*models.Rate, error) {
+	cached, cacheErr := r.cache.GetRubleRate(currency)
+	if cacheErr == nil && time.Since(cached.FetchedAt) < rateExpireTime {
+		return cached, nil
 	}
 
-	rate, err = r.netRepo.GetRubleRate(currency)
+	rate, err := r.fetch(currency)
 	if err != nil {
-		return 0, err
+		// Если источник недоступен, отдаем последний известный курс с пометкой об устаревании
+		if cacheErr == nil {
+			log.Println(err)
+			cached.Stale = true
+			return cached, nil
+		}
+
+		return nil, err
 	}
 
-	err = r.cache.SetRate(currency, rate)
+	return rate, nil
+}
+
+// GetRubleRates возвращает закешированные курсы, не обращаясь к источнику; кеш заполняет Refresh
+func (r *Repository) GetRubleRates() ([]*models.Rate, error) {
+	currencies, err := r.cache.Currencies()
 	if err != nil {
-		log.Println(err)
+		return nil, err
 	}
 
-	return rate, nil
+	rates := make([]*models.Rate, 0, len(currencies))
+	for _, currency := range currencies {
+		rate, err := r.cache.GetRubleRate(currency)
+		if err != nil {
+			// Курс мог быть вытеснен из кеша
+			continue
+		}
+		rate.Stale = time.Since(rate.FetchedAt) >= rateExpireTime
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
+
+// GetRubleRateAt не кеширует исторические курсы, они хранятся источником
+func (r *Repository) GetRubleRateAt(currency string, date time.Time) (*models.Rate, error) {
+	return r.netRepo.GetRubleRateAt(currency, date)
+}
+
+// Refresh обновляет курсы всех валют одним запросом к источнику, не дожидаясь их устаревания
+func (r *Repository) Refresh() error {
+	rates, err := r.netRepo.GetRubleRates()
+	if err != nil {
+		return err
+	}
+
+	for _, rate := range rates {
+		if err := r.cache.SetRate(rate); err != nil {
+			log.Printf("caching %s rate: %v", rate.Currency, err)
+		}
+	}
+
+	return nil
+}
+
+// fetch запрашивает курс у источника; одновременные запросы одной валюты разделяют один ответ
+func (r *Repository) fetch(currency string) (*models.Rate, error) {
+	return r.flights.do(currency, func() (*models.Rate, error) {
+		rate, err := r.netRepo.GetRubleRate(currency)
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.cache.SetRate(rate)
+		if err != nil {
+			log.Println(err)
+		}
+
+		return rate, nil
+	})
 }
@@ -0,0 +1,179 @@
+package exchangerates
+
+import (
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"sync"
+	"testing"
+	"time"
+)
+
+type exchangeRepositorySuite struct {
+	suite.Suite
+	netRepo    *mocks.RateRepository
+	cache      *mocks.Cacher
+	repository *Repository
+}
+
+func (suite *exchangeRepositorySuite) SetupTest() {
+	netRepo := new(mocks.RateRepository)
+	cache := new(mocks.Cacher)
+
+	suite.netRepo = netRepo
+	suite.cache = cache
+	suite.repository = NewExchangeRepository(netRepo, cache)
+}
+
+func (suite *exchangeRepositorySuite) TestGetRubleRate_Fresh() {
+	currency := "USD"
+	cached := &models.Rate{Currency: currency, Value: 70, FetchedAt: time.Now()}
+
+	suite.cache.On("GetRubleRate", currency).Return(cached, nil)
+
+	rate, err := suite.repository.GetRubleRate(currency)
+
+	suite.NoError(err)
+	suite.Equal(cached, rate)
+	suite.False(rate.Stale)
+	suite.netRepo.AssertNotCalled(suite.T(), "GetRubleRate", currency)
+}
+
+func (suite *exchangeRepositorySuite) TestGetRubleRate_Expired() {
+	currency := "USD"
+	cached := &models.Rate{Currency: currency, Value: 70, FetchedAt: time.Now().Add(-2 * rateExpireTime)}
+	fetched := &models.Rate{Currency: currency, Value: 75, FetchedAt: time.Now()}
+
+	suite.cache.On("GetRubleRate", currency).Return(cached, nil)
+	suite.netRepo.On("GetRubleRate", currency).Return(fetched, nil)
+	suite.cache.On("SetRate", fetched).Return(nil)
+
+	rate, err := suite.repository.GetRubleRate(currency)
+
+	suite.NoError(err)
+	suite.Equal(fetched, rate)
+	suite.cache.AssertCalled(suite.T(), "SetRate", fetched)
+}
+
+func (suite *exchangeRepositorySuite) TestGetRubleRate_StaleOnUpstreamError() {
+	currency := "USD"
+	cached := &models.Rate{Currency: currency, Value: 70, FetchedAt: time.Now().Add(-2 * rateExpireTime)}
+
+	suite.cache.On("GetRubleRate", currency).Return(cached, nil)
+	suite.netRepo.On("GetRubleRate", currency).Return(nil, errors.New("upstream error"))
+
+	rate, err := suite.repository.GetRubleRate(currency)
+
+	suite.NoError(err, "stale rate should be served")
+	suite.Equal(cached.Value, rate.Value)
+	suite.True(rate.Stale)
+}
+
+func (suite *exchangeRepositorySuite) TestGetRubleRate_NoCacheUpstreamError() {
+	currency := "USD"
+	upstreamErr := errors.New("upstream error")
+
+	suite.cache.On("GetRubleRate", currency).Return(nil, errors.New("cache miss"))
+	suite.netRepo.On("GetRubleRate", currency).Return(nil, upstreamErr)
+
+	_, err := suite.repository.GetRubleRate(currency)
+
+	suite.Equal(upstreamErr, err)
+}
+
+func (suite *exchangeRepositorySuite) TestGetRubleRates() {
+	fresh := &models.Rate{Currency: "USD", Value: 75, FetchedAt: time.Now()}
+	expired := &models.Rate{Currency: "EUR", Value: 85, FetchedAt: time.Now().Add(-2 * rateExpireTime)}
+
+	suite.cache.On("Currencies").Return([]string{"USD", "EUR", "GBP"}, nil)
+	suite.cache.On("GetRubleRate", "USD").Return(fresh, nil)
+	suite.cache.On("GetRubleRate", "EUR").Return(expired, nil)
+	suite.cache.On("GetRubleRate", "GBP").Return(nil, errors.New("evicted"))
+
+	rates, err := suite.repository.GetRubleRates()
+
+	suite.NoError(err)
+	suite.Len(rates, 2)
+	suite.False(rates[0].Stale)
+	suite.True(rates[1].Stale)
+	suite.netRepo.AssertNotCalled(suite.T(), "GetRubleRates")
+}
+
+func (suite *exchangeRepositorySuite) TestRefresh() {
+	rates := []*models.Rate{
+		{Currency: "USD", Value: 75, FetchedAt: time.Now()},
+		{Currency: "EUR", Value: 85, FetchedAt: time.Now()},
+	}
+
+	suite.netRepo.On("GetRubleRates").Return(rates, nil)
+	suite.cache.On("SetRate", mock.Anything).Return(nil)
+
+	err := suite.repository.Refresh()
+
+	suite.NoError(err)
+	suite.cache.AssertCalled(suite.T(), "SetRate", rates[0])
+	suite.cache.AssertCalled(suite.T(), "SetRate", rates[1])
+}
+
+func (suite *exchangeRepositorySuite) TestRefresh_UpstreamError() {
+	upstreamErr := errors.New("upstream error")
+	suite.netRepo.On("GetRubleRates").Return(nil, upstreamErr)
+
+	err := suite.repository.Refresh()
+
+	suite.Equal(upstreamErr, err)
+	suite.cache.AssertNotCalled(suite.T(), "SetRate", mock.Anything)
+}
+
+func (suite *exchangeRepositorySuite) TestGetRubleRate_ConcurrentMisses() {
+	currency := "USD"
+	fetched := &models.Rate{Currency: currency, Value: 75, FetchedAt: time.Now()}
+	waiters := 10
+	// Источник не отвечает, пока остальные запросы не промахнутся мимо кеша и не присоединятся к первому
+	started := make(chan struct{})
+	release := make(chan struct{})
+	missed := make(chan struct{}, waiters)
+
+	suite.cache.On("GetRubleRate", currency).Run(func(mock.Arguments) {
+		missed <- struct{}{}
+	}).Return(nil, errors.New("cache miss"))
+	suite.netRepo.On("GetRubleRate", currency).Run(func(mock.Arguments) {
+		close(started)
+		<-release
+	}).Return(fetched, nil)
+	suite.cache.On("SetRate", fetched).Return(nil)
+
+	var wg sync.WaitGroup
+	results := make([]*models.Rate, waiters)
+	request := func(i int) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = suite.repository.GetRubleRate(currency)
+		}()
+	}
+
+	request(0)
+	<-started
+	for i := 1; i < waiters; i++ {
+		request(i)
+	}
+	for i := 0; i < waiters; i++ {
+		<-missed
+	}
+	// После промаха по кешу запросу остается только присоединиться к выполняющемуся
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	suite.netRepo.AssertNumberOfCalls(suite.T(), "GetRubleRate", 1)
+	for _, result := range results {
+		suite.Equal(fetched, result, "every waiter should share the upstream result")
+	}
+}
+
+func TestExchangeRepository(t *testing.T) {
+	suite.Run(t, new(exchangeRepositorySuite))
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"database/sql"
+	"time"
+)
+
+const dateLayout = "2006-01-02"
+
+type RateHistoryRepository struct {
+	db *sql.DB
+}
+
+func NewRateHistoryRepository(dbConn *sql.DB) *RateHistoryRepository {
+	return &RateHistoryRepository{dbConn}
+}
+
+// exactString возвращает точный курс дробью для колонки exact, rate хранит его округление до 6 знаков
+func exactString(rate *models.Rate) sql.NullString {
+	if rate.Exact == nil {
+		return sql.NullString{}
+	}
+
+	return sql.NullString{String: rate.Exact.RatString(), Valid: true}
+}
+
+// SaveRate сохраняет курс за день date, более поздний курс того же дня заменяет ранее сохраненный
+func (r RateHistoryRepository) SaveRate(rate *models.Rate, date time.Time) error {
+	_, err := r.db.Exec(
+		`INSERT INTO exchange_rates (currency, date, rate, exact, source, updated_at, fetched_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (currency, date) DO UPDATE SET rate = EXCLUDED.rate, exact = EXCLUDED.exact,
+			source = EXCLUDED.source, updated_at = EXCLUDED.updated_at, fetched_at = EXCLUDED.fetched_at
+		WHERE exchange_rates.updated_at <= EXCLUDED.updated_at`,
+		rate.Currency, date.UTC().Format(dateLayout), rate.Value, exactString(rate), rate.Source,
+		rate.UpdatedAt.UTC(), rate.FetchedAt.UTC())
+	return err
+}
+
+func (r RateHistoryRepository) GetRate(currency string, date time.Time) (*models.Rate, error) {
+	rate := models.Rate{
+		Base:     exchange.RUB,
+		Currency: currency,
+	}
+
+	row := r.db.QueryRow(
+		`SELECT rate, exact, source, updated_at, fetched_at FROM exchange_rates WHERE currency = $1 AND date = $2`,
+		currency, date.UTC().Format(dateLayout))
+	var exact sql.NullString
+	err := row.Scan(&rate.Value, &exact, &rate.Source, &rate.UpdatedAt, &rate.FetchedAt)
+	if err == sql.ErrNoRows {
+		return nil, exchange.ErrRateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if exact.Valid {
+		rate.Exact, err = exchange.ParseDecimal(exact.String)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &rate, nil
+}
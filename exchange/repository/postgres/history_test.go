@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"avito-intership/utils"
+	"database/sql"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/suite"
+	"log"
+	"testing"
+	"time"
+)
+
+type rateHistoryRepositorySuite struct {
+	suite.Suite
+
+	db       *sql.DB
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+
+	repository exchange.RateHistoryRepository
+}
+
+func (suite *rateHistoryRepositorySuite) SetupSuite() {
+	db, pool, resource := utils.DockerDBUp()
+	err := utils.InitTable(db, "../../../init.sql")
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	suite.repository = NewRateHistoryRepository(db)
+	suite.db = db
+	suite.pool = pool
+	suite.resource = resource
+}
+
+func (suite *rateHistoryRepositorySuite) TestSaveRate() {
+	date := time.Date(2021, 11, 15, 0, 0, 0, 0, time.UTC)
+	rate := &models.Rate{
+		Currency:  "USD",
+		Value:     72.5,
+		UpdatedAt: date.Add(10 * time.Hour),
+		FetchedAt: date.Add(11 * time.Hour),
+		Source:    "test",
+	}
+
+	err := suite.repository.SaveRate(rate, date.Add(12*time.Hour))
+	suite.NoError(err, "saving rate should not produce error")
+
+	stored, err := suite.repository.GetRate("USD", date.Add(23*time.Hour))
+	suite.NoError(err, "getting rate should not produce error")
+	suite.Equal(rate.Value, stored.Value)
+	suite.Equal(rate.Source, stored.Source)
+}
+
+func (suite *rateHistoryRepositorySuite) TestSaveRate_LaterRateWins() {
+	date := time.Date(2021, 11, 16, 0, 0, 0, 0, time.UTC)
+	morning := &models.Rate{Currency: "EUR", Value: 83, UpdatedAt: date.Add(9 * time.Hour), FetchedAt: date}
+	evening := &models.Rate{Currency: "EUR", Value: 84, UpdatedAt: date.Add(18 * time.Hour), FetchedAt: date}
+
+	suite.NoError(suite.repository.SaveRate(evening, date))
+	suite.NoError(suite.repository.SaveRate(morning, date))
+
+	stored, err := suite.repository.GetRate("EUR", date)
+	suite.NoError(err)
+	suite.Equal(evening.Value, stored.Value)
+}
+
+func (suite *rateHistoryRepositorySuite) TestGetRate_NotFound() {
+	_, err := suite.repository.GetRate("GBP", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	suite.Equal(exchange.ErrRateNotFound, err)
+}
+
+func (suite *rateHistoryRepositorySuite) TearDownSuite() {
+	err := utils.DropTable(suite.db, []string{"exchange_rates"})
+	if err != nil {
+		log.Println(err)
+	}
+
+	if err := suite.pool.Purge(suite.resource); err != nil {
+		log.Fatalf("Could not purge resource: %s", err)
+	}
+}
+
+func TestRateHistoryRepository(t *testing.T) {
+	suite.Run(t, new(rateHistoryRepositorySuite))
+}
@@ -0,0 +1,243 @@
+package postgres
+
+import (
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"database/sql"
+	"time"
+)
+
+type OverrideRepository struct {
+	db *sql.DB
+}
+
+func NewOverrideRepository(dbConn *sql.DB) *OverrideRepository {
+	return &OverrideRepository{dbConn}
+}
+
+const overrideColumns = "id, currency, rate, valid_from, valid_to, created_at"
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanOverride(row scanner) (*models.RateOverride, error) {
+	var override models.RateOverride
+	var validTo sql.NullTime
+
+	err := row.Scan(&override.Id, &override.Currency, &override.Rate,
+		&override.ValidFrom, &validTo, &override.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if validTo.Valid {
+		override.ValidTo = &validTo.Time
+	}
+
+	return &override, nil
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func (r OverrideRepository) insertLog(override *models.RateOverride, action string, operator string, reason string, tx *sql.Tx) error {
+	_, err := tx.Exec(
+		`INSERT INTO rate_override_log (override_id, action, operator, reason, rate, valid_from, valid_to)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		override.Id, action, operator, reason, override.Rate, override.ValidFrom, nullTime(override.ValidTo))
+	return err
+}
+
+func (r OverrideRepository) CreateOverride(override *models.RateOverride, operator string, reason string) (*models.RateOverride, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	row := tx.QueryRow(
+		`INSERT INTO rate_overrides (currency, rate, valid_from, valid_to) VALUES ($1, $2, $3, $4)
+		RETURNING `+overrideColumns,
+		override.Currency, override.Rate, override.ValidFrom, nullTime(override.ValidTo))
+	created, err := scanOverride(row)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.insertLog(created, exchange.OverrideCreateAction, operator, reason, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (r OverrideRepository) UpdateOverride(override *models.RateOverride, operator string, reason string) (*models.RateOverride, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	row := tx.QueryRow(
+		`UPDATE rate_overrides SET rate = $1, valid_from = $2, valid_to = $3 WHERE id = $4
+		RETURNING `+overrideColumns,
+		override.Rate, override.ValidFrom, nullTime(override.ValidTo), override.Id)
+	updated, err := scanOverride(row)
+	if err == sql.ErrNoRows {
+		err = exchange.ErrOverrideNotFound
+		return nil, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.insertLog(updated, exchange.OverrideUpdateAction, operator, reason, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// RevokeOverride прекращает действие курса с текущего момента, запись при этом сохраняется
+func (r OverrideRepository) RevokeOverride(id int64, operator string, reason string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	row := tx.QueryRow(
+		`UPDATE rate_overrides SET valid_to = LEAST(COALESCE(valid_to, NOW()), NOW()) WHERE id = $1
+		RETURNING `+overrideColumns, id)
+	revoked, err := scanOverride(row)
+	if err == sql.ErrNoRows {
+		err = exchange.ErrOverrideNotFound
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	err = r.insertLog(revoked, exchange.OverrideRevokeAction, operator, reason, tx)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r OverrideRepository) GetOverride(id int64) (*models.RateOverride, error) {
+	row := r.db.QueryRow("SELECT "+overrideColumns+" FROM rate_overrides WHERE id = $1", id)
+	override, err := scanOverride(row)
+	if err == sql.ErrNoRows {
+		return nil, exchange.ErrOverrideNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return override, nil
+}
+
+func (r OverrideRepository) queryOverrides(query string, args ...interface{}) ([]*models.RateOverride, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	overrides := make([]*models.RateOverride, 0)
+	for rows.Next() {
+		override, err := scanOverride(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		overrides = append(overrides, override)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return overrides, nil
+}
+
+// GetOverrides возвращает назначенные курсы валюты, при пустой currency - всех валют
+func (r OverrideRepository) GetOverrides(currency string) ([]*models.RateOverride, error) {
+	if currency == "" {
+		return r.queryOverrides("SELECT " + overrideColumns + " FROM rate_overrides ORDER BY id DESC")
+	}
+
+	return r.queryOverrides("SELECT "+overrideColumns+" FROM rate_overrides WHERE currency = $1 ORDER BY id DESC",
+		currency)
+}
+
+// GetActiveOverrides возвращает курсы, действующие в момент at, более поздние назначения идут первыми
+func (r OverrideRepository) GetActiveOverrides(at time.Time) ([]*models.RateOverride, error) {
+	return r.queryOverrides(
+		`SELECT `+overrideColumns+` FROM rate_overrides
+		WHERE valid_from <= $1 AND (valid_to IS NULL OR valid_to > $1) ORDER BY id DESC`, at)
+}
+
+func (r OverrideRepository) GetOverrideLog(id int64) ([]*models.RateOverrideChange, error) {
+	rows, err := r.db.Query(
+		`SELECT override_id, action, operator, reason, rate, valid_from, valid_to, date
+		FROM rate_override_log WHERE override_id = $1 ORDER BY id`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := make([]*models.RateOverrideChange, 0)
+	for rows.Next() {
+		var change models.RateOverrideChange
+		var validTo sql.NullTime
+
+		err = rows.Scan(&change.OverrideId, &change.Action, &change.Operator, &change.Reason,
+			&change.Rate, &change.ValidFrom, &validTo, &change.Time)
+		if err != nil {
+			return nil, err
+		}
+
+		if validTo.Valid {
+			change.ValidTo = &validTo.Time
+		}
+		changes = append(changes, &change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
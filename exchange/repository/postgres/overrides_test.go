@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"avito-intership/utils"
+	"database/sql"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/suite"
+	"log"
+	"testing"
+	"time"
+)
+
+type overrideRepositorySuite struct {
+	suite.Suite
+
+	db       *sql.DB
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+
+	repository exchange.OverrideRepository
+}
+
+func (suite *overrideRepositorySuite) SetupSuite() {
+	db, pool, resource := utils.DockerDBUp()
+	err := utils.InitTable(db, "../../../init.sql")
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	suite.repository = NewOverrideRepository(db)
+	suite.db = db
+	suite.pool = pool
+	suite.resource = resource
+}
+
+func (suite *overrideRepositorySuite) TestCreateOverride() {
+	validTo := time.Now().Add(time.Hour)
+	override := &models.RateOverride{Currency: "USD", Rate: 90, ValidFrom: time.Now(), ValidTo: &validTo}
+
+	created, err := suite.repository.CreateOverride(override, "finance", "outage")
+	suite.NoError(err, "creating override should not produce error")
+	suite.NotZero(created.Id)
+
+	changes, err := suite.repository.GetOverrideLog(created.Id)
+	suite.NoError(err, "getting log should not produce error")
+	suite.Len(changes, 1)
+	suite.Equal(exchange.OverrideCreateAction, changes[0].Action)
+	suite.Equal("finance", changes[0].Operator)
+	suite.Equal("outage", changes[0].Reason)
+}
+
+func (suite *overrideRepositorySuite) TestGetActiveOverrides() {
+	now := time.Now()
+	expired := now.Add(-time.Hour)
+	_, err := suite.repository.CreateOverride(
+		&models.RateOverride{Currency: "EUR", Rate: 95, ValidFrom: now.Add(-2 * time.Hour), ValidTo: &expired},
+		"finance", "expired")
+	suite.NoError(err)
+	active, err := suite.repository.CreateOverride(
+		&models.RateOverride{Currency: "GBP", Rate: 110, ValidFrom: now.Add(-time.Hour)}, "finance", "active")
+	suite.NoError(err)
+
+	overrides, err := suite.repository.GetActiveOverrides(now)
+
+	suite.NoError(err, "getting active overrides should not produce error")
+	currencies := make([]string, 0)
+	for _, override := range overrides {
+		currencies = append(currencies, override.Currency)
+	}
+	suite.Contains(currencies, active.Currency)
+	suite.NotContains(currencies, "EUR")
+}
+
+func (suite *overrideRepositorySuite) TestRevokeOverride() {
+	created, err := suite.repository.CreateOverride(
+		&models.RateOverride{Currency: "CHF", Rate: 80, ValidFrom: time.Now().Add(-time.Hour)}, "finance", "pin")
+	suite.NoError(err)
+
+	err = suite.repository.RevokeOverride(created.Id, "finance", "unpin")
+	suite.NoError(err, "revoking override should not produce error")
+
+	revoked, err := suite.repository.GetOverride(created.Id)
+	suite.NoError(err)
+	suite.NotNil(revoked.ValidTo)
+	suite.False(revoked.IsActive(time.Now()))
+
+	changes, err := suite.repository.GetOverrideLog(created.Id)
+	suite.NoError(err)
+	suite.Len(changes, 2)
+	suite.Equal(exchange.OverrideRevokeAction, changes[1].Action)
+}
+
+func (suite *overrideRepositorySuite) TestRevokeOverride_NotFound() {
+	err := suite.repository.RevokeOverride(100500, "finance", "missing")
+
+	suite.Equal(exchange.ErrOverrideNotFound, err)
+}
+
+func (suite *overrideRepositorySuite) TearDownSuite() {
+	err := utils.DropTable(suite.db, []string{"rate_override_log", "rate_overrides"})
+	if err != nil {
+		log.Println(err)
+	}
+
+	if err := suite.pool.Purge(suite.resource); err != nil {
+		log.Fatalf("Could not purge resource: %s", err)
+	}
+}
+
+func TestOverrideRepository(t *testing.T) {
+	suite.Run(t, new(overrideRepositorySuite))
+}
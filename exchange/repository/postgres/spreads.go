@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"database/sql"
+)
+
+type SpreadRepository struct {
+	db *sql.DB
+}
+
+func NewSpreadRepository(dbConn *sql.DB) *SpreadRepository {
+	return &SpreadRepository{dbConn}
+}
+
+func (r SpreadRepository) GetSpreads() ([]*models.Spread, error) {
+	rows, err := r.db.Query("SELECT currency, direction, percent FROM fx_spreads ORDER BY currency, direction")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	spreads := make([]*models.Spread, 0)
+	for rows.Next() {
+		var spread models.Spread
+		err = rows.Scan(&spread.Currency, &spread.Direction, &spread.Percent)
+		if err != nil {
+			return nil, err
+		}
+
+		spreads = append(spreads, &spread)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return spreads, nil
+}
+
+func (r SpreadRepository) GetSpread(currency string, direction string) (*models.Spread, error) {
+	spread := models.Spread{
+		Currency:  currency,
+		Direction: direction,
+	}
+
+	row := r.db.QueryRow("SELECT percent FROM fx_spreads WHERE currency = $1 AND direction = $2",
+		currency, direction)
+	err := row.Scan(&spread.Percent)
+	if err == sql.ErrNoRows {
+		return nil, exchange.ErrSpreadNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &spread, nil
+}
+
+func (r SpreadRepository) SetSpread(spread *models.Spread) error {
+	_, err := r.db.Exec(
+		`INSERT INTO fx_spreads (currency, direction, percent) VALUES ($1, $2, $3)
+		ON CONFLICT (currency, direction) DO UPDATE SET percent = EXCLUDED.percent`,
+		spread.Currency, spread.Direction, spread.Percent)
+	return err
+}
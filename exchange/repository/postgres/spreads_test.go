@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"avito-intership/utils"
+	"database/sql"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/suite"
+	"log"
+	"testing"
+)
+
+type spreadRepositorySuite struct {
+	suite.Suite
+
+	db       *sql.DB
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+
+	repository exchange.SpreadRepository
+}
+
+func (suite *spreadRepositorySuite) SetupSuite() {
+	db, pool, resource := utils.DockerDBUp()
+	err := utils.InitTable(db, "../../../init.sql")
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	suite.repository = NewSpreadRepository(db)
+	suite.db = db
+	suite.pool = pool
+	suite.resource = resource
+}
+
+func (suite *spreadRepositorySuite) TestSetSpread() {
+	spread := &models.Spread{Currency: "USD", Direction: exchange.BuyDirection, Percent: 1.5}
+
+	err := suite.repository.SetSpread(spread)
+	suite.NoError(err, "setting spread should not produce error")
+
+	stored, err := suite.repository.GetSpread("USD", exchange.BuyDirection)
+	suite.NoError(err, "getting spread should not produce error")
+	suite.Equal(spread, stored)
+}
+
+func (suite *spreadRepositorySuite) TestSetSpread_Replace() {
+	err := suite.repository.SetSpread(&models.Spread{Currency: "EUR", Direction: exchange.SellDirection, Percent: 1})
+	suite.NoError(err, "setting spread should not produce error")
+	err = suite.repository.SetSpread(&models.Spread{Currency: "EUR", Direction: exchange.SellDirection, Percent: 2})
+	suite.NoError(err, "replacing spread should not produce error")
+
+	stored, err := suite.repository.GetSpread("EUR", exchange.SellDirection)
+	suite.NoError(err, "getting spread should not produce error")
+	suite.Equal(float32(2), stored.Percent)
+}
+
+func (suite *spreadRepositorySuite) TestGetSpread_NotFound() {
+	_, err := suite.repository.GetSpread("GBP", exchange.BuyDirection)
+
+	suite.Equal(exchange.ErrSpreadNotFound, err)
+}
+
+func (suite *spreadRepositorySuite) TearDownSuite() {
+	err := utils.DropTable(suite.db, []string{"fx_spreads"})
+	if err != nil {
+		log.Println(err)
+	}
+
+	if err := suite.pool.Purge(suite.resource); err != nil {
+		log.Fatalf("Could not purge resource: %s", err)
+	}
+}
+
+func TestSpreadRepository(t *testing.T) {
+	suite.Run(t, new(spreadRepositorySuite))
+}
@@ -0,0 +1,17 @@
+package exchange
+
+import "avito-intership/models"
+
+const (
+	// BuyDirection - клиент покупает валюту за рубли
+	BuyDirection string = "buy"
+	// SellDirection - клиент продает валюту за рубли
+	SellDirection string = "sell"
+)
+
+type SpreadRepository interface {
+	GetSpreads() ([]*models.Spread, error)
+	// GetSpread возвращает ErrSpreadNotFound, если для валюты и направления правило не задано
+	GetSpread(currency string, direction string) (*models.Spread, error)
+	SetSpread(spread *models.Spread) error
+}
@@ -2,26 +2,269 @@ package usecase
 
 import (
 	"avito-intership/exchange"
-	"math"
+	"avito-intership/models"
+	"log"
+	"math/big"
+	"time"
 )
 
 type Exchanger struct {
 	repository exchange.RateRepository
+	spreads    exchange.SpreadRepository
 }
 
-func NewExchanger(netRepository exchange.RateRepository) *Exchanger {
+func NewExchanger(netRepository exchange.RateRepository, spreads exchange.SpreadRepository) *Exchanger {
 	return &Exchanger{
 		repository: netRepository,
+		spreads:    spreads,
 	}
 }
 
-func (e *Exchanger) ConvertRubles(amount float32, currency string) (float32, error) {
-	rate, err := e.repository.GetRubleRate(currency)
+func (e *Exchanger) ConvertRubles(amount float32, currency string) (*models.Conversion, error) {
+	return e.Convert(amount, exchange.RUB, currency)
+}
+
+func (e *Exchanger) Convert(amount float32, from string, to string) (*models.Conversion, error) {
+	return e.convert(amount, from, to, e.rubleRate, true)
+}
+
+func (e *Exchanger) ConvertAt(amount float32, from string, to string, at time.Time) (*models.Conversion, error) {
+	return e.convert(amount, from, to, func(currency string) (*models.Rate, error) {
+		if currency == exchange.RUB {
+			return e.rubleRate(currency)
+		}
+
+		return e.repository.GetRubleRateAt(currency, at)
+	}, false)
+}
+
+// convert конвертирует по биржевому курсу; при withSpread дополнительно вычисляется курс с учетом
+// действующих спредов: продажи from и покупки to
+func (e *Exchanger) convert(amount float32, from string, to string,
+	rubleRate func(currency string) (*models.Rate, error), withSpread bool) (*models.Conversion, error) {
+	if !exchange.IsSupported(from) || !exchange.IsSupported(to) {
+		return nil, exchange.ErrUnsupportedCurrency
+	}
+
+	// Конвертация в ту же валюту не зависит от курсов и не должна падать при недоступности источника
+	if from == to {
+		now := time.Now()
+		rounded, _ := exchange.Round(exchange.Decimal(amount), to).Float32()
+		return &models.Conversion{
+			Amount:      rounded,
+			From:        from,
+			Currency:    to,
+			AppliedRate: 1,
+			Rate:        &models.Rate{Base: from, Currency: to, Value: 1, UpdatedAt: now, FetchedAt: now},
+		}, nil
+	}
+
+	fromRate, err := rubleRate(from)
+	if err != nil {
+		return nil, err
+	}
+
+	toRate, err := rubleRate(to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Кросс-курс через рубль: цена единицы to в единицах from
+	rate := new(big.Rat).Quo(exchange.RateValue(toRate), exchange.RateValue(fromRate))
+
+	converted := new(big.Rat).Mul(exchange.Decimal(amount), exchange.RateValue(fromRate))
+	converted.Quo(converted, exchange.RateValue(toRate))
+	convertedAmount, _ := exchange.Round(converted, to).Float32()
+
+	applied := rate
+	if withSpread {
+		applied = e.applySpreads(rate, from, to)
+	}
+	appliedValue, _ := applied.Float32()
+
+	crossRate := &models.Rate{
+		Base:      from,
+		Currency:  to,
+		UpdatedAt: older(fromRate.UpdatedAt, toRate.UpdatedAt),
+		FetchedAt: older(fromRate.FetchedAt, toRate.FetchedAt),
+		Stale:     fromRate.Stale || toRate.Stale,
+	}
+	exchange.SetRateValue(crossRate, rate)
+
+	return &models.Conversion{
+		Amount:      convertedAmount,
+		From:        from,
+		Currency:    to,
+		AppliedRate: appliedValue,
+		Rate:        crossRate,
+	}, nil
+}
+
+// applySpreads используется только для отображения, поэтому ошибка получения спреда не прерывает конвертацию
+func (e *Exchanger) applySpreads(rate *big.Rat, from string, to string) *big.Rat {
+	buy, err := e.spread(to, exchange.BuyDirection)
+	if err != nil {
+		log.Println(err)
+		return rate
+	}
+
+	sell, err := e.spread(from, exchange.SellDirection)
+	if err != nil {
+		log.Println(err)
+		return rate
+	}
+
+	applied := new(big.Rat).Mul(rate, spreadFactor(buy, exchange.BuyDirection))
+	return applied.Quo(applied, spreadFactor(sell, exchange.SellDirection))
+}
+
+func (e *Exchanger) Exchange(amount float32, currency string, direction string) (*models.Conversion, error) {
+	if !exchange.IsSupported(currency) {
+		return nil, exchange.ErrUnsupportedCurrency
+	}
+	if direction != exchange.BuyDirection && direction != exchange.SellDirection {
+		return nil, exchange.ErrBadSpread
+	}
+
+	rate, err := e.rubleRate(currency)
+	if err != nil {
+		return nil, err
+	}
+
+	percent, err := e.spread(currency, direction)
+	if err != nil {
+		return nil, err
+	}
+
+	mid := exchange.RateValue(rate)
+	applied := new(big.Rat).Mul(mid, spreadFactor(percent, direction))
+
+	midRubles := exchange.Round(new(big.Rat).Mul(exchange.Decimal(amount), mid), exchange.RUB)
+	appliedRubles := exchange.Round(new(big.Rat).Mul(exchange.Decimal(amount), applied), exchange.RUB)
+	fee := new(big.Rat).Sub(appliedRubles, midRubles)
+
+	appliedRublesValue, _ := appliedRubles.Float32()
+	appliedValue, _ := applied.Float32()
+	feeValue, _ := fee.Abs(fee).Float32()
+
+	return &models.Conversion{
+		Amount:      appliedRublesValue,
+		From:        currency,
+		Currency:    exchange.RUB,
+		Rate:        rate,
+		AppliedRate: appliedValue,
+		Fee:         feeValue,
+	}, nil
+}
+
+// spread возвращает наценку в процентах, для рубля и валют без правила наценка нулевая
+func (e *Exchanger) spread(currency string, direction string) (*big.Rat, error) {
+	if currency == exchange.RUB {
+		return new(big.Rat), nil
+	}
+
+	spread, err := e.spreads.GetSpread(currency, direction)
+	if err == exchange.ErrSpreadNotFound {
+		return new(big.Rat), nil
+	}
 	if err != nil {
-		return 0, err
+		return nil, err
+	}
+
+	return exchange.Decimal(spread.Percent), nil
+}
+
+// spreadFactor - множитель к цене валюты: при покупке клиент платит больше, при продаже получает меньше
+func spreadFactor(percent *big.Rat, direction string) *big.Rat {
+	factor := new(big.Rat).Quo(percent, big.NewRat(100, 1))
+	if direction == exchange.SellDirection {
+		factor.Neg(factor)
+	}
+
+	return factor.Add(factor, big.NewRat(1, 1))
+}
+
+func (e *Exchanger) Spreads() ([]*models.Spread, error) {
+	return e.spreads.GetSpreads()
+}
+
+func (e *Exchanger) SetSpread(spread *models.Spread) error {
+	if !exchange.IsSupported(spread.Currency) || spread.Currency == exchange.RUB {
+		return exchange.ErrUnsupportedCurrency
+	}
+
+	if spread.Direction != exchange.BuyDirection && spread.Direction != exchange.SellDirection {
+		return exchange.ErrBadSpread
+	}
+
+	if spread.Percent < 0 || spread.Percent >= 100 {
+		return exchange.ErrBadSpread
+	}
+
+	return e.spreads.SetSpread(spread)
+}
+
+func (e *Exchanger) Currencies() []*models.Currency {
+	return exchange.Currencies()
+}
+
+// Rates возвращает известные курсы валют, выраженные в base
+func (e *Exchanger) Rates(base string) ([]*models.Rate, error) {
+	if !exchange.IsSupported(base) {
+		return nil, exchange.ErrUnsupportedCurrency
+	}
+
+	rubleRates, err := e.repository.GetRubleRates()
+	if err != nil {
+		return nil, err
+	}
+
+	if base == exchange.RUB {
+		return rubleRates, nil
+	}
+
+	baseRate, err := e.rubleRate(base)
+	if err != nil {
+		return nil, err
+	}
+
+	ruble, _ := e.rubleRate(exchange.RUB)
+	rubleRates = append(rubleRates, ruble)
+
+	rates := make([]*models.Rate, 0, len(rubleRates))
+	for _, rubleRate := range rubleRates {
+		if rubleRate.Currency == base {
+			continue
+		}
+
+		rate := &models.Rate{
+			Base:      base,
+			Currency:  rubleRate.Currency,
+			UpdatedAt: older(rubleRate.UpdatedAt, baseRate.UpdatedAt),
+			FetchedAt: older(rubleRate.FetchedAt, baseRate.FetchedAt),
+			Stale:     rubleRate.Stale || baseRate.Stale,
+			Source:    rubleRate.Source,
+		}
+		exchange.SetRateValue(rate, new(big.Rat).Quo(exchange.RateValue(rubleRate), exchange.RateValue(baseRate)))
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
+
+func (e *Exchanger) rubleRate(currency string) (*models.Rate, error) {
+	if currency == exchange.RUB {
+		now := time.Now()
+		return &models.Rate{Base: exchange.RUB, Currency: exchange.RUB, Value: 1, UpdatedAt: now, FetchedAt: now}, nil
+	}
+
+	return e.repository.GetRubleRate(currency)
+}
+
+func older(a time.Time, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
 	}
-	// Округляем до 2 знаков после запятой
-	converted := float32(math.Round(float64(amount/rate*100)) / 100)
 
-	return converted, nil
+	return b
 }
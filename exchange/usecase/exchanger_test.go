@@ -3,21 +3,35 @@ package usecase
 import (
 	"avito-intership/exchange"
 	"avito-intership/mocks"
+	"avito-intership/models"
+	"errors"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"math/big"
 	"testing"
+	"time"
 )
 
 type exchangeUseCaseSuite struct {
 	suite.Suite
 	repository *mocks.RateRepository
+	spreads    *mocks.SpreadRepository
 	useCase    exchange.Exchanger
 }
 
+func noSpreads() *mocks.SpreadRepository {
+	spreads := new(mocks.SpreadRepository)
+	spreads.On("GetSpread", mock.Anything, mock.Anything).Return(nil, exchange.ErrSpreadNotFound)
+	return spreads
+}
+
 func (suite *exchangeUseCaseSuite) SetupTest() {
 	repository := new(mocks.RateRepository)
-	useCase := NewExchanger(repository)
+	spreads := new(mocks.SpreadRepository)
+	useCase := NewExchanger(repository, spreads)
 
 	suite.repository = repository
+	suite.spreads = spreads
 	suite.useCase = useCase
 }
 
@@ -27,12 +41,252 @@ func (suite *exchangeUseCaseSuite) TestGetBalance_RUB() {
 
 	currency := "USD"
 
-	suite.repository.On("GetRubleRate", currency).Return(rate, nil)
+	suite.repository.On("GetRubleRate", currency).Return(&models.Rate{Currency: currency, Value: rate}, nil)
+
+	suite.spreads.On("GetSpread", currency, exchange.BuyDirection).Return(nil, exchange.ErrSpreadNotFound)
 
 	result, err := suite.useCase.ConvertRubles(amount, currency)
 
 	suite.Nil(err, "no error while converting")
-	suite.Equal(amount / rate, result, )
+	suite.Equal(amount / rate, result.Amount, )
+	suite.Equal(rate, result.Rate.Value)
+}
+
+func (suite *exchangeUseCaseSuite) TestConvert() {
+	rates := map[string]float32{
+		"USD": 73.5,
+		"EUR": 83.2,
+		"JPY": 0.65,
+		"BHD": 195.3,
+		"CHF": 8,
+	}
+
+	cases := []struct {
+		name     string
+		amount   float32
+		from     string
+		to       string
+		expected float32
+		rate     float32
+	}{
+		{name: "rubles to dollars", amount: 100, from: "RUB", to: "USD", expected: 1.36, rate: 73.5},
+		{name: "dollars to rubles", amount: 10, from: "USD", to: "RUB", expected: 735, rate: 1 / 73.5},
+		{name: "cross rate", amount: 10, from: "USD", to: "EUR", expected: 8.83, rate: 83.2 / 73.5},
+		{name: "same currency", amount: 10.005, from: "USD", to: "USD", expected: 10.01, rate: 1},
+		{name: "zero minor units", amount: 1000, from: "RUB", to: "JPY", expected: 1538, rate: 0.65},
+		{name: "three minor units", amount: 100, from: "RUB", to: "BHD", expected: 0.512, rate: 195.3},
+		{name: "cross to three minor units", amount: 1000, from: "JPY", to: "BHD", expected: 3.328, rate: 195.3 / 0.65},
+		{name: "half rounded up", amount: 1, from: "RUB", to: "CHF", expected: 0.13, rate: 8},
+		{name: "negative half rounded away from zero", amount: -1, from: "RUB", to: "CHF", expected: -0.13, rate: 8},
+		{name: "zero amount", amount: 0, from: "USD", to: "JPY", expected: 0, rate: 0.65 / 73.5},
+	}
+
+	for _, c := range cases {
+		suite.Run(c.name, func() {
+			repository := new(mocks.RateRepository)
+			for currency, value := range rates {
+				repository.On("GetRubleRate", currency).
+					Return(&models.Rate{Base: "RUB", Currency: currency, Value: value}, nil)
+			}
+			useCase := NewExchanger(repository, noSpreads())
+
+			result, err := useCase.Convert(c.amount, c.from, c.to)
+
+			suite.NoError(err, "no error while converting")
+			suite.Equal(c.expected, result.Amount)
+			suite.Equal(c.from, result.From)
+			suite.Equal(c.to, result.Currency)
+			suite.InDelta(c.rate, result.Rate.Value, 1e-6)
+			repository.AssertNotCalled(suite.T(), "GetRubleRate", "RUB")
+		})
+	}
+}
+
+func (suite *exchangeUseCaseSuite) TestConvert_SameCurrency() {
+	result, err := suite.useCase.Convert(10, "USD", "USD")
+
+	suite.NoError(err)
+	suite.Equal(float32(10), result.Amount)
+	suite.Equal(float32(1), result.Rate.Value)
+	suite.Equal(float32(1), result.AppliedRate)
+	suite.repository.AssertNotCalled(suite.T(), "GetRubleRate", mock.Anything)
+}
+
+func (suite *exchangeUseCaseSuite) TestConvert_ExactRate() {
+	// 1/3 рубля за единицу не представима во float32: конвертация должна идти по точному значению
+	exact := big.NewRat(1, 3)
+	rate := &models.Rate{Currency: "JPY"}
+	exchange.SetRateValue(rate, exact)
+	suite.repository.On("GetRubleRate", "JPY").Return(rate, nil)
+	suite.spreads.On("GetSpread", mock.Anything, mock.Anything).Return(nil, exchange.ErrSpreadNotFound)
+
+	result, err := suite.useCase.Convert(3000000, "JPY", "RUB")
+
+	suite.NoError(err)
+	suite.Equal(float32(1000000), result.Amount)
+	suite.Equal(0, big.NewRat(3, 1).Cmp(result.Rate.Exact))
+}
+
+func (suite *exchangeUseCaseSuite) TestConvert_RateError() {
+	rateErr := errors.New("upstream error")
+	suite.repository.On("GetRubleRate", "USD").Return(nil, rateErr)
+
+	_, err := suite.useCase.Convert(10, "USD", "RUB")
+
+	suite.Equal(rateErr, err)
+}
+
+func (suite *exchangeUseCaseSuite) TestConvert_StaleLeg() {
+	suite.repository.On("GetRubleRate", "USD").Return(&models.Rate{Currency: "USD", Value: 73.5}, nil)
+	suite.repository.On("GetRubleRate", "EUR").Return(&models.Rate{Currency: "EUR", Value: 83.2, Stale: true}, nil)
+	suite.spreads.On("GetSpread", mock.Anything, mock.Anything).Return(nil, exchange.ErrSpreadNotFound)
+
+	result, err := suite.useCase.Convert(10, "USD", "EUR")
+
+	suite.NoError(err)
+	suite.True(result.Rate.Stale, "conversion is stale if any leg is stale")
+}
+
+func (suite *exchangeUseCaseSuite) TestConvert_UnsupportedCurrency() {
+	_, err := suite.useCase.Convert(10, "RUB", "XYZ")
+
+	suite.Equal(exchange.ErrUnsupportedCurrency, err)
+	suite.repository.AssertNotCalled(suite.T(), "GetRubleRate", "XYZ")
+}
+
+func (suite *exchangeUseCaseSuite) TestConvertAt() {
+	at := time.Date(2021, 11, 15, 12, 0, 0, 0, time.UTC)
+	suite.repository.On("GetRubleRateAt", "USD", at).Return(&models.Rate{Currency: "USD", Value: 72.5}, nil)
+
+	result, err := suite.useCase.ConvertAt(145, "RUB", "USD", at)
+
+	suite.NoError(err)
+	suite.Equal(float32(2), result.Amount)
+	suite.repository.AssertNotCalled(suite.T(), "GetRubleRate", "USD")
+}
+
+func (suite *exchangeUseCaseSuite) TestConvert_AppliedRate() {
+	suite.repository.On("GetRubleRate", "USD").Return(&models.Rate{Currency: "USD", Value: 80}, nil)
+	suite.spreads.On("GetSpread", "USD", exchange.BuyDirection).
+		Return(&models.Spread{Currency: "USD", Direction: exchange.BuyDirection, Percent: 2.5}, nil)
+
+	result, err := suite.useCase.Convert(820, "RUB", "USD")
+
+	suite.NoError(err)
+	suite.Equal(float32(10.25), result.Amount, "amount is converted at the mid rate")
+	suite.Equal(float32(80), result.Rate.Value)
+	suite.Equal(float32(82), result.AppliedRate)
+}
+
+func (suite *exchangeUseCaseSuite) TestExchange() {
+	cases := []struct {
+		name      string
+		direction string
+		percent   float32
+		rubles    float32
+		applied   float32
+		fee       float32
+	}{
+		{name: "buy", direction: exchange.BuyDirection, percent: 1.5, rubles: 1015, applied: 81.2, fee: 15},
+		{name: "sell", direction: exchange.SellDirection, percent: 2, rubles: 980, applied: 78.4, fee: 20},
+		{name: "no spread", direction: exchange.SellDirection, percent: 0, rubles: 1000, applied: 80, fee: 0},
+	}
+
+	for _, c := range cases {
+		suite.Run(c.name, func() {
+			repository := new(mocks.RateRepository)
+			repository.On("GetRubleRate", "USD").Return(&models.Rate{Currency: "USD", Value: 80}, nil)
+			spreads := new(mocks.SpreadRepository)
+			spreads.On("GetSpread", "USD", c.direction).
+				Return(&models.Spread{Currency: "USD", Direction: c.direction, Percent: c.percent}, nil)
+
+			result, err := NewExchanger(repository, spreads).Exchange(12.5, "USD", c.direction)
+
+			suite.NoError(err)
+			suite.Equal(c.rubles, result.Amount)
+			suite.Equal(exchange.RUB, result.Currency)
+			suite.Equal(float32(80), result.Rate.Value)
+			suite.InDelta(c.applied, result.AppliedRate, 1e-4)
+			suite.Equal(c.fee, result.Fee)
+		})
+	}
+}
+
+func (suite *exchangeUseCaseSuite) TestExchange_SpreadError() {
+	spreadErr := errors.New("db error")
+	suite.repository.On("GetRubleRate", "USD").Return(&models.Rate{Currency: "USD", Value: 80}, nil)
+	suite.spreads.On("GetSpread", "USD", exchange.BuyDirection).Return(nil, spreadErr)
+
+	_, err := suite.useCase.Exchange(10, "USD", exchange.BuyDirection)
+
+	suite.Equal(spreadErr, err)
+}
+
+func (suite *exchangeUseCaseSuite) TestSetSpread() {
+	cases := []struct {
+		name   string
+		spread *models.Spread
+		err    error
+	}{
+		{name: "valid", spread: &models.Spread{Currency: "USD", Direction: exchange.BuyDirection, Percent: 1.5}},
+		{name: "ruble", spread: &models.Spread{Currency: "RUB", Direction: exchange.BuyDirection, Percent: 1},
+			err: exchange.ErrUnsupportedCurrency},
+		{name: "unknown direction", spread: &models.Spread{Currency: "USD", Direction: "hold", Percent: 1},
+			err: exchange.ErrBadSpread},
+		{name: "negative percent", spread: &models.Spread{Currency: "USD", Direction: exchange.SellDirection, Percent: -1},
+			err: exchange.ErrBadSpread},
+		{name: "too large percent", spread: &models.Spread{Currency: "USD", Direction: exchange.SellDirection, Percent: 100},
+			err: exchange.ErrBadSpread},
+	}
+
+	for _, c := range cases {
+		suite.Run(c.name, func() {
+			spreads := new(mocks.SpreadRepository)
+			spreads.On("SetSpread", c.spread).Return(nil)
+
+			err := NewExchanger(new(mocks.RateRepository), spreads).SetSpread(c.spread)
+
+			suite.Equal(c.err, err)
+			if c.err != nil {
+				spreads.AssertNotCalled(suite.T(), "SetSpread", c.spread)
+			}
+		})
+	}
+}
+
+func (suite *exchangeUseCaseSuite) TestRates_RUB() {
+	rates := []*models.Rate{{Base: "RUB", Currency: "USD", Value: 73.5, Source: "test"}}
+	suite.repository.On("GetRubleRates").Return(rates, nil)
+
+	result, err := suite.useCase.Rates("RUB")
+
+	suite.NoError(err)
+	suite.Equal(rates, result)
+}
+
+func (suite *exchangeUseCaseSuite) TestRates_Rebase() {
+	rates := []*models.Rate{
+		{Base: "RUB", Currency: "USD", Value: 80, Source: "test"},
+		{Base: "RUB", Currency: "EUR", Value: 100, Source: "test"},
+	}
+	suite.repository.On("GetRubleRates").Return(rates, nil)
+	suite.repository.On("GetRubleRate", "USD").Return(rates[0], nil)
+
+	result, err := suite.useCase.Rates("USD")
+
+	suite.NoError(err)
+	values := make(map[string]float32)
+	for _, rate := range result {
+		suite.Equal("USD", rate.Base)
+		values[rate.Currency] = rate.Value
+	}
+	suite.Equal(map[string]float32{"EUR": 1.25, "RUB": 0.0125}, values)
+}
+
+func (suite *exchangeUseCaseSuite) TestRates_UnsupportedBase() {
+	_, err := suite.useCase.Rates("XYZ")
+
+	suite.Equal(exchange.ErrUnsupportedCurrency, err)
 }
 
 func TestBalanceUseCase(t *testing.T) {
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"avito-intership/exchange"
+	"avito-intership/models"
+)
+
+type OverrideUseCase struct {
+	repository exchange.OverrideRepository
+}
+
+func NewOverrideUseCase(repository exchange.OverrideRepository) *OverrideUseCase {
+	return &OverrideUseCase{
+		repository: repository,
+	}
+}
+
+func validateOverride(override *models.RateOverride, operator string, reason string) error {
+	if operator == "" || reason == "" {
+		return exchange.ErrNoOperator
+	}
+
+	if override.ValidTo != nil && !override.ValidTo.After(override.ValidFrom) {
+		return exchange.ErrBadOverridePeriod
+	}
+
+	return nil
+}
+
+func (u OverrideUseCase) CreateOverride(override *models.RateOverride, operator string, reason string) (*models.RateOverride, error) {
+	if !exchange.IsSupported(override.Currency) || override.Currency == exchange.RUB {
+		return nil, exchange.ErrUnsupportedCurrency
+	}
+
+	if err := validateOverride(override, operator, reason); err != nil {
+		return nil, err
+	}
+
+	return u.repository.CreateOverride(override, operator, reason)
+}
+
+func (u OverrideUseCase) UpdateOverride(override *models.RateOverride, operator string, reason string) (*models.RateOverride, error) {
+	if err := validateOverride(override, operator, reason); err != nil {
+		return nil, err
+	}
+
+	return u.repository.UpdateOverride(override, operator, reason)
+}
+
+func (u OverrideUseCase) RevokeOverride(id int64, operator string, reason string) error {
+	if operator == "" || reason == "" {
+		return exchange.ErrNoOperator
+	}
+
+	return u.repository.RevokeOverride(id, operator, reason)
+}
+
+func (u OverrideUseCase) GetOverrides(currency string) ([]*models.RateOverride, error) {
+	return u.repository.GetOverrides(currency)
+}
+
+func (u OverrideUseCase) GetOverrideLog(id int64) ([]*models.RateOverrideChange, error) {
+	if _, err := u.repository.GetOverride(id); err != nil {
+		return nil, err
+	}
+
+	return u.repository.GetOverrideLog(id)
+}
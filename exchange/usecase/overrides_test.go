@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"avito-intership/exchange"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type overrideUseCaseSuite struct {
+	suite.Suite
+	repository *mocks.OverrideRepository
+	useCase    exchange.OverrideUseCase
+}
+
+func (suite *overrideUseCaseSuite) SetupTest() {
+	repository := new(mocks.OverrideRepository)
+
+	suite.repository = repository
+	suite.useCase = NewOverrideUseCase(repository)
+}
+
+func (suite *overrideUseCaseSuite) TestCreateOverride_Ok() {
+	validTo := time.Now().Add(72 * time.Hour)
+	override := &models.RateOverride{Currency: "USD", Rate: 90, ValidFrom: time.Now(), ValidTo: &validTo}
+	created := &models.RateOverride{Id: 1, Currency: "USD", Rate: 90, ValidFrom: override.ValidFrom, ValidTo: &validTo}
+
+	suite.repository.On("CreateOverride", override, "finance", "settlement").Return(created, nil)
+
+	result, err := suite.useCase.CreateOverride(override, "finance", "settlement")
+
+	suite.NoError(err)
+	suite.Equal(created, result)
+}
+
+func (suite *overrideUseCaseSuite) TestCreateOverride_Invalid() {
+	now := time.Now()
+	before := now.Add(-time.Hour)
+
+	cases := []struct {
+		name     string
+		override *models.RateOverride
+		operator string
+		reason   string
+		err      error
+	}{
+		{name: "unsupported currency", override: &models.RateOverride{Currency: "XYZ", Rate: 1, ValidFrom: now},
+			operator: "finance", reason: "test", err: exchange.ErrUnsupportedCurrency},
+		{name: "ruble", override: &models.RateOverride{Currency: "RUB", Rate: 1, ValidFrom: now},
+			operator: "finance", reason: "test", err: exchange.ErrUnsupportedCurrency},
+		{name: "no operator", override: &models.RateOverride{Currency: "USD", Rate: 1, ValidFrom: now},
+			reason: "test", err: exchange.ErrNoOperator},
+		{name: "no reason", override: &models.RateOverride{Currency: "USD", Rate: 1, ValidFrom: now},
+			operator: "finance", err: exchange.ErrNoOperator},
+		{name: "ends before start", override: &models.RateOverride{Currency: "USD", Rate: 1, ValidFrom: now, ValidTo: &before},
+			operator: "finance", reason: "test", err: exchange.ErrBadOverridePeriod},
+	}
+
+	for _, c := range cases {
+		suite.Run(c.name, func() {
+			_, err := suite.useCase.CreateOverride(c.override, c.operator, c.reason)
+
+			suite.Equal(c.err, err)
+		})
+	}
+	suite.repository.AssertNotCalled(suite.T(), "CreateOverride")
+}
+
+func (suite *overrideUseCaseSuite) TestRevokeOverride() {
+	var id int64 = 1
+	suite.repository.On("RevokeOverride", id, "finance", "upstream is back").Return(nil)
+
+	err := suite.useCase.RevokeOverride(id, "finance", "upstream is back")
+
+	suite.NoError(err)
+}
+
+func (suite *overrideUseCaseSuite) TestGetOverrideLog_NotFound() {
+	var id int64 = 1
+	suite.repository.On("GetOverride", id).Return(nil, exchange.ErrOverrideNotFound)
+
+	_, err := suite.useCase.GetOverrideLog(id)
+
+	suite.Equal(exchange.ErrOverrideNotFound, err)
+}
+
+func TestOverrideUseCase(t *testing.T) {
+	suite.Run(t, new(overrideUseCaseSuite))
+}
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/auth"
+	"avito-intership/balance"
+	"avito-intership/fraud"
+	"avito-intership/problem"
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type AdminHandler struct {
+	useCase fraud.ReviewUseCase
+}
+
+func NewAdminHandler(useCase fraud.ReviewUseCase) *AdminHandler {
+	return &AdminHandler{
+		useCase: useCase,
+	}
+}
+
+type StatusMessage struct {
+	Success bool    `json:"success"`
+	Message *string `json:"message"`
+}
+
+func (h AdminHandler) writeStatus(success bool, message *string, w *http.ResponseWriter) {
+	status := StatusMessage{
+		Success: success,
+		Message: message,
+	}
+
+	(*w).Header().Add("Content-Type", "application/json")
+	_ = json.NewEncoder(*w).Encode(status)
+}
+
+func (h AdminHandler) writeJSON(value interface{}, w http.ResponseWriter) {
+	w.Header().Add("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		problem.Write(w, err)
+	}
+}
+
+// writeError отвечает 202, если решение по проверке отправило перевод на одобрение, иначе - ошибкой
+func (h AdminHandler) writeError(err error, w http.ResponseWriter) {
+	var approvalErr *balance.ApprovalRequiredError
+	if errors.As(err, &approvalErr) {
+		message := err.Error()
+		w.WriteHeader(http.StatusAccepted)
+		h.writeStatus(false, &message, &w)
+		return
+	}
+
+	problem.Write(w, err)
+}
+
+func (h AdminHandler) parseId(r *http.Request, w http.ResponseWriter) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil || id <= 0 {
+		problem.Write(w, apperror.BadArgument("id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+// GetReviewsEndpoint по умолчанию возвращает очередь ожидающих решения переводов
+func (h AdminHandler) GetReviewsEndpoint(w http.ResponseWriter, r *http.Request) {
+	status := r.FormValue("status")
+	switch status {
+	case "":
+		status = fraud.ReviewPending
+	case "all":
+		status = ""
+	case fraud.ReviewPending, fraud.ReviewApproved, fraud.ReviewRejected:
+	default:
+		problem.Write(w, apperror.BadArgument("status"))
+		return
+	}
+
+	reviews, err := h.useCase.GetReviews(status)
+	if err != nil {
+		h.writeError(err, w)
+		return
+	}
+
+	h.writeJSON(reviews, w)
+}
+
+func (h AdminHandler) GetReviewEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	review, err := h.useCase.GetReview(id)
+	if err != nil {
+		h.writeError(err, w)
+		return
+	}
+
+	h.writeJSON(review, w)
+}
+
+func (h AdminHandler) ApproveEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	operator, err := auth.Operator(r.Context())
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	review, err := h.useCase.Approve(id, operator, r.FormValue("note"))
+	if err != nil {
+		h.writeError(err, w)
+		return
+	}
+
+	h.writeJSON(review, w)
+}
+
+func (h AdminHandler) RejectEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	operator, err := auth.Operator(r.Context())
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	review, err := h.useCase.Reject(id, operator, r.FormValue("note"))
+	if err != nil {
+		h.writeError(err, w)
+		return
+	}
+
+	h.writeJSON(review, w)
+}
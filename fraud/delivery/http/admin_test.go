@@ -0,0 +1,168 @@
+package http
+
+import (
+	"avito-intership/auth"
+	"avito-intership/balance"
+	"avito-intership/fraud"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"avito-intership/server/middleware"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+type reviewHandlerSuite struct {
+	suite.Suite
+
+	useCase       *mocks.ReviewUseCase
+	testingServer *httptest.Server
+}
+
+func (suite *reviewHandlerSuite) SetupSuite() {
+	useCase := new(mocks.ReviewUseCase)
+
+	router := mux.NewRouter()
+	router.Use(middleware.WithPrincipal(&models.Principal{Service: "alice", Scopes: []string{auth.ScopeOperator}}))
+	RegisterAdminEndpoints(router.PathPrefix("/api/v1/admin").Subrouter(), useCase)
+
+	suite.testingServer = httptest.NewServer(router)
+	suite.useCase = useCase
+}
+
+func (suite *reviewHandlerSuite) TearDownSuite() {
+	defer suite.testingServer.Close()
+}
+
+func (suite *reviewHandlerSuite) TestGetReviews_PendingByDefault() {
+	suite.useCase.On("GetReviews", fraud.ReviewPending).
+		Return([]*models.TransferReview{{Id: 1, Status: fraud.ReviewPending}}, nil)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/admin/reviews", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody []*models.TransferReview
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Len(responseBody, 1)
+}
+
+func (suite *reviewHandlerSuite) TestGetReviews_All() {
+	suite.useCase.On("GetReviews", "").Return([]*models.TransferReview{}, nil)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/admin/reviews?status=all", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+}
+
+func (suite *reviewHandlerSuite) TestGetReviews_BadStatus() {
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/admin/reviews?status=lost", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *reviewHandlerSuite) TestGetReview_NotFound() {
+	suite.useCase.On("GetReview", int64(404)).Return(nil, fraud.ErrReviewNotFound)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/admin/reviews/404", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusNotFound, response.StatusCode)
+}
+
+func (suite *reviewHandlerSuite) TestApprove() {
+	operator := "alice"
+	suite.useCase.On("Approve", int64(1), "alice", "checked").
+		Return(&models.TransferReview{Id: 1, Status: fraud.ReviewApproved, Operator: &operator}, nil)
+
+	// Оператор определяется ключом, имя из параметров запроса не учитывается
+	data := url.Values{}
+	data.Set("operator", "bob")
+	data.Set("note", "checked")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/reviews/1/approve", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.TransferReview
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(fraud.ReviewApproved, responseBody.Status)
+	suite.Equal(operator, *responseBody.Operator)
+}
+
+func (suite *reviewHandlerSuite) TestApprove_LowBalance() {
+	suite.useCase.On("Approve", int64(2), "alice", "").Return(nil, balance.ErrTooLowBalance)
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/reviews/2/approve", suite.testingServer.URL),
+		url.Values{})
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusConflict, response.StatusCode)
+}
+
+func (suite *reviewHandlerSuite) TestReject_Decided() {
+	suite.useCase.On("Reject", int64(3), "alice", mock.Anything).Return(nil, fraud.ErrReviewDecided)
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/reviews/3/reject", suite.testingServer.URL),
+		url.Values{})
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusConflict, response.StatusCode)
+}
+
+func (suite *reviewHandlerSuite) TestDecide_NoOperator() {
+	cases := []struct {
+		principal *models.Principal
+		status    int
+	}{
+		{principal: nil, status: http.StatusUnauthorized},
+		{principal: &models.Principal{UserId: 1, Scopes: auth.UserScopes}, status: http.StatusForbidden},
+		{principal: &models.Principal{Service: "billing", Scopes: []string{auth.ScopeDebit}}, status: http.StatusForbidden},
+	}
+
+	for _, c := range cases {
+		useCase := new(mocks.ReviewUseCase)
+		router := mux.NewRouter()
+		router.Use(middleware.WithPrincipal(c.principal))
+		RegisterAdminEndpoints(router.PathPrefix("/api/v1/admin").Subrouter(), useCase)
+		server := httptest.NewServer(router)
+
+		for _, action := range []string{"approve", "reject"} {
+			data := url.Values{}
+			data.Set("operator", "alice")
+
+			response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/reviews/4/%s", server.URL, action), data)
+			suite.NoError(err, "request should not produce error")
+			response.Body.Close()
+
+			suite.Equal(c.status, response.StatusCode)
+		}
+
+		server.Close()
+		useCase.AssertNotCalled(suite.T(), "Approve", mock.Anything, mock.Anything, mock.Anything)
+		useCase.AssertNotCalled(suite.T(), "Reject", mock.Anything, mock.Anything, mock.Anything)
+	}
+}
+
+func TestReviewHandler(t *testing.T) {
+	suite.Run(t, new(reviewHandlerSuite))
+}
@@ -0,0 +1,21 @@
+package http
+
+import (
+	"avito-intership/fraud"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// RegisterAdminEndpoints регистрирует очередь ручной проверки переводов, router - подмаршрутизатор /api/v1/admin
+func RegisterAdminEndpoints(router *mux.Router, uc fraud.ReviewUseCase) {
+	handler := NewAdminHandler(uc)
+
+	router.HandleFunc("/reviews", handler.GetReviewsEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/reviews/{id:[0-9]+}", handler.GetReviewEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/reviews/{id:[0-9]+}/approve", handler.ApproveEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/reviews/{id:[0-9]+}/reject", handler.RejectEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+}
@@ -0,0 +1,9 @@
+package fraud
+
+import "avito-intership/apperror"
+
+var (
+	ErrReviewNotFound = apperror.New(apperror.NotFound, "review not found")
+	ErrReviewDecided  = apperror.New(apperror.Conflict, "review is already decided")
+	ErrNoOperator     = apperror.WithParam(apperror.InvalidArgument, "operator", "operator must be specified")
+)
@@ -0,0 +1,18 @@
+package fraud
+
+import "avito-intership/models"
+
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+	ReviewRejected = "rejected"
+)
+
+type ReviewRepository interface {
+	CreateReview(review *models.TransferReview) (*models.TransferReview, error)
+	GetReview(id int64) (*models.TransferReview, error)
+	// GetReviews возвращает проверки в статусе status, пустой status - все проверки
+	GetReviews(status string) ([]*models.TransferReview, error)
+	// SetStatus меняет статус, только если проверка находится в статусе from
+	SetStatus(id int64, from string, to string, operator *string, note *string) (*models.TransferReview, error)
+}
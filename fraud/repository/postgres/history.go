@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"avito-intership/balance"
+	"database/sql"
+	"time"
+)
+
+// TransferHistoryRepository считает показатели для правил антифрода по выполненным операциям
+type TransferHistoryRepository struct {
+	db *sql.DB
+}
+
+func NewTransferHistoryRepository(dbConn *sql.DB) *TransferHistoryRepository {
+	return &TransferHistoryRepository{dbConn}
+}
+
+func (r TransferHistoryRepository) AccountAge(userId int64) (*time.Duration, error) {
+	var seconds sql.NullFloat64
+	err := r.db.QueryRow(
+		"SELECT EXTRACT(EPOCH FROM NOW() - MIN(date)) FROM transactions WHERE user_id = $1 AND status = 'completed'",
+		userId).Scan(&seconds)
+	if err != nil {
+		return nil, err
+	}
+
+	if !seconds.Valid {
+		return nil, nil
+	}
+
+	age := time.Duration(seconds.Float64 * float64(time.Second))
+	return &age, nil
+}
+
+func (r TransferHistoryRepository) CountSenders(userId int64, window time.Duration) (int64, error) {
+	var senders int64
+	err := r.db.QueryRow(
+		`SELECT COUNT(DISTINCT target_id) FROM transactions
+		WHERE user_id = $1 AND type = $2 AND status = 'completed' AND amount > 0 AND target_id > 0
+			AND date >= NOW() - $3::float8 * INTERVAL '1 second'`,
+		userId, balance.TransferType, window.Seconds()).Scan(&senders)
+	return senders, err
+}
+
+func (r TransferHistoryRepository) HasTransfer(srcUserId int64, dstUserId int64, window time.Duration) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM transactions
+		WHERE user_id = $1 AND target_id = $2 AND type = $3 AND status = 'completed' AND amount < 0
+			AND date >= NOW() - $4::float8 * INTERVAL '1 second')`,
+		srcUserId, dstUserId, balance.TransferType, window.Seconds()).Scan(&exists)
+	return exists, err
+}
+
+func (r TransferHistoryRepository) OutgoingStats(userId int64) (float32, int64, error) {
+	var average float32
+	var count int64
+	err := r.db.QueryRow(
+		`SELECT COALESCE(AVG(-amount), 0), COUNT(*) FROM transactions
+		WHERE user_id = $1 AND type = $2 AND status = 'completed' AND amount < 0`,
+		userId, balance.TransferType).Scan(&average, &count)
+	return average, count, err
+}
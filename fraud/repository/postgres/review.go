@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"avito-intership/fraud"
+	"avito-intership/models"
+	"database/sql"
+)
+
+type ReviewRepository struct {
+	db *sql.DB
+}
+
+func NewReviewRepository(dbConn *sql.DB) *ReviewRepository {
+	return &ReviewRepository{dbConn}
+}
+
+const reviewColumns = `id, src_id, dst_id, amount, fee_percent, fee_min, fee_fixed, rule, reason, status,
+	operator, note, created_at, decided_at`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReview(row scanner) (*models.TransferReview, error) {
+	var review models.TransferReview
+	var feePercent, feeMin, feeFixed sql.NullFloat64
+	var operator, note sql.NullString
+	var decidedAt sql.NullTime
+
+	err := row.Scan(&review.Id, &review.SrcId, &review.DstId, &review.Amount, &feePercent, &feeMin, &feeFixed,
+		&review.Rule, &review.Reason, &review.Status, &operator, &note, &review.CreatedAt, &decidedAt)
+	if err == sql.ErrNoRows {
+		return nil, fraud.ErrReviewNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Комиссия хранится тремя колонками, заполненными только вместе
+	if feePercent.Valid {
+		review.Commission = &models.Commission{
+			Percent: float32(feePercent.Float64),
+			Min:     float32(feeMin.Float64),
+			Fixed:   float32(feeFixed.Float64),
+		}
+	}
+	if operator.Valid {
+		review.Operator = &operator.String
+	}
+	if note.Valid {
+		review.Note = &note.String
+	}
+	if decidedAt.Valid {
+		review.DecidedAt = &decidedAt.Time
+	}
+
+	return &review, nil
+}
+
+func (r ReviewRepository) CreateReview(review *models.TransferReview) (*models.TransferReview, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	var feePercent, feeMin, feeFixed interface{}
+	if review.Commission != nil {
+		feePercent, feeMin, feeFixed = review.Commission.Percent, review.Commission.Min, review.Commission.Fixed
+	}
+
+	row := tx.QueryRow(
+		`INSERT INTO transfer_reviews (src_id, dst_id, amount, fee_percent, fee_min, fee_fixed, rule, reason)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING `+reviewColumns,
+		review.SrcId, review.DstId, review.Amount, feePercent, feeMin, feeFixed, review.Rule, review.Reason)
+	created, err := scanReview(row)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, share := range review.Shares {
+		_, err = tx.Exec("INSERT INTO transfer_review_shares (review_id, user_id, share) VALUES ($1, $2, $3)",
+			created.Id, share.UserId, share.Share)
+		if err != nil {
+			return nil, err
+		}
+	}
+	created.Shares = review.Shares
+
+	return created, nil
+}
+
+// loadShares загружает доли получателей разделенного платежа, у обычного перевода их нет
+func (r ReviewRepository) loadShares(review *models.TransferReview) error {
+	rows, err := r.db.Query("SELECT user_id, share FROM transfer_review_shares WHERE review_id = $1", review.Id)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var share models.Share
+		err = rows.Scan(&share.UserId, &share.Share)
+		if err != nil {
+			return err
+		}
+
+		review.Shares = append(review.Shares, &share)
+	}
+
+	return rows.Err()
+}
+
+func (r ReviewRepository) GetReview(id int64) (*models.TransferReview, error) {
+	row := r.db.QueryRow("SELECT "+reviewColumns+" FROM transfer_reviews WHERE id = $1", id)
+	review, err := scanReview(row)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.loadShares(review); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (r ReviewRepository) GetReviews(status string) ([]*models.TransferReview, error) {
+	rows, err := r.db.Query(
+		`SELECT `+reviewColumns+` FROM transfer_reviews
+		WHERE $1 = '' OR status::text = $1 ORDER BY created_at, id`, status)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := make([]*models.TransferReview, 0)
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		reviews = append(reviews, review)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, review := range reviews {
+		if err = r.loadShares(review); err != nil {
+			return nil, err
+		}
+	}
+
+	return reviews, nil
+}
+
+// SetStatus меняет статус условным обновлением, поэтому из двух одновременных решений по проверке
+// применится одно. Возврат в pending очищает решение
+func (r ReviewRepository) SetStatus(id int64, from string, to string, operator *string,
+	note *string) (*models.TransferReview, error) {
+	row := r.db.QueryRow(
+		`UPDATE transfer_reviews SET status = $3::review_status, operator = $4, note = $5,
+			decided_at = CASE WHEN $3::review_status = 'pending' THEN NULL ELSE NOW() END
+		WHERE id = $1 AND status = $2::review_status
+		RETURNING `+reviewColumns,
+		id, from, to, operator, note)
+	review, err := scanReview(row)
+	if err == fraud.ErrReviewNotFound {
+		// Отличаем несуществующую проверку от уже решенной
+		if _, err = r.GetReview(id); err != nil {
+			return nil, err
+		}
+		return nil, fraud.ErrReviewDecided
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err = r.loadShares(review); err != nil {
+		return nil, err
+	}
+
+	return review, nil
+}
@@ -0,0 +1,159 @@
+package postgres
+
+import (
+	"avito-intership/balance"
+	balancePostgres "avito-intership/balance/repository/postgres"
+	"avito-intership/fraud"
+	"avito-intership/models"
+	"avito-intership/utils"
+	"database/sql"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/suite"
+	"log"
+	"testing"
+	"time"
+)
+
+type reviewRepositorySuite struct {
+	suite.Suite
+
+	db       *sql.DB
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+
+	repository fraud.ReviewRepository
+	history    fraud.TransferHistoryRepository
+	balance    balance.Repository
+}
+
+func (suite *reviewRepositorySuite) SetupSuite() {
+	db, pool, resource := utils.DockerDBUp()
+	err := utils.InitTable(db, "../../../init.sql")
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	suite.repository = NewReviewRepository(db)
+	suite.history = NewTransferHistoryRepository(db)
+	suite.balance = balancePostgres.NewBalanceRepository(db)
+	suite.db = db
+	suite.pool = pool
+	suite.resource = resource
+}
+
+func (suite *reviewRepositorySuite) TestCreateAndDecide() {
+	commission := &models.Commission{Percent: 1, Min: 5, Fixed: 0}
+	created, err := suite.repository.CreateReview(&models.TransferReview{SrcId: 1, DstId: 2, Amount: 5000,
+		Commission: commission, Rule: "amount_above_average", Reason: "too much"})
+	suite.NoError(err, "creating review should not produce error")
+	suite.Equal(fraud.ReviewPending, created.Status)
+	suite.Equal(commission, created.Commission)
+	suite.Nil(created.DecidedAt)
+
+	pending, err := suite.repository.GetReviews(fraud.ReviewPending)
+	suite.NoError(err, "getting reviews should not produce error")
+	suite.Equal(created.Id, pending[len(pending)-1].Id)
+
+	operator := "alice"
+	approved, err := suite.repository.SetStatus(created.Id, fraud.ReviewPending, fraud.ReviewApproved, &operator, nil)
+	suite.NoError(err, "approving review should not produce error")
+	suite.Equal(fraud.ReviewApproved, approved.Status)
+	suite.Equal(operator, *approved.Operator)
+	suite.NotNil(approved.DecidedAt)
+
+	_, err = suite.repository.SetStatus(created.Id, fraud.ReviewPending, fraud.ReviewRejected, &operator, nil)
+	suite.Equal(fraud.ErrReviewDecided, err)
+
+	reverted, err := suite.repository.SetStatus(created.Id, fraud.ReviewApproved, fraud.ReviewPending, nil, nil)
+	suite.NoError(err, "reverting review should not produce error")
+	suite.Nil(reverted.Operator)
+	suite.Nil(reverted.DecidedAt)
+}
+
+func (suite *reviewRepositorySuite) TestSplitPaymentShares() {
+	shares := []*models.Share{{UserId: 6, Share: 0.25}, {UserId: 7, Share: 0.75}}
+	created, err := suite.repository.CreateReview(&models.TransferReview{SrcId: 5, DstId: 6, Amount: 4000,
+		Shares: shares, Rule: "amount_above_average", Reason: "too much"})
+	suite.NoError(err, "creating review should not produce error")
+	suite.Equal(shares, created.Shares)
+
+	stored, err := suite.repository.GetReview(created.Id)
+	suite.NoError(err, "getting review should not produce error")
+	suite.Equal(shares, stored.Shares)
+
+	operator := "alice"
+	approved, err := suite.repository.SetStatus(created.Id, fraud.ReviewPending, fraud.ReviewApproved, &operator, nil)
+	suite.NoError(err, "approving review should not produce error")
+	suite.Equal(shares, approved.Shares, "approved split payment keeps its shares")
+}
+
+func (suite *reviewRepositorySuite) TestWithoutCommission() {
+	created, err := suite.repository.CreateReview(&models.TransferReview{SrcId: 3, DstId: 4, Amount: 100,
+		Rule: "round_trip", Reason: "returned"})
+	suite.NoError(err, "creating review should not produce error")
+
+	review, err := suite.repository.GetReview(created.Id)
+	suite.NoError(err, "getting review should not produce error")
+	suite.Nil(review.Commission)
+}
+
+func (suite *reviewRepositorySuite) TestNotFound() {
+	_, err := suite.repository.GetReview(100500)
+	suite.Equal(fraud.ErrReviewNotFound, err)
+
+	operator := "alice"
+	_, err = suite.repository.SetStatus(100500, fraud.ReviewPending, fraud.ReviewApproved, &operator, nil)
+	suite.Equal(fraud.ErrReviewNotFound, err)
+}
+
+func (suite *reviewRepositorySuite) TestHistory() {
+	var src int64 = 101
+	var dst int64 = 102
+	err := suite.balance.ChangeBalance(src, 1000, 0)
+	suite.NoError(err, "filling balance should not produce error")
+
+	age, err := suite.history.AccountAge(dst)
+	suite.NoError(err, "getting account age should not produce error")
+	suite.Nil(age)
+
+	err = suite.balance.TransferMoney(src, dst, 100)
+	suite.NoError(err, "transfer should not produce error")
+	err = suite.balance.TransferMoney(src, dst, 300)
+	suite.NoError(err, "transfer should not produce error")
+
+	age, err = suite.history.AccountAge(dst)
+	suite.NoError(err, "getting account age should not produce error")
+	suite.Less(*age, time.Minute)
+
+	senders, err := suite.history.CountSenders(dst, time.Hour)
+	suite.NoError(err, "counting senders should not produce error")
+	suite.Equal(int64(1), senders)
+
+	sent, err := suite.history.HasTransfer(src, dst, time.Hour)
+	suite.NoError(err, "checking transfer should not produce error")
+	suite.True(sent)
+
+	returned, err := suite.history.HasTransfer(dst, src, time.Hour)
+	suite.NoError(err, "checking transfer should not produce error")
+	suite.False(returned)
+
+	average, count, err := suite.history.OutgoingStats(src)
+	suite.NoError(err, "getting stats should not produce error")
+	suite.Equal(int64(2), count)
+	suite.Equal(float32(200), average)
+}
+
+func (suite *reviewRepositorySuite) TearDownSuite() {
+	err := utils.DropTable(suite.db, []string{"transfer_review_shares", "transfer_reviews"})
+	if err != nil {
+		log.Println(err)
+	}
+
+	if err := suite.pool.Purge(suite.resource); err != nil {
+		log.Fatalf("Could not purge resource: %s", err)
+	}
+}
+
+func TestReviewRepository(t *testing.T) {
+	suite.Run(t, new(reviewRepositorySuite))
+}
@@ -0,0 +1,47 @@
+package fraud
+
+import "time"
+
+// Решения правил в порядке возрастания строгости
+const (
+	Allow  = "allow"
+	Review = "review"
+	Block  = "block"
+)
+
+// Transfer - проверяемый перевод
+type Transfer struct {
+	SrcId  int64
+	DstId  int64
+	Amount float32
+}
+
+type Decision struct {
+	Verdict string
+	// Правило, принявшее решение, и пояснение для оператора
+	Rule   string
+	Reason string
+}
+
+// Rule - правило антифрода. Check возвращает nil, если правило не возражает против перевода
+type Rule interface {
+	Name() string
+	Check(transfer Transfer, history TransferHistoryRepository) (*Decision, error)
+}
+
+// Evaluator проверяет перевод всеми правилами и возвращает самое строгое решение
+type Evaluator interface {
+	Evaluate(transfer Transfer) (*Decision, error)
+}
+
+// TransferHistoryRepository предоставляет правилам сведения об истории операций
+type TransferHistoryRepository interface {
+	// AccountAge возвращает время с первой операции счета, nil - у счета еще нет операций
+	AccountAge(userId int64) (*time.Duration, error)
+	// CountSenders возвращает число разных отправителей переводов на счет userId за последние window
+	CountSenders(userId int64, window time.Duration) (int64, error)
+	// HasTransfer проверяет, был ли перевод от srcUserId к dstUserId за последние window
+	HasTransfer(srcUserId int64, dstUserId int64, window time.Duration) (bool, error)
+	// OutgoingStats возвращает среднюю сумму и количество исходящих переводов счета
+	OutgoingStats(userId int64) (float32, int64, error)
+}
@@ -0,0 +1,11 @@
+package fraud
+
+import "avito-intership/models"
+
+type ReviewUseCase interface {
+	GetReviews(status string) ([]*models.TransferReview, error)
+	GetReview(id int64) (*models.TransferReview, error)
+	// Approve выполняет отложенный перевод
+	Approve(id int64, operator string, note string) (*models.TransferReview, error)
+	Reject(id int64, operator string, note string) (*models.TransferReview, error)
+}
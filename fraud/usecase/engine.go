@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"avito-intership/fraud"
+)
+
+var severity = map[string]int{fraud.Allow: 0, fraud.Review: 1, fraud.Block: 2}
+
+// Engine проверяет перевод всеми правилами и возвращает самое строгое решение
+type Engine struct {
+	history fraud.TransferHistoryRepository
+	rules   []fraud.Rule
+}
+
+func NewEngine(history fraud.TransferHistoryRepository, rules ...fraud.Rule) *Engine {
+	return &Engine{
+		history: history,
+		rules:   rules,
+	}
+}
+
+// Register добавляет правило, правила проверяются в порядке регистрации
+func (e *Engine) Register(rule fraud.Rule) {
+	e.rules = append(e.rules, rule)
+}
+
+func (e *Engine) Evaluate(transfer fraud.Transfer) (*fraud.Decision, error) {
+	result := &fraud.Decision{Verdict: fraud.Allow}
+
+	for _, rule := range e.rules {
+		decision, err := rule.Check(transfer, e.history)
+		if err != nil {
+			return nil, err
+		}
+		if decision == nil || severity[decision.Verdict] <= severity[result.Verdict] {
+			continue
+		}
+
+		decision.Rule = rule.Name()
+		result = decision
+		if result.Verdict == fraud.Block {
+			break
+		}
+	}
+
+	return result, nil
+}
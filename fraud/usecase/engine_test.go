@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"avito-intership/fraud"
+	"avito-intership/mocks"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type engineSuite struct {
+	suite.Suite
+	history *mocks.TransferHistoryRepository
+	engine  *Engine
+}
+
+func (suite *engineSuite) SetupTest() {
+	history := new(mocks.TransferHistoryRepository)
+
+	suite.history = history
+	suite.engine = NewEngine(history, DefaultRules()...)
+}
+
+func (suite *engineSuite) expectHistory(age time.Duration, senders int64, roundTrip bool, average float32, count int64) {
+	suite.history.On("AccountAge", int64(2)).Return(&age, nil)
+	suite.history.On("CountSenders", int64(2), mock.Anything).Return(senders, nil)
+	suite.history.On("HasTransfer", int64(2), int64(1), mock.Anything).Return(roundTrip, nil)
+	suite.history.On("OutgoingStats", int64(1)).Return(average, count, nil)
+}
+
+func (suite *engineSuite) TestEvaluate_Allow() {
+	suite.expectHistory(30*24*time.Hour, 0, false, 100, 10)
+
+	decision, err := suite.engine.Evaluate(fraud.Transfer{SrcId: 1, DstId: 2, Amount: 500})
+
+	suite.NoError(err)
+	suite.Equal(fraud.Allow, decision.Verdict)
+	suite.Empty(decision.Rule)
+}
+
+func (suite *engineSuite) TestEvaluate_FanIn() {
+	suite.expectHistory(time.Hour, 10, false, 100, 10)
+
+	decision, err := suite.engine.Evaluate(fraud.Transfer{SrcId: 1, DstId: 2, Amount: 100})
+
+	suite.NoError(err)
+	suite.Equal(fraud.Review, decision.Verdict)
+	suite.Equal("fan_in", decision.Rule)
+}
+
+func (suite *engineSuite) TestEvaluate_FanInNoHistory() {
+	suite.history.On("AccountAge", int64(2)).Return(nil, nil)
+	suite.history.On("CountSenders", int64(2), mock.Anything).Return(int64(3), nil)
+	suite.history.On("HasTransfer", int64(2), int64(1), mock.Anything).Return(false, nil)
+	suite.history.On("OutgoingStats", int64(1)).Return(float32(0), int64(0), nil)
+
+	decision, err := suite.engine.Evaluate(fraud.Transfer{SrcId: 1, DstId: 2, Amount: 100})
+
+	suite.NoError(err)
+	suite.Equal(fraud.Allow, decision.Verdict)
+}
+
+func (suite *engineSuite) TestEvaluate_RoundTrip() {
+	suite.expectHistory(30*24*time.Hour, 0, true, 100, 10)
+
+	decision, err := suite.engine.Evaluate(fraud.Transfer{SrcId: 1, DstId: 2, Amount: 100})
+
+	suite.NoError(err)
+	suite.Equal(fraud.Review, decision.Verdict)
+	suite.Equal("round_trip", decision.Rule)
+}
+
+func (suite *engineSuite) TestEvaluate_AmountAboveAverage() {
+	suite.expectHistory(30*24*time.Hour, 0, false, 100, 10)
+
+	decision, err := suite.engine.Evaluate(fraud.Transfer{SrcId: 1, DstId: 2, Amount: 5000})
+
+	suite.NoError(err)
+	suite.Equal(fraud.Review, decision.Verdict)
+	suite.Equal("amount_above_average", decision.Rule)
+}
+
+func (suite *engineSuite) TestEvaluate_ShortHistory() {
+	suite.expectHistory(30*24*time.Hour, 0, false, 100, 4)
+
+	decision, err := suite.engine.Evaluate(fraud.Transfer{SrcId: 1, DstId: 2, Amount: 50000})
+
+	suite.NoError(err)
+	suite.Equal(fraud.Allow, decision.Verdict)
+}
+
+func (suite *engineSuite) TestEvaluate_BlockWins() {
+	suite.expectHistory(time.Hour, 20, true, 100, 10)
+
+	decision, err := suite.engine.Evaluate(fraud.Transfer{SrcId: 1, DstId: 2, Amount: 50000})
+
+	suite.NoError(err)
+	suite.Equal(fraud.Block, decision.Verdict)
+	suite.Equal("amount_above_average", decision.Rule)
+}
+
+type blockRule struct{}
+
+func (blockRule) Name() string {
+	return "blacklist"
+}
+
+func (blockRule) Check(transfer fraud.Transfer, history fraud.TransferHistoryRepository) (*fraud.Decision, error) {
+	return &fraud.Decision{Verdict: fraud.Block, Reason: "account is blacklisted"}, nil
+}
+
+func (suite *engineSuite) TestEvaluate_Register() {
+	engine := NewEngine(suite.history)
+	engine.Register(blockRule{})
+	engine.Register(RoundTripRule{Window: time.Minute, Verdict: fraud.Review})
+
+	decision, err := engine.Evaluate(fraud.Transfer{SrcId: 1, DstId: 2, Amount: 1})
+
+	suite.NoError(err)
+	suite.Equal(fraud.Block, decision.Verdict)
+	suite.Equal("blacklist", decision.Rule)
+	// После блокировки остальные правила не проверяются
+	suite.history.AssertNotCalled(suite.T(), "HasTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *engineSuite) TestEvaluate_HistoryError() {
+	suite.history.On("AccountAge", int64(2)).Return(nil, errors.New("connection refused"))
+
+	_, err := suite.engine.Evaluate(fraud.Transfer{SrcId: 1, DstId: 2, Amount: 1})
+
+	suite.Error(err)
+}
+
+func (suite *engineSuite) TestEvaluate_ServiceAccount() {
+	// Служебный счет получает деньги от многих пользователей и возвращает их, это не подозрительно
+	suite.history.On("OutgoingStats", int64(1)).Return(float32(100), int64(10), nil)
+
+	decision, err := suite.engine.Evaluate(fraud.Transfer{SrcId: 1, DstId: -2, Amount: 100})
+
+	suite.NoError(err)
+	suite.Equal(fraud.Allow, decision.Verdict)
+	suite.history.AssertNotCalled(suite.T(), "CountSenders", mock.Anything, mock.Anything)
+	suite.history.AssertNotCalled(suite.T(), "HasTransfer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestEngine(t *testing.T) {
+	suite.Run(t, new(engineSuite))
+}
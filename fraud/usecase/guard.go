@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/fraud"
+	"avito-intership/models"
+	"log"
+)
+
+// GuardedBalance проверяет переводы правилами антифрода до их выполнения;
+// остальные операции передаются без изменений
+type GuardedBalance struct {
+	balance.UseCase
+	engine  *Engine
+	reviews fraud.ReviewRepository
+}
+
+func NewGuardedBalance(next balance.UseCase, engine *Engine, reviews fraud.ReviewRepository) *GuardedBalance {
+	return &GuardedBalance{
+		UseCase: next,
+		engine:  engine,
+		reviews: reviews,
+	}
+}
+
+func (g GuardedBalance) TransferMoney(srcUserId int64, dstUserId int64, amount float32) error {
+	err := g.check(srcUserId, dstUserId, amount, nil)
+	if err != nil {
+		return err
+	}
+
+	return g.UseCase.TransferMoney(srcUserId, dstUserId, amount)
+}
+
+func (g GuardedBalance) TransferMoneyWithCommission(srcUserId int64, dstUserId int64, amount float32,
+	commission *models.Commission) ([]*models.Payout, error) {
+	err := g.check(srcUserId, dstUserId, amount, commission)
+	if err != nil {
+		return nil, err
+	}
+
+	return g.UseCase.TransferMoneyWithCommission(srcUserId, dstUserId, amount, commission)
+}
+
+// SplitPayment проверяется по каждому получателю и применяет самое строгое решение: блокировка отклоняет
+// платеж, а проверка откладывает до решения оператора весь платеж, чтобы получатели не получили деньги частично.
+// Доли - веса, которые не обязаны давать в сумме 1, поэтому каждому получателю приходится amount * доля / сумма долей
+func (g GuardedBalance) SplitPayment(srcUserId int64, amount float32, shares []*models.Share,
+	commission *models.Commission) ([]*models.Payout, error) {
+	var total float32
+	for _, share := range shares {
+		if share != nil && share.Share > 0 {
+			total += share.Share
+		}
+	}
+
+	var held *fraud.Decision
+	var heldId int64
+	for _, share := range shares {
+		// Неверные доли отклонит сам перевод
+		if share == nil || share.Share <= 0 {
+			continue
+		}
+
+		transfer := fraud.Transfer{SrcId: srcUserId, DstId: share.UserId, Amount: amount * share.Share / total}
+		decision, err := g.engine.Evaluate(transfer)
+		if err != nil {
+			return nil, err
+		}
+
+		switch decision.Verdict {
+		case fraud.Block:
+			log.Printf("split payment %d -> %d blocked by %s: %s", srcUserId, share.UserId, decision.Rule, decision.Reason)
+			return nil, balance.ErrTransferBlocked
+		case fraud.Review:
+			if held == nil {
+				held, heldId = decision, share.UserId
+			}
+		}
+	}
+
+	if held != nil {
+		review, err := g.reviews.CreateReview(&models.TransferReview{
+			SrcId:      srcUserId,
+			DstId:      heldId,
+			Amount:     amount,
+			Commission: commission,
+			Shares:     shares,
+			Rule:       held.Rule,
+			Reason:     held.Reason,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, &balance.TransferHeldError{Review: review}
+	}
+
+	return g.UseCase.SplitPayment(srcUserId, amount, shares, commission)
+}
+
+func (g GuardedBalance) check(srcUserId int64, dstUserId int64, amount float32, commission *models.Commission) error {
+	decision, err := g.engine.Evaluate(fraud.Transfer{SrcId: srcUserId, DstId: dstUserId, Amount: amount})
+	if err != nil {
+		return err
+	}
+
+	switch decision.Verdict {
+	case fraud.Block:
+		log.Printf("transfer %d -> %d blocked by %s: %s", srcUserId, dstUserId, decision.Rule, decision.Reason)
+		return balance.ErrTransferBlocked
+	case fraud.Review:
+		review, err := g.reviews.CreateReview(&models.TransferReview{
+			SrcId:      srcUserId,
+			DstId:      dstUserId,
+			Amount:     amount,
+			Commission: commission,
+			Rule:       decision.Rule,
+			Reason:     decision.Reason,
+		})
+		if err != nil {
+			return err
+		}
+
+		return &balance.TransferHeldError{Review: review}
+	}
+
+	return nil
+}
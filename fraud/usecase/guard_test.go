@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/fraud"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type guardSuite struct {
+	suite.Suite
+	history *mocks.TransferHistoryRepository
+	reviews *mocks.ReviewRepository
+	next    *mocks.UseCase
+	guard   balance.UseCase
+}
+
+func (suite *guardSuite) SetupTest() {
+	history := new(mocks.TransferHistoryRepository)
+	reviews := new(mocks.ReviewRepository)
+	next := new(mocks.UseCase)
+
+	suite.history = history
+	suite.reviews = reviews
+	suite.next = next
+	suite.guard = NewGuardedBalance(next, NewEngine(history, DefaultRules()...), reviews)
+}
+
+func (suite *guardSuite) expectHistory(dstId int64, average float32) {
+	age := 30 * 24 * time.Hour
+	suite.history.On("AccountAge", dstId).Return(&age, nil)
+	suite.history.On("CountSenders", dstId, mock.Anything).Return(int64(0), nil)
+	suite.history.On("HasTransfer", dstId, int64(1), mock.Anything).Return(false, nil)
+	suite.history.On("OutgoingStats", int64(1)).Return(average, int64(10), nil)
+}
+
+func (suite *guardSuite) TestTransferMoney_Allow() {
+	suite.expectHistory(2, 100)
+	suite.next.On("TransferMoney", int64(1), int64(2), float32(100)).Return(nil)
+
+	err := suite.guard.TransferMoney(1, 2, 100)
+
+	suite.NoError(err)
+	suite.next.AssertExpectations(suite.T())
+}
+
+func (suite *guardSuite) TestTransferMoney_Held() {
+	suite.expectHistory(2, 100)
+	suite.reviews.On("CreateReview", mock.MatchedBy(func(r *models.TransferReview) bool {
+		return r.SrcId == 1 && r.DstId == 2 && r.Amount == 5000 && r.Commission == nil &&
+			r.Rule == "amount_above_average"
+	})).Return(&models.TransferReview{Id: 3, Status: fraud.ReviewPending}, nil)
+
+	err := suite.guard.TransferMoney(1, 2, 5000)
+
+	var heldErr *balance.TransferHeldError
+	suite.True(errors.As(err, &heldErr))
+	suite.Equal(int64(3), heldErr.Review.Id)
+	suite.next.AssertNotCalled(suite.T(), "TransferMoney", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *guardSuite) TestTransferMoney_Blocked() {
+	suite.expectHistory(2, 100)
+
+	err := suite.guard.TransferMoney(1, 2, 50000)
+
+	suite.Equal(balance.ErrTransferBlocked, err)
+	suite.reviews.AssertNotCalled(suite.T(), "CreateReview", mock.Anything)
+	suite.next.AssertNotCalled(suite.T(), "TransferMoney", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *guardSuite) TestTransferMoneyWithCommission_HeldKeepsCommission() {
+	commission := &models.Commission{Percent: 1, Min: 5}
+	suite.expectHistory(2, 100)
+	suite.reviews.On("CreateReview", mock.MatchedBy(func(r *models.TransferReview) bool {
+		return r.Commission == commission
+	})).Return(&models.TransferReview{Id: 4}, nil)
+
+	_, err := suite.guard.TransferMoneyWithCommission(1, 2, 5000, commission)
+
+	var heldErr *balance.TransferHeldError
+	suite.True(errors.As(err, &heldErr))
+}
+
+func (suite *guardSuite) TestSplitPayment_Review() {
+	shares := []*models.Share{{UserId: 2, Share: 0.5}, {UserId: 3, Share: 0.5}}
+	commission := &models.Commission{Percent: 1}
+	suite.expectHistory(2, 100)
+	suite.expectHistory(3, 1000)
+	suite.reviews.On("CreateReview", mock.MatchedBy(func(r *models.TransferReview) bool {
+		return r.SrcId == 1 && r.DstId == 2 && r.Amount == 4000 && r.Commission == commission &&
+			len(r.Shares) == 2 && r.Rule == "amount_above_average"
+	})).Return(&models.TransferReview{Id: 5, Status: fraud.ReviewPending}, nil)
+
+	_, err := suite.guard.SplitPayment(1, 4000, shares, commission)
+
+	var heldErr *balance.TransferHeldError
+	suite.True(errors.As(err, &heldErr), "whole split payment is held for review")
+	suite.Equal(int64(5), heldErr.Review.Id)
+	suite.next.AssertNotCalled(suite.T(), "SplitPayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *guardSuite) TestSplitPayment_BlockWins() {
+	shares := []*models.Share{{UserId: 2, Share: 0.1}, {UserId: 3, Share: 0.9}}
+	suite.expectHistory(2, 100)
+	suite.expectHistory(3, 100)
+
+	_, err := suite.guard.SplitPayment(1, 50000, shares, nil)
+
+	suite.Equal(balance.ErrTransferBlocked, err)
+	suite.reviews.AssertNotCalled(suite.T(), "CreateReview", mock.Anything)
+	suite.next.AssertNotCalled(suite.T(), "SplitPayment", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *guardSuite) TestSplitPayment_Allow() {
+	shares := []*models.Share{{UserId: 2, Share: 0.5}, {UserId: 3, Share: 0.5}}
+	suite.expectHistory(2, 100)
+	suite.expectHistory(3, 100)
+	suite.next.On("SplitPayment", int64(1), float32(1000), shares, (*models.Commission)(nil)).
+		Return([]*models.Payout{}, nil)
+
+	_, err := suite.guard.SplitPayment(1, 1000, shares, nil)
+
+	suite.NoError(err)
+}
+
+func (suite *guardSuite) TestSplitPayment_UnnormalizedShares() {
+	// Доли 70 и 30 дают получателям 70 и 30 рублей, а не 7000 и 3000
+	shares := []*models.Share{{UserId: 2, Share: 70}, {UserId: 3, Share: 30}}
+	suite.expectHistory(2, 100)
+	suite.expectHistory(3, 100)
+	suite.next.On("SplitPayment", int64(1), float32(100), shares, (*models.Commission)(nil)).
+		Return([]*models.Payout{}, nil)
+
+	_, err := suite.guard.SplitPayment(1, 100, shares, nil)
+
+	suite.NoError(err)
+	suite.reviews.AssertNotCalled(suite.T(), "CreateReview", mock.Anything)
+	suite.next.AssertExpectations(suite.T())
+}
+
+func (suite *guardSuite) TestSplitPayment_UnnormalizedSharesReview() {
+	shares := []*models.Share{{UserId: 2, Share: 2}, {UserId: 3, Share: 8}}
+	suite.expectHistory(2, 100)
+	suite.expectHistory(3, 100)
+	suite.reviews.On("CreateReview", mock.MatchedBy(func(r *models.TransferReview) bool {
+		return r.DstId == 3 && r.Amount == 2000
+	})).Return(&models.TransferReview{Id: 6, Status: fraud.ReviewPending}, nil)
+
+	_, err := suite.guard.SplitPayment(1, 2000, shares, nil)
+
+	var heldErr *balance.TransferHeldError
+	suite.True(errors.As(err, &heldErr), "only the 1600 share exceeds ten times the average")
+	suite.Equal(int64(6), heldErr.Review.Id)
+}
+
+func (suite *guardSuite) TestOtherOperationsPassThrough() {
+	suite.next.On("ChangeBalance", int64(1), float32(-100), int64(5)).Return(nil)
+
+	err := suite.guard.ChangeBalance(1, -100, 5)
+
+	suite.NoError(err)
+	suite.history.AssertNotCalled(suite.T(), "OutgoingStats", mock.Anything)
+}
+
+func TestGuardedBalance(t *testing.T) {
+	suite.Run(t, new(guardSuite))
+}
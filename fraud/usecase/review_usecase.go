@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/fraud"
+	"avito-intership/models"
+	"errors"
+	"log"
+	"strings"
+)
+
+type ReviewUseCase struct {
+	reviews fraud.ReviewRepository
+	// Одобренный перевод выполняется без повторной проверки правилами
+	balance balance.UseCase
+}
+
+func NewReviewUseCase(reviews fraud.ReviewRepository, balance balance.UseCase) *ReviewUseCase {
+	return &ReviewUseCase{
+		reviews: reviews,
+		balance: balance,
+	}
+}
+
+func (u ReviewUseCase) GetReviews(status string) ([]*models.TransferReview, error) {
+	return u.reviews.GetReviews(status)
+}
+
+func (u ReviewUseCase) GetReview(id int64) (*models.TransferReview, error) {
+	return u.reviews.GetReview(id)
+}
+
+// Approve сначала занимает проверку сменой статуса, затем выполняет перевод; если перевод не удался,
+// например из-за нехватки средств, проверка возвращается в очередь
+func (u ReviewUseCase) Approve(id int64, operator string, note string) (*models.TransferReview, error) {
+	operator = strings.TrimSpace(operator)
+	review, err := u.decide(id, fraud.ReviewApproved, operator, note)
+	if err != nil {
+		return nil, err
+	}
+
+	// Перевод выше порога после проверки антифрода ждет одобрения вторым оператором: оператор проверки
+	// записывается создателем операции и одобрить ее не может. Решение по проверке при этом остается
+	err = u.balance.TransferReviewed(review, operator)
+	var approvalErr *balance.ApprovalRequiredError
+	if errors.As(err, &approvalErr) {
+		return review, err
+	}
+
+	if err != nil {
+		if _, revertErr := u.reviews.SetStatus(id, fraud.ReviewApproved, fraud.ReviewPending, nil, nil); revertErr != nil {
+			log.Printf("review %d: failed to revert approval: %v", id, revertErr)
+		}
+		return nil, err
+	}
+
+	return review, nil
+}
+
+func (u ReviewUseCase) Reject(id int64, operator string, note string) (*models.TransferReview, error) {
+	return u.decide(id, fraud.ReviewRejected, operator, note)
+}
+
+func (u ReviewUseCase) decide(id int64, status string, operator string, note string) (*models.TransferReview, error) {
+	operator = strings.TrimSpace(operator)
+	if operator == "" {
+		return nil, fraud.ErrNoOperator
+	}
+
+	var notePtr *string
+	if note = strings.TrimSpace(note); note != "" {
+		notePtr = &note
+	}
+
+	return u.reviews.SetStatus(id, fraud.ReviewPending, status, &operator, notePtr)
+}
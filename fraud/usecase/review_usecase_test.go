@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/fraud"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type reviewUseCaseSuite struct {
+	suite.Suite
+	reviews *mocks.ReviewRepository
+	balance *mocks.UseCase
+	useCase fraud.ReviewUseCase
+}
+
+func (suite *reviewUseCaseSuite) SetupTest() {
+	reviews := new(mocks.ReviewRepository)
+	balanceUseCase := new(mocks.UseCase)
+
+	suite.reviews = reviews
+	suite.balance = balanceUseCase
+	suite.useCase = NewReviewUseCase(reviews, balanceUseCase)
+}
+
+func (suite *reviewUseCaseSuite) TestApprove_Ok() {
+	operator := "alice"
+	note := "customer confirmed by phone"
+	review := &models.TransferReview{Id: 1, SrcId: 1, DstId: 2, Amount: 5000, Status: fraud.ReviewApproved}
+	suite.reviews.On("SetStatus", int64(1), fraud.ReviewPending, fraud.ReviewApproved, &operator, &note).
+		Return(review, nil)
+	suite.balance.On("TransferReviewed", review, "alice").Return(nil)
+
+	approved, err := suite.useCase.Approve(1, " alice ", " customer confirmed by phone ")
+
+	suite.NoError(err)
+	suite.Equal(review, approved)
+	suite.balance.AssertExpectations(suite.T())
+}
+
+func (suite *reviewUseCaseSuite) TestApprove_Commission() {
+	operator := "alice"
+	commission := &models.Commission{Fixed: 10}
+	review := &models.TransferReview{Id: 2, SrcId: 1, DstId: 2, Amount: 5000, Commission: commission}
+	suite.reviews.On("SetStatus", int64(2), fraud.ReviewPending, fraud.ReviewApproved, &operator, (*string)(nil)).
+		Return(review, nil)
+	suite.balance.On("TransferReviewed", review, "alice").Return(nil)
+
+	_, err := suite.useCase.Approve(2, "alice", "")
+
+	suite.NoError(err)
+	suite.balance.AssertExpectations(suite.T())
+}
+
+func (suite *reviewUseCaseSuite) TestApprove_SplitPayment() {
+	operator := "alice"
+	shares := []*models.Share{{UserId: 2, Share: 0.5}, {UserId: 3, Share: 0.5}}
+	review := &models.TransferReview{Id: 6, SrcId: 1, DstId: 2, Amount: 4000, Shares: shares}
+	suite.reviews.On("SetStatus", int64(6), fraud.ReviewPending, fraud.ReviewApproved, &operator, (*string)(nil)).
+		Return(review, nil)
+	suite.balance.On("TransferReviewed", review, "alice").Return(nil)
+
+	_, err := suite.useCase.Approve(6, "alice", "")
+
+	suite.NoError(err)
+	suite.balance.AssertExpectations(suite.T())
+}
+
+func (suite *reviewUseCaseSuite) TestApprove_TransferFailed() {
+	operator := "alice"
+	review := &models.TransferReview{Id: 3, SrcId: 1, DstId: 2, Amount: 5000}
+	suite.reviews.On("SetStatus", int64(3), fraud.ReviewPending, fraud.ReviewApproved, &operator, (*string)(nil)).
+		Return(review, nil)
+	suite.balance.On("TransferReviewed", review, "alice").Return(balance.ErrTooLowBalance)
+	suite.reviews.On("SetStatus", int64(3), fraud.ReviewApproved, fraud.ReviewPending, (*string)(nil), (*string)(nil)).
+		Return(&models.TransferReview{Id: 3, Status: fraud.ReviewPending}, nil)
+
+	_, err := suite.useCase.Approve(3, "alice", "")
+
+	suite.Equal(balance.ErrTooLowBalance, err)
+	suite.reviews.AssertExpectations(suite.T())
+}
+
+func (suite *reviewUseCaseSuite) TestApprove_ApprovalRequired() {
+	operator := "alice"
+	review := &models.TransferReview{Id: 7, SrcId: 1, DstId: 2, Amount: 500000}
+	suite.reviews.On("SetStatus", int64(7), fraud.ReviewPending, fraud.ReviewApproved, &operator, (*string)(nil)).
+		Return(review, nil)
+	suite.balance.On("TransferReviewed", review, "alice").
+		Return(&balance.ApprovalRequiredError{Operation: &models.PendingOperation{Id: 1, Maker: &operator}})
+
+	approved, err := suite.useCase.Approve(7, "alice", "")
+
+	var approvalErr *balance.ApprovalRequiredError
+	suite.True(errors.As(err, &approvalErr))
+	suite.Equal(review, approved)
+	suite.reviews.AssertNotCalled(suite.T(), "SetStatus", int64(7), fraud.ReviewApproved, fraud.ReviewPending,
+		mock.Anything, mock.Anything)
+}
+
+func (suite *reviewUseCaseSuite) TestApprove_Decided() {
+	operator := "alice"
+	suite.reviews.On("SetStatus", int64(4), fraud.ReviewPending, fraud.ReviewApproved, &operator, (*string)(nil)).
+		Return(nil, fraud.ErrReviewDecided)
+
+	_, err := suite.useCase.Approve(4, "alice", "")
+
+	suite.Equal(fraud.ErrReviewDecided, err)
+	suite.balance.AssertNotCalled(suite.T(), "TransferMoney", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *reviewUseCaseSuite) TestReject() {
+	operator := "bob"
+	note := "mule account"
+	suite.reviews.On("SetStatus", int64(5), fraud.ReviewPending, fraud.ReviewRejected, &operator, &note).
+		Return(&models.TransferReview{Id: 5, Status: fraud.ReviewRejected}, nil)
+
+	review, err := suite.useCase.Reject(5, "bob", "mule account")
+
+	suite.NoError(err)
+	suite.Equal(fraud.ReviewRejected, review.Status)
+	suite.balance.AssertNotCalled(suite.T(), "TransferMoney", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *reviewUseCaseSuite) TestDecide_NoOperator() {
+	_, err := suite.useCase.Reject(6, "  ", "")
+
+	suite.Equal(fraud.ErrNoOperator, err)
+	suite.reviews.AssertNotCalled(suite.T(), "SetStatus", mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything, mock.Anything)
+}
+
+func TestReviewUseCase(t *testing.T) {
+	suite.Run(t, new(reviewUseCaseSuite))
+}
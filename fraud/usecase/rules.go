@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"avito-intership/fraud"
+	"fmt"
+	"time"
+)
+
+// FanInRule срабатывает, когда новый счет получает переводы от многих отправителей. Служебные счета
+// с id <= 0 получают деньги от всех пользователей, поэтому правило к ним не применяется
+type FanInRule struct {
+	MaxAccountAge time.Duration
+	Window        time.Duration
+	MaxSenders    int64
+	Verdict       string
+}
+
+func (r FanInRule) Name() string {
+	return "fan_in"
+}
+
+func (r FanInRule) Check(transfer fraud.Transfer, history fraud.TransferHistoryRepository) (*fraud.Decision, error) {
+	if transfer.DstId <= 0 {
+		return nil, nil
+	}
+
+	age, err := history.AccountAge(transfer.DstId)
+	if err != nil {
+		return nil, err
+	}
+	if age != nil && *age > r.MaxAccountAge {
+		return nil, nil
+	}
+
+	senders, err := history.CountSenders(transfer.DstId, r.Window)
+	if err != nil {
+		return nil, err
+	}
+
+	// Текущий отправитель мог уже переводить на счет, поэтому оценка сверху
+	if senders+1 <= r.MaxSenders {
+		return nil, nil
+	}
+
+	return &fraud.Decision{
+		Verdict: r.Verdict,
+		Reason:  fmt.Sprintf("new account %d received transfers from %d senders within %s", transfer.DstId, senders, r.Window),
+	}, nil
+}
+
+// RoundTripRule срабатывает на перевод обратно отправителю, от которого деньги пришли недавно;
+// возврат со служебного счета, например из сделки, переводом обратно не считается
+type RoundTripRule struct {
+	Window  time.Duration
+	Verdict string
+}
+
+func (r RoundTripRule) Name() string {
+	return "round_trip"
+}
+
+func (r RoundTripRule) Check(transfer fraud.Transfer, history fraud.TransferHistoryRepository) (*fraud.Decision, error) {
+	if transfer.DstId <= 0 {
+		return nil, nil
+	}
+
+	received, err := history.HasTransfer(transfer.DstId, transfer.SrcId, r.Window)
+	if err != nil {
+		return nil, err
+	}
+	if !received {
+		return nil, nil
+	}
+
+	return &fraud.Decision{
+		Verdict: r.Verdict,
+		Reason:  fmt.Sprintf("account %d returns money received from %d within %s", transfer.SrcId, transfer.DstId, r.Window),
+	}, nil
+}
+
+// AmountRule срабатывает на перевод, превышающий среднюю сумму переводов пользователя в Factor раз.
+// Пока переводов меньше MinHistory, средняя сумма не показательна и правило не применяется
+type AmountRule struct {
+	Factor     float32
+	MinHistory int64
+	Verdict    string
+}
+
+func (r AmountRule) Name() string {
+	return "amount_above_average"
+}
+
+func (r AmountRule) Check(transfer fraud.Transfer, history fraud.TransferHistoryRepository) (*fraud.Decision, error) {
+	average, count, err := history.OutgoingStats(transfer.SrcId)
+	if err != nil {
+		return nil, err
+	}
+	if count < r.MinHistory || transfer.Amount <= average*r.Factor {
+		return nil, nil
+	}
+
+	return &fraud.Decision{
+		Verdict: r.Verdict,
+		Reason:  fmt.Sprintf("amount %.2f is more than %.0f times the average %.2f", transfer.Amount, r.Factor, average),
+	}, nil
+}
+
+// DefaultRules - правила, с которыми запускается сервис
+func DefaultRules() []fraud.Rule {
+	return []fraud.Rule{
+		FanInRule{MaxAccountAge: 7 * 24 * time.Hour, Window: 24 * time.Hour, MaxSenders: 10, Verdict: fraud.Review},
+		RoundTripRule{Window: 10 * time.Minute, Verdict: fraud.Review},
+		AmountRule{Factor: 10, MinHistory: 5, Verdict: fraud.Review},
+		AmountRule{Factor: 100, MinHistory: 5, Verdict: fraud.Block},
+	}
+}
@@ -3,7 +3,37 @@ CREATE TABLE IF NOT EXISTS balances(
   amount NUMERIC(1000, 2) NOT NULL DEFAULT 0
 );
 
-CREATE TYPE transaction_type AS ENUM ('product', 'transfer', 'fill');
+CREATE TYPE transaction_type AS ENUM ('product', 'transfer', 'fill', 'fee', 'bonus', 'bonus_expired', 'cashback',
+  'adjustment');
+
+CREATE TYPE transaction_status AS ENUM ('pending', 'completed', 'rejected', 'expired');
+
+CREATE TYPE pending_operation_type AS ENUM ('transfer', 'operator_adjustment');
+
+CREATE TABLE IF NOT EXISTS pending_operations(
+  id SERIAL PRIMARY KEY,
+  type pending_operation_type NOT NULL,
+  user_id INTEGER NOT NULL,
+  target_id INTEGER NOT NULL DEFAULT 0,
+  amount NUMERIC(1000, 2) NOT NULL,
+  fee NUMERIC(1000, 2) NOT NULL DEFAULT 0,
+  status transaction_status NOT NULL DEFAULT 'pending',
+  maker TEXT,
+  checker TEXT,
+  note TEXT,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  expires_at TIMESTAMP NOT NULL,
+  decided_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS pending_operations_expires_idx ON pending_operations(expires_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS pending_payouts(
+  operation_id INTEGER NOT NULL REFERENCES pending_operations(id),
+  user_id INTEGER NOT NULL,
+  amount NUMERIC(1000, 2) NOT NULL,
+  fee NUMERIC(1000, 2) NOT NULL DEFAULT 0
+);
 
 CREATE TABLE IF NOT EXISTS transactions(
   id SERIAL PRIMARY KEY,
@@ -11,5 +41,255 @@ CREATE TABLE IF NOT EXISTS transactions(
   amount NUMERIC(1000, 2) NOT NULL,
   target_id INTEGER NOT NULL,
   type transaction_type NOT NULL,
+  fee NUMERIC(1000, 2) NOT NULL DEFAULT 0,
+  bonus NUMERIC(1000, 2) NOT NULL DEFAULT 0,
+  reference TEXT,
+  linked_id INTEGER REFERENCES transactions(id),
+  status transaction_status NOT NULL DEFAULT 'completed',
+  operation_id INTEGER REFERENCES pending_operations(id),
   date TIMESTAMP DEFAULT NOW()
-);
\ No newline at end of file
+);
+
+CREATE INDEX IF NOT EXISTS transactions_operation_idx ON transactions(operation_id) WHERE operation_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS bonuses(
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL REFERENCES balances(id),
+  amount NUMERIC(1000, 2) NOT NULL CHECK (amount >= 0),
+  expires_at TIMESTAMP NOT NULL,
+  created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS bonuses_user_idx ON bonuses(user_id, expires_at) WHERE amount > 0;
+
+CREATE TABLE IF NOT EXISTS rate_overrides(
+  id SERIAL PRIMARY KEY,
+  currency VARCHAR(3) NOT NULL,
+  rate NUMERIC(1000, 6) NOT NULL,
+  valid_from TIMESTAMP NOT NULL,
+  valid_to TIMESTAMP,
+  created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS rate_overrides_currency_idx ON rate_overrides(currency, valid_from);
+
+CREATE TYPE rate_override_action AS ENUM ('create', 'update', 'revoke');
+
+CREATE TABLE IF NOT EXISTS rate_override_log(
+  id SERIAL PRIMARY KEY,
+  override_id INTEGER NOT NULL REFERENCES rate_overrides(id),
+  action rate_override_action NOT NULL,
+  operator TEXT NOT NULL,
+  reason TEXT NOT NULL,
+  rate NUMERIC(1000, 6) NOT NULL,
+  valid_from TIMESTAMP NOT NULL,
+  valid_to TIMESTAMP,
+  date TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS exchange_rates(
+  currency VARCHAR(3) NOT NULL,
+  date DATE NOT NULL,
+  rate NUMERIC(1000, 6) NOT NULL,
+  exact TEXT,
+  source TEXT NOT NULL,
+  updated_at TIMESTAMP NOT NULL,
+  fetched_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (currency, date)
+);
+
+CREATE TYPE fx_direction AS ENUM ('buy', 'sell');
+
+CREATE TABLE IF NOT EXISTS fx_spreads(
+  currency VARCHAR(3) NOT NULL,
+  direction fx_direction NOT NULL,
+  percent NUMERIC(7, 4) NOT NULL CHECK (percent >= 0 AND percent < 100),
+  PRIMARY KEY (currency, direction)
+);
+
+CREATE TABLE IF NOT EXISTS products(
+  id SERIAL PRIMARY KEY,
+  name TEXT NOT NULL,
+  price NUMERIC(1000, 2) NOT NULL CHECK (price > 0),
+  currency VARCHAR(3) NOT NULL DEFAULT 'RUB',
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS cashback_rules(
+  id SERIAL PRIMARY KEY,
+  product_id INTEGER REFERENCES products(id),
+  percent NUMERIC(7, 4) NOT NULL CHECK (percent > 0 AND percent <= 100),
+  monthly_cap NUMERIC(1000, 2) CHECK (monthly_cap > 0),
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TYPE deal_status AS ENUM ('created', 'funded', 'released', 'refunded');
+
+CREATE TABLE IF NOT EXISTS deals(
+  id SERIAL PRIMARY KEY,
+  buyer_id INTEGER NOT NULL,
+  seller_id INTEGER NOT NULL,
+  amount NUMERIC(1000, 2) NOT NULL CHECK (amount > 0),
+  status deal_status NOT NULL DEFAULT 'created',
+  release_after INTEGER NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  funded_at TIMESTAMP,
+  closed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS deals_funded_idx ON deals(funded_at) WHERE status = 'funded';
+
+CREATE TABLE IF NOT EXISTS voucher_batches(
+  id SERIAL PRIMARY KEY,
+  amount NUMERIC(1000, 2) NOT NULL CHECK (amount > 0),
+  currency VARCHAR(3) NOT NULL,
+  usage_limit INTEGER NOT NULL CHECK (usage_limit > 0),
+  expires_at TIMESTAMP NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS vouchers(
+  code VARCHAR(32) PRIMARY KEY,
+  batch_id INTEGER NOT NULL REFERENCES voucher_batches(id),
+  uses INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS voucher_redemptions(
+  code VARCHAR(32) NOT NULL REFERENCES vouchers(code),
+  user_id INTEGER NOT NULL,
+  credited NUMERIC(1000, 2) NOT NULL,
+  date TIMESTAMP NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (code, user_id)
+);
+
+CREATE TYPE limit_operation AS ENUM ('transfer', 'spend');
+CREATE TYPE limit_period AS ENUM ('hour', 'day', 'week', 'month');
+
+CREATE TABLE IF NOT EXISTS account_tiers(
+  user_id INTEGER PRIMARY KEY,
+  tier TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS account_limits(
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER,
+  tier TEXT,
+  operation limit_operation NOT NULL,
+  period limit_period NOT NULL,
+  max_amount NUMERIC(1000, 2) CHECK (max_amount > 0),
+  max_count INTEGER CHECK (max_count > 0),
+  CHECK ((user_id IS NULL) <> (tier IS NULL)),
+  CHECK (max_amount IS NOT NULL OR max_count IS NOT NULL)
+);
+
+CREATE INDEX IF NOT EXISTS transactions_user_type_idx ON transactions(user_id, type, date);
+
+CREATE TYPE review_status AS ENUM ('pending', 'approved', 'rejected');
+
+CREATE TABLE IF NOT EXISTS transfer_reviews(
+  id SERIAL PRIMARY KEY,
+  src_id INTEGER NOT NULL,
+  dst_id INTEGER NOT NULL,
+  amount NUMERIC(1000, 2) NOT NULL,
+  fee_percent NUMERIC(1000, 2),
+  fee_min NUMERIC(1000, 2),
+  fee_fixed NUMERIC(1000, 2),
+  rule TEXT NOT NULL,
+  reason TEXT NOT NULL,
+  status review_status NOT NULL DEFAULT 'pending',
+  operator TEXT,
+  note TEXT,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  decided_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS transfer_reviews_pending_idx ON transfer_reviews(created_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS transfer_review_shares(
+  review_id INTEGER NOT NULL REFERENCES transfer_reviews(id),
+  user_id INTEGER NOT NULL,
+  share NUMERIC(1000, 6) NOT NULL
+);
+
+CREATE TYPE adjustment_reason AS ENUM ('duplicate_charge', 'failed_service', 'chargeback', 'goodwill',
+  'fraud_recovery', 'correction');
+
+CREATE TABLE IF NOT EXISTS adjustments(
+  id SERIAL PRIMARY KEY,
+  user_id INTEGER NOT NULL,
+  amount NUMERIC(1000, 2) NOT NULL CHECK (amount <> 0),
+  operator TEXT NOT NULL,
+  reason adjustment_reason NOT NULL,
+  comment TEXT NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS adjustments_user_idx ON adjustments(user_id, created_at);
+
+CREATE TABLE IF NOT EXISTS outbox_events(
+  id BIGSERIAL PRIMARY KEY,
+  type TEXT NOT NULL,
+  schema_version INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  payload JSONB NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  published_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS outbox_events_unpublished_idx ON outbox_events(id) WHERE published_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions(
+  id SERIAL PRIMARY KEY,
+  url TEXT NOT NULL,
+  event_types TEXT[] NOT NULL,
+  secret TEXT NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries(
+  id BIGSERIAL PRIMARY KEY,
+  subscription_id INTEGER NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+  event_id BIGINT NOT NULL,
+  event JSONB NOT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  last_error TEXT,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  delivered_at TIMESTAMP,
+  UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS webhook_deliveries_due_idx ON webhook_deliveries(next_attempt_at) WHERE delivered_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS webhook_dead_letters(
+  id BIGSERIAL PRIMARY KEY,
+  subscription_id INTEGER NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+  event_id BIGINT NOT NULL,
+  event JSONB NOT NULL,
+  attempts INTEGER NOT NULL,
+  last_error TEXT NOT NULL,
+  failed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  replayed_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS webhook_dead_letters_subscription_idx ON webhook_dead_letters(subscription_id, failed_at);
+
+CREATE TABLE IF NOT EXISTS alert_settings(
+  user_id INTEGER PRIMARY KEY,
+  low_balance NUMERIC(1000, 2) CHECK (low_balance > 0),
+  large_debit NUMERIC(1000, 2) CHECK (large_debit > 0),
+  low_balance_notified BOOLEAN NOT NULL DEFAULT FALSE,
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS api_keys(
+  id SERIAL PRIMARY KEY,
+  service TEXT NOT NULL,
+  key_hash BYTEA NOT NULL UNIQUE,
+  prefix TEXT NOT NULL,
+  scopes TEXT[] NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  revoked_at TIMESTAMP
+);
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/limits"
+	"avito-intership/models"
+	"avito-intership/problem"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type AdminHandler struct {
+	useCase limits.LimitUseCase
+}
+
+func NewAdminHandler(useCase limits.LimitUseCase) *AdminHandler {
+	return &AdminHandler{
+		useCase: useCase,
+	}
+}
+
+type StatusMessage struct {
+	Success bool    `json:"success"`
+	Message *string `json:"message"`
+}
+
+func (h AdminHandler) writeStatus(success bool, message *string, w *http.ResponseWriter) {
+	status := StatusMessage{
+		Success: success,
+		Message: message,
+	}
+
+	(*w).Header().Add("Content-Type", "application/json")
+	_ = json.NewEncoder(*w).Encode(status)
+}
+
+func (h AdminHandler) writeJSON(value interface{}, w http.ResponseWriter) {
+	w.Header().Add("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		problem.Write(w, err)
+	}
+}
+
+func (h AdminHandler) parseId(r *http.Request, w http.ResponseWriter) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil || id <= 0 {
+		problem.Write(w, apperror.BadArgument("id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+// parseLimit читает лимит; обязателен один из параметров user_id и tier и хотя бы один из max_amount и max_count
+func (h AdminHandler) parseLimit(r *http.Request, w http.ResponseWriter) (*models.Limit, bool) {
+	limit := &models.Limit{
+		Operation: r.FormValue("operation"),
+		Period:    r.FormValue("period"),
+	}
+
+	if userId := r.FormValue("user_id"); userId != "" {
+		id, err := strconv.ParseInt(userId, 10, 64)
+		if err != nil {
+			problem.Write(w, apperror.BadArgument("user_id"))
+			return nil, false
+		}
+		limit.UserId = &id
+	}
+
+	if tier := r.FormValue("tier"); tier != "" {
+		limit.Tier = &tier
+	}
+
+	if maxAmount := r.FormValue("max_amount"); maxAmount != "" {
+		value, err := strconv.ParseFloat(maxAmount, 32)
+		if err != nil {
+			problem.Write(w, apperror.BadArgument("max_amount"))
+			return nil, false
+		}
+		amount := float32(value)
+		limit.MaxAmount = &amount
+	}
+
+	if maxCount := r.FormValue("max_count"); maxCount != "" {
+		count, err := strconv.ParseInt(maxCount, 10, 64)
+		if err != nil {
+			problem.Write(w, apperror.BadArgument("max_count"))
+			return nil, false
+		}
+		limit.MaxCount = &count
+	}
+
+	return limit, true
+}
+
+func (h AdminHandler) GetLimitsEndpoint(w http.ResponseWriter, r *http.Request) {
+	result, err := h.useCase.GetLimits()
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(result, w)
+}
+
+func (h AdminHandler) CreateLimitEndpoint(w http.ResponseWriter, r *http.Request) {
+	limit, ok := h.parseLimit(r, w)
+	if !ok {
+		return
+	}
+
+	created, err := h.useCase.CreateLimit(limit)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(created, w)
+}
+
+func (h AdminHandler) DeleteLimitEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	err := h.useCase.DeleteLimit(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeStatus(true, nil, &w)
+}
+
+func (h AdminHandler) GetTierEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	tier, err := h.useCase.GetTier(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(tier, w)
+}
+
+func (h AdminHandler) SetTierEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	tier, err := h.useCase.SetTier(id, r.FormValue("tier"))
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(tier, w)
+}
@@ -0,0 +1,113 @@
+package http
+
+import (
+	"avito-intership/limits"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+type limitHandlerSuite struct {
+	suite.Suite
+
+	useCase       *mocks.LimitUseCase
+	testingServer *httptest.Server
+}
+
+func (suite *limitHandlerSuite) SetupSuite() {
+	useCase := new(mocks.LimitUseCase)
+
+	router := mux.NewRouter()
+	RegisterAdminEndpoints(router.PathPrefix("/api/v1/admin").Subrouter(), useCase)
+
+	suite.testingServer = httptest.NewServer(router)
+	suite.useCase = useCase
+}
+
+func (suite *limitHandlerSuite) TearDownSuite() {
+	defer suite.testingServer.Close()
+}
+
+func (suite *limitHandlerSuite) TestCreateLimit() {
+	tier := "default"
+	var maxCount int64 = 20
+	created := &models.Limit{Id: 1, Tier: &tier, Operation: "transfer", Period: "hour", MaxCount: &maxCount}
+	suite.useCase.On("CreateLimit", mock.MatchedBy(func(l *models.Limit) bool {
+		return l.Tier != nil && *l.Tier == "default" && l.UserId == nil && l.Operation == "transfer" &&
+			l.Period == "hour" && l.MaxCount != nil && *l.MaxCount == 20 && l.MaxAmount == nil
+	})).Return(created, nil)
+
+	data := url.Values{}
+	data.Set("tier", "default")
+	data.Set("operation", "transfer")
+	data.Set("period", "hour")
+	data.Set("max_count", "20")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/limits", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.Limit
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(created, &responseBody)
+}
+
+func (suite *limitHandlerSuite) TestCreateLimit_BadMaxAmount() {
+	data := url.Values{}
+	data.Set("user_id", "1")
+	data.Set("operation", "transfer")
+	data.Set("period", "day")
+	data.Set("max_amount", "lots")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/limits", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *limitHandlerSuite) TestDeleteLimit_NotFound() {
+	suite.useCase.On("DeleteLimit", int64(404)).Return(limits.ErrLimitNotFound)
+
+	request, _ := http.NewRequest(http.MethodDelete,
+		fmt.Sprintf("%s/api/v1/admin/limits/404", suite.testingServer.URL), nil)
+	response, err := http.DefaultClient.Do(request)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusNotFound, response.StatusCode)
+}
+
+func (suite *limitHandlerSuite) TestSetTier() {
+	suite.useCase.On("SetTier", int64(5), "premium").Return(&models.AccountTier{UserId: 5, Tier: "premium"}, nil)
+
+	request, _ := http.NewRequest(http.MethodPut,
+		fmt.Sprintf("%s/api/v1/admin/accounts/5/tier", suite.testingServer.URL), strings.NewReader("tier=premium"))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	response, err := http.DefaultClient.Do(request)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.AccountTier
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal("premium", responseBody.Tier)
+}
+
+func TestLimitHandler(t *testing.T) {
+	suite.Run(t, new(limitHandlerSuite))
+}
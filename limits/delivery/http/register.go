@@ -0,0 +1,23 @@
+package http
+
+import (
+	"avito-intership/limits"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// RegisterAdminEndpoints регистрирует управление лимитами и уровнями счетов, router - подмаршрутизатор /api/v1/admin
+func RegisterAdminEndpoints(router *mux.Router, uc limits.LimitUseCase) {
+	handler := NewAdminHandler(uc)
+
+	router.HandleFunc("/limits", handler.GetLimitsEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/limits", handler.CreateLimitEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/limits/{id:[0-9]+}", handler.DeleteLimitEndpoint).
+		Methods(http.MethodOptions, http.MethodDelete)
+	router.HandleFunc("/accounts/{id:[0-9]+}/tier", handler.GetTierEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/accounts/{id:[0-9]+}/tier", handler.SetTierEndpoint).
+		Methods(http.MethodOptions, http.MethodPut)
+}
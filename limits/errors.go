@@ -0,0 +1,11 @@
+package limits
+
+import "avito-intership/apperror"
+
+var (
+	ErrLimitNotFound = apperror.New(apperror.NotFound, "limit not found")
+	ErrBadLimit      = apperror.New(apperror.InvalidArgument,
+		"limit must target either an account or a tier, have a known operation and period "+
+			"and a positive max amount or count")
+	ErrBadTier = apperror.WithParam(apperror.InvalidArgument, "tier", "tier must not be empty")
+)
@@ -0,0 +1,13 @@
+package limits
+
+import "avito-intership/models"
+
+// Лимиты проверяются репозиторием баланса под блокировкой счета, здесь только управление ими
+type LimitRepository interface {
+	CreateLimit(limit *models.Limit) (*models.Limit, error)
+	DeleteLimit(id int64) error
+	GetLimits() ([]*models.Limit, error)
+	// SetTier назначает уровень счету, уровень balance.DefaultTier удаляет назначение
+	SetTier(tier *models.AccountTier) error
+	GetTier(userId int64) (*models.AccountTier, error)
+}
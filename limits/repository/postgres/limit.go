@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"avito-intership/balance"
+	"avito-intership/limits"
+	"avito-intership/models"
+	"database/sql"
+)
+
+type LimitRepository struct {
+	db *sql.DB
+}
+
+func NewLimitRepository(dbConn *sql.DB) *LimitRepository {
+	return &LimitRepository{dbConn}
+}
+
+const limitColumns = "id, user_id, tier, operation, period, max_amount, max_count"
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanLimit(row scanner) (*models.Limit, error) {
+	var limit models.Limit
+	var userId, maxCount sql.NullInt64
+	var tier sql.NullString
+	var maxAmount sql.NullFloat64
+
+	err := row.Scan(&limit.Id, &userId, &tier, &limit.Operation, &limit.Period, &maxAmount, &maxCount)
+	if err == sql.ErrNoRows {
+		return nil, limits.ErrLimitNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if userId.Valid {
+		limit.UserId = &userId.Int64
+	}
+	if tier.Valid {
+		limit.Tier = &tier.String
+	}
+	if maxAmount.Valid {
+		value := float32(maxAmount.Float64)
+		limit.MaxAmount = &value
+	}
+	if maxCount.Valid {
+		limit.MaxCount = &maxCount.Int64
+	}
+
+	return &limit, nil
+}
+
+func (r LimitRepository) CreateLimit(limit *models.Limit) (*models.Limit, error) {
+	row := r.db.QueryRow(
+		`INSERT INTO account_limits (user_id, tier, operation, period, max_amount, max_count)
+		VALUES ($1, $2, $3::limit_operation, $4::limit_period, $5, $6)
+		RETURNING `+limitColumns,
+		limit.UserId, limit.Tier, limit.Operation, limit.Period, limit.MaxAmount, limit.MaxCount)
+	return scanLimit(row)
+}
+
+func (r LimitRepository) DeleteLimit(id int64) error {
+	result, err := r.db.Exec("DELETE FROM account_limits WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return limits.ErrLimitNotFound
+	}
+
+	return nil
+}
+
+func (r LimitRepository) GetLimits() ([]*models.Limit, error) {
+	rows, err := r.db.Query("SELECT " + limitColumns + " FROM account_limits ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*models.Limit, 0)
+	for rows.Next() {
+		limit, err := scanLimit(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, limit)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r LimitRepository) SetTier(tier *models.AccountTier) error {
+	if tier.Tier == balance.DefaultTier {
+		_, err := r.db.Exec("DELETE FROM account_tiers WHERE user_id = $1", tier.UserId)
+		return err
+	}
+
+	_, err := r.db.Exec(
+		`INSERT INTO account_tiers (user_id, tier) VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET tier = EXCLUDED.tier`, tier.UserId, tier.Tier)
+	return err
+}
+
+func (r LimitRepository) GetTier(userId int64) (*models.AccountTier, error) {
+	tier := &models.AccountTier{UserId: userId, Tier: balance.DefaultTier}
+
+	err := r.db.QueryRow("SELECT tier FROM account_tiers WHERE user_id = $1", userId).Scan(&tier.Tier)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	return tier, nil
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"avito-intership/balance"
+	"avito-intership/limits"
+	"avito-intership/models"
+	"avito-intership/utils"
+	"database/sql"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/suite"
+	"log"
+	"testing"
+)
+
+type limitRepositorySuite struct {
+	suite.Suite
+
+	db       *sql.DB
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+
+	repository limits.LimitRepository
+}
+
+func (suite *limitRepositorySuite) SetupSuite() {
+	db, pool, resource := utils.DockerDBUp()
+	err := utils.InitTable(db, "../../../init.sql")
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	suite.repository = NewLimitRepository(db)
+	suite.db = db
+	suite.pool = pool
+	suite.resource = resource
+}
+
+func (suite *limitRepositorySuite) TestCreateLimit() {
+	var userId int64 = 1
+	var maxAmount float32 = 100000
+	created, err := suite.repository.CreateLimit(&models.Limit{UserId: &userId, Operation: balance.TransferOperation,
+		Period: "day", MaxAmount: &maxAmount})
+	suite.NoError(err, "creating limit should not produce error")
+	suite.Equal(userId, *created.UserId)
+	suite.Nil(created.Tier)
+	suite.Nil(created.MaxCount)
+
+	all, err := suite.repository.GetLimits()
+	suite.NoError(err, "getting limits should not produce error")
+	suite.Equal(created, all[len(all)-1])
+
+	err = suite.repository.DeleteLimit(created.Id)
+	suite.NoError(err, "deleting limit should not produce error")
+
+	err = suite.repository.DeleteLimit(created.Id)
+	suite.Equal(limits.ErrLimitNotFound, err)
+}
+
+func (suite *limitRepositorySuite) TestSetTier() {
+	tier, err := suite.repository.GetTier(2)
+	suite.NoError(err, "getting tier should not produce error")
+	suite.Equal(balance.DefaultTier, tier.Tier)
+
+	err = suite.repository.SetTier(&models.AccountTier{UserId: 2, Tier: "premium"})
+	suite.NoError(err, "setting tier should not produce error")
+
+	tier, err = suite.repository.GetTier(2)
+	suite.NoError(err, "getting tier should not produce error")
+	suite.Equal("premium", tier.Tier)
+
+	err = suite.repository.SetTier(&models.AccountTier{UserId: 2, Tier: balance.DefaultTier})
+	suite.NoError(err, "resetting tier should not produce error")
+
+	tier, err = suite.repository.GetTier(2)
+	suite.NoError(err, "getting tier should not produce error")
+	suite.Equal(balance.DefaultTier, tier.Tier)
+}
+
+func (suite *limitRepositorySuite) TearDownSuite() {
+	err := utils.DropTable(suite.db, []string{"account_limits", "account_tiers"})
+	if err != nil {
+		log.Println(err)
+	}
+
+	if err := suite.pool.Purge(suite.resource); err != nil {
+		log.Fatalf("Could not purge resource: %s", err)
+	}
+}
+
+func TestLimitRepository(t *testing.T) {
+	suite.Run(t, new(limitRepositorySuite))
+}
@@ -0,0 +1,11 @@
+package limits
+
+import "avito-intership/models"
+
+type LimitUseCase interface {
+	CreateLimit(limit *models.Limit) (*models.Limit, error)
+	DeleteLimit(id int64) error
+	GetLimits() ([]*models.Limit, error)
+	SetTier(userId int64, tier string) (*models.AccountTier, error)
+	GetTier(userId int64) (*models.AccountTier, error)
+}
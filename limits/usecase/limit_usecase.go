@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/exchange"
+	"avito-intership/limits"
+	"avito-intership/models"
+	"strings"
+)
+
+var (
+	operations = map[string]bool{balance.TransferOperation: true, balance.SpendOperation: true}
+	periods    = map[string]bool{"hour": true, "day": true, "week": true, "month": true}
+)
+
+type LimitUseCase struct {
+	repository limits.LimitRepository
+}
+
+func NewLimitUseCase(repository limits.LimitRepository) *LimitUseCase {
+	return &LimitUseCase{
+		repository: repository,
+	}
+}
+
+// validateLimit проверяет лимит и округляет максимальную сумму до копеек
+func validateLimit(limit *models.Limit) error {
+	if limit.Tier != nil {
+		tier := strings.TrimSpace(*limit.Tier)
+		limit.Tier = &tier
+	}
+
+	if (limit.UserId == nil) == (limit.Tier == nil) {
+		return limits.ErrBadLimit
+	}
+	if limit.UserId != nil && *limit.UserId <= 0 || limit.Tier != nil && *limit.Tier == "" {
+		return limits.ErrBadLimit
+	}
+
+	if !operations[limit.Operation] || !periods[limit.Period] {
+		return limits.ErrBadLimit
+	}
+
+	if limit.MaxAmount == nil && limit.MaxCount == nil {
+		return limits.ErrBadLimit
+	}
+	if limit.MaxAmount != nil {
+		maxAmount, _ := exchange.Round(exchange.Decimal(*limit.MaxAmount), exchange.RUB).Float32()
+		if maxAmount <= 0 {
+			return limits.ErrBadLimit
+		}
+		limit.MaxAmount = &maxAmount
+	}
+	if limit.MaxCount != nil && *limit.MaxCount <= 0 {
+		return limits.ErrBadLimit
+	}
+
+	return nil
+}
+
+func (u LimitUseCase) CreateLimit(limit *models.Limit) (*models.Limit, error) {
+	if err := validateLimit(limit); err != nil {
+		return nil, err
+	}
+
+	return u.repository.CreateLimit(limit)
+}
+
+func (u LimitUseCase) DeleteLimit(id int64) error {
+	return u.repository.DeleteLimit(id)
+}
+
+func (u LimitUseCase) GetLimits() ([]*models.Limit, error) {
+	return u.repository.GetLimits()
+}
+
+func (u LimitUseCase) SetTier(userId int64, tier string) (*models.AccountTier, error) {
+	tier = strings.TrimSpace(tier)
+	if tier == "" {
+		return nil, limits.ErrBadTier
+	}
+
+	accountTier := &models.AccountTier{UserId: userId, Tier: tier}
+	err := u.repository.SetTier(accountTier)
+	if err != nil {
+		return nil, err
+	}
+
+	return accountTier, nil
+}
+
+func (u LimitUseCase) GetTier(userId int64) (*models.AccountTier, error) {
+	return u.repository.GetTier(userId)
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"avito-intership/limits"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type limitUseCaseSuite struct {
+	suite.Suite
+	repository *mocks.LimitRepository
+	useCase    limits.LimitUseCase
+}
+
+func (suite *limitUseCaseSuite) SetupTest() {
+	repository := new(mocks.LimitRepository)
+
+	suite.repository = repository
+	suite.useCase = NewLimitUseCase(repository)
+}
+
+func (suite *limitUseCaseSuite) TestCreateLimit_Ok() {
+	tier := " premium "
+	var maxAmount float32 = 1000.004
+	limit := &models.Limit{Tier: &tier, Operation: "transfer", Period: "day", MaxAmount: &maxAmount}
+
+	suite.repository.On("CreateLimit", mock.MatchedBy(func(l *models.Limit) bool {
+		return *l.Tier == "premium" && *l.MaxAmount == 1000
+	})).Return(&models.Limit{Id: 1}, nil)
+
+	created, err := suite.useCase.CreateLimit(limit)
+
+	suite.NoError(err)
+	suite.Equal(int64(1), created.Id)
+}
+
+func (suite *limitUseCaseSuite) TestCreateLimit_Invalid() {
+	var userId int64 = 1
+	tier := "premium"
+	var maxCount int64 = 20
+	var zeroCount int64 = 0
+	cases := []struct {
+		name  string
+		limit *models.Limit
+	}{
+		{name: "no target", limit: &models.Limit{Operation: "transfer", Period: "hour", MaxCount: &maxCount}},
+		{name: "both targets", limit: &models.Limit{UserId: &userId, Tier: &tier, Operation: "transfer",
+			Period: "hour", MaxCount: &maxCount}},
+		{name: "unknown operation", limit: &models.Limit{UserId: &userId, Operation: "fill", Period: "hour",
+			MaxCount: &maxCount}},
+		{name: "unknown period", limit: &models.Limit{UserId: &userId, Operation: "spend", Period: "year",
+			MaxCount: &maxCount}},
+		{name: "no maximum", limit: &models.Limit{UserId: &userId, Operation: "spend", Period: "day"}},
+		{name: "zero count", limit: &models.Limit{UserId: &userId, Operation: "spend", Period: "day",
+			MaxCount: &zeroCount}},
+	}
+
+	for _, c := range cases {
+		suite.Run(c.name, func() {
+			_, err := suite.useCase.CreateLimit(c.limit)
+
+			suite.Equal(limits.ErrBadLimit, err)
+		})
+	}
+	suite.repository.AssertNotCalled(suite.T(), "CreateLimit", mock.Anything)
+}
+
+func (suite *limitUseCaseSuite) TestSetTier() {
+	suite.repository.On("SetTier", &models.AccountTier{UserId: 1, Tier: "premium"}).Return(nil)
+
+	tier, err := suite.useCase.SetTier(1, " premium ")
+
+	suite.NoError(err)
+	suite.Equal("premium", tier.Tier)
+}
+
+func (suite *limitUseCaseSuite) TestSetTier_Empty() {
+	_, err := suite.useCase.SetTier(1, " ")
+
+	suite.Equal(limits.ErrBadTier, err)
+	suite.repository.AssertNotCalled(suite.T(), "SetTier", mock.Anything)
+}
+
+func TestLimitUseCase(t *testing.T) {
+	suite.Run(t, new(limitUseCaseSuite))
+}
@@ -0,0 +1,83 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AdjustmentUseCase is an autogenerated mock type for the AdjustmentUseCase type
+type AdjustmentUseCase struct {
+	mock.Mock
+}
+
+// Adjust provides a mock function with given fields: adjustment
+func (_m *AdjustmentUseCase) Adjust(adjustment *models.Adjustment) (*models.Adjustment, error) {
+	ret := _m.Called(adjustment)
+
+	var r0 *models.Adjustment
+	if rf, ok := ret.Get(0).(func(*models.Adjustment) *models.Adjustment); ok {
+		r0 = rf(adjustment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Adjustment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Adjustment) error); ok {
+		r1 = rf(adjustment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAdjustment provides a mock function with given fields: id
+func (_m *AdjustmentUseCase) GetAdjustment(id int64) (*models.Adjustment, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Adjustment
+	if rf, ok := ret.Get(0).(func(int64) *models.Adjustment); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Adjustment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAdjustments provides a mock function with given fields: filter
+func (_m *AdjustmentUseCase) GetAdjustments(filter *models.AdjustmentFilter) ([]*models.Adjustment, error) {
+	ret := _m.Called(filter)
+
+	var r0 []*models.Adjustment
+	if rf, ok := ret.Get(0).(func(*models.AdjustmentFilter) []*models.Adjustment); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Adjustment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.AdjustmentFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,81 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AlertRepository is an autogenerated mock type for the AlertRepository type
+type AlertRepository struct {
+	mock.Mock
+}
+
+// GetSettings provides a mock function with given fields: userId
+func (_m *AlertRepository) GetSettings(userId int64) (*models.AlertSettings, error) {
+	ret := _m.Called(userId)
+
+	var r0 *models.AlertSettings
+	if rf, ok := ret.Get(0).(func(int64) *models.AlertSettings); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AlertSettings)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetLowBalanceNotified provides a mock function with given fields: userId, notified
+func (_m *AlertRepository) SetLowBalanceNotified(userId int64, notified bool) (bool, error) {
+	ret := _m.Called(userId, notified)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int64, bool) bool); ok {
+		r0 = rf(userId, notified)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, bool) error); ok {
+		r1 = rf(userId, notified)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSettings provides a mock function with given fields: settings
+func (_m *AlertRepository) SetSettings(settings *models.AlertSettings) (*models.AlertSettings, error) {
+	ret := _m.Called(settings)
+
+	var r0 *models.AlertSettings
+	if rf, ok := ret.Get(0).(func(*models.AlertSettings) *models.AlertSettings); ok {
+		r0 = rf(settings)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AlertSettings)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.AlertSettings) error); ok {
+		r1 = rf(settings)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,74 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AlertUseCase is an autogenerated mock type for the AlertUseCase type
+type AlertUseCase struct {
+	mock.Mock
+}
+
+// Evaluate provides a mock function with given fields: userId, debit
+func (_m *AlertUseCase) Evaluate(userId int64, debit float32) error {
+	ret := _m.Called(userId, debit)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, float32) error); ok {
+		r0 = rf(userId, debit)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetSettings provides a mock function with given fields: userId
+func (_m *AlertUseCase) GetSettings(userId int64) (*models.AlertSettings, error) {
+	ret := _m.Called(userId)
+
+	var r0 *models.AlertSettings
+	if rf, ok := ret.Get(0).(func(int64) *models.AlertSettings); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AlertSettings)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSettings provides a mock function with given fields: settings
+func (_m *AlertUseCase) SetSettings(settings *models.AlertSettings) (*models.AlertSettings, error) {
+	ret := _m.Called(settings)
+
+	var r0 *models.AlertSettings
+	if rf, ok := ret.Get(0).(func(*models.AlertSettings) *models.AlertSettings); ok {
+		r0 = rf(settings)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AlertSettings)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.AlertSettings) error); ok {
+		r1 = rf(settings)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
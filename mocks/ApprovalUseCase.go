@@ -0,0 +1,120 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ApprovalUseCase is an autogenerated mock type for the ApprovalUseCase type
+type ApprovalUseCase struct {
+	mock.Mock
+}
+
+// Approve provides a mock function with given fields: id, checker, note
+func (_m *ApprovalUseCase) Approve(id int64, checker string, note string) (*models.PendingOperation, error) {
+	ret := _m.Called(id, checker, note)
+
+	var r0 *models.PendingOperation
+	if rf, ok := ret.Get(0).(func(int64, string, string) *models.PendingOperation); ok {
+		r0 = rf(id, checker, note)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PendingOperation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string, string) error); ok {
+		r1 = rf(id, checker, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExpireOperations provides a mock function with given fields:
+func (_m *ApprovalUseCase) ExpireOperations() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetOperation provides a mock function with given fields: id
+func (_m *ApprovalUseCase) GetOperation(id int64) (*models.PendingOperation, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.PendingOperation
+	if rf, ok := ret.Get(0).(func(int64) *models.PendingOperation); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PendingOperation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOperations provides a mock function with given fields: status
+func (_m *ApprovalUseCase) GetOperations(status string) ([]*models.PendingOperation, error) {
+	ret := _m.Called(status)
+
+	var r0 []*models.PendingOperation
+	if rf, ok := ret.Get(0).(func(string) []*models.PendingOperation); ok {
+		r0 = rf(status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PendingOperation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reject provides a mock function with given fields: id, checker, note
+func (_m *ApprovalUseCase) Reject(id int64, checker string, note string) (*models.PendingOperation, error) {
+	ret := _m.Called(id, checker, note)
+
+	var r0 *models.PendingOperation
+	if rf, ok := ret.Get(0).(func(int64, string, string) *models.PendingOperation); ok {
+		r0 = rf(id, checker, note)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PendingOperation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string, string) error); ok {
+		r1 = rf(id, checker, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
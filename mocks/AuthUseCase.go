@@ -0,0 +1,143 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AuthUseCase is an autogenerated mock type for the AuthUseCase type
+type AuthUseCase struct {
+	mock.Mock
+}
+
+// AuthenticateKey provides a mock function with given fields: key
+func (_m *AuthUseCase) AuthenticateKey(key string) (*models.Principal, error) {
+	ret := _m.Called(key)
+
+	var r0 *models.Principal
+	if rf, ok := ret.Get(0).(func(string) *models.Principal); ok {
+		r0 = rf(key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Principal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AuthenticateToken provides a mock function with given fields: token
+func (_m *AuthUseCase) AuthenticateToken(token string) (*models.Principal, error) {
+	ret := _m.Called(token)
+
+	var r0 *models.Principal
+	if rf, ok := ret.Get(0).(func(string) *models.Principal); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Principal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKeys provides a mock function with given fields:
+func (_m *AuthUseCase) GetKeys() ([]*models.ApiKey, error) {
+	ret := _m.Called()
+
+	var r0 []*models.ApiKey
+	if rf, ok := ret.Get(0).(func() []*models.ApiKey); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ApiKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IssueKey provides a mock function with given fields: service, scopes
+func (_m *AuthUseCase) IssueKey(service string, scopes []string) (*models.ApiKey, error) {
+	ret := _m.Called(service, scopes)
+
+	var r0 *models.ApiKey
+	if rf, ok := ret.Get(0).(func(string, []string) *models.ApiKey); ok {
+		r0 = rf(service, scopes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ApiKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(service, scopes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IssueUserToken provides a mock function with given fields: userId
+func (_m *AuthUseCase) IssueUserToken(userId int64) (*models.UserToken, error) {
+	ret := _m.Called(userId)
+
+	var r0 *models.UserToken
+	if rf, ok := ret.Get(0).(func(int64) *models.UserToken); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeKey provides a mock function with given fields: id
+func (_m *AuthUseCase) RevokeKey(id int64) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
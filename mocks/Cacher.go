@@ -0,0 +1,74 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Cacher is an autogenerated mock type for the Cacher type
+type Cacher struct {
+	mock.Mock
+}
+
+// Currencies provides a mock function with given fields:
+func (_m *Cacher) Currencies() ([]string, error) {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRubleRate provides a mock function with given fields: currency
+func (_m *Cacher) GetRubleRate(currency string) (*models.Rate, error) {
+	ret := _m.Called(currency)
+
+	var r0 *models.Rate
+	if rf, ok := ret.Get(0).(func(string) *models.Rate); ok {
+		r0 = rf(currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Rate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetRate provides a mock function with given fields: rate
+func (_m *Cacher) SetRate(rate *models.Rate) error {
+	ret := _m.Called(rate)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Rate) error); ok {
+		r0 = rf(rate)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,120 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CashbackRepository is an autogenerated mock type for the CashbackRepository type
+type CashbackRepository struct {
+	mock.Mock
+}
+
+// CreateRule provides a mock function with given fields: rule
+func (_m *CashbackRepository) CreateRule(rule *models.CashbackRule) (*models.CashbackRule, error) {
+	ret := _m.Called(rule)
+
+	var r0 *models.CashbackRule
+	if rf, ok := ret.Get(0).(func(*models.CashbackRule) *models.CashbackRule); ok {
+		r0 = rf(rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CashbackRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.CashbackRule) error); ok {
+		r1 = rf(rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeactivateRule provides a mock function with given fields: id
+func (_m *CashbackRepository) DeactivateRule(id int64) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetRule provides a mock function with given fields: id
+func (_m *CashbackRepository) GetRule(id int64) (*models.CashbackRule, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.CashbackRule
+	if rf, ok := ret.Get(0).(func(int64) *models.CashbackRule); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CashbackRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRules provides a mock function with given fields:
+func (_m *CashbackRepository) GetRules() ([]*models.CashbackRule, error) {
+	ret := _m.Called()
+
+	var r0 []*models.CashbackRule
+	if rf, ok := ret.Get(0).(func() []*models.CashbackRule); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.CashbackRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateRule provides a mock function with given fields: rule
+func (_m *CashbackRepository) UpdateRule(rule *models.CashbackRule) (*models.CashbackRule, error) {
+	ret := _m.Called(rule)
+
+	var r0 *models.CashbackRule
+	if rf, ok := ret.Get(0).(func(*models.CashbackRule) *models.CashbackRule); ok {
+		r0 = rf(rule)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CashbackRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.CashbackRule) error); ok {
+		r1 = rf(rule)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,107 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DealRepository is an autogenerated mock type for the DealRepository type
+type DealRepository struct {
+	mock.Mock
+}
+
+// CreateDeal provides a mock function with given fields: deal
+func (_m *DealRepository) CreateDeal(deal *models.Deal) (*models.Deal, error) {
+	ret := _m.Called(deal)
+
+	var r0 *models.Deal
+	if rf, ok := ret.Get(0).(func(*models.Deal) *models.Deal); ok {
+		r0 = rf(deal)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Deal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Deal) error); ok {
+		r1 = rf(deal)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeal provides a mock function with given fields: id
+func (_m *DealRepository) GetDeal(id int64) (*models.Deal, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Deal
+	if rf, ok := ret.Get(0).(func(int64) *models.Deal); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Deal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDueDeals provides a mock function with given fields: at
+func (_m *DealRepository) GetDueDeals(at time.Time) ([]*models.Deal, error) {
+	ret := _m.Called(at)
+
+	var r0 []*models.Deal
+	if rf, ok := ret.Get(0).(func(time.Time) []*models.Deal); ok {
+		r0 = rf(at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Deal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Transition provides a mock function with given fields: id, from, to
+func (_m *DealRepository) Transition(id int64, from string, to string) (*models.Deal, error) {
+	ret := _m.Called(id, from, to)
+
+	var r0 *models.Deal
+	if rf, ok := ret.Get(0).(func(int64, string, string) *models.Deal); ok {
+		r0 = rf(id, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Deal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string, string) error); ok {
+		r1 = rf(id, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,143 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DealUseCase is an autogenerated mock type for the DealUseCase type
+type DealUseCase struct {
+	mock.Mock
+}
+
+// CreateDeal provides a mock function with given fields: buyerId, sellerId, amount, releaseAfter
+func (_m *DealUseCase) CreateDeal(buyerId int64, sellerId int64, amount float32, releaseAfter int64) (*models.Deal, error) {
+	ret := _m.Called(buyerId, sellerId, amount, releaseAfter)
+
+	var r0 *models.Deal
+	if rf, ok := ret.Get(0).(func(int64, int64, float32, int64) *models.Deal); ok {
+		r0 = rf(buyerId, sellerId, amount, releaseAfter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Deal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64, float32, int64) error); ok {
+		r1 = rf(buyerId, sellerId, amount, releaseAfter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Fund provides a mock function with given fields: id
+func (_m *DealUseCase) Fund(id int64) (*models.Deal, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Deal
+	if rf, ok := ret.Get(0).(func(int64) *models.Deal); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Deal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeal provides a mock function with given fields: id
+func (_m *DealUseCase) GetDeal(id int64) (*models.Deal, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Deal
+	if rf, ok := ret.Get(0).(func(int64) *models.Deal); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Deal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Refund provides a mock function with given fields: id
+func (_m *DealUseCase) Refund(id int64) (*models.Deal, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Deal
+	if rf, ok := ret.Get(0).(func(int64) *models.Deal); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Deal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Release provides a mock function with given fields: id
+func (_m *DealUseCase) Release(id int64) (*models.Deal, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Deal
+	if rf, ok := ret.Get(0).(func(int64) *models.Deal); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Deal)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseDue provides a mock function with given fields:
+func (_m *DealUseCase) ReleaseDue() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
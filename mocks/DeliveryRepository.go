@@ -0,0 +1,172 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// DeliveryRepository is an autogenerated mock type for the DeliveryRepository type
+type DeliveryRepository struct {
+	mock.Mock
+}
+
+// ClaimDue provides a mock function with given fields: at, lease, limit
+func (_m *DeliveryRepository) ClaimDue(at time.Time, lease time.Duration, limit int) ([]*models.Delivery, error) {
+	ret := _m.Called(at, lease, limit)
+
+	var r0 []*models.Delivery
+	if rf, ok := ret.Get(0).(func(time.Time, time.Duration, int) []*models.Delivery); ok {
+		r0 = rf(at, lease, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Delivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time, time.Duration, int) error); ok {
+		r1 = rf(at, lease, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Enqueue provides a mock function with given fields: event, subscriptionIds
+func (_m *DeliveryRepository) Enqueue(event *models.Event, subscriptionIds []int64) error {
+	ret := _m.Called(event, subscriptionIds)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Event, []int64) error); ok {
+		r0 = rf(event, subscriptionIds)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetDeadLetter provides a mock function with given fields: id
+func (_m *DeliveryRepository) GetDeadLetter(id int64) (*models.DeadLetter, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.DeadLetter
+	if rf, ok := ret.Get(0).(func(int64) *models.DeadLetter); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeadLetter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeadLetters provides a mock function with given fields: subscriptionId
+func (_m *DeliveryRepository) GetDeadLetters(subscriptionId int64) ([]*models.DeadLetter, error) {
+	ret := _m.Called(subscriptionId)
+
+	var r0 []*models.DeadLetter
+	if rf, ok := ret.Get(0).(func(int64) []*models.DeadLetter); ok {
+		r0 = rf(subscriptionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.DeadLetter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(subscriptionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkDelivered provides a mock function with given fields: id
+func (_m *DeliveryRepository) MarkDelivered(id int64) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MoveToDeadLetter provides a mock function with given fields: id, attempts, lastError
+func (_m *DeliveryRepository) MoveToDeadLetter(id int64, attempts int, lastError string) (*models.DeadLetter, error) {
+	ret := _m.Called(id, attempts, lastError)
+
+	var r0 *models.DeadLetter
+	if rf, ok := ret.Get(0).(func(int64, int, string) *models.DeadLetter); ok {
+		r0 = rf(id, attempts, lastError)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeadLetter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int, string) error); ok {
+		r1 = rf(id, attempts, lastError)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Replay provides a mock function with given fields: id
+func (_m *DeliveryRepository) Replay(id int64) (*models.Delivery, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Delivery
+	if rf, ok := ret.Get(0).(func(int64) *models.Delivery); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Delivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleRetry provides a mock function with given fields: id, attempts, nextAttemptAt, lastError
+func (_m *DeliveryRepository) ScheduleRetry(id int64, attempts int, nextAttemptAt time.Time, lastError string) error {
+	ret := _m.Called(id, attempts, nextAttemptAt, lastError)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, int, time.Time, string) error); ok {
+		r0 = rf(id, attempts, nextAttemptAt, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,37 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	fraud "avito-intership/fraud"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Evaluator is an autogenerated mock type for the Evaluator type
+type Evaluator struct {
+	mock.Mock
+}
+
+// Evaluate provides a mock function with given fields: transfer
+func (_m *Evaluator) Evaluate(transfer fraud.Transfer) (*fraud.Decision, error) {
+	ret := _m.Called(transfer)
+
+	var r0 *fraud.Decision
+	if rf, ok := ret.Get(0).(func(fraud.Transfer) *fraud.Decision); ok {
+		r0 = rf(transfer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*fraud.Decision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(fraud.Transfer) error); ok {
+		r1 = rf(transfer)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,74 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventRepository is an autogenerated mock type for the EventRepository type
+type EventRepository struct {
+	mock.Mock
+}
+
+// CreateEvent provides a mock function with given fields: event
+func (_m *EventRepository) CreateEvent(event *models.Event) (*models.Event, error) {
+	ret := _m.Called(event)
+
+	var r0 *models.Event
+	if rf, ok := ret.Get(0).(func(*models.Event) *models.Event); ok {
+		r0 = rf(event)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Event)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Event) error); ok {
+		r1 = rf(event)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUnpublished provides a mock function with given fields: limit
+func (_m *EventRepository) GetUnpublished(limit int) ([]*models.Event, error) {
+	ret := _m.Called(limit)
+
+	var r0 []*models.Event
+	if rf, ok := ret.Get(0).(func(int) []*models.Event); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Event)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkPublished provides a mock function with given fields: id
+func (_m *EventRepository) MarkPublished(id int64) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -2,22 +2,75 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	models "avito-intership/models"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // Exchanger is an autogenerated mock type for the Exchanger type
 type Exchanger struct {
 	mock.Mock
 }
 
+// Convert provides a mock function with given fields: amount, from, to
+func (_m *Exchanger) Convert(amount float32, from string, to string) (*models.Conversion, error) {
+	ret := _m.Called(amount, from, to)
+
+	var r0 *models.Conversion
+	if rf, ok := ret.Get(0).(func(float32, string, string) *models.Conversion); ok {
+		r0 = rf(amount, from, to)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Conversion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(float32, string, string) error); ok {
+		r1 = rf(amount, from, to)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConvertAt provides a mock function with given fields: amount, from, to, at
+func (_m *Exchanger) ConvertAt(amount float32, from string, to string, at time.Time) (*models.Conversion, error) {
+	ret := _m.Called(amount, from, to, at)
+
+	var r0 *models.Conversion
+	if rf, ok := ret.Get(0).(func(float32, string, string, time.Time) *models.Conversion); ok {
+		r0 = rf(amount, from, to, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Conversion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(float32, string, string, time.Time) error); ok {
+		r1 = rf(amount, from, to, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ConvertRubles provides a mock function with given fields: amount, currency
-func (_m *Exchanger) ConvertRubles(amount float32, currency string) (float32, error) {
+func (_m *Exchanger) ConvertRubles(amount float32, currency string) (*models.Conversion, error) {
 	ret := _m.Called(amount, currency)
 
-	var r0 float32
-	if rf, ok := ret.Get(0).(func(float32, string) float32); ok {
+	var r0 *models.Conversion
+	if rf, ok := ret.Get(0).(func(float32, string) *models.Conversion); ok {
 		r0 = rf(amount, currency)
 	} else {
-		r0 = ret.Get(0).(float32)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Conversion)
+		}
 	}
 
 	var r1 error
@@ -29,3 +82,102 @@ func (_m *Exchanger) ConvertRubles(amount float32, currency string) (float32, er
 
 	return r0, r1
 }
+
+// Currencies provides a mock function with given fields:
+func (_m *Exchanger) Currencies() []*models.Currency {
+	ret := _m.Called()
+
+	var r0 []*models.Currency
+	if rf, ok := ret.Get(0).(func() []*models.Currency); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Currency)
+		}
+	}
+
+	return r0
+}
+
+// Exchange provides a mock function with given fields: amount, currency, direction
+func (_m *Exchanger) Exchange(amount float32, currency string, direction string) (*models.Conversion, error) {
+	ret := _m.Called(amount, currency, direction)
+
+	var r0 *models.Conversion
+	if rf, ok := ret.Get(0).(func(float32, string, string) *models.Conversion); ok {
+		r0 = rf(amount, currency, direction)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Conversion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(float32, string, string) error); ok {
+		r1 = rf(amount, currency, direction)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Rates provides a mock function with given fields: base
+func (_m *Exchanger) Rates(base string) ([]*models.Rate, error) {
+	ret := _m.Called(base)
+
+	var r0 []*models.Rate
+	if rf, ok := ret.Get(0).(func(string) []*models.Rate); ok {
+		r0 = rf(base)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Rate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(base)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSpread provides a mock function with given fields: spread
+func (_m *Exchanger) SetSpread(spread *models.Spread) error {
+	ret := _m.Called(spread)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Spread) error); ok {
+		r0 = rf(spread)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Spreads provides a mock function with given fields:
+func (_m *Exchanger) Spreads() ([]*models.Spread, error) {
+	ret := _m.Called()
+
+	var r0 []*models.Spread
+	if rf, ok := ret.Get(0).(func() []*models.Spread); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Spread)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// KeyRepository is an autogenerated mock type for the KeyRepository type
+type KeyRepository struct {
+	mock.Mock
+}
+
+// CreateKey provides a mock function with given fields: key, hash
+func (_m *KeyRepository) CreateKey(key *models.ApiKey, hash []byte) (*models.ApiKey, error) {
+	ret := _m.Called(key, hash)
+
+	var r0 *models.ApiKey
+	if rf, ok := ret.Get(0).(func(*models.ApiKey, []byte) *models.ApiKey); ok {
+		r0 = rf(key, hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ApiKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.ApiKey, []byte) error); ok {
+		r1 = rf(key, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKeyByHash provides a mock function with given fields: hash
+func (_m *KeyRepository) GetKeyByHash(hash []byte) (*models.ApiKey, error) {
+	ret := _m.Called(hash)
+
+	var r0 *models.ApiKey
+	if rf, ok := ret.Get(0).(func([]byte) *models.ApiKey); ok {
+		r0 = rf(hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ApiKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func([]byte) error); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetKeys provides a mock function with given fields:
+func (_m *KeyRepository) GetKeys() ([]*models.ApiKey, error) {
+	ret := _m.Called()
+
+	var r0 []*models.ApiKey
+	if rf, ok := ret.Get(0).(func() []*models.ApiKey); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.ApiKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeKey provides a mock function with given fields: id
+func (_m *KeyRepository) RevokeKey(id int64) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
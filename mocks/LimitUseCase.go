@@ -0,0 +1,120 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// LimitUseCase is an autogenerated mock type for the LimitUseCase type
+type LimitUseCase struct {
+	mock.Mock
+}
+
+// CreateLimit provides a mock function with given fields: limit
+func (_m *LimitUseCase) CreateLimit(limit *models.Limit) (*models.Limit, error) {
+	ret := _m.Called(limit)
+
+	var r0 *models.Limit
+	if rf, ok := ret.Get(0).(func(*models.Limit) *models.Limit); ok {
+		r0 = rf(limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Limit)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Limit) error); ok {
+		r1 = rf(limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteLimit provides a mock function with given fields: id
+func (_m *LimitUseCase) DeleteLimit(id int64) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetLimits provides a mock function with given fields:
+func (_m *LimitUseCase) GetLimits() ([]*models.Limit, error) {
+	ret := _m.Called()
+
+	var r0 []*models.Limit
+	if rf, ok := ret.Get(0).(func() []*models.Limit); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Limit)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTier provides a mock function with given fields: userId
+func (_m *LimitUseCase) GetTier(userId int64) (*models.AccountTier, error) {
+	ret := _m.Called(userId)
+
+	var r0 *models.AccountTier
+	if rf, ok := ret.Get(0).(func(int64) *models.AccountTier); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccountTier)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetTier provides a mock function with given fields: userId, tier
+func (_m *LimitUseCase) SetTier(userId int64, tier string) (*models.AccountTier, error) {
+	ret := _m.Called(userId, tier)
+
+	var r0 *models.AccountTier
+	if rf, ok := ret.Get(0).(func(int64, string) *models.AccountTier); ok {
+		r0 = rf(userId, tier)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AccountTier)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string) error); ok {
+		r1 = rf(userId, tier)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,29 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Listener is an autogenerated mock type for the Listener type
+type Listener struct {
+	mock.Mock
+}
+
+// Listen provides a mock function with given fields: ctx, handle
+func (_m *Listener) Listen(ctx context.Context, handle func(*models.Transaction)) error {
+	ret := _m.Called(ctx, handle)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*models.Transaction)) error); ok {
+		r0 = rf(ctx, handle)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,120 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// OverrideUseCase is an autogenerated mock type for the OverrideUseCase type
+type OverrideUseCase struct {
+	mock.Mock
+}
+
+// CreateOverride provides a mock function with given fields: override, operator, reason
+func (_m *OverrideUseCase) CreateOverride(override *models.RateOverride, operator string, reason string) (*models.RateOverride, error) {
+	ret := _m.Called(override, operator, reason)
+
+	var r0 *models.RateOverride
+	if rf, ok := ret.Get(0).(func(*models.RateOverride, string, string) *models.RateOverride); ok {
+		r0 = rf(override, operator, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RateOverride)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.RateOverride, string, string) error); ok {
+		r1 = rf(override, operator, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOverrideLog provides a mock function with given fields: id
+func (_m *OverrideUseCase) GetOverrideLog(id int64) ([]*models.RateOverrideChange, error) {
+	ret := _m.Called(id)
+
+	var r0 []*models.RateOverrideChange
+	if rf, ok := ret.Get(0).(func(int64) []*models.RateOverrideChange); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.RateOverrideChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOverrides provides a mock function with given fields: currency
+func (_m *OverrideUseCase) GetOverrides(currency string) ([]*models.RateOverride, error) {
+	ret := _m.Called(currency)
+
+	var r0 []*models.RateOverride
+	if rf, ok := ret.Get(0).(func(string) []*models.RateOverride); ok {
+		r0 = rf(currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.RateOverride)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeOverride provides a mock function with given fields: id, operator, reason
+func (_m *OverrideUseCase) RevokeOverride(id int64, operator string, reason string) error {
+	ret := _m.Called(id, operator, reason)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, string, string) error); ok {
+		r0 = rf(id, operator, reason)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateOverride provides a mock function with given fields: override, operator, reason
+func (_m *OverrideUseCase) UpdateOverride(override *models.RateOverride, operator string, reason string) (*models.RateOverride, error) {
+	ret := _m.Called(override, operator, reason)
+
+	var r0 *models.RateOverride
+	if rf, ok := ret.Get(0).(func(*models.RateOverride, string, string) *models.RateOverride); ok {
+		r0 = rf(override, operator, reason)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.RateOverride)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.RateOverride, string, string) error); ok {
+		r1 = rf(override, operator, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,143 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ProductUseCase is an autogenerated mock type for the ProductUseCase type
+type ProductUseCase struct {
+	mock.Mock
+}
+
+// CreateProduct provides a mock function with given fields: _a0
+func (_m *ProductUseCase) CreateProduct(_a0 *models.Product) (*models.Product, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *models.Product
+	if rf, ok := ret.Get(0).(func(*models.Product) *models.Product); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Product) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeactivateProduct provides a mock function with given fields: id
+func (_m *ProductUseCase) DeactivateProduct(id int64) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetProduct provides a mock function with given fields: id
+func (_m *ProductUseCase) GetProduct(id int64) (*models.Product, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Product
+	if rf, ok := ret.Get(0).(func(int64) *models.Product); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProducts provides a mock function with given fields: onlyActive
+func (_m *ProductUseCase) GetProducts(onlyActive bool) ([]*models.Product, error) {
+	ret := _m.Called(onlyActive)
+
+	var r0 []*models.Product
+	if rf, ok := ret.Get(0).(func(bool) []*models.Product); ok {
+		r0 = rf(onlyActive)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Product)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(bool) error); ok {
+		r1 = rf(onlyActive)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Purchase provides a mock function with given fields: userId, productId
+func (_m *ProductUseCase) Purchase(userId int64, productId int64) (*models.Purchase, error) {
+	ret := _m.Called(userId, productId)
+
+	var r0 *models.Purchase
+	if rf, ok := ret.Get(0).(func(int64, int64) *models.Purchase); ok {
+		r0 = rf(userId, productId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Purchase)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64) error); ok {
+		r1 = rf(userId, productId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateProduct provides a mock function with given fields: _a0
+func (_m *ProductUseCase) UpdateProduct(_a0 *models.Product) (*models.Product, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *models.Product
+	if rf, ok := ret.Get(0).(func(*models.Product) *models.Product); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Product)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Product) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
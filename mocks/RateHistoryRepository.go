@@ -0,0 +1,52 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// RateHistoryRepository is an autogenerated mock type for the RateHistoryRepository type
+type RateHistoryRepository struct {
+	mock.Mock
+}
+
+// GetRate provides a mock function with given fields: currency, date
+func (_m *RateHistoryRepository) GetRate(currency string, date time.Time) (*models.Rate, error) {
+	ret := _m.Called(currency, date)
+
+	var r0 *models.Rate
+	if rf, ok := ret.Get(0).(func(string, time.Time) *models.Rate); ok {
+		r0 = rf(currency, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Rate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(currency, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveRate provides a mock function with given fields: rate, date
+func (_m *RateHistoryRepository) SaveRate(rate *models.Rate, date time.Time) error {
+	ret := _m.Called(rate, date)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Rate, time.Time) error); ok {
+		r0 = rf(rate, date)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -2,7 +2,12 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	models "avito-intership/models"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // RateRepository is an autogenerated mock type for the RateRepository type
 type RateRepository struct {
@@ -10,14 +15,16 @@ type RateRepository struct {
 }
 
 // GetRubleRate provides a mock function with given fields: currency
-func (_m *RateRepository) GetRubleRate(currency string) (float32, error) {
+func (_m *RateRepository) GetRubleRate(currency string) (*models.Rate, error) {
 	ret := _m.Called(currency)
 
-	var r0 float32
-	if rf, ok := ret.Get(0).(func(string) float32); ok {
+	var r0 *models.Rate
+	if rf, ok := ret.Get(0).(func(string) *models.Rate); ok {
 		r0 = rf(currency)
 	} else {
-		r0 = ret.Get(0).(float32)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Rate)
+		}
 	}
 
 	var r1 error
@@ -29,3 +36,49 @@ func (_m *RateRepository) GetRubleRate(currency string) (float32, error) {
 
 	return r0, r1
 }
+
+// GetRubleRateAt provides a mock function with given fields: currency, date
+func (_m *RateRepository) GetRubleRateAt(currency string, date time.Time) (*models.Rate, error) {
+	ret := _m.Called(currency, date)
+
+	var r0 *models.Rate
+	if rf, ok := ret.Get(0).(func(string, time.Time) *models.Rate); ok {
+		r0 = rf(currency, date)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Rate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, time.Time) error); ok {
+		r1 = rf(currency, date)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRubleRates provides a mock function with given fields:
+func (_m *RateRepository) GetRubleRates() ([]*models.Rate, error) {
+	ret := _m.Called()
+
+	var r0 []*models.Rate
+	if rf, ok := ret.Get(0).(func() []*models.Rate); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Rate)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
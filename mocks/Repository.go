@@ -4,6 +4,7 @@ package mocks
 
 import (
 	models "avito-intership/models"
+	time "time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -13,6 +14,29 @@ type Repository struct {
 	mock.Mock
 }
 
+// ApprovePending provides a mock function with given fields: id, checker, note
+func (_m *Repository) ApprovePending(id int64, checker string, note *string) (*models.PendingOperation, error) {
+	ret := _m.Called(id, checker, note)
+
+	var r0 *models.PendingOperation
+	if rf, ok := ret.Get(0).(func(int64, string, *string) *models.PendingOperation); ok {
+		r0 = rf(id, checker, note)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PendingOperation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string, *string) error); ok {
+		r1 = rf(id, checker, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ChangeBalance provides a mock function with given fields: userId, amount, productId
 func (_m *Repository) ChangeBalance(userId int64, amount float32, productId int64) error {
 	ret := _m.Called(userId, amount, productId)
@@ -27,6 +51,154 @@ func (_m *Repository) ChangeBalance(userId int64, amount float32, productId int6
 	return r0
 }
 
+// ChangeBalanceWithFee provides a mock function with given fields: userId, amount, productId, fee
+func (_m *Repository) ChangeBalanceWithFee(userId int64, amount float32, productId int64, fee float32) error {
+	ret := _m.Called(userId, amount, productId, fee)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, float32, int64, float32) error); ok {
+		r0 = rf(userId, amount, productId, fee)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateAdjustment provides a mock function with given fields: adjustment, expiresAt
+func (_m *Repository) CreateAdjustment(adjustment *models.Adjustment, expiresAt *time.Time) (*models.Adjustment, error) {
+	ret := _m.Called(adjustment, expiresAt)
+
+	var r0 *models.Adjustment
+	if rf, ok := ret.Get(0).(func(*models.Adjustment, *time.Time) *models.Adjustment); ok {
+		r0 = rf(adjustment, expiresAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Adjustment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Adjustment, *time.Time) error); ok {
+		r1 = rf(adjustment, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreatePending provides a mock function with given fields: operation
+func (_m *Repository) CreatePending(operation *models.PendingOperation) (*models.PendingOperation, error) {
+	ret := _m.Called(operation)
+
+	var r0 *models.PendingOperation
+	if rf, ok := ret.Get(0).(func(*models.PendingOperation) *models.PendingOperation); ok {
+		r0 = rf(operation)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PendingOperation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.PendingOperation) error); ok {
+		r1 = rf(operation)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExpireBonuses provides a mock function with given fields: at
+func (_m *Repository) ExpireBonuses(at time.Time) (int64, error) {
+	ret := _m.Called(at)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(at)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExpirePending provides a mock function with given fields: at
+func (_m *Repository) ExpirePending(at time.Time) (int64, error) {
+	ret := _m.Called(at)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(time.Time) int64); ok {
+		r0 = rf(at)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time) error); ok {
+		r1 = rf(at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAdjustment provides a mock function with given fields: id
+func (_m *Repository) GetAdjustment(id int64) (*models.Adjustment, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Adjustment
+	if rf, ok := ret.Get(0).(func(int64) *models.Adjustment); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Adjustment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAdjustments provides a mock function with given fields: filter
+func (_m *Repository) GetAdjustments(filter *models.AdjustmentFilter) ([]*models.Adjustment, error) {
+	ret := _m.Called(filter)
+
+	var r0 []*models.Adjustment
+	if rf, ok := ret.Get(0).(func(*models.AdjustmentFilter) []*models.Adjustment); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Adjustment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.AdjustmentFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetBalance provides a mock function with given fields: userId
 func (_m *Repository) GetBalance(userId int64) (float32, error) {
 	ret := _m.Called(userId)
@@ -48,6 +220,29 @@ func (_m *Repository) GetBalance(userId int64) (float32, error) {
 	return r0, r1
 }
 
+// GetBonuses provides a mock function with given fields: userId, at
+func (_m *Repository) GetBonuses(userId int64, at time.Time) ([]*models.Bonus, error) {
+	ret := _m.Called(userId, at)
+
+	var r0 []*models.Bonus
+	if rf, ok := ret.Get(0).(func(int64, time.Time) []*models.Bonus); ok {
+		r0 = rf(userId, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Bonus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, time.Time) error); ok {
+		r1 = rf(userId, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetHistory provides a mock function with given fields: userId, page, perPage, sort, desc
 func (_m *Repository) GetHistory(userId int64, page int64, perPage int64, sort int, desc bool) ([]*models.Transaction, error) {
 	ret := _m.Called(userId, page, perPage, sort, desc)
@@ -71,6 +266,98 @@ func (_m *Repository) GetHistory(userId int64, page int64, perPage int64, sort i
 	return r0, r1
 }
 
+// GetPending provides a mock function with given fields: id
+func (_m *Repository) GetPending(id int64) (*models.PendingOperation, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.PendingOperation
+	if rf, ok := ret.Get(0).(func(int64) *models.PendingOperation); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PendingOperation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPendingOperations provides a mock function with given fields: status
+func (_m *Repository) GetPendingOperations(status string) ([]*models.PendingOperation, error) {
+	ret := _m.Called(status)
+
+	var r0 []*models.PendingOperation
+	if rf, ok := ret.Get(0).(func(string) []*models.PendingOperation); ok {
+		r0 = rf(status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.PendingOperation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantBonus provides a mock function with given fields: userId, amount, expiresAt
+func (_m *Repository) GrantBonus(userId int64, amount float32, expiresAt time.Time) (*models.Bonus, error) {
+	ret := _m.Called(userId, amount, expiresAt)
+
+	var r0 *models.Bonus
+	if rf, ok := ret.Get(0).(func(int64, float32, time.Time) *models.Bonus); ok {
+		r0 = rf(userId, amount, expiresAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Bonus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, float32, time.Time) error); ok {
+		r1 = rf(userId, amount, expiresAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RejectPending provides a mock function with given fields: id, checker, note
+func (_m *Repository) RejectPending(id int64, checker string, note *string) (*models.PendingOperation, error) {
+	ret := _m.Called(id, checker, note)
+
+	var r0 *models.PendingOperation
+	if rf, ok := ret.Get(0).(func(int64, string, *string) *models.PendingOperation); ok {
+		r0 = rf(id, checker, note)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.PendingOperation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string, *string) error); ok {
+		r1 = rf(id, checker, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TransferMoney provides a mock function with given fields: srcUserId, dstUserId, amount
 func (_m *Repository) TransferMoney(srcUserId int64, dstUserId int64, amount float32) error {
 	ret := _m.Called(srcUserId, dstUserId, amount)
@@ -84,3 +371,17 @@ func (_m *Repository) TransferMoney(srcUserId int64, dstUserId int64, amount flo
 
 	return r0
 }
+
+// TransferMoneyWithFee provides a mock function with given fields: srcUserId, payouts
+func (_m *Repository) TransferMoneyWithFee(srcUserId int64, payouts []*models.Payout) error {
+	ret := _m.Called(srcUserId, payouts)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, []*models.Payout) error); ok {
+		r0 = rf(srcUserId, payouts)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
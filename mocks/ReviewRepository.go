@@ -0,0 +1,106 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReviewRepository is an autogenerated mock type for the ReviewRepository type
+type ReviewRepository struct {
+	mock.Mock
+}
+
+// CreateReview provides a mock function with given fields: review
+func (_m *ReviewRepository) CreateReview(review *models.TransferReview) (*models.TransferReview, error) {
+	ret := _m.Called(review)
+
+	var r0 *models.TransferReview
+	if rf, ok := ret.Get(0).(func(*models.TransferReview) *models.TransferReview); ok {
+		r0 = rf(review)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TransferReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.TransferReview) error); ok {
+		r1 = rf(review)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReview provides a mock function with given fields: id
+func (_m *ReviewRepository) GetReview(id int64) (*models.TransferReview, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.TransferReview
+	if rf, ok := ret.Get(0).(func(int64) *models.TransferReview); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TransferReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReviews provides a mock function with given fields: status
+func (_m *ReviewRepository) GetReviews(status string) ([]*models.TransferReview, error) {
+	ret := _m.Called(status)
+
+	var r0 []*models.TransferReview
+	if rf, ok := ret.Get(0).(func(string) []*models.TransferReview); ok {
+		r0 = rf(status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TransferReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetStatus provides a mock function with given fields: id, from, to, operator, note
+func (_m *ReviewRepository) SetStatus(id int64, from string, to string, operator *string, note *string) (*models.TransferReview, error) {
+	ret := _m.Called(id, from, to, operator, note)
+
+	var r0 *models.TransferReview
+	if rf, ok := ret.Get(0).(func(int64, string, string, *string, *string) *models.TransferReview); ok {
+		r0 = rf(id, from, to, operator, note)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TransferReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string, string, *string, *string) error); ok {
+		r1 = rf(id, from, to, operator, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
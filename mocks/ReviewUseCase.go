@@ -0,0 +1,106 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ReviewUseCase is an autogenerated mock type for the ReviewUseCase type
+type ReviewUseCase struct {
+	mock.Mock
+}
+
+// Approve provides a mock function with given fields: id, operator, note
+func (_m *ReviewUseCase) Approve(id int64, operator string, note string) (*models.TransferReview, error) {
+	ret := _m.Called(id, operator, note)
+
+	var r0 *models.TransferReview
+	if rf, ok := ret.Get(0).(func(int64, string, string) *models.TransferReview); ok {
+		r0 = rf(id, operator, note)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TransferReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string, string) error); ok {
+		r1 = rf(id, operator, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReview provides a mock function with given fields: id
+func (_m *ReviewUseCase) GetReview(id int64) (*models.TransferReview, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.TransferReview
+	if rf, ok := ret.Get(0).(func(int64) *models.TransferReview); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TransferReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReviews provides a mock function with given fields: status
+func (_m *ReviewUseCase) GetReviews(status string) ([]*models.TransferReview, error) {
+	ret := _m.Called(status)
+
+	var r0 []*models.TransferReview
+	if rf, ok := ret.Get(0).(func(string) []*models.TransferReview); ok {
+		r0 = rf(status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.TransferReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Reject provides a mock function with given fields: id, operator, note
+func (_m *ReviewUseCase) Reject(id int64, operator string, note string) (*models.TransferReview, error) {
+	ret := _m.Called(id, operator, note)
+
+	var r0 *models.TransferReview
+	if rf, ok := ret.Get(0).(func(int64, string, string) *models.TransferReview); ok {
+		r0 = rf(id, operator, note)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TransferReview)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, string, string) error); ok {
+		r1 = rf(id, operator, note)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
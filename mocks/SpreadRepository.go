@@ -0,0 +1,74 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SpreadRepository is an autogenerated mock type for the SpreadRepository type
+type SpreadRepository struct {
+	mock.Mock
+}
+
+// GetSpread provides a mock function with given fields: currency, direction
+func (_m *SpreadRepository) GetSpread(currency string, direction string) (*models.Spread, error) {
+	ret := _m.Called(currency, direction)
+
+	var r0 *models.Spread
+	if rf, ok := ret.Get(0).(func(string, string) *models.Spread); ok {
+		r0 = rf(currency, direction)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Spread)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(currency, direction)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSpreads provides a mock function with given fields:
+func (_m *SpreadRepository) GetSpreads() ([]*models.Spread, error) {
+	ret := _m.Called()
+
+	var r0 []*models.Spread
+	if rf, ok := ret.Get(0).(func() []*models.Spread); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Spread)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSpread provides a mock function with given fields: spread
+func (_m *SpreadRepository) SetSpread(spread *models.Spread) error {
+	ret := _m.Called(spread)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Spread) error); ok {
+		r0 = rf(spread)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
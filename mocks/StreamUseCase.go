@@ -0,0 +1,39 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StreamUseCase is an autogenerated mock type for the StreamUseCase type
+type StreamUseCase struct {
+	mock.Mock
+}
+
+// Subscribe provides a mock function with given fields: userId
+func (_m *StreamUseCase) Subscribe(userId int64) (<-chan *models.BalanceUpdate, func()) {
+	ret := _m.Called(userId)
+
+	var r0 <-chan *models.BalanceUpdate
+	if rf, ok := ret.Get(0).(func(int64) <-chan *models.BalanceUpdate); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *models.BalanceUpdate)
+		}
+	}
+
+	var r1 func()
+	if rf, ok := ret.Get(1).(func(int64) func()); ok {
+		r1 = rf(userId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+
+	return r0, r1
+}
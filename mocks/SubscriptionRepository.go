@@ -0,0 +1,97 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SubscriptionRepository is an autogenerated mock type for the SubscriptionRepository type
+type SubscriptionRepository struct {
+	mock.Mock
+}
+
+// CreateSubscription provides a mock function with given fields: subscription
+func (_m *SubscriptionRepository) CreateSubscription(subscription *models.Subscription) (*models.Subscription, error) {
+	ret := _m.Called(subscription)
+
+	var r0 *models.Subscription
+	if rf, ok := ret.Get(0).(func(*models.Subscription) *models.Subscription); ok {
+		r0 = rf(subscription)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.Subscription) error); ok {
+		r1 = rf(subscription)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteSubscription provides a mock function with given fields: id
+func (_m *SubscriptionRepository) DeleteSubscription(id int64) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetSubscription provides a mock function with given fields: id
+func (_m *SubscriptionRepository) GetSubscription(id int64) (*models.Subscription, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Subscription
+	if rf, ok := ret.Get(0).(func(int64) *models.Subscription); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubscriptions provides a mock function with given fields:
+func (_m *SubscriptionRepository) GetSubscriptions() ([]*models.Subscription, error) {
+	ret := _m.Called()
+
+	var r0 []*models.Subscription
+	if rf, ok := ret.Get(0).(func() []*models.Subscription); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
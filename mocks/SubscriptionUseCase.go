@@ -0,0 +1,166 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// SubscriptionUseCase is an autogenerated mock type for the SubscriptionUseCase type
+type SubscriptionUseCase struct {
+	mock.Mock
+}
+
+// GetDeadLetter provides a mock function with given fields: id
+func (_m *SubscriptionUseCase) GetDeadLetter(id int64) (*models.DeadLetter, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.DeadLetter
+	if rf, ok := ret.Get(0).(func(int64) *models.DeadLetter); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.DeadLetter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeadLetters provides a mock function with given fields: subscriptionId
+func (_m *SubscriptionUseCase) GetDeadLetters(subscriptionId int64) ([]*models.DeadLetter, error) {
+	ret := _m.Called(subscriptionId)
+
+	var r0 []*models.DeadLetter
+	if rf, ok := ret.Get(0).(func(int64) []*models.DeadLetter); ok {
+		r0 = rf(subscriptionId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.DeadLetter)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(subscriptionId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubscription provides a mock function with given fields: id
+func (_m *SubscriptionUseCase) GetSubscription(id int64) (*models.Subscription, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Subscription
+	if rf, ok := ret.Get(0).(func(int64) *models.Subscription); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSubscriptions provides a mock function with given fields:
+func (_m *SubscriptionUseCase) GetSubscriptions() ([]*models.Subscription, error) {
+	ret := _m.Called()
+
+	var r0 []*models.Subscription
+	if rf, ok := ret.Get(0).(func() []*models.Subscription); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Replay provides a mock function with given fields: id
+func (_m *SubscriptionUseCase) Replay(id int64) (*models.Delivery, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.Delivery
+	if rf, ok := ret.Get(0).(func(int64) *models.Delivery); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Delivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Subscribe provides a mock function with given fields: url, eventTypes
+func (_m *SubscriptionUseCase) Subscribe(url string, eventTypes []string) (*models.Subscription, error) {
+	ret := _m.Called(url, eventTypes)
+
+	var r0 *models.Subscription
+	if rf, ok := ret.Get(0).(func(string, []string) *models.Subscription); ok {
+		r0 = rf(url, eventTypes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(url, eventTypes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Unsubscribe provides a mock function with given fields: id
+func (_m *SubscriptionUseCase) Unsubscribe(id int64) error {
+	ret := _m.Called(id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64) error); ok {
+		r0 = rf(id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,51 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TokenUseCase is an autogenerated mock type for the TokenUseCase type
+type TokenUseCase struct {
+	mock.Mock
+}
+
+// IssueToken provides a mock function with given fields: userId
+func (_m *TokenUseCase) IssueToken(userId int64) (*models.StreamToken, error) {
+	ret := _m.Called(userId)
+
+	var r0 *models.StreamToken
+	if rf, ok := ret.Get(0).(func(int64) *models.StreamToken); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.StreamToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyToken provides a mock function with given fields: userId, token
+func (_m *TokenUseCase) VerifyToken(userId int64, token string) error {
+	ret := _m.Called(userId, token)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, string) error); ok {
+		r0 = rf(userId, token)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,107 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TransferHistoryRepository is an autogenerated mock type for the TransferHistoryRepository type
+type TransferHistoryRepository struct {
+	mock.Mock
+}
+
+// AccountAge provides a mock function with given fields: userId
+func (_m *TransferHistoryRepository) AccountAge(userId int64) (*time.Duration, error) {
+	ret := _m.Called(userId)
+
+	var r0 *time.Duration
+	if rf, ok := ret.Get(0).(func(int64) *time.Duration); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*time.Duration)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountSenders provides a mock function with given fields: userId, window
+func (_m *TransferHistoryRepository) CountSenders(userId int64, window time.Duration) (int64, error) {
+	ret := _m.Called(userId, window)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(int64, time.Duration) int64); ok {
+		r0 = rf(userId, window)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, time.Duration) error); ok {
+		r1 = rf(userId, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HasTransfer provides a mock function with given fields: srcUserId, dstUserId, window
+func (_m *TransferHistoryRepository) HasTransfer(srcUserId int64, dstUserId int64, window time.Duration) (bool, error) {
+	ret := _m.Called(srcUserId, dstUserId, window)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int64, int64, time.Duration) bool); ok {
+		r0 = rf(srcUserId, dstUserId, window)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64, time.Duration) error); ok {
+		r1 = rf(srcUserId, dstUserId, window)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// OutgoingStats provides a mock function with given fields: userId
+func (_m *TransferHistoryRepository) OutgoingStats(userId int64) (float32, int64, error) {
+	ret := _m.Called(userId)
+
+	var r0 float32
+	if rf, ok := ret.Get(0).(func(int64) float32); ok {
+		r0 = rf(userId)
+	} else {
+		r0 = ret.Get(0).(float32)
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(int64) int64); ok {
+		r1 = rf(userId)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(int64) error); ok {
+		r2 = rf(userId)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
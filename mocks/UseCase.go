@@ -27,15 +27,54 @@ func (_m *UseCase) ChangeBalance(userId int64, amount float32, productId int64)
 	return r0
 }
 
+// ChangeBalanceInCurrency provides a mock function with given fields: userId, amount, productId, currency
+func (_m *UseCase) ChangeBalanceInCurrency(userId int64, amount float32, productId int64, currency string) (*models.Conversion, error) {
+	ret := _m.Called(userId, amount, productId, currency)
+
+	var r0 *models.Conversion
+	if rf, ok := ret.Get(0).(func(int64, float32, int64, string) *models.Conversion); ok {
+		r0 = rf(userId, amount, productId, currency)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Conversion)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, float32, int64, string) error); ok {
+		r1 = rf(userId, amount, productId, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExpireBonuses provides a mock function with given fields:
+func (_m *UseCase) ExpireBonuses() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetBalance provides a mock function with given fields: userId, currency
-func (_m *UseCase) GetBalance(userId int64, currency string) (float32, error) {
+func (_m *UseCase) GetBalance(userId int64, currency string) (*models.Balance, error) {
 	ret := _m.Called(userId, currency)
 
-	var r0 float32
-	if rf, ok := ret.Get(0).(func(int64, string) float32); ok {
+	var r0 *models.Balance
+	if rf, ok := ret.Get(0).(func(int64, string) *models.Balance); ok {
 		r0 = rf(userId, currency)
 	} else {
-		r0 = ret.Get(0).(float32)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Balance)
+		}
 	}
 
 	var r1 error
@@ -48,13 +87,13 @@ func (_m *UseCase) GetBalance(userId int64, currency string) (float32, error) {
 	return r0, r1
 }
 
-// GetHistory provides a mock function with given fields: userId, page, perPage, sort, desc
-func (_m *UseCase) GetHistory(userId int64, page int64, perPage int64, sort int, desc bool) ([]*models.Transaction, error) {
-	ret := _m.Called(userId, page, perPage, sort, desc)
+// GetHistory provides a mock function with given fields: userId, page, perPage, sort, desc, currency
+func (_m *UseCase) GetHistory(userId int64, page int64, perPage int64, sort int, desc bool, currency string) ([]*models.Transaction, error) {
+	ret := _m.Called(userId, page, perPage, sort, desc, currency)
 
 	var r0 []*models.Transaction
-	if rf, ok := ret.Get(0).(func(int64, int64, int64, int, bool) []*models.Transaction); ok {
-		r0 = rf(userId, page, perPage, sort, desc)
+	if rf, ok := ret.Get(0).(func(int64, int64, int64, int, bool, string) []*models.Transaction); ok {
+		r0 = rf(userId, page, perPage, sort, desc, currency)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*models.Transaction)
@@ -62,8 +101,54 @@ func (_m *UseCase) GetHistory(userId int64, page int64, perPage int64, sort int,
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(int64, int64, int64, int, bool) error); ok {
-		r1 = rf(userId, page, perPage, sort, desc)
+	if rf, ok := ret.Get(1).(func(int64, int64, int64, int, bool, string) error); ok {
+		r1 = rf(userId, page, perPage, sort, desc, currency)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GrantBonus provides a mock function with given fields: userId, amount, days
+func (_m *UseCase) GrantBonus(userId int64, amount float32, days int) (*models.Bonus, error) {
+	ret := _m.Called(userId, amount, days)
+
+	var r0 *models.Bonus
+	if rf, ok := ret.Get(0).(func(int64, float32, int) *models.Bonus); ok {
+		r0 = rf(userId, amount, days)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Bonus)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, float32, int) error); ok {
+		r1 = rf(userId, amount, days)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SplitPayment provides a mock function with given fields: srcUserId, amount, shares, commission
+func (_m *UseCase) SplitPayment(srcUserId int64, amount float32, shares []*models.Share, commission *models.Commission) ([]*models.Payout, error) {
+	ret := _m.Called(srcUserId, amount, shares, commission)
+
+	var r0 []*models.Payout
+	if rf, ok := ret.Get(0).(func(int64, float32, []*models.Share, *models.Commission) []*models.Payout); ok {
+		r0 = rf(srcUserId, amount, shares, commission)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Payout)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, float32, []*models.Share, *models.Commission) error); ok {
+		r1 = rf(srcUserId, amount, shares, commission)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -84,3 +169,40 @@ func (_m *UseCase) TransferMoney(srcUserId int64, dstUserId int64, amount float3
 
 	return r0
 }
+
+// TransferMoneyWithCommission provides a mock function with given fields: srcUserId, dstUserId, amount, commission
+func (_m *UseCase) TransferMoneyWithCommission(srcUserId int64, dstUserId int64, amount float32, commission *models.Commission) ([]*models.Payout, error) {
+	ret := _m.Called(srcUserId, dstUserId, amount, commission)
+
+	var r0 []*models.Payout
+	if rf, ok := ret.Get(0).(func(int64, int64, float32, *models.Commission) []*models.Payout); ok {
+		r0 = rf(srcUserId, dstUserId, amount, commission)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Payout)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64, int64, float32, *models.Commission) error); ok {
+		r1 = rf(srcUserId, dstUserId, amount, commission)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TransferReviewed provides a mock function with given fields: review, maker
+func (_m *UseCase) TransferReviewed(review *models.TransferReview, maker string) error {
+	ret := _m.Called(review, maker)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.TransferReview, string) error); ok {
+		r0 = rf(review, maker)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
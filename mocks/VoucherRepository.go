@@ -0,0 +1,98 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// VoucherRepository is an autogenerated mock type for the VoucherRepository type
+type VoucherRepository struct {
+	mock.Mock
+}
+
+// CreateBatch provides a mock function with given fields: batch
+func (_m *VoucherRepository) CreateBatch(batch *models.VoucherBatch) (*models.VoucherBatch, error) {
+	ret := _m.Called(batch)
+
+	var r0 *models.VoucherBatch
+	if rf, ok := ret.Get(0).(func(*models.VoucherBatch) *models.VoucherBatch); ok {
+		r0 = rf(batch)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.VoucherBatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*models.VoucherBatch) error); ok {
+		r1 = rf(batch)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBatch provides a mock function with given fields: id
+func (_m *VoucherRepository) GetBatch(id int64) (*models.VoucherBatch, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.VoucherBatch
+	if rf, ok := ret.Get(0).(func(int64) *models.VoucherBatch); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.VoucherBatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVoucherBatch provides a mock function with given fields: code
+func (_m *VoucherRepository) GetVoucherBatch(code string) (*models.VoucherBatch, error) {
+	ret := _m.Called(code)
+
+	var r0 *models.VoucherBatch
+	if rf, ok := ret.Get(0).(func(string) *models.VoucherBatch); ok {
+		r0 = rf(code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.VoucherBatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Redeem provides a mock function with given fields: code, userId, credited, at
+func (_m *VoucherRepository) Redeem(code string, userId int64, credited float32, at time.Time) error {
+	ret := _m.Called(code, userId, credited, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64, float32, time.Time) error); ok {
+		r0 = rf(code, userId, credited, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.9.4. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "avito-intership/models"
+	time "time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// VoucherUseCase is an autogenerated mock type for the VoucherUseCase type
+type VoucherUseCase struct {
+	mock.Mock
+}
+
+// GetBatch provides a mock function with given fields: id
+func (_m *VoucherUseCase) GetBatch(id int64) (*models.VoucherBatch, error) {
+	ret := _m.Called(id)
+
+	var r0 *models.VoucherBatch
+	if rf, ok := ret.Get(0).(func(int64) *models.VoucherBatch); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.VoucherBatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(int64) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IssueBatch provides a mock function with given fields: amount, currency, expiresAt, usageLimit, count
+func (_m *VoucherUseCase) IssueBatch(amount float32, currency string, expiresAt time.Time, usageLimit int64, count int) (*models.VoucherBatch, error) {
+	ret := _m.Called(amount, currency, expiresAt, usageLimit, count)
+
+	var r0 *models.VoucherBatch
+	if rf, ok := ret.Get(0).(func(float32, string, time.Time, int64, int) *models.VoucherBatch); ok {
+		r0 = rf(amount, currency, expiresAt, usageLimit, count)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.VoucherBatch)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(float32, string, time.Time, int64, int) error); ok {
+		r1 = rf(amount, currency, expiresAt, usageLimit, count)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Redeem provides a mock function with given fields: code, userId
+func (_m *VoucherUseCase) Redeem(code string, userId int64) (*models.Redemption, error) {
+	ret := _m.Called(code, userId)
+
+	var r0 *models.Redemption
+	if rf, ok := ret.Get(0).(func(string, int64) *models.Redemption); ok {
+		r0 = rf(code, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Redemption)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, int64) error); ok {
+		r1 = rf(code, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
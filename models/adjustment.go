@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Adjustment - исправление баланса оператором поддержки
+type Adjustment struct {
+	Id     int64   `json:"id"`
+	UserId int64   `json:"user_id"`
+	Amount float32 `json:"amount"`
+	// Оператор, код причины из фиксированного списка и комментарий обязательны
+	Operator string `json:"operator"`
+	Reason   string `json:"reason"`
+	Comment  string `json:"comment"`
+	// Состояние операции корректировки в истории
+	Status string `json:"status"`
+	// Операция, ожидающая одобрения, если сумма корректировки выше порога
+	OperationId *int64    `json:"operation_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// AdjustmentFilter - условия выборки корректировок, нулевые поля не ограничивают выборку
+type AdjustmentFilter struct {
+	UserId   int64
+	Operator string
+	Reason   string
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// AlertSettings - пороги уведомлений пользователя, nil отключает уведомление
+type AlertSettings struct {
+	UserId int64 `json:"user_id"`
+	// Уведомлять, когда баланс опускается ниже LowBalance
+	LowBalance *float32 `json:"low_balance"`
+	// Уведомлять о списании больше LargeDebit одной операцией
+	LargeDebit *float32 `json:"large_debit"`
+}
+
+type Alert struct {
+	Type   string `json:"type"`
+	UserId int64  `json:"user_id"`
+	// Порог из настроек, который был пересечен
+	Threshold float32 `json:"threshold"`
+	Balance   float32 `json:"balance"`
+	// Сумма списания для уведомления о крупном списании
+	Amount    float32   `json:"amount,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
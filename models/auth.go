@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// ApiKey - ключ внутреннего сервиса; в базе хранится только хэш ключа
+type ApiKey struct {
+	Id      int64  `json:"id"`
+	Service string `json:"service"`
+	// Сам ключ, возвращается только при выпуске
+	Key string `json:"key,omitempty"`
+	// Начало ключа, по которому его можно узнать в списке
+	Prefix    string     `json:"prefix"`
+	Scopes    []string   `json:"scopes"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+}
+
+// UserToken - JWT, с которым клиентское приложение вызывает API от имени пользователя
+type UserToken struct {
+	UserId    int64     `json:"user_id"`
+	Token     string    `json:"token"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Principal - вызывающий, от имени которого выполняется запрос
+type Principal struct {
+	// Сервис, которому выдан ключ API; пусто для пользователя
+	Service string `json:"service"`
+	// Пользователь, которому выдан токен; 0 для сервиса
+	UserId int64    `json:"user_id"`
+	Scopes []string `json:"scopes"`
+}
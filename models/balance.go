@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+type Balance struct {
+	UserId int64 `json:"user_id"`
+	// Основной баланс, только его можно переводить другим пользователям
+	Amount float32 `json:"amount"`
+	// Бонусный баланс, тратится на услуги в первую очередь
+	Bonus float32 `json:"bonus"`
+	// Ближайшая дата сгорания бонусов
+	BonusExpiresAt *time.Time `json:"bonus_expires_at"`
+	Currency       string     `json:"currency"`
+	Rate           *Rate      `json:"rate"`
+	// Курс с учетом спреда при покупке валюты
+	AppliedRate float32 `json:"applied_rate"`
+}
+
+type Bonus struct {
+	Id     int64 `json:"id"`
+	UserId int64 `json:"user_id"`
+	// Неизрасходованный остаток начисления
+	Amount    float32   `json:"amount"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+type CashbackRule struct {
+	Id int64 `json:"id"`
+	// Товар, на покупку которого начисляется кэшбэк; nil - любая покупка
+	ProductId *int64  `json:"product_id"`
+	Percent   float32 `json:"percent"`
+	// Максимальная сумма кэшбэка по правилу для одного пользователя за календарный месяц, nil - без ограничения
+	MonthlyCap *float32  `json:"monthly_cap"`
+	Active     bool      `json:"active"`
+	CreatedAt  time.Time `json:"created_at"`
+}
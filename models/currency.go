@@ -0,0 +1,7 @@
+package models
+
+type Currency struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	MinorUnits int    `json:"minor_units"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type Deal struct {
+	Id       int64   `json:"id"`
+	BuyerId  int64   `json:"buyer_id"`
+	SellerId int64   `json:"seller_id"`
+	Amount   float32 `json:"amount"`
+	Status   string  `json:"status"`
+	// Через сколько секунд после оплаты сделка закрывается в пользу продавца без подтверждения покупателя
+	ReleaseAfter int64      `json:"release_after"`
+	CreatedAt    time.Time  `json:"created_at"`
+	FundedAt     *time.Time `json:"funded_at"`
+	ClosedAt     *time.Time `json:"closed_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event - доменное событие из outbox, публикуется в порядке id
+type Event struct {
+	Id   int64  `json:"id"`
+	Type string `json:"type"`
+	// Версия схемы payload, увеличивается при несовместимых изменениях
+	SchemaVersion int `json:"schema_version"`
+	// Пользователь, баланс которого изменился
+	UserId    int64           `json:"user_id"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
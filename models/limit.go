@@ -0,0 +1,22 @@
+package models
+
+// Limit ограничивает исходящие операции счета за календарный период.
+// Задается либо для конкретного счета UserId, либо для всех счетов уровня Tier
+type Limit struct {
+	Id     int64   `json:"id"`
+	UserId *int64  `json:"user_id"`
+	Tier   *string `json:"tier"`
+	// Операция: "transfer" - исходящие переводы, "spend" - списания на услуги
+	Operation string `json:"operation"`
+	// Период: "hour", "day", "week" или "month"
+	Period string `json:"period"`
+	// Максимальная сумма операций за период в рублях, nil - без ограничения
+	MaxAmount *float32 `json:"max_amount"`
+	// Максимальное количество операций за период, nil - без ограничения
+	MaxCount *int64 `json:"max_count"`
+}
+
+type AccountTier struct {
+	UserId int64  `json:"user_id"`
+	Tier   string `json:"tier"`
+}
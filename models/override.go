@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// RateOverride - курс рубля, назначенный вручную на период действия
+type RateOverride struct {
+	Id        int64      `json:"id"`
+	Currency  string     `json:"currency"`
+	Rate      float32    `json:"rate"`
+	ValidFrom time.Time  `json:"valid_from"`
+	ValidTo   *time.Time `json:"valid_to"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// IsActive проверяет, действует ли назначенный курс в момент at
+func (o *RateOverride) IsActive(at time.Time) bool {
+	return !at.Before(o.ValidFrom) && (o.ValidTo == nil || at.Before(*o.ValidTo))
+}
+
+// RateOverrideChange - запись журнала изменений назначенных курсов
+type RateOverrideChange struct {
+	OverrideId int64      `json:"override_id"`
+	Action     string     `json:"action"`
+	Operator   string     `json:"operator"`
+	Reason     string     `json:"reason"`
+	Rate       float32    `json:"rate"`
+	ValidFrom  time.Time  `json:"valid_from"`
+	ValidTo    *time.Time `json:"valid_to"`
+	Time       time.Time  `json:"time"`
+}
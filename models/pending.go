@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// PendingOperation - перевод или ручное изменение баланса выше порога, ожидающее одобрения оператором
+type PendingOperation struct {
+	Id   int64  `json:"id"`
+	Type string `json:"type"`
+	// Счет, с которого переводятся деньги, либо счет, баланс которого изменяется
+	UserId int64 `json:"user_id"`
+	// id товара для списания, id корректировки для корректировок оператором, 0 для пополнения и переводов
+	TargetId int64 `json:"target_id"`
+	// Сумма списания со счета при переводе, либо изменение баланса в рублях
+	Amount float32 `json:"amount"`
+	// Доход от спреда при изменении баланса в валюте
+	Fee float32 `json:"fee,omitempty"`
+	// Получатели перевода
+	Payouts []*Payout `json:"payouts,omitempty"`
+	Status  string    `json:"status"`
+	// Оператор, создавший операцию; одобрить ее должен другой оператор
+	Maker     *string    `json:"maker"`
+	Checker   *string    `json:"checker"`
+	Note      *string    `json:"note"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	DecidedAt *time.Time `json:"decided_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+type Product struct {
+	Id       int64   `json:"id"`
+	Name     string  `json:"name"`
+	Price    float32 `json:"price"`
+	Currency string  `json:"currency"`
+	// Неактивный товар остается в каталоге, но не может быть куплен
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type Purchase struct {
+	UserId  int64    `json:"user_id"`
+	Product *Product `json:"product"`
+	// Списанная с баланса сумма в рублях
+	Amount     float32     `json:"amount"`
+	Conversion *Conversion `json:"conversion,omitempty"`
+}
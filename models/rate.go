@@ -0,0 +1,36 @@
+package models
+
+import (
+	"math/big"
+	"time"
+)
+
+// Rate - цена единицы Currency в единицах Base
+type Rate struct {
+	Base     string  `json:"base"`
+	Currency string  `json:"currency"`
+	Value    float32 `json:"value"`
+	// Точное значение курса, по которому выполняется конвертация; Value - его округление для ответов API.
+	// Отсутствует у курсов, назначенных вручную, для них точным считается Value
+	Exact     *big.Rat  `json:"-"`
+	UpdatedAt time.Time `json:"updated_at"`
+	FetchedAt time.Time `json:"fetched_at"`
+	Stale     bool      `json:"stale"`
+	Source    string    `json:"source"`
+}
+
+// Age возвращает возраст курса относительно времени его публикации источником
+func (r *Rate) Age() time.Duration {
+	return time.Since(r.UpdatedAt)
+}
+
+// Conversion - результат конвертации; Rate содержит биржевой курс, AppliedRate - курс с учетом спреда,
+// Fee - доход платформы от спреда в валюте Currency
+type Conversion struct {
+	Amount      float32 `json:"amount"`
+	From        string  `json:"from"`
+	Currency    string  `json:"currency"`
+	Rate        *Rate   `json:"rate"`
+	AppliedRate float32 `json:"applied_rate"`
+	Fee         float32 `json:"fee"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// TransferReview - перевод, отложенный правилами антифрода до решения оператора
+type TransferReview struct {
+	Id     int64   `json:"id"`
+	SrcId  int64   `json:"src_id"`
+	DstId  int64   `json:"dst_id"`
+	Amount float32 `json:"amount"`
+	// Комиссия перевода, nil - перевод без комиссии
+	Commission *Commission `json:"commission"`
+	// Доли получателей, если на проверку отправлен разделенный платеж целиком; DstId - получатель,
+	// перевод которому потребовал проверки
+	Shares []*Share `json:"shares,omitempty"`
+	// Правило, отправившее перевод на проверку, и его пояснение
+	Rule      string     `json:"rule"`
+	Reason    string     `json:"reason"`
+	Status    string     `json:"status"`
+	Operator  *string    `json:"operator"`
+	Note      *string    `json:"note"`
+	CreatedAt time.Time  `json:"created_at"`
+	DecidedAt *time.Time `json:"decided_at"`
+}
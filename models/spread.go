@@ -0,0 +1,8 @@
+package models
+
+// Spread - наценка к биржевому курсу в процентах при покупке или продаже валюты клиентом
+type Spread struct {
+	Currency  string  `json:"currency"`
+	Direction string  `json:"direction"`
+	Percent   float32 `json:"percent"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// BalanceUpdate - состояние счета после операции, которое отправляется подписчикам потока
+type BalanceUpdate struct {
+	Balance *Balance `json:"balance"`
+	// Операция, изменившая счет; отсутствует в первом сообщении потока и после переподключения к базе
+	Transaction *Transaction `json:"transaction"`
+}
+
+type StreamToken struct {
+	UserId    int64     `json:"user_id"`
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
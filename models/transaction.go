@@ -3,9 +3,26 @@ package models
 import "time"
 
 type Transaction struct {
+	Id       int64     `json:"id"`
 	UserId   int64     `json:"user_id"`
 	Amount   float32   `json:"amount"`
 	TargetId int64     `json:"target_id"`
 	Type     string    `json:"type"`
 	Time     time.Time `json:"time"`
+	// Состояние операции: "completed", либо "pending", "rejected", "expired" для операций, требующих одобрения
+	Status string `json:"status"`
+	// Операция, ожидающая одобрения, в составе которой записана эта
+	OperationId *int64 `json:"operation_id,omitempty"`
+	// Комиссия, удержанная при переводе
+	Fee float32 `json:"fee,omitempty"`
+	// Изменение бонусного баланса в составе операции
+	Bonus float32 `json:"bonus,omitempty"`
+	// Внешний идентификатор основания операции, например код ваучера
+	Reference *string `json:"reference,omitempty"`
+	// Операция, в связи с которой начислена эта, например покупка для кэшбэка
+	LinkedId *int64 `json:"linked_id,omitempty"`
+	// Название товара из каталога для операций типа product
+	ProductName *string `json:"product_name,omitempty"`
+	// Сумма в запрошенной валюте по курсу на момент операции
+	Converted *Conversion `json:"converted,omitempty"`
 }
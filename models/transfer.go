@@ -0,0 +1,21 @@
+package models
+
+// Commission - комиссия за перевод: процент от суммы, но не меньше Min, либо фиксированная сумма Fixed
+type Commission struct {
+	Percent float32 `json:"percent"`
+	Min     float32 `json:"min"`
+	Fixed   float32 `json:"fixed"`
+}
+
+// Share - доля получателя при разделении платежа, доли не обязаны давать в сумме 100
+type Share struct {
+	UserId int64   `json:"user_id"`
+	Share  float32 `json:"share"`
+}
+
+// Payout - часть перевода одному получателю: отправитель платит Amount + Fee, получатель получает Amount
+type Payout struct {
+	UserId int64   `json:"user_id"`
+	Amount float32 `json:"amount"`
+	Fee    float32 `json:"fee"`
+}
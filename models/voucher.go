@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// VoucherBatch - выпуск кодов с одинаковыми условиями
+type VoucherBatch struct {
+	Id       int64   `json:"id"`
+	Amount   float32 `json:"amount"`
+	Currency string  `json:"currency"`
+	// Сколько раз можно погасить каждый код, каждый пользователь гасит код не более одного раза
+	UsageLimit int64      `json:"usage_limit"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	Vouchers   []*Voucher `json:"vouchers"`
+}
+
+type Voucher struct {
+	Code    string `json:"code"`
+	BatchId int64  `json:"batch_id"`
+	Uses    int64  `json:"uses"`
+}
+
+type Redemption struct {
+	Code     string  `json:"code"`
+	UserId   int64   `json:"user_id"`
+	Amount   float32 `json:"amount"`
+	Currency string  `json:"currency"`
+	// Зачисленная на баланс сумма в рублях
+	Credited float32 `json:"credited"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// Subscription - подписка внутреннего сервиса на события
+type Subscription struct {
+	Id  int64  `json:"id"`
+	Url string `json:"url"`
+	// Типы событий: точный тип, "balance.*" - все типы с префиксом "balance." или "*" - все события
+	EventTypes []string `json:"event_types"`
+	// Ключ подписи HMAC-SHA256, возвращается только при создании подписки
+	Secret    string    `json:"secret,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Delivery - доставка события подписчику, ожидающая очередной попытки
+type Delivery struct {
+	Id             int64  `json:"id"`
+	SubscriptionId int64  `json:"subscription_id"`
+	Url            string `json:"url"`
+	Secret         string `json:"-"`
+	Event          *Event `json:"event"`
+	// Количество неудачных попыток
+	Attempts      int       `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	LastError     *string   `json:"last_error"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// DeadLetter - доставка, для которой закончились попытки
+type DeadLetter struct {
+	Id             int64     `json:"id"`
+	SubscriptionId int64     `json:"subscription_id"`
+	Event          *Event    `json:"event"`
+	Attempts       int       `json:"attempts"`
+	LastError      string    `json:"last_error"`
+	FailedAt       time.Time `json:"failed_at"`
+	// Момент повторной постановки в очередь доставки
+	ReplayedAt *time.Time `json:"replayed_at"`
+}
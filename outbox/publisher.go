@@ -0,0 +1,9 @@
+package outbox
+
+import "avito-intership/models"
+
+// Publisher доставляет событие подписчикам. Ошибка означает, что событие нужно отправить повторно,
+// поэтому подписчики должны быть готовы получить одно событие несколько раз
+type Publisher interface {
+	Publish(event *models.Event) error
+}
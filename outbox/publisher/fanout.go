@@ -0,0 +1,27 @@
+package publisher
+
+import (
+	"avito-intership/models"
+	"avito-intership/outbox"
+)
+
+// Fanout передает событие всем публикаторам по очереди. При ошибке событие отправляется повторно
+// всем публикаторам, в том числе тем, кто его уже получил
+type Fanout struct {
+	publishers []outbox.Publisher
+}
+
+func NewFanout(publishers ...outbox.Publisher) *Fanout {
+	return &Fanout{publishers: publishers}
+}
+
+func (f *Fanout) Publish(event *models.Event) error {
+	for _, publisher := range f.publishers {
+		err := publisher.Publish(event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
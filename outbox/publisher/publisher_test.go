@@ -0,0 +1,68 @@
+package publisher
+
+import (
+	"avito-intership/models"
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func testEvent() *models.Event {
+	return &models.Event{Id: 7, Type: "balance.debited", SchemaVersion: 1, UserId: 1,
+		Payload: json.RawMessage(`{"id":3,"amount":-100}`), CreatedAt: time.Now().UTC()}
+}
+
+func TestWriterPublisher(t *testing.T) {
+	var buffer bytes.Buffer
+	publisher := NewWriterPublisher(&buffer)
+
+	assert.NoError(t, publisher.Publish(testEvent()))
+	assert.NoError(t, publisher.Publish(testEvent()))
+
+	lines := bytes.Split(bytes.TrimSpace(buffer.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+
+	var event models.Event
+	assert.NoError(t, json.Unmarshal(lines[0], &event))
+	assert.Equal(t, int64(7), event.Id)
+	assert.JSONEq(t, `{"id":3,"amount":-100}`, string(event.Payload))
+}
+
+func TestWebhookPublisher(t *testing.T) {
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := NewWebhookPublisher(server.URL).Publish(testEvent())
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "7", received.Header.Get("X-Event-Id"))
+	assert.Equal(t, "balance.debited", received.Header.Get("X-Event-Type"))
+	assert.Equal(t, "1", received.Header.Get("X-Schema-Version"))
+
+	var event models.Event
+	assert.NoError(t, json.Unmarshal(body, &event))
+	assert.Equal(t, "balance.debited", event.Type)
+}
+
+func TestWebhookPublisher_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	err := NewWebhookPublisher(server.URL).Publish(testEvent())
+
+	assert.Error(t, err)
+}
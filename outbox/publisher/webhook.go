@@ -0,0 +1,55 @@
+package publisher
+
+import (
+	"avito-intership/models"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// WebhookPublisher отправляет каждое событие POST-запросом на url,
+// событие считается доставленным при ответе с кодом 2xx
+type WebhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookPublisher(url string) *WebhookPublisher {
+	return &WebhookPublisher{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+func (p *WebhookPublisher) Publish(event *models.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Event-Id", strconv.FormatInt(event.Id, 10))
+	request.Header.Set("X-Event-Type", event.Type)
+	request.Header.Set("X-Schema-Version", strconv.Itoa(event.SchemaVersion))
+
+	response, err := p.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded with %d to event %d", p.url, response.StatusCode, event.Id)
+	}
+
+	return nil
+}
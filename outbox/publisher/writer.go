@@ -0,0 +1,46 @@
+package publisher
+
+import (
+	"avito-intership/models"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+)
+
+// WriterPublisher пишет события в w по одному JSON-объекту на строку
+type WriterPublisher struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewWriterPublisher(w io.Writer) *WriterPublisher {
+	return &WriterPublisher{w: w}
+}
+
+func NewStdoutPublisher() *WriterPublisher {
+	return NewWriterPublisher(os.Stdout)
+}
+
+// NewFilePublisher дописывает события в конец файла path
+func NewFilePublisher(path string) (*WriterPublisher, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWriterPublisher(file), nil
+}
+
+func (p *WriterPublisher) Publish(event *models.Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	_, err = p.w.Write(append(line, '\n'))
+	return err
+}
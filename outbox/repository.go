@@ -0,0 +1,42 @@
+package outbox
+
+import (
+	"avito-intership/balance"
+	"avito-intership/models"
+)
+
+// SchemaVersion - текущая версия схемы событий, payload событий об изменении баланса - models.Transaction
+const SchemaVersion = 1
+
+// Типы событий об изменении баланса, тип самой операции передается в payload
+const (
+	EventBalanceCredited   = "balance.credited"
+	EventBalanceDebited    = "balance.debited"
+	EventTransferCompleted = "transfer.completed"
+	EventBonusGranted      = "bonus.granted"
+	EventBonusExpired      = "bonus.expired"
+)
+
+// TransactionEventType возвращает тип события для выполненной операции
+func TransactionEventType(transaction *models.Transaction) string {
+	switch {
+	case transaction.Type == balance.TransferType:
+		return EventTransferCompleted
+	case transaction.Type == balance.BonusType:
+		return EventBonusGranted
+	case transaction.Type == balance.BonusExpiredType:
+		return EventBonusExpired
+	case transaction.Amount < 0:
+		return EventBalanceDebited
+	default:
+		return EventBalanceCredited
+	}
+}
+
+type EventRepository interface {
+	// CreateEvent записывает событие, не связанное с операцией с балансом
+	CreateEvent(event *models.Event) (*models.Event, error)
+	// GetUnpublished возвращает до limit неопубликованных событий в порядке записи
+	GetUnpublished(limit int) ([]*models.Event, error)
+	MarkPublished(id int64) error
+}
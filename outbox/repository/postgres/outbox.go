@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"avito-intership/models"
+	"avito-intership/outbox"
+	"database/sql"
+	"encoding/json"
+)
+
+type EventRepository struct {
+	db *sql.DB
+}
+
+func NewEventRepository(dbConn *sql.DB) *EventRepository {
+	return &EventRepository{dbConn}
+}
+
+// AppendTransaction записывает событие об операции с балансом в транзакции tx, в которой записана сама операция.
+// Операции одного пользователя сериализуются блокировкой его счета, поэтому их события идут в порядке id
+func AppendTransaction(tx *sql.Tx, transaction *models.Transaction) error {
+	payload, err := json.Marshal(transaction)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(
+		"INSERT INTO outbox_events (type, schema_version, user_id, payload) VALUES ($1, $2, $3, $4)",
+		outbox.TransactionEventType(transaction), outbox.SchemaVersion, transaction.UserId, payload)
+	return err
+}
+
+func (r EventRepository) CreateEvent(event *models.Event) (*models.Event, error) {
+	created := *event
+	created.SchemaVersion = outbox.SchemaVersion
+	err := r.db.QueryRow(
+		`INSERT INTO outbox_events (type, schema_version, user_id, payload) VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`,
+		event.Type, outbox.SchemaVersion, event.UserId, []byte(event.Payload)).Scan(&created.Id, &created.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (r EventRepository) GetUnpublished(limit int) ([]*models.Event, error) {
+	rows, err := r.db.Query(
+		`SELECT id, type, schema_version, user_id, payload, created_at FROM outbox_events
+		WHERE published_at IS NULL ORDER BY id LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*models.Event, 0)
+	for rows.Next() {
+		var event models.Event
+		var payload []byte
+		err = rows.Scan(&event.Id, &event.Type, &event.SchemaVersion, &event.UserId, &payload, &event.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		event.Payload = payload
+		events = append(events, &event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r EventRepository) MarkPublished(id int64) error {
+	_, err := r.db.Exec("UPDATE outbox_events SET published_at = NOW() WHERE id = $1", id)
+	return err
+}
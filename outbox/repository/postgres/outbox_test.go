@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"avito-intership/balance"
+	"avito-intership/models"
+	"avito-intership/outbox"
+	"avito-intership/utils"
+	"database/sql"
+	"encoding/json"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/suite"
+	"log"
+	"testing"
+)
+
+type eventRepositorySuite struct {
+	suite.Suite
+
+	db       *sql.DB
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+
+	repository outbox.EventRepository
+}
+
+func (suite *eventRepositorySuite) SetupSuite() {
+	db, pool, resource := utils.DockerDBUp()
+	err := utils.InitTable(db, "../../../init.sql")
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	suite.repository = NewEventRepository(db)
+	suite.db = db
+	suite.pool = pool
+	suite.resource = resource
+}
+
+func (suite *eventRepositorySuite) append(transaction *models.Transaction) {
+	tx, err := suite.db.Begin()
+	suite.Require().NoError(err, "beginning transaction should not produce error")
+
+	err = AppendTransaction(tx, transaction)
+	suite.NoError(err, "appending event should not produce error")
+	suite.NoError(tx.Commit())
+}
+
+func (suite *eventRepositorySuite) TestAppendAndPublish() {
+	suite.append(&models.Transaction{Id: 1, UserId: 1, Amount: 100, Type: balance.RefillType})
+	suite.append(&models.Transaction{Id: 2, UserId: 1, Amount: -40, TargetId: 2, Type: balance.TransferType})
+
+	events, err := suite.repository.GetUnpublished(10)
+	suite.NoError(err, "getting events should not produce error")
+	suite.Len(events, 2)
+
+	suite.Equal(outbox.EventBalanceCredited, events[0].Type)
+	suite.Equal(outbox.SchemaVersion, events[0].SchemaVersion)
+	suite.Equal(int64(1), events[0].UserId)
+	suite.True(events[0].Id < events[1].Id)
+
+	var transaction models.Transaction
+	err = json.Unmarshal(events[1].Payload, &transaction)
+	suite.NoError(err, "payload should be a transaction")
+	suite.Equal(float32(-40), transaction.Amount)
+	suite.Equal(int64(2), transaction.TargetId)
+
+	suite.NoError(suite.repository.MarkPublished(events[0].Id), "marking event should not produce error")
+
+	events, err = suite.repository.GetUnpublished(10)
+	suite.NoError(err, "getting events should not produce error")
+	suite.Len(events, 1)
+	suite.Equal(outbox.EventTransferCompleted, events[0].Type)
+}
+
+func (suite *eventRepositorySuite) TestRolledBackEventIsNotVisible() {
+	tx, err := suite.db.Begin()
+	suite.Require().NoError(err, "beginning transaction should not produce error")
+	suite.NoError(AppendTransaction(tx, &models.Transaction{UserId: 99, Amount: 1, Type: balance.RefillType}))
+	suite.NoError(tx.Rollback())
+
+	events, err := suite.repository.GetUnpublished(100)
+	suite.NoError(err, "getting events should not produce error")
+	for _, event := range events {
+		suite.NotEqual(int64(99), event.UserId)
+	}
+}
+
+func (suite *eventRepositorySuite) TearDownSuite() {
+	err := utils.DropTable(suite.db, []string{"outbox_events"})
+	if err != nil {
+		log.Println(err)
+	}
+
+	if err := suite.pool.Purge(suite.resource); err != nil {
+		log.Fatalf("Could not purge resource: %s", err)
+	}
+}
+
+func TestEventRepository(t *testing.T) {
+	suite.Run(t, new(eventRepositorySuite))
+}
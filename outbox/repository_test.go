@@ -0,0 +1,27 @@
+package outbox
+
+import (
+	"avito-intership/balance"
+	"avito-intership/models"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTransactionEventType(t *testing.T) {
+	cases := []struct {
+		transaction models.Transaction
+		eventType   string
+	}{
+		{models.Transaction{Type: balance.RefillType, Amount: 100}, EventBalanceCredited},
+		{models.Transaction{Type: balance.CashbackType, Amount: 5}, EventBalanceCredited},
+		{models.Transaction{Type: balance.WithdrawType, Amount: -100}, EventBalanceDebited},
+		{models.Transaction{Type: balance.TransferType, Amount: -100}, EventTransferCompleted},
+		{models.Transaction{Type: balance.TransferType, Amount: 100}, EventTransferCompleted},
+		{models.Transaction{Type: balance.BonusType, Amount: 50, Bonus: 50}, EventBonusGranted},
+		{models.Transaction{Type: balance.BonusExpiredType, Amount: -50, Bonus: -50}, EventBonusExpired},
+	}
+
+	for _, c := range cases {
+		assert.Equal(t, c.eventType, TransactionEventType(&c.transaction))
+	}
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"avito-intership/outbox"
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	relayInterval  = time.Second
+	relayBatchSize = 100
+)
+
+// Relay публикует события из outbox строго по порядку: если событие не удалось опубликовать,
+// следующие ждут его повторной отправки
+type Relay struct {
+	repo      outbox.EventRepository
+	publisher outbox.Publisher
+	interval  time.Duration
+}
+
+func NewRelay(repo outbox.EventRepository, publisher outbox.Publisher) *Relay {
+	return &Relay{
+		repo:      repo,
+		publisher: publisher,
+		interval:  relayInterval,
+	}
+}
+
+// RelayOnce публикует накопленные события и возвращает количество опубликованных
+func (r *Relay) RelayOnce() (int, error) {
+	published := 0
+	for {
+		events, err := r.repo.GetUnpublished(relayBatchSize)
+		if err != nil {
+			return published, err
+		}
+
+		for _, event := range events {
+			err = r.publisher.Publish(event)
+			if err != nil {
+				return published, err
+			}
+
+			err = r.repo.MarkPublished(event.Id)
+			if err != nil {
+				return published, err
+			}
+			published++
+		}
+
+		if len(events) < relayBatchSize {
+			return published, nil
+		}
+	}
+}
+
+// Run периодически публикует события до отмены ctx
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.RelayOnce(); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type relaySuite struct {
+	suite.Suite
+	repository *mocks.EventRepository
+	publisher  *mocks.Publisher
+	relay      *Relay
+}
+
+func (suite *relaySuite) SetupTest() {
+	suite.repository = new(mocks.EventRepository)
+	suite.publisher = new(mocks.Publisher)
+	suite.relay = NewRelay(suite.repository, suite.publisher)
+}
+
+func (suite *relaySuite) TestRelayOnce_PublishesInOrder() {
+	events := []*models.Event{{Id: 1}, {Id: 2}, {Id: 3}}
+	suite.repository.On("GetUnpublished", relayBatchSize).Return(events, nil)
+
+	published := make([]int64, 0)
+	suite.publisher.On("Publish", mock.Anything).Run(func(args mock.Arguments) {
+		published = append(published, args.Get(0).(*models.Event).Id)
+	}).Return(nil)
+	suite.repository.On("MarkPublished", mock.Anything).Return(nil)
+
+	count, err := suite.relay.RelayOnce()
+
+	suite.NoError(err)
+	suite.Equal(3, count)
+	suite.Equal([]int64{1, 2, 3}, published)
+	suite.repository.AssertNumberOfCalls(suite.T(), "MarkPublished", 3)
+}
+
+func (suite *relaySuite) TestRelayOnce_StopsOnFailure() {
+	events := []*models.Event{{Id: 1}, {Id: 2}, {Id: 3}}
+	suite.repository.On("GetUnpublished", relayBatchSize).Return(events, nil)
+	suite.publisher.On("Publish", events[0]).Return(nil)
+	suite.publisher.On("Publish", events[1]).Return(errors.New("subscriber is down"))
+	suite.repository.On("MarkPublished", int64(1)).Return(nil)
+
+	count, err := suite.relay.RelayOnce()
+
+	suite.Error(err)
+	suite.Equal(1, count)
+	suite.publisher.AssertNotCalled(suite.T(), "Publish", events[2])
+	suite.repository.AssertNotCalled(suite.T(), "MarkPublished", int64(2))
+}
+
+func (suite *relaySuite) TestRelayOnce_DrainsFullBatches() {
+	batch := make([]*models.Event, relayBatchSize)
+	for i := range batch {
+		batch[i] = &models.Event{Id: int64(i + 1)}
+	}
+	suite.repository.On("GetUnpublished", relayBatchSize).Return(batch, nil).Once()
+	suite.repository.On("GetUnpublished", relayBatchSize).Return([]*models.Event{{Id: 101}}, nil).Once()
+	suite.publisher.On("Publish", mock.Anything).Return(nil)
+	suite.repository.On("MarkPublished", mock.Anything).Return(nil)
+
+	count, err := suite.relay.RelayOnce()
+
+	suite.NoError(err)
+	suite.Equal(relayBatchSize+1, count)
+}
+
+func TestRelay(t *testing.T) {
+	suite.Run(t, new(relaySuite))
+}
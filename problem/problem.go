@@ -0,0 +1,92 @@
+package problem
+
+import (
+	"avito-intership/apperror"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+const ContentType = "application/problem+json"
+
+// statuses - единственное место, где коды ошибок сопоставляются кодам HTTP
+var statuses = map[apperror.Code]int{
+	apperror.InvalidArgument:   http.StatusBadRequest,
+	apperror.Unauthorized:      http.StatusUnauthorized,
+	apperror.PermissionDenied:  http.StatusForbidden,
+	apperror.NotFound:          http.StatusNotFound,
+	apperror.Conflict:          http.StatusConflict,
+	apperror.InsufficientFunds: http.StatusConflict,
+	apperror.LimitExceeded:     http.StatusTooManyRequests,
+	apperror.ConversionFailed:  http.StatusServiceUnavailable,
+	apperror.TransferHeld:      http.StatusAccepted,
+	apperror.ApprovalRequired:  http.StatusAccepted,
+	apperror.Unavailable:       http.StatusServiceUnavailable,
+	apperror.Internal:          http.StatusInternalServerError,
+}
+
+// Problem - ответ с ошибкой по RFC 7807; code, param и retryable - расширения документа
+type Problem struct {
+	Type      string        `json:"type"`
+	Title     string        `json:"title"`
+	Status    int           `json:"status"`
+	Detail    string        `json:"detail"`
+	Code      apperror.Code `json:"code"`
+	Param     string        `json:"param,omitempty"`
+	Retryable bool          `json:"retryable"`
+
+	// Секунды до момента, когда запрос имеет смысл повторить, передаются в заголовке Retry-After
+	retryAfter *int64
+}
+
+// New описывает ошибку err; внутренние ошибки записываются в журнал, клиент получает только их код
+func New(err error) *Problem {
+	appErr := apperror.From(err)
+	if appErr.Code == apperror.Internal {
+		log.Println(err)
+	}
+
+	status, ok := statuses[appErr.Code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	p := &Problem{
+		Type:      "about:blank",
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    appErr.Message,
+		Code:      appErr.Code,
+		Param:     appErr.Param,
+		Retryable: appErr.Retryable,
+	}
+
+	var retryErr interface{ RetryAfter() int64 }
+	if errors.As(err, &retryErr) {
+		retryAfter := retryErr.RetryAfter()
+		p.retryAfter = &retryAfter
+	}
+
+	return p
+}
+
+// Write отвечает ошибкой err
+func Write(w http.ResponseWriter, err error) {
+	WriteProblem(w, New(err), nil)
+}
+
+// WriteProblem отвечает документом body, который дополняет p своими полями; nil означает сам p
+func WriteProblem(w http.ResponseWriter, p *Problem, body interface{}) {
+	if body == nil {
+		body = p
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	if p.retryAfter != nil {
+		w.Header().Set("Retry-After", strconv.FormatInt(*p.retryAfter, 10))
+	}
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,80 @@
+package problem
+
+import (
+	"avito-intership/apperror"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type retryErr struct{}
+
+func (e retryErr) Error() string {
+	return "retry later"
+}
+
+func (e retryErr) AppError() *apperror.Error {
+	return apperror.NewRetryable(apperror.LimitExceeded, e.Error())
+}
+
+func (e retryErr) RetryAfter() int64 {
+	return 30
+}
+
+func TestWrite(t *testing.T) {
+	cases := []struct {
+		name       string
+		err        error
+		status     int
+		code       apperror.Code
+		detail     string
+		param      string
+		retryAfter string
+	}{
+		{name: "bad argument", err: apperror.BadArgument("id"), status: http.StatusBadRequest,
+			code: apperror.InvalidArgument, detail: "Bad id argument", param: "id"},
+		{name: "insufficient funds", err: apperror.New(apperror.InsufficientFunds, "balance can't be lower than 0"),
+			status: http.StatusConflict, code: apperror.InsufficientFunds, detail: "balance can't be lower than 0"},
+		{name: "coder with retry after", err: retryErr{}, status: http.StatusTooManyRequests,
+			code: apperror.LimitExceeded, detail: "retry later", retryAfter: "30"},
+		{name: "transfer held", err: apperror.New(apperror.TransferHeld, "transfer is held for manual review 7"),
+			status: http.StatusAccepted, code: apperror.TransferHeld, detail: "transfer is held for manual review 7"},
+		{name: "approval required", err: apperror.New(apperror.ApprovalRequired, "operation 3 is pending approval"),
+			status: http.StatusAccepted, code: apperror.ApprovalRequired, detail: "operation 3 is pending approval"},
+		{name: "internal error is hidden", err: errors.New("pq: connection refused"),
+			status: http.StatusInternalServerError, code: apperror.Internal, detail: "Server error"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			Write(recorder, c.err)
+
+			var body Problem
+			err := json.NewDecoder(recorder.Body).Decode(&body)
+			assert.NoError(t, err)
+
+			assert.Equal(t, c.status, recorder.Code)
+			assert.Equal(t, ContentType, recorder.Header().Get("Content-Type"))
+			assert.Equal(t, c.retryAfter, recorder.Header().Get("Retry-After"))
+			assert.Equal(t, c.status, body.Status)
+			assert.Equal(t, http.StatusText(c.status), body.Title)
+			assert.Equal(t, c.code, body.Code)
+			assert.Equal(t, c.detail, body.Detail)
+			assert.Equal(t, c.param, body.Param)
+		})
+	}
+}
+
+func TestNew_WrappedError(t *testing.T) {
+	notFound := apperror.New(apperror.NotFound, "deal not found")
+
+	p := New(fmt.Errorf("get deal: %w", notFound))
+
+	assert.Equal(t, http.StatusNotFound, p.Status)
+	assert.Equal(t, apperror.NotFound, p.Code)
+}
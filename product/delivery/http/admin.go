@@ -0,0 +1,122 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/models"
+	"avito-intership/problem"
+	"avito-intership/product"
+	"net/http"
+	"strconv"
+)
+
+type AdminHandler struct {
+	Handler
+}
+
+func NewAdminHandler(useCase product.ProductUseCase) *AdminHandler {
+	return &AdminHandler{
+		Handler: Handler{useCase: useCase},
+	}
+}
+
+// parseProduct читает описание товара; currency по умолчанию "RUB", active - true
+func (h AdminHandler) parseProduct(r *http.Request, w http.ResponseWriter) (*models.Product, bool) {
+	price, err := strconv.ParseFloat(r.FormValue("price"), 32)
+	if err != nil {
+		problem.Write(w, apperror.BadArgument("price"))
+		return nil, false
+	}
+
+	p := &models.Product{
+		Name:     r.FormValue("name"),
+		Price:    float32(price),
+		Currency: r.FormValue("currency"),
+		Active:   true,
+	}
+
+	if active := r.FormValue("active"); active != "" {
+		p.Active, err = strconv.ParseBool(active)
+		if err != nil {
+			problem.Write(w, apperror.BadArgument("active"))
+			return nil, false
+		}
+	}
+
+	return p, true
+}
+
+func (h AdminHandler) GetProductsEndpoint(w http.ResponseWriter, r *http.Request) {
+	products, err := h.useCase.GetProducts(false)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(products, w)
+}
+
+func (h AdminHandler) GetProductEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	p, err := h.useCase.GetProduct(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(p, w)
+}
+
+func (h AdminHandler) CreateProductEndpoint(w http.ResponseWriter, r *http.Request) {
+	p, ok := h.parseProduct(r, w)
+	if !ok {
+		return
+	}
+
+	created, err := h.useCase.CreateProduct(p)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(created, w)
+}
+
+func (h AdminHandler) UpdateProductEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	p, ok := h.parseProduct(r, w)
+	if !ok {
+		return
+	}
+	p.Id = id
+
+	updated, err := h.useCase.UpdateProduct(p)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(updated, w)
+}
+
+func (h AdminHandler) DeactivateProductEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	err := h.useCase.DeactivateProduct(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeStatus(true, nil, &w)
+}
@@ -0,0 +1,99 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/auth"
+	"avito-intership/models"
+	"avito-intership/problem"
+	"avito-intership/product"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	useCase product.ProductUseCase
+}
+
+func NewHandler(useCase product.ProductUseCase) *Handler {
+	return &Handler{
+		useCase: useCase,
+	}
+}
+
+type StatusMessage struct {
+	Success bool    `json:"success"`
+	Message *string `json:"message"`
+}
+
+type PurchaseStatus struct {
+	StatusMessage
+	Purchase *models.Purchase `json:"purchase"`
+}
+
+func (h Handler) writeStatus(success bool, message *string, w *http.ResponseWriter) {
+	status := StatusMessage{
+		Success: success,
+		Message: message,
+	}
+
+	(*w).Header().Add("Content-Type", "application/json")
+	_ = json.NewEncoder(*w).Encode(status)
+}
+
+func (h Handler) writeJSON(value interface{}, w http.ResponseWriter) {
+	w.Header().Add("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		problem.Write(w, err)
+	}
+}
+
+func (h Handler) parseId(r *http.Request, w http.ResponseWriter) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil || id <= 0 {
+		problem.Write(w, apperror.BadArgument("id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+// GetProductsEndpoint возвращает товары, доступные для покупки
+func (h Handler) GetProductsEndpoint(w http.ResponseWriter, r *http.Request) {
+	products, err := h.useCase.GetProducts(true)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(products, w)
+}
+
+// PurchaseEndpoint списывает оплату со счета, поэтому доступен только биллингу
+func (h Handler) PurchaseEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	if err := auth.Authorize(r.Context(), auth.ScopeDebit, id); err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	productId, err := strconv.ParseInt(r.FormValue("product"), 10, 64)
+	if err != nil || productId <= 0 {
+		problem.Write(w, apperror.BadArgument("product"))
+		return
+	}
+
+	purchase, err := h.useCase.Purchase(id, productId)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(PurchaseStatus{StatusMessage{Success: true}, purchase}, w)
+}
@@ -0,0 +1,188 @@
+package http
+
+import (
+	"avito-intership/auth"
+	"avito-intership/balance"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"avito-intership/product"
+	"avito-intership/server/middleware"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+type productHandlerSuite struct {
+	suite.Suite
+
+	useCase       *mocks.ProductUseCase
+	testingServer *httptest.Server
+}
+
+func (suite *productHandlerSuite) SetupSuite() {
+	useCase := new(mocks.ProductUseCase)
+
+	router := mux.NewRouter()
+	router.Use(middleware.WithPrincipal(&models.Principal{Service: auth.BillingService,
+		Scopes: []string{auth.ScopeDebit}}))
+	RegisterEndpoints(router, useCase)
+	RegisterAdminEndpoints(router.PathPrefix("/api/v1/admin").Subrouter(), useCase)
+
+	suite.testingServer = httptest.NewServer(router)
+	suite.useCase = useCase
+}
+
+func (suite *productHandlerSuite) TearDownSuite() {
+	defer suite.testingServer.Close()
+}
+
+func (suite *productHandlerSuite) TestGetProducts() {
+	products := []*models.Product{{Id: 1, Name: "Premium", Price: 150, Currency: "RUB", Active: true}}
+	suite.useCase.On("GetProducts", true).Return(products, nil)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/products", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody []*models.Product
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(products[0].Name, responseBody[0].Name)
+}
+
+func (suite *productHandlerSuite) TestPurchase_UserToken() {
+	router := mux.NewRouter()
+	router.Use(middleware.WithPrincipal(&models.Principal{UserId: 5, Scopes: auth.UserScopes}))
+	RegisterEndpoints(router, suite.useCase)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/balance/5/purchase?product=2", server.URL), "", nil)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusForbidden, response.StatusCode, "only billing debits for products")
+	suite.useCase.AssertNotCalled(suite.T(), "Purchase", int64(5), mock.Anything)
+}
+
+func (suite *productHandlerSuite) TestPurchase() {
+	var id int64 = 1
+	var productId int64 = 2
+	purchase := &models.Purchase{
+		UserId:  id,
+		Product: &models.Product{Id: productId, Name: "Premium", Price: 150, Currency: "RUB", Active: true},
+		Amount:  150,
+	}
+	suite.useCase.On("Purchase", id, productId).Return(purchase, nil)
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/balance/%d/purchase?product=%d",
+		suite.testingServer.URL, id, productId), "", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody PurchaseStatus
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.True(responseBody.Success)
+	suite.Equal(purchase.Amount, responseBody.Purchase.Amount)
+	suite.Equal("Premium", responseBody.Purchase.Product.Name)
+}
+
+func (suite *productHandlerSuite) TestPurchase_Errors() {
+	cases := []struct {
+		name      string
+		productId int64
+		err       error
+		status    int
+	}{
+		{name: "unknown product", productId: 10, err: product.ErrProductNotFound, status: http.StatusNotFound},
+		{name: "inactive product", productId: 11, err: product.ErrProductInactive, status: http.StatusConflict},
+		{name: "too low balance", productId: 12, err: balance.ErrTooLowBalance, status: http.StatusConflict},
+		{name: "rate unavailable", productId: 13, err: balance.ErrRateUnavailable, status: http.StatusServiceUnavailable},
+	}
+
+	for _, c := range cases {
+		suite.Run(c.name, func() {
+			suite.useCase.On("Purchase", int64(3), c.productId).Return(nil, c.err)
+
+			response, err := http.Post(fmt.Sprintf("%s/api/v1/balance/3/purchase?product=%d",
+				suite.testingServer.URL, c.productId), "", bytes.NewBuffer([]byte{}))
+			suite.NoError(err, "request should not produce error")
+			defer response.Body.Close()
+
+			suite.Equal(c.status, response.StatusCode)
+		})
+	}
+}
+
+func (suite *productHandlerSuite) TestPurchase_BadProduct() {
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/balance/3/purchase", suite.testingServer.URL),
+		"", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *productHandlerSuite) TestCreateProduct() {
+	created := &models.Product{Id: 5, Name: "Storage", Price: 12.5, Currency: "USD", Active: false}
+	suite.useCase.On("CreateProduct", mock.MatchedBy(func(p *models.Product) bool {
+		return p.Name == "Storage" && p.Price == 12.5 && p.Currency == "USD" && !p.Active
+	})).Return(created, nil)
+
+	data := url.Values{}
+	data.Set("name", "Storage")
+	data.Set("price", "12.5")
+	data.Set("currency", "USD")
+	data.Set("active", "false")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/products", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.Product
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(created.Id, responseBody.Id)
+}
+
+func (suite *productHandlerSuite) TestCreateProduct_BadPrice() {
+	data := url.Values{}
+	data.Set("name", "Storage")
+	data.Set("price", "free")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/products", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *productHandlerSuite) TestDeactivateProduct_NotFound() {
+	suite.useCase.On("DeactivateProduct", int64(404)).Return(product.ErrProductNotFound)
+
+	request, _ := http.NewRequest(http.MethodDelete,
+		fmt.Sprintf("%s/api/v1/admin/products/404", suite.testingServer.URL), nil)
+	response, err := http.DefaultClient.Do(request)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusNotFound, response.StatusCode)
+}
+
+func TestProductHandler(t *testing.T) {
+	suite.Run(t, new(productHandlerSuite))
+}
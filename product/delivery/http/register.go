@@ -0,0 +1,32 @@
+package http
+
+import (
+	"avito-intership/product"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+func RegisterEndpoints(router *mux.Router, uc product.ProductUseCase) {
+	handler := NewHandler(uc)
+
+	router.HandleFunc("/api/v1/products", handler.GetProductsEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/api/v1/balance/{id:[0-9]+}/purchase", handler.PurchaseEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+}
+
+// RegisterAdminEndpoints регистрирует управление каталогом, router - подмаршрутизатор /api/v1/admin
+func RegisterAdminEndpoints(router *mux.Router, uc product.ProductUseCase) {
+	handler := NewAdminHandler(uc)
+
+	router.HandleFunc("/products", handler.GetProductsEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/products", handler.CreateProductEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/products/{id:[0-9]+}", handler.GetProductEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/products/{id:[0-9]+}", handler.UpdateProductEndpoint).
+		Methods(http.MethodOptions, http.MethodPut)
+	router.HandleFunc("/products/{id:[0-9]+}", handler.DeactivateProductEndpoint).
+		Methods(http.MethodOptions, http.MethodDelete)
+}
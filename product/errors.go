@@ -0,0 +1,9 @@
+package product
+
+import "avito-intership/apperror"
+
+var (
+	ErrProductNotFound = apperror.New(apperror.NotFound, "product not found")
+	ErrProductInactive = apperror.New(apperror.Conflict, "product is not available for purchase")
+	ErrBadProduct      = apperror.New(apperror.InvalidArgument, "product must have a name and a positive price")
+)
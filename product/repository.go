@@ -0,0 +1,12 @@
+package product
+
+import "avito-intership/models"
+
+type ProductRepository interface {
+	CreateProduct(product *models.Product) (*models.Product, error)
+	UpdateProduct(product *models.Product) (*models.Product, error)
+	DeactivateProduct(id int64) error
+	GetProduct(id int64) (*models.Product, error)
+	// GetProducts возвращает весь каталог, при onlyActive - только доступные для покупки товары
+	GetProducts(onlyActive bool) ([]*models.Product, error)
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"avito-intership/models"
+	"avito-intership/product"
+	"database/sql"
+)
+
+type ProductRepository struct {
+	db *sql.DB
+}
+
+func NewProductRepository(dbConn *sql.DB) *ProductRepository {
+	return &ProductRepository{dbConn}
+}
+
+const productColumns = "id, name, price, currency, active, created_at"
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanProduct(row scanner) (*models.Product, error) {
+	var p models.Product
+
+	err := row.Scan(&p.Id, &p.Name, &p.Price, &p.Currency, &p.Active, &p.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, product.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+func (r ProductRepository) CreateProduct(p *models.Product) (*models.Product, error) {
+	row := r.db.QueryRow(
+		`INSERT INTO products (name, price, currency, active) VALUES ($1, $2, $3, $4)
+		RETURNING `+productColumns,
+		p.Name, p.Price, p.Currency, p.Active)
+	return scanProduct(row)
+}
+
+func (r ProductRepository) UpdateProduct(p *models.Product) (*models.Product, error) {
+	row := r.db.QueryRow(
+		`UPDATE products SET name = $1, price = $2, currency = $3, active = $4 WHERE id = $5
+		RETURNING `+productColumns,
+		p.Name, p.Price, p.Currency, p.Active, p.Id)
+	return scanProduct(row)
+}
+
+// DeactivateProduct снимает товар с продажи; запись не удаляется, чтобы в истории операций оставались названия
+func (r ProductRepository) DeactivateProduct(id int64) error {
+	result, err := r.db.Exec("UPDATE products SET active = FALSE WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return product.ErrProductNotFound
+	}
+
+	return nil
+}
+
+func (r ProductRepository) GetProduct(id int64) (*models.Product, error) {
+	row := r.db.QueryRow("SELECT "+productColumns+" FROM products WHERE id = $1", id)
+	return scanProduct(row)
+}
+
+func (r ProductRepository) GetProducts(onlyActive bool) ([]*models.Product, error) {
+	query := "SELECT " + productColumns + " FROM products"
+	if onlyActive {
+		query += " WHERE active"
+	}
+	query += " ORDER BY id"
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]*models.Product, 0)
+	for rows.Next() {
+		p, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		products = append(products, p)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"avito-intership/models"
+	"avito-intership/product"
+	"avito-intership/utils"
+	"database/sql"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/suite"
+	"log"
+	"testing"
+)
+
+type productRepositorySuite struct {
+	suite.Suite
+
+	db       *sql.DB
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+
+	repository product.ProductRepository
+}
+
+func (suite *productRepositorySuite) SetupSuite() {
+	db, pool, resource := utils.DockerDBUp()
+	err := utils.InitTable(db, "../../../init.sql")
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	suite.repository = NewProductRepository(db)
+	suite.db = db
+	suite.pool = pool
+	suite.resource = resource
+}
+
+func (suite *productRepositorySuite) TestCreateProduct() {
+	created, err := suite.repository.CreateProduct(
+		&models.Product{Name: "Premium", Price: 150, Currency: "RUB", Active: true})
+	suite.NoError(err, "creating product should not produce error")
+
+	stored, err := suite.repository.GetProduct(created.Id)
+	suite.NoError(err, "getting product should not produce error")
+	suite.Equal("Premium", stored.Name)
+	suite.Equal(float32(150), stored.Price)
+	suite.True(stored.Active)
+}
+
+func (suite *productRepositorySuite) TestUpdateProduct() {
+	created, err := suite.repository.CreateProduct(
+		&models.Product{Name: "Storage", Price: 10, Currency: "USD", Active: true})
+	suite.NoError(err, "creating product should not produce error")
+
+	created.Price = 12.5
+	updated, err := suite.repository.UpdateProduct(created)
+	suite.NoError(err, "updating product should not produce error")
+	suite.Equal(float32(12.5), updated.Price)
+}
+
+func (suite *productRepositorySuite) TestDeactivateProduct() {
+	created, err := suite.repository.CreateProduct(
+		&models.Product{Name: "Legacy", Price: 5, Currency: "RUB", Active: true})
+	suite.NoError(err, "creating product should not produce error")
+
+	err = suite.repository.DeactivateProduct(created.Id)
+	suite.NoError(err, "deactivating product should not produce error")
+
+	active, err := suite.repository.GetProducts(true)
+	suite.NoError(err, "getting products should not produce error")
+	for _, p := range active {
+		suite.NotEqual(created.Id, p.Id, "deactivated product should not be listed")
+	}
+
+	stored, err := suite.repository.GetProduct(created.Id)
+	suite.NoError(err, "deactivated product should be kept")
+	suite.False(stored.Active)
+}
+
+func (suite *productRepositorySuite) TestGetProduct_NotFound() {
+	_, err := suite.repository.GetProduct(100500)
+
+	suite.Equal(product.ErrProductNotFound, err)
+
+	err = suite.repository.DeactivateProduct(100500)
+	suite.Equal(product.ErrProductNotFound, err)
+}
+
+func (suite *productRepositorySuite) TearDownSuite() {
+	err := utils.DropTable(suite.db, []string{"products"})
+	if err != nil {
+		log.Println(err)
+	}
+
+	if err := suite.pool.Purge(suite.resource); err != nil {
+		log.Fatalf("Could not purge resource: %s", err)
+	}
+}
+
+func TestProductRepository(t *testing.T) {
+	suite.Run(t, new(productRepositorySuite))
+}
@@ -0,0 +1,13 @@
+package product
+
+import "avito-intership/models"
+
+type ProductUseCase interface {
+	CreateProduct(product *models.Product) (*models.Product, error)
+	UpdateProduct(product *models.Product) (*models.Product, error)
+	DeactivateProduct(id int64) error
+	GetProduct(id int64) (*models.Product, error)
+	GetProducts(onlyActive bool) ([]*models.Product, error)
+	// Purchase списывает с баланса пользователя цену товара из каталога
+	Purchase(userId int64, productId int64) (*models.Purchase, error)
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"avito-intership/product"
+	"strings"
+)
+
+type ProductUseCase struct {
+	repository product.ProductRepository
+	balance    balance.UseCase
+}
+
+func NewProductUseCase(repository product.ProductRepository, balance balance.UseCase) *ProductUseCase {
+	return &ProductUseCase{
+		repository: repository,
+		balance:    balance,
+	}
+}
+
+// validateProduct проверяет товар и округляет цену до минимальной единицы валюты
+func validateProduct(p *models.Product) error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Currency == "" {
+		p.Currency = exchange.RUB
+	}
+
+	if !exchange.IsSupported(p.Currency) {
+		return exchange.ErrUnsupportedCurrency
+	}
+
+	if p.Name == "" || p.Price <= 0 {
+		return product.ErrBadProduct
+	}
+
+	p.Price, _ = exchange.Round(exchange.Decimal(p.Price), p.Currency).Float32()
+	if p.Price <= 0 {
+		return product.ErrBadProduct
+	}
+
+	return nil
+}
+
+func (u ProductUseCase) CreateProduct(p *models.Product) (*models.Product, error) {
+	if err := validateProduct(p); err != nil {
+		return nil, err
+	}
+
+	return u.repository.CreateProduct(p)
+}
+
+func (u ProductUseCase) UpdateProduct(p *models.Product) (*models.Product, error) {
+	if err := validateProduct(p); err != nil {
+		return nil, err
+	}
+
+	return u.repository.UpdateProduct(p)
+}
+
+func (u ProductUseCase) DeactivateProduct(id int64) error {
+	return u.repository.DeactivateProduct(id)
+}
+
+func (u ProductUseCase) GetProduct(id int64) (*models.Product, error) {
+	return u.repository.GetProduct(id)
+}
+
+func (u ProductUseCase) GetProducts(onlyActive bool) ([]*models.Product, error) {
+	return u.repository.GetProducts(onlyActive)
+}
+
+func (u ProductUseCase) Purchase(userId int64, productId int64) (*models.Purchase, error) {
+	p, err := u.repository.GetProduct(productId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !p.Active {
+		return nil, product.ErrProductInactive
+	}
+
+	purchase := &models.Purchase{
+		UserId:  userId,
+		Product: p,
+		Amount:  p.Price,
+	}
+
+	// Цена в иностранной валюте списывается в рублях по курсу покупки
+	conversion, err := u.balance.ChangeBalanceInCurrency(userId, -p.Price, p.Id, p.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	if conversion != nil {
+		purchase.Amount = -conversion.Amount
+		purchase.Conversion = conversion
+	}
+
+	return purchase, nil
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/exchange"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"avito-intership/product"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type productUseCaseSuite struct {
+	suite.Suite
+	repository *mocks.ProductRepository
+	balance    *mocks.UseCase
+	useCase    product.ProductUseCase
+}
+
+func (suite *productUseCaseSuite) SetupTest() {
+	repository := new(mocks.ProductRepository)
+	balanceUseCase := new(mocks.UseCase)
+
+	suite.repository = repository
+	suite.balance = balanceUseCase
+	suite.useCase = NewProductUseCase(repository, balanceUseCase)
+}
+
+func (suite *productUseCaseSuite) TestCreateProduct_Ok() {
+	p := &models.Product{Name: " Premium ", Price: 99.999, Active: true}
+	created := &models.Product{Id: 1, Name: "Premium", Price: 100, Currency: "RUB", Active: true}
+
+	suite.repository.On("CreateProduct", mock.MatchedBy(func(p *models.Product) bool {
+		return p.Name == "Premium" && p.Price == 100 && p.Currency == "RUB"
+	})).Return(created, nil)
+
+	result, err := suite.useCase.CreateProduct(p)
+
+	suite.NoError(err)
+	suite.Equal(created, result)
+}
+
+func (suite *productUseCaseSuite) TestCreateProduct_Invalid() {
+	cases := []struct {
+		name    string
+		product *models.Product
+		err     error
+	}{
+		{name: "empty name", product: &models.Product{Name: " ", Price: 10}, err: product.ErrBadProduct},
+		{name: "zero price", product: &models.Product{Name: "Premium", Price: 0}, err: product.ErrBadProduct},
+		{name: "price below minor unit", product: &models.Product{Name: "Premium", Price: 0.4, Currency: "JPY"},
+			err: product.ErrBadProduct},
+		{name: "unsupported currency", product: &models.Product{Name: "Premium", Price: 10, Currency: "XYZ"},
+			err: exchange.ErrUnsupportedCurrency},
+	}
+
+	for _, c := range cases {
+		suite.Run(c.name, func() {
+			_, err := suite.useCase.CreateProduct(c.product)
+
+			suite.Equal(c.err, err)
+		})
+	}
+	suite.repository.AssertNotCalled(suite.T(), "CreateProduct", mock.Anything)
+}
+
+func (suite *productUseCaseSuite) TestPurchase_RUB() {
+	var userId int64 = 1
+	p := &models.Product{Id: 2, Name: "Premium", Price: 150, Currency: "RUB", Active: true}
+
+	suite.repository.On("GetProduct", p.Id).Return(p, nil)
+	suite.balance.On("ChangeBalanceInCurrency", userId, float32(-150), p.Id, "RUB").Return(nil, nil)
+
+	purchase, err := suite.useCase.Purchase(userId, p.Id)
+
+	suite.NoError(err)
+	suite.Equal(float32(150), purchase.Amount)
+	suite.Equal(p, purchase.Product)
+	suite.Nil(purchase.Conversion)
+}
+
+func (suite *productUseCaseSuite) TestPurchase_Currency() {
+	var userId int64 = 1
+	p := &models.Product{Id: 3, Name: "Storage", Price: 12.5, Currency: "USD", Active: true}
+	conversion := &models.Conversion{Amount: -1015, From: "USD", Currency: "RUB", Fee: 15}
+
+	suite.repository.On("GetProduct", p.Id).Return(p, nil)
+	suite.balance.On("ChangeBalanceInCurrency", userId, float32(-12.5), p.Id, "USD").Return(conversion, nil)
+
+	purchase, err := suite.useCase.Purchase(userId, p.Id)
+
+	suite.NoError(err)
+	suite.Equal(float32(1015), purchase.Amount)
+	suite.Equal(conversion, purchase.Conversion)
+}
+
+func (suite *productUseCaseSuite) TestPurchase_Inactive() {
+	p := &models.Product{Id: 4, Name: "Legacy", Price: 10, Currency: "RUB", Active: false}
+	suite.repository.On("GetProduct", p.Id).Return(p, nil)
+
+	_, err := suite.useCase.Purchase(1, p.Id)
+
+	suite.Equal(product.ErrProductInactive, err)
+	suite.balance.AssertNotCalled(suite.T(), "ChangeBalanceInCurrency", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *productUseCaseSuite) TestPurchase_NotFound() {
+	suite.repository.On("GetProduct", int64(5)).Return(nil, product.ErrProductNotFound)
+
+	_, err := suite.useCase.Purchase(1, 5)
+
+	suite.Equal(product.ErrProductNotFound, err)
+}
+
+func (suite *productUseCaseSuite) TestPurchase_TooLowBalance() {
+	p := &models.Product{Id: 6, Name: "Premium", Price: 150, Currency: "RUB", Active: true}
+
+	suite.repository.On("GetProduct", p.Id).Return(p, nil)
+	suite.balance.On("ChangeBalanceInCurrency", int64(1), float32(-150), p.Id, "RUB").Return(nil, balance.ErrTooLowBalance)
+
+	_, err := suite.useCase.Purchase(1, p.Id)
+
+	suite.Equal(balance.ErrTooLowBalance, err)
+}
+
+func TestProductUseCase(t *testing.T) {
+	suite.Run(t, new(productUseCaseSuite))
+}
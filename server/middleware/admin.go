@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/problem"
+	"crypto/subtle"
+	"net/http"
+)
+
+// AdminTokenHeader - заголовок, в котором служебные методы ожидают токен администратора
+const AdminTokenHeader = "X-Admin-Token"
+
+var errAdminToken = apperror.WithParam(apperror.Unauthorized, AdminTokenHeader, "Admin token required")
+
+// AdminAuth пропускает к служебным методам только запросы с токеном из ADMIN_TOKEN;
+// пустой токен закрывает служебные методы полностью
+func AdminAuth(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			provided := r.Header.Get(AdminTokenHeader)
+			if r.Method == http.MethodOptions ||
+				token != "" && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			problem.Write(w, errAdminToken)
+		})
+	}
+}
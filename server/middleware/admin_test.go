@@ -0,0 +1,40 @@
+package middleware
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAdminAuth(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	cases := []struct {
+		name     string
+		token    string
+		provided string
+		status   int
+	}{
+		{"valid token", "secret", "secret", http.StatusOK},
+		{"wrong token", "secret", "guess", http.StatusUnauthorized},
+		{"missing token", "secret", "", http.StatusUnauthorized},
+		{"token not configured", "", "", http.StatusUnauthorized},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/admin/operations", nil)
+			if c.provided != "" {
+				request.Header.Set(AdminTokenHeader, c.provided)
+			}
+			recorder := httptest.NewRecorder()
+
+			AdminAuth(c.token)(ok).ServeHTTP(recorder, request)
+
+			assert.Equal(t, c.status, recorder.Code)
+		})
+	}
+}
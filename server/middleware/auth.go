@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"avito-intership/auth"
+	"avito-intership/models"
+	"avito-intership/problem"
+	"net/http"
+)
+
+// Authenticate пропускает только запросы с действующим ключом API или токеном пользователя
+// и сохраняет вызывающего в контексте запроса. Права вызывающего проверяют обработчики,
+// так как только они знают, с каким счетом выполняется операция
+func Authenticate(uc auth.AuthUseCase) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodOptions {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			principal, err := auth.Authenticate(uc, r.Header.Get(auth.ApiKeyHeader), r.Header.Get("Authorization"))
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				problem.Write(w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), principal)))
+		})
+	}
+}
+
+// Identify сохраняет в контексте вызывающего, если запрос подписан ключом API или токеном, и пропускает
+// запросы без них. Служебные методы защищены токеном администратора, а ключ определяет оператора
+func Identify(uc auth.AuthUseCase) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			apiKey, authorization := r.Header.Get(auth.ApiKeyHeader), r.Header.Get("Authorization")
+			if apiKey == "" && authorization == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			principal, err := auth.Authenticate(uc, apiKey, authorization)
+			if err != nil {
+				problem.Write(w, err)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), principal)))
+		})
+	}
+}
+
+// WithPrincipal выполняет все запросы от имени principal без проверки ключа, например в тестах обработчиков
+func WithPrincipal(principal *models.Principal) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(auth.NewContext(r.Context(), principal)))
+		})
+	}
+}
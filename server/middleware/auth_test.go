@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"avito-intership/auth"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthenticate(t *testing.T) {
+	useCase := new(mocks.AuthUseCase)
+	useCase.On("AuthenticateKey", "ak_billing").
+		Return(&models.Principal{Service: "billing", Scopes: []string{auth.ScopeDebit}}, nil)
+	useCase.On("AuthenticateKey", "ak_revoked").Return(nil, auth.ErrUnauthenticated)
+	useCase.On("AuthenticateKey", "ak_broken").Return(nil, errors.New("connection refused"))
+	useCase.On("AuthenticateToken", "jwt").Return(&models.Principal{UserId: 1, Scopes: auth.UserScopes}, nil)
+
+	var principal *models.Principal
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal = auth.FromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	cases := []struct {
+		name          string
+		apiKey        string
+		authorization string
+		status        int
+		principal     *models.Principal
+	}{
+		{"api key", "ak_billing", "", http.StatusOK,
+			&models.Principal{Service: "billing", Scopes: []string{auth.ScopeDebit}}},
+		{"user token", "", "Bearer jwt", http.StatusOK, &models.Principal{UserId: 1, Scopes: auth.UserScopes}},
+		{"revoked key", "ak_revoked", "", http.StatusUnauthorized, nil},
+		{"basic credentials", "", "Basic dXNlcjpwYXNz", http.StatusUnauthorized, nil},
+		{"no credentials", "", "", http.StatusUnauthorized, nil},
+		{"key storage unavailable", "ak_broken", "", http.StatusInternalServerError, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			principal = nil
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/balance/1", nil)
+			if c.apiKey != "" {
+				request.Header.Set(auth.ApiKeyHeader, c.apiKey)
+			}
+			if c.authorization != "" {
+				request.Header.Set("Authorization", c.authorization)
+			}
+			recorder := httptest.NewRecorder()
+
+			Authenticate(useCase)(ok).ServeHTTP(recorder, request)
+
+			assert.Equal(t, c.status, recorder.Code)
+			assert.Equal(t, c.principal, principal)
+		})
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	useCase := new(mocks.AuthUseCase)
+	useCase.On("AuthenticateKey", "ak_alice").
+		Return(&models.Principal{Service: "alice", Scopes: []string{auth.ScopeOperator}}, nil)
+	useCase.On("AuthenticateKey", "ak_revoked").Return(nil, auth.ErrUnauthenticated)
+
+	var principal *models.Principal
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal = auth.FromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+
+	cases := []struct {
+		name      string
+		apiKey    string
+		status    int
+		principal *models.Principal
+	}{
+		{"operator key", "ak_alice", http.StatusOK,
+			&models.Principal{Service: "alice", Scopes: []string{auth.ScopeOperator}}},
+		{"no credentials", "", http.StatusOK, nil},
+		{"revoked key", "ak_revoked", http.StatusUnauthorized, nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			principal = nil
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/admin/operations/1/approve", nil)
+			if c.apiKey != "" {
+				request.Header.Set(auth.ApiKeyHeader, c.apiKey)
+			}
+			recorder := httptest.NewRecorder()
+
+			Identify(useCase)(ok).ServeHTTP(recorder, request)
+
+			assert.Equal(t, c.status, recorder.Code)
+			assert.Equal(t, c.principal, principal)
+		})
+	}
+}
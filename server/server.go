@@ -1,43 +1,268 @@
 package server
 
 import (
+	"avito-intership/alert"
+	alertHttp "avito-intership/alert/delivery/http"
+	"avito-intership/alert/notifier"
+	alertPostgres "avito-intership/alert/repository/postgres"
+	alertUseCase "avito-intership/alert/usecase"
+	"avito-intership/auth"
+	authHttp "avito-intership/auth/delivery/http"
+	authPostgres "avito-intership/auth/repository/postgres"
+	authUseCase "avito-intership/auth/usecase"
 	"avito-intership/balance"
+	balanceGrpc "avito-intership/balance/delivery/grpc"
 	balanceHttp "avito-intership/balance/delivery/http"
 	"avito-intership/balance/repository/postgres"
 	"avito-intership/balance/usecase"
+	"avito-intership/cashback"
+	cashbackHttp "avito-intership/cashback/delivery/http"
+	cashbackPostgres "avito-intership/cashback/repository/postgres"
+	cashbackUseCase "avito-intership/cashback/usecase"
 	"avito-intership/db"
+	"avito-intership/escrow"
+	escrowHttp "avito-intership/escrow/delivery/http"
+	escrowPostgres "avito-intership/escrow/repository/postgres"
+	escrowUseCase "avito-intership/escrow/usecase"
+	"avito-intership/exchange"
+	exchangeHttp "avito-intership/exchange/delivery/http"
 	"avito-intership/exchange/repository/cache"
 	"avito-intership/exchange/repository/exchangerates"
+	exchangePostgres "avito-intership/exchange/repository/postgres"
 	exchangeUseCase "avito-intership/exchange/usecase"
+	"avito-intership/fraud"
+	fraudHttp "avito-intership/fraud/delivery/http"
+	fraudPostgres "avito-intership/fraud/repository/postgres"
+	fraudUseCase "avito-intership/fraud/usecase"
+	"avito-intership/limits"
+	limitsHttp "avito-intership/limits/delivery/http"
+	limitsPostgres "avito-intership/limits/repository/postgres"
+	limitsUseCase "avito-intership/limits/usecase"
+	"avito-intership/outbox"
+	"avito-intership/outbox/publisher"
+	outboxPostgres "avito-intership/outbox/repository/postgres"
+	outboxUseCase "avito-intership/outbox/usecase"
+	"avito-intership/product"
+	productHttp "avito-intership/product/delivery/http"
+	productPostgres "avito-intership/product/repository/postgres"
+	productUseCase "avito-intership/product/usecase"
+	"avito-intership/server/middleware"
+	"avito-intership/stream"
+	streamHttp "avito-intership/stream/delivery/http"
+	streamPostgres "avito-intership/stream/repository/postgres"
+	streamUseCase "avito-intership/stream/usecase"
+	"avito-intership/voucher"
+	voucherHttp "avito-intership/voucher/delivery/http"
+	voucherPostgres "avito-intership/voucher/repository/postgres"
+	voucherUseCase "avito-intership/voucher/usecase"
+	"avito-intership/webhook"
+	webhookHttp "avito-intership/webhook/delivery/http"
+	webhookPostgres "avito-intership/webhook/repository/postgres"
+	webhookUseCase "avito-intership/webhook/usecase"
 	"context"
 	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"time"
 )
 
 type App struct {
 	httpServer *http.Server
+	// Потоки баланса открыты дольше WriteTimeout основного сервера, поэтому обслуживаются отдельным
+	streamServer *http.Server
+	grpcServer   *grpc.Server
 
-	balance balance.UseCase
+	balance       balance.UseCase
+	approvals     balance.ApprovalUseCase
+	adjustments   balance.AdjustmentUseCase
+	exchanger     exchange.Exchanger
+	overrides     exchange.OverrideUseCase
+	products      product.ProductUseCase
+	deals         escrow.DealUseCase
+	vouchers      voucher.VoucherUseCase
+	cashback      cashback.CashbackUseCase
+	limits        limits.LimitUseCase
+	reviews       fraud.ReviewUseCase
+	webhooks      webhook.SubscriptionUseCase
+	alerts        alert.AlertUseCase
+	auth          auth.AuthUseCase
+	rateRefresher *exchangerates.Refresher
+	dealReleaser  *escrowUseCase.Releaser
+	bonusExpirer  *usecase.BonusExpirer
+	opExpirer     *usecase.PendingExpirer
+	eventRelay    *outboxUseCase.Relay
+	webhookSender *webhookUseCase.Sender
+	streamHub     *streamUseCase.Hub
+	streamTokens  stream.TokenUseCase
 }
 
 func NewApp() *App {
 	balanceRepo := postgres.NewBalanceRepository(db.GetDB())
-	exchanger := exchangeUseCase.NewExchanger(
-		exchangerates.NewExchangeRepository(exchangerates.NewNetRepository(), cache.NewRedisCache()))
+	netRateRepo := exchangerates.NewRecordingRepository(
+		exchangerates.NewCircuitBreaker(exchangerates.NewNetRepository()),
+		exchangePostgres.NewRateHistoryRepository(db.GetDB()))
+	rateRepo := exchangerates.NewExchangeRepository(netRateRepo, cache.NewRedisCache())
+	// Назначения читаются при каждой конвертации, а изменения через админку сбрасывают общий кеш
+	overrideRepo := exchangerates.NewOverrideCache(exchangePostgres.NewOverrideRepository(db.GetDB()))
+	exchanger := exchangeUseCase.NewExchanger(exchangerates.NewOverridingRepository(rateRepo, overrideRepo),
+		exchangePostgres.NewSpreadRepository(db.GetDB()))
+
+	eventRepo := outboxPostgres.NewEventRepository(db.GetDB())
+
+	alerts := alertUseCase.NewAlertUseCase(alertPostgres.NewAlertRepository(db.GetDB()), balanceRepo,
+		notifier.NewOutboxNotifier(eventRepo))
+	uncheckedBalance := usecase.NewBalanceUseCase(balanceRepo, exchanger, approvalThreshold())
+	approvalUseCase := usecase.NewApprovalUseCase(balanceRepo)
+	productRepo := productPostgres.NewProductRepository(db.GetDB())
+
+	// Правила антифрода применяются ко всем переводам через общий экземпляр и к оплате сделок;
+	// без проверки выполняются только переводы, одобренные оператором после проверки
+	reviewRepo := fraudPostgres.NewReviewRepository(db.GetDB())
+	engine := fraudUseCase.NewEngine(fraudPostgres.NewTransferHistoryRepository(db.GetDB()), fraudUseCase.DefaultRules()...)
+	balanceUseCase := fraudUseCase.NewGuardedBalance(uncheckedBalance, engine, reviewRepo)
+	dealUseCase := escrowUseCase.NewDealUseCase(escrowPostgres.NewDealRepository(db.GetDB()), engine,
+		approvalThreshold())
+
+	// События публикуются выбранным в OUTBOX_PUBLISHER способом и ставятся в очередь доставки подписчикам.
+	// По событиям об операциях проверяются уведомления, так что их вызывает любое изменение баланса
+	subscriptionRepo := webhookPostgres.NewSubscriptionRepository(db.GetDB())
+	deliveryRepo := webhookPostgres.NewDeliveryRepository(db.GetDB())
+	publishers := publisher.NewFanout(eventPublisher(), webhookUseCase.NewDispatcher(subscriptionRepo, deliveryRepo),
+		alertUseCase.NewAlertPublisher(alerts))
+
+	streamSecret := os.Getenv("STREAM_SECRET")
+	if streamSecret == "" {
+		log.Println("STREAM_SECRET is not set, stream tokens are disabled")
+	}
+
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		log.Println("JWT_SECRET is not set, user tokens are disabled")
+	}
 
 	return &App{
-		balance: usecase.NewBalanceUseCase(balanceRepo, exchanger),
+		balance:       balanceUseCase,
+		approvals:     approvalUseCase,
+		adjustments:   usecase.NewAdjustmentUseCase(balanceRepo, approvalThreshold()),
+		exchanger:     exchanger,
+		overrides:     exchangeUseCase.NewOverrideUseCase(overrideRepo),
+		products:      productUseCase.NewProductUseCase(productRepo, balanceUseCase),
+		deals:         dealUseCase,
+		vouchers:      voucherUseCase.NewVoucherUseCase(voucherPostgres.NewVoucherRepository(db.GetDB()), exchanger),
+		cashback:      cashbackUseCase.NewCashbackUseCase(cashbackPostgres.NewCashbackRepository(db.GetDB()), productRepo),
+		limits:        limitsUseCase.NewLimitUseCase(limitsPostgres.NewLimitRepository(db.GetDB())),
+		reviews:       fraudUseCase.NewReviewUseCase(reviewRepo, uncheckedBalance),
+		rateRefresher: exchangerates.NewRefresher(rateRepo),
+		dealReleaser:  escrowUseCase.NewReleaser(dealUseCase),
+		bonusExpirer:  usecase.NewBonusExpirer(balanceUseCase),
+		opExpirer:     usecase.NewPendingExpirer(approvalUseCase),
+		webhooks:      webhookUseCase.NewSubscriptionUseCase(subscriptionRepo, deliveryRepo),
+		alerts:        alerts,
+		auth:          authUseCase.NewAuthUseCase(authPostgres.NewKeyRepository(db.GetDB()), jwtSecret),
+		eventRelay:    outboxUseCase.NewRelay(eventRepo, publishers),
+		webhookSender: webhookUseCase.NewSender(deliveryRepo),
+		streamHub:     streamUseCase.NewHub(streamPostgres.NewListener(db.GetConnInfo()), balanceUseCase),
+		streamTokens:  streamUseCase.NewTokenUseCase(streamSecret),
+	}
+}
+
+// eventPublisher выбирает способ публикации событий по OUTBOX_PUBLISHER: "stdout" (по умолчанию),
+// "file" - в файл OUTBOX_FILE, "webhook" - POST-запросами на OUTBOX_WEBHOOK_URL
+func eventPublisher() outbox.Publisher {
+	switch kind := os.Getenv("OUTBOX_PUBLISHER"); kind {
+	case "", "stdout":
+		return publisher.NewStdoutPublisher()
+	case "file":
+		filePublisher, err := publisher.NewFilePublisher(os.Getenv("OUTBOX_FILE"))
+		if err != nil {
+			log.Fatalf("Failed to open outbox file: %+v", err)
+		}
+		return filePublisher
+	case "webhook":
+		url := os.Getenv("OUTBOX_WEBHOOK_URL")
+		if url == "" {
+			log.Fatal("OUTBOX_WEBHOOK_URL is not set")
+		}
+		return publisher.NewWebhookPublisher(url)
+	default:
+		log.Fatalf("Unknown OUTBOX_PUBLISHER %q", kind)
+		return nil
+	}
+}
+
+// streamPort возвращает адрес сервера потоков баланса из STREAM_PORT, по умолчанию ":5556"
+func streamPort() string {
+	port := os.Getenv("STREAM_PORT")
+	if port == "" {
+		port = "5556"
+	}
+
+	return ":" + port
+}
+
+// grpcPort возвращает адрес сервера gRPC из GRPC_PORT, по умолчанию ":5557"
+func grpcPort() string {
+	port := os.Getenv("GRPC_PORT")
+	if port == "" {
+		port = "5557"
+	}
+
+	return ":" + port
+}
+
+// approvalThreshold читает порог одобрения операций из APPROVAL_THRESHOLD, 0 отключает одобрение
+func approvalThreshold() float32 {
+	value := os.Getenv("APPROVAL_THRESHOLD")
+	if value == "" {
+		return usecase.DefaultApprovalThreshold
+	}
+
+	threshold, err := strconv.ParseFloat(value, 32)
+	if err != nil || threshold < 0 {
+		log.Printf("bad APPROVAL_THRESHOLD %q, using default", value)
+		return usecase.DefaultApprovalThreshold
 	}
+
+	return float32(threshold)
 }
 
 func (a *App) Run(port string) error {
 	router := mux.NewRouter()
 
-	balanceHttp.RegisterEndpoints(router, a.balance)
+	// Курсы валют открыты всем, остальные методы вызываются только с ключом API или токеном пользователя
+	exchangeHttp.RegisterEndpoints(router, a.exchanger)
+
+	api := router.NewRoute().Subrouter()
+	api.Use(middleware.Authenticate(a.auth))
+	balanceHttp.RegisterEndpoints(api, a.balance)
+	balanceHttp.RegisterV2Endpoints(api, a.balance)
+	productHttp.RegisterEndpoints(api, a.products)
+	escrowHttp.RegisterEndpoints(api, a.deals)
+	voucherHttp.RegisterEndpoints(api, a.vouchers)
+	alertHttp.RegisterEndpoints(api, a.alerts)
+
+	adminToken := os.Getenv("ADMIN_TOKEN")
+	if adminToken == "" {
+		log.Println("ADMIN_TOKEN is not set, admin endpoints are disabled")
+	}
+
+	admin := router.PathPrefix("/api/v1/admin").Subrouter()
+	admin.Use(middleware.AdminAuth(adminToken), middleware.Identify(a.auth))
+	balanceHttp.RegisterAdminEndpoints(admin, a.balance, a.approvals, a.adjustments)
+	exchangeHttp.RegisterAdminEndpoints(admin, a.exchanger, a.overrides)
+	productHttp.RegisterAdminEndpoints(admin, a.products)
+	voucherHttp.RegisterAdminEndpoints(admin, a.vouchers)
+	cashbackHttp.RegisterAdminEndpoints(admin, a.cashback)
+	limitsHttp.RegisterAdminEndpoints(admin, a.limits)
+	fraudHttp.RegisterAdminEndpoints(admin, a.reviews)
+	webhookHttp.RegisterAdminEndpoints(admin, a.webhooks)
+	streamHttp.RegisterAdminEndpoints(admin, a.streamTokens)
+	authHttp.RegisterAdminEndpoints(admin, a.auth)
 
 	router.Use(mux.CORSMethodMiddleware(router))
 	a.httpServer = &http.Server{
@@ -48,11 +273,49 @@ func (a *App) Run(port string) error {
 		MaxHeaderBytes: 1 << 20,
 	}
 
+	streamRouter := mux.NewRouter()
+	streamHttp.RegisterEndpoints(streamRouter, a.streamHub, a.streamTokens, a.balance)
+	streamRouter.Use(middleware.Identify(a.auth), mux.CORSMethodMiddleware(streamRouter))
+	a.streamServer = &http.Server{
+		Addr:           streamPort(),
+		Handler:        streamRouter,
+		ReadTimeout:    10 * time.Second,
+		MaxHeaderBytes: 1 << 20,
+	}
+
+	a.grpcServer = grpc.NewServer(grpc.UnaryInterceptor(balanceGrpc.UnaryAuthInterceptor(a.auth)),
+		grpc.StreamInterceptor(balanceGrpc.StreamAuthInterceptor(a.auth)))
+	balanceGrpc.RegisterService(a.grpcServer, a.balance)
+
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	go a.rateRefresher.Run(backgroundCtx)
+	go a.dealReleaser.Run(backgroundCtx)
+	go a.bonusExpirer.Run(backgroundCtx)
+	go a.opExpirer.Run(backgroundCtx)
+	go a.eventRelay.Run(backgroundCtx)
+	go a.webhookSender.Run(backgroundCtx)
+	go a.streamHub.Run(backgroundCtx)
+
 	go func() {
 		if err := a.httpServer.ListenAndServe(); err != nil {
 			log.Fatalf("Failed to listen and serve: %+v", err)
 		}
 	}()
+	go func() {
+		if err := a.streamServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to listen and serve streams: %+v", err)
+		}
+	}()
+	go func() {
+		listener, err := net.Listen("tcp", grpcPort())
+		if err != nil {
+			log.Fatalf("Failed to listen gRPC: %+v", err)
+		}
+		if err := a.grpcServer.Serve(listener); err != nil {
+			log.Fatalf("Failed to serve gRPC: %+v", err)
+		}
+	}()
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, os.Interrupt)
@@ -62,5 +325,22 @@ func (a *App) Run(port string) error {
 	ctx, shutdown := context.WithTimeout(context.Background(), 5*time.Second)
 	defer shutdown()
 
+	// Незавершенные вызовы gRPC, в том числе чтение истории, дорабатывают, пока не истечет ctx
+	grpcStopped := make(chan struct{})
+	go func() {
+		a.grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		a.grpcServer.Stop()
+	}
+
+	// Потоки не завершаются сами, поэтому их соединения закрываются сразу
+	if err := a.streamServer.Close(); err != nil {
+		log.Println(err)
+	}
+
 	return a.httpServer.Shutdown(ctx)
 }
\ No newline at end of file
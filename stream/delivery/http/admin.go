@@ -0,0 +1,33 @@
+package http
+
+import (
+	"avito-intership/problem"
+	"avito-intership/stream"
+	"net/http"
+)
+
+type AdminHandler struct {
+	Handler
+}
+
+func NewAdminHandler(tokens stream.TokenUseCase) *AdminHandler {
+	return &AdminHandler{
+		Handler: Handler{tokens: tokens},
+	}
+}
+
+// IssueTokenEndpoint выдает токен потока пользователя; его запрашивает бэкенд клиента и передает в браузер
+func (h AdminHandler) IssueTokenEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	token, err := h.tokens.IssueToken(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(token, w)
+}
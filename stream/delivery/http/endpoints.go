@@ -0,0 +1,150 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/auth"
+	"avito-intership/balance"
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"avito-intership/problem"
+	"avito-intership/stream"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// keepAliveInterval - период комментариев в потоке, которые не дают прокси закрыть соединение без данных
+const keepAliveInterval = 15 * time.Second
+
+type Handler struct {
+	streams stream.StreamUseCase
+	tokens  stream.TokenUseCase
+	balance balance.UseCase
+}
+
+func NewHandler(streams stream.StreamUseCase, tokens stream.TokenUseCase, balance balance.UseCase) *Handler {
+	return &Handler{
+		streams: streams,
+		tokens:  tokens,
+		balance: balance,
+	}
+}
+
+func (h Handler) writeJSON(value interface{}, w http.ResponseWriter) {
+	w.Header().Add("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		problem.Write(w, err)
+	}
+}
+
+func (h Handler) parseId(r *http.Request, w http.ResponseWriter) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil || id <= 0 {
+		problem.Write(w, apperror.BadArgument("id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+// authorize проверяет право на чтение баланса у вызывающего, определенного по ключу API или токену пользователя.
+// Токен потока из параметра token принимается только без них, так как EventSource в браузере
+// не умеет передавать заголовки
+func (h Handler) authorize(r *http.Request, id int64) error {
+	if auth.FromContext(r.Context()) == nil {
+		if token := r.URL.Query().Get("token"); token != "" {
+			return h.tokens.VerifyToken(id, token)
+		}
+	}
+
+	return auth.Authorize(r.Context(), auth.ScopeBalanceRead, id)
+}
+
+// writeEvent записывает обновление событием "balance"; id события - id операции,
+// поэтому клиент может отбросить повторы
+func (h Handler) writeEvent(update *models.BalanceUpdate, w http.ResponseWriter) error {
+	data, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	if update.Transaction != nil {
+		_, err = fmt.Fprintf(w, "id: %d\n", update.Transaction.Id)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "event: balance\ndata: %s\n\n", data)
+	return err
+}
+
+// StreamEndpoint отправляет текущий баланс, а затем баланс после каждой операции со счетом
+func (h Handler) StreamEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	err := h.authorize(r, id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		problem.Write(w, fmt.Errorf("streaming is not supported by %T", w))
+		return
+	}
+
+	// Подписка оформляется до чтения баланса, чтобы не пропустить операцию между ними
+	updates, unsubscribe := h.streams.Subscribe(id)
+	defer unsubscribe()
+
+	current, err := h.balance.GetBalance(id, exchange.RUB)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	err = h.writeEvent(&models.BalanceUpdate{Balance: current}, w)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+
+			err = h.writeEvent(update, w)
+		case <-keepAlive.C:
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+		}
+
+		if err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
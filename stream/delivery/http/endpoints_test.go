@@ -0,0 +1,207 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/auth"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"avito-intership/problem"
+	"avito-intership/server/middleware"
+	"avito-intership/stream"
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type streamHandlerSuite struct {
+	suite.Suite
+
+	streams       *mocks.StreamUseCase
+	tokens        *mocks.TokenUseCase
+	balance       *mocks.UseCase
+	testingServer *httptest.Server
+}
+
+func (suite *streamHandlerSuite) SetupTest() {
+	suite.streams = new(mocks.StreamUseCase)
+	suite.tokens = new(mocks.TokenUseCase)
+	suite.balance = new(mocks.UseCase)
+	suite.testingServer = suite.serve(nil)
+}
+
+func (suite *streamHandlerSuite) TearDownTest() {
+	suite.testingServer.Close()
+}
+
+// serve запускает сервер, выполняющий запросы от имени principal; nil - запросы без ключа и токена
+func (suite *streamHandlerSuite) serve(principal *models.Principal) *httptest.Server {
+	router := mux.NewRouter()
+	if principal != nil {
+		router.Use(middleware.WithPrincipal(principal))
+	}
+	RegisterEndpoints(router, suite.streams, suite.tokens, suite.balance)
+	RegisterAdminEndpoints(router.PathPrefix("/api/v1/admin").Subrouter(), suite.tokens)
+
+	return httptest.NewServer(router)
+}
+
+// readEvent читает из потока следующее событие, пропуская комментарии
+func (suite *streamHandlerSuite) readEvent(reader *bufio.Reader) (string, *models.BalanceUpdate) {
+	var id string
+	var update models.BalanceUpdate
+	for {
+		line, err := reader.ReadString('\n')
+		suite.Require().NoError(err, "reading stream should not produce error")
+
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(line, "id: "):
+			id = strings.TrimPrefix(line, "id: ")
+		case strings.HasPrefix(line, "data: "):
+			suite.Require().NoError(json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &update))
+		case line == "" && update.Balance != nil:
+			return id, &update
+		}
+	}
+}
+
+func (suite *streamHandlerSuite) TestStream() {
+	updates := make(chan *models.BalanceUpdate, 1)
+	unsubscribed := make(chan struct{})
+	suite.streams.On("Subscribe", int64(1)).
+		Return((<-chan *models.BalanceUpdate)(updates), func() { close(unsubscribed) })
+	suite.balance.On("GetBalance", int64(1), "RUB").Return(&models.Balance{UserId: 1, Amount: 1000}, nil)
+
+	server := suite.serve(&models.Principal{UserId: 1, Scopes: auth.UserScopes})
+	defer server.Close()
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/1/stream", server.URL))
+	suite.Require().NoError(err, "request should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal("text/event-stream", response.Header.Get("Content-Type"))
+
+	reader := bufio.NewReader(response.Body)
+	id, update := suite.readEvent(reader)
+	suite.Equal("", id)
+	suite.Equal(float32(1000), update.Balance.Amount)
+	suite.Nil(update.Transaction)
+
+	updates <- &models.BalanceUpdate{Balance: &models.Balance{UserId: 1, Amount: 900},
+		Transaction: &models.Transaction{Id: 7, UserId: 1, Amount: -100}}
+	id, update = suite.readEvent(reader)
+	suite.Equal("7", id)
+	suite.Equal(float32(900), update.Balance.Amount)
+	suite.Equal(float32(-100), update.Transaction.Amount)
+
+	_ = response.Body.Close()
+	select {
+	case <-unsubscribed:
+	case <-time.After(time.Second):
+		suite.Fail("subscription is not cancelled after client disconnects")
+	}
+}
+
+func (suite *streamHandlerSuite) TestStream_QueryToken() {
+	suite.tokens.On("VerifyToken", int64(1), "valid").Return(nil)
+	suite.streams.On("Subscribe", int64(1)).
+		Return((<-chan *models.BalanceUpdate)(make(chan *models.BalanceUpdate)), func() {})
+	suite.balance.On("GetBalance", int64(1), "RUB").Return(&models.Balance{UserId: 1, Amount: 1000}, nil)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/1/stream?token=valid", suite.testingServer.URL))
+	suite.Require().NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	_, update := suite.readEvent(bufio.NewReader(response.Body))
+	suite.Equal(float32(1000), update.Balance.Amount)
+}
+
+func (suite *streamHandlerSuite) TestStream_Forbidden() {
+	cases := []*models.Principal{
+		{UserId: 1, Scopes: auth.UserScopes},
+		{Service: "billing", Scopes: []string{auth.ScopeDebit}},
+	}
+
+	for _, principal := range cases {
+		server := suite.serve(principal)
+
+		// Токен потока не заменяет права вызывающего, определенного по ключу или токену
+		response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/2/stream?token=valid", server.URL))
+		suite.Require().NoError(err, "request should not produce error")
+		response.Body.Close()
+		server.Close()
+
+		suite.Equal(http.StatusForbidden, response.StatusCode)
+	}
+
+	suite.tokens.AssertNotCalled(suite.T(), "VerifyToken", mock.Anything, mock.Anything)
+	suite.streams.AssertNotCalled(suite.T(), "Subscribe", int64(2))
+}
+
+func (suite *streamHandlerSuite) TestStream_NoCredentials() {
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/2/stream", suite.testingServer.URL))
+	suite.Require().NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusUnauthorized, response.StatusCode)
+	suite.tokens.AssertNotCalled(suite.T(), "VerifyToken", mock.Anything, mock.Anything)
+	suite.streams.AssertNotCalled(suite.T(), "Subscribe", int64(2))
+}
+
+func (suite *streamHandlerSuite) TestStream_Unauthorized() {
+	suite.tokens.On("VerifyToken", int64(2), "stolen").Return(stream.ErrUnauthorized)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/2/stream?token=stolen", suite.testingServer.URL))
+	suite.Require().NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody problem.Problem
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusUnauthorized, response.StatusCode)
+	suite.Equal(apperror.Unauthorized, responseBody.Code)
+	suite.streams.AssertNotCalled(suite.T(), "Subscribe", int64(2))
+}
+
+func (suite *streamHandlerSuite) TestStream_Disabled() {
+	suite.tokens.On("VerifyToken", int64(3), "any").Return(stream.ErrStreamDisabled)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/balance/3/stream?token=any", suite.testingServer.URL))
+	suite.Require().NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusServiceUnavailable, response.StatusCode)
+}
+
+func (suite *streamHandlerSuite) TestIssueToken() {
+	expiresAt := time.Date(2021, 11, 18, 3, 16, 0, 0, time.UTC)
+	suite.tokens.On("IssueToken", int64(1)).
+		Return(&models.StreamToken{UserId: 1, Token: "1637205360.abc", ExpiresAt: expiresAt}, nil)
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/admin/balance/1/stream-token", suite.testingServer.URL),
+		"application/x-www-form-urlencoded", nil)
+	suite.Require().NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.StreamToken
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal("1637205360.abc", responseBody.Token)
+	suite.True(expiresAt.Equal(responseBody.ExpiresAt))
+}
+
+func TestStreamHandler(t *testing.T) {
+	suite.Run(t, new(streamHandlerSuite))
+}
@@ -0,0 +1,24 @@
+package http
+
+import (
+	"avito-intership/balance"
+	"avito-intership/stream"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+func RegisterEndpoints(router *mux.Router, streams stream.StreamUseCase, tokens stream.TokenUseCase,
+	balance balance.UseCase) {
+	handler := NewHandler(streams, tokens, balance)
+
+	router.HandleFunc("/api/v1/balance/{id:[0-9]+}/stream", handler.StreamEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+}
+
+// RegisterAdminEndpoints регистрирует служебные методы, router - подмаршрутизатор /api/v1/admin
+func RegisterAdminEndpoints(router *mux.Router, tokens stream.TokenUseCase) {
+	handler := NewAdminHandler(tokens)
+
+	router.HandleFunc("/balance/{id:[0-9]+}/stream-token", handler.IssueTokenEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+}
@@ -0,0 +1,9 @@
+package stream
+
+import "avito-intership/apperror"
+
+var (
+	ErrUnauthorized = apperror.New(apperror.Unauthorized,
+		"stream token is missing, expired or issued for another user")
+	ErrStreamDisabled = apperror.New(apperror.Unavailable, "balance stream is disabled")
+)
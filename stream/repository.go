@@ -0,0 +1,15 @@
+package stream
+
+import (
+	"avito-intership/models"
+	"context"
+)
+
+// Channel - канал LISTEN/NOTIFY, в который репозитории пишут выполненные операции
+const Channel = "balance_changes"
+
+type Listener interface {
+	// Listen передает в handle операции из всех экземпляров сервиса до отмены ctx.
+	// После переподключения к базе в handle передается nil, так как уведомления за это время могли потеряться
+	Listen(ctx context.Context, handle func(transaction *models.Transaction)) error
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"avito-intership/models"
+	"avito-intership/stream"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/lib/pq"
+	"log"
+	"time"
+)
+
+const (
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+	pingInterval         = 90 * time.Second
+)
+
+// NotifyTransaction отправляет операцию в канал stream.Channel в транзакции tx.
+// Postgres доставляет уведомление слушателям только после фиксации tx, а при откате отбрасывает его
+func NotifyTransaction(tx *sql.Tx, transaction *models.Transaction) error {
+	payload, err := json.Marshal(transaction)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec("SELECT pg_notify($1, $2)", stream.Channel, string(payload))
+	return err
+}
+
+// Listener слушает канал stream.Channel отдельным соединением, которое не входит в пул database/sql
+type Listener struct {
+	connInfo string
+}
+
+func NewListener(connInfo string) *Listener {
+	return &Listener{connInfo: connInfo}
+}
+
+func (l *Listener) Listen(ctx context.Context, handle func(transaction *models.Transaction)) error {
+	listener := pq.NewListener(l.connInfo, minReconnectInterval, maxReconnectInterval,
+		func(event pq.ListenerEventType, err error) {
+			if err != nil {
+				log.Println(err)
+			}
+		})
+	defer listener.Close()
+
+	err := listener.Listen(stream.Channel)
+	if err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-listener.Notify:
+			// nil приходит после восстановления соединения
+			if notification == nil {
+				handle(nil)
+				continue
+			}
+
+			var transaction models.Transaction
+			err = json.Unmarshal([]byte(notification.Extra), &transaction)
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+
+			handle(&transaction)
+		case <-time.After(pingInterval):
+			go func() {
+				if err := listener.Ping(); err != nil {
+					log.Println(err)
+				}
+			}()
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"avito-intership/models"
+	"avito-intership/utils"
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/suite"
+	"log"
+	"testing"
+	"time"
+)
+
+type listenerSuite struct {
+	suite.Suite
+
+	db       *sql.DB
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+
+	listener *Listener
+}
+
+func (suite *listenerSuite) SetupSuite() {
+	db, pool, resource := utils.DockerDBUp()
+
+	suite.listener = NewListener(fmt.Sprintf("postgres://user_name:secret@%s/dbname?sslmode=disable",
+		resource.GetHostPort("5432/tcp")))
+	suite.db = db
+	suite.pool = pool
+	suite.resource = resource
+}
+
+func (suite *listenerSuite) notify(transaction *models.Transaction, commit bool) {
+	tx, err := suite.db.Begin()
+	suite.Require().NoError(err)
+
+	suite.Require().NoError(NotifyTransaction(tx, transaction), "notifying should not produce error")
+	if commit {
+		suite.Require().NoError(tx.Commit())
+	} else {
+		suite.Require().NoError(tx.Rollback())
+	}
+}
+
+func (suite *listenerSuite) TestListen() {
+	received := make(chan *models.Transaction, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- suite.listener.Listen(ctx, func(transaction *models.Transaction) {
+			received <- transaction
+		})
+	}()
+
+	// Слушатель подписывается асинхронно, поэтому уведомления отправляются, пока первое не дойдет
+	first := &models.Transaction{Id: 1, UserId: 1, Amount: -100, Type: "product", Status: "completed"}
+	var transaction *models.Transaction
+	for transaction == nil {
+		suite.notify(first, true)
+		select {
+		case transaction = <-received:
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+	suite.Equal(int64(1), transaction.UserId)
+	suite.Equal(float32(-100), transaction.Amount)
+
+	suite.notify(&models.Transaction{Id: 2, UserId: 2, Amount: 50}, false)
+	suite.notify(&models.Transaction{Id: 3, UserId: 3, Amount: 70}, true)
+
+	// Повторы первого уведомления могли прийти позже
+	transaction = <-received
+	for transaction.Id == 1 {
+		transaction = <-received
+	}
+	suite.Equal(int64(3), transaction.Id, "notification from rolled back transaction is not delivered")
+
+	cancel()
+	suite.NoError(<-done)
+}
+
+func (suite *listenerSuite) TearDownSuite() {
+	if err := suite.pool.Purge(suite.resource); err != nil {
+		log.Fatalf("Could not purge resource: %s", err)
+	}
+}
+
+func TestListener(t *testing.T) {
+	suite.Run(t, new(listenerSuite))
+}
@@ -0,0 +1,13 @@
+package stream
+
+import "avito-intership/models"
+
+type StreamUseCase interface {
+	// Subscribe возвращает канал обновлений счета и функцию отписки, после которой канал закрывается
+	Subscribe(userId int64) (<-chan *models.BalanceUpdate, func())
+}
+
+type TokenUseCase interface {
+	IssueToken(userId int64) (*models.StreamToken, error)
+	VerifyToken(userId int64, token string) error
+}
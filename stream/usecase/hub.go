@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"avito-intership/stream"
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// subscriberBuffer - сколько обновлений ждут медленного клиента, более новые отбрасываются:
+	// каждое обновление содержит баланс целиком, поэтому клиент получит актуальное состояние со следующим
+	subscriberBuffer = 16
+	listenRetryDelay = 5 * time.Second
+)
+
+// Hub раздает операции из Listener подписчикам этого экземпляра сервиса,
+// баланс читается один раз на операцию и только если у пользователя есть подписчики
+type Hub struct {
+	listener stream.Listener
+	balance  balance.UseCase
+
+	mu          sync.Mutex
+	subscribers map[int64]map[chan *models.BalanceUpdate]struct{}
+}
+
+func NewHub(listener stream.Listener, balance balance.UseCase) *Hub {
+	return &Hub{
+		listener:    listener,
+		balance:     balance,
+		subscribers: make(map[int64]map[chan *models.BalanceUpdate]struct{}),
+	}
+}
+
+func (h *Hub) Subscribe(userId int64) (<-chan *models.BalanceUpdate, func()) {
+	updates := make(chan *models.BalanceUpdate, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subscribers[userId] == nil {
+		h.subscribers[userId] = make(map[chan *models.BalanceUpdate]struct{})
+	}
+	h.subscribers[userId][updates] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers[userId], updates)
+			if len(h.subscribers[userId]) == 0 {
+				delete(h.subscribers, userId)
+			}
+			h.mu.Unlock()
+
+			close(updates)
+		})
+	}
+
+	return updates, unsubscribe
+}
+
+// Dispatch отправляет подписчикам пользователя его баланс после операции transaction.
+// nil означает, что операции могли быть пропущены, и баланс отправляется всем подписчикам
+func (h *Hub) Dispatch(transaction *models.Transaction) {
+	if transaction == nil {
+		h.mu.Lock()
+		userIds := make([]int64, 0, len(h.subscribers))
+		for userId := range h.subscribers {
+			userIds = append(userIds, userId)
+		}
+		h.mu.Unlock()
+
+		for _, userId := range userIds {
+			h.send(userId, nil)
+		}
+		return
+	}
+
+	h.send(transaction.UserId, transaction)
+}
+
+func (h *Hub) send(userId int64, transaction *models.Transaction) {
+	h.mu.Lock()
+	subscribed := len(h.subscribers[userId]) > 0
+	h.mu.Unlock()
+	if !subscribed {
+		return
+	}
+
+	current, err := h.balance.GetBalance(userId, exchange.RUB)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	update := &models.BalanceUpdate{Balance: current, Transaction: transaction}
+
+	// Отправка идет под блокировкой, чтобы отписка не закрыла канал во время записи в него
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for updates := range h.subscribers[userId] {
+		select {
+		case updates <- update:
+		default:
+		}
+	}
+}
+
+// Run слушает операции до отмены ctx и переподключается при ошибках
+func (h *Hub) Run(ctx context.Context) {
+	for {
+		err := h.listener.Listen(ctx, h.Dispatch)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Println(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryDelay):
+		}
+		h.Dispatch(nil)
+	}
+}
@@ -0,0 +1,126 @@
+package usecase
+
+import (
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"context"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+)
+
+type hubSuite struct {
+	suite.Suite
+	listener *mocks.Listener
+	balance  *mocks.UseCase
+	hub      *Hub
+}
+
+func (suite *hubSuite) SetupTest() {
+	suite.listener = new(mocks.Listener)
+	suite.balance = new(mocks.UseCase)
+	suite.hub = NewHub(suite.listener, suite.balance)
+}
+
+func (suite *hubSuite) TestDispatch() {
+	suite.balance.On("GetBalance", int64(1), "RUB").Return(&models.Balance{UserId: 1, Amount: 900}, nil)
+	first, unsubscribeFirst := suite.hub.Subscribe(1)
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := suite.hub.Subscribe(1)
+	defer unsubscribeSecond()
+
+	transaction := &models.Transaction{Id: 5, UserId: 1, Amount: -100}
+	suite.hub.Dispatch(transaction)
+
+	for _, updates := range []<-chan *models.BalanceUpdate{first, second} {
+		update := <-updates
+		suite.Equal(float32(900), update.Balance.Amount)
+		suite.Equal(transaction, update.Transaction)
+	}
+	suite.balance.AssertNumberOfCalls(suite.T(), "GetBalance", 1)
+}
+
+func (suite *hubSuite) TestDispatch_NoSubscribers() {
+	_, unsubscribe := suite.hub.Subscribe(2)
+	defer unsubscribe()
+
+	suite.hub.Dispatch(&models.Transaction{Id: 5, UserId: 1, Amount: -100})
+
+	suite.balance.AssertNotCalled(suite.T(), "GetBalance", mock.Anything, mock.Anything)
+}
+
+func (suite *hubSuite) TestDispatch_SlowSubscriber() {
+	suite.balance.On("GetBalance", int64(1), "RUB").Return(&models.Balance{UserId: 1}, nil)
+	updates, unsubscribe := suite.hub.Subscribe(1)
+	defer unsubscribe()
+
+	for i := 0; i < subscriberBuffer+5; i++ {
+		suite.hub.Dispatch(&models.Transaction{Id: int64(i), UserId: 1})
+	}
+
+	suite.Len(updates, subscriberBuffer, "updates beyond the buffer are dropped instead of blocking")
+}
+
+func (suite *hubSuite) TestUnsubscribe() {
+	updates, unsubscribe := suite.hub.Subscribe(1)
+	unsubscribe()
+	unsubscribe()
+
+	_, ok := <-updates
+	suite.False(ok, "channel is closed after unsubscribing")
+
+	suite.hub.Dispatch(&models.Transaction{Id: 5, UserId: 1})
+	suite.balance.AssertNotCalled(suite.T(), "GetBalance", mock.Anything, mock.Anything)
+}
+
+func (suite *hubSuite) TestDispatch_Resync() {
+	suite.balance.On("GetBalance", int64(1), "RUB").Return(&models.Balance{UserId: 1, Amount: 10}, nil)
+	suite.balance.On("GetBalance", int64(2), "RUB").Return(&models.Balance{UserId: 2, Amount: 20}, nil)
+	first, unsubscribeFirst := suite.hub.Subscribe(1)
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := suite.hub.Subscribe(2)
+	defer unsubscribeSecond()
+
+	suite.hub.Dispatch(nil)
+
+	update := <-first
+	suite.Equal(float32(10), update.Balance.Amount)
+	suite.Nil(update.Transaction)
+	update = <-second
+	suite.Equal(float32(20), update.Balance.Amount)
+}
+
+func (suite *hubSuite) TestRun() {
+	suite.balance.On("GetBalance", int64(1), "RUB").Return(&models.Balance{UserId: 1, Amount: 900}, nil)
+	suite.listener.On("Listen", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			handle := args.Get(1).(func(transaction *models.Transaction))
+			handle(&models.Transaction{Id: 5, UserId: 1})
+			<-args.Get(0).(context.Context).Done()
+		}).Return(nil)
+
+	updates, unsubscribe := suite.hub.Subscribe(1)
+	defer unsubscribe()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		suite.hub.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case update := <-updates:
+		suite.Equal(int64(5), update.Transaction.Id)
+	case <-time.After(time.Second):
+		suite.Fail("update is not delivered")
+	}
+
+	cancel()
+	<-done
+}
+
+func TestHub(t *testing.T) {
+	suite.Run(t, new(hubSuite))
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"avito-intership/models"
+	"avito-intership/stream"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tokenLifetime - время, в течение которого по токену можно открыть поток; открытый поток токен не прерывает
+const tokenLifetime = time.Hour
+
+// TokenUseCase выдает токены доступа к потоку одного пользователя.
+// Токен имеет вид "<expires_at>.<подпись>", где подпись - HMAC-SHA256 от "<user_id>.<expires_at>",
+// поэтому проверить его может любой экземпляр сервиса с тем же секретом
+type TokenUseCase struct {
+	secret []byte
+	now    func() time.Time
+}
+
+func NewTokenUseCase(secret string) *TokenUseCase {
+	return &TokenUseCase{
+		secret: []byte(secret),
+		now:    time.Now,
+	}
+}
+
+func (u *TokenUseCase) sign(userId int64, expiresAt int64) string {
+	mac := hmac.New(sha256.New, u.secret)
+	mac.Write([]byte(strconv.FormatInt(userId, 10) + "." + strconv.FormatInt(expiresAt, 10)))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (u *TokenUseCase) IssueToken(userId int64) (*models.StreamToken, error) {
+	if len(u.secret) == 0 {
+		return nil, stream.ErrStreamDisabled
+	}
+
+	expiresAt := u.now().Add(tokenLifetime).Truncate(time.Second)
+	return &models.StreamToken{
+		UserId:    userId,
+		Token:     strconv.FormatInt(expiresAt.Unix(), 10) + "." + u.sign(userId, expiresAt.Unix()),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (u *TokenUseCase) VerifyToken(userId int64, token string) error {
+	if len(u.secret) == 0 {
+		return stream.ErrStreamDisabled
+	}
+
+	parts := strings.SplitN(token, ".", 2)
+	if len(parts) != 2 {
+		return stream.ErrUnauthorized
+	}
+
+	expiresAt, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || !u.now().Before(time.Unix(expiresAt, 0)) {
+		return stream.ErrUnauthorized
+	}
+
+	if !hmac.Equal([]byte(parts[1]), []byte(u.sign(userId, expiresAt))) {
+		return stream.ErrUnauthorized
+	}
+
+	return nil
+}
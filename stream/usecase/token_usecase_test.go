@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"avito-intership/stream"
+	"github.com/stretchr/testify/suite"
+	"strings"
+	"testing"
+	"time"
+)
+
+type tokenUseCaseSuite struct {
+	suite.Suite
+	now    time.Time
+	tokens *TokenUseCase
+}
+
+func (suite *tokenUseCaseSuite) SetupTest() {
+	suite.now = time.Date(2021, 11, 18, 2, 16, 0, 0, time.UTC)
+	suite.tokens = NewTokenUseCase("secret")
+	suite.tokens.now = func() time.Time { return suite.now }
+}
+
+func (suite *tokenUseCaseSuite) TestIssueAndVerify() {
+	token, err := suite.tokens.IssueToken(1)
+	suite.NoError(err)
+	suite.Equal(int64(1), token.UserId)
+	suite.Equal(suite.now.Add(time.Hour), token.ExpiresAt)
+
+	suite.NoError(suite.tokens.VerifyToken(1, token.Token))
+}
+
+func (suite *tokenUseCaseSuite) TestVerify_AnotherUser() {
+	token, err := suite.tokens.IssueToken(1)
+	suite.Require().NoError(err)
+
+	suite.Equal(stream.ErrUnauthorized, suite.tokens.VerifyToken(2, token.Token))
+}
+
+func (suite *tokenUseCaseSuite) TestVerify_Expired() {
+	token, err := suite.tokens.IssueToken(1)
+	suite.Require().NoError(err)
+
+	suite.now = suite.now.Add(time.Hour)
+	suite.Equal(stream.ErrUnauthorized, suite.tokens.VerifyToken(1, token.Token))
+}
+
+func (suite *tokenUseCaseSuite) TestVerify_Forged() {
+	token, err := suite.tokens.IssueToken(1)
+	suite.Require().NoError(err)
+
+	// Продление срока действия делает подпись неверной
+	parts := strings.SplitN(token.Token, ".", 2)
+	forged := "9999999999." + parts[1]
+
+	suite.Equal(stream.ErrUnauthorized, suite.tokens.VerifyToken(1, forged))
+	suite.Equal(stream.ErrUnauthorized, suite.tokens.VerifyToken(1, ""))
+	suite.Equal(stream.ErrUnauthorized, suite.tokens.VerifyToken(1, "garbage"))
+
+	other := NewTokenUseCase("other secret")
+	other.now = suite.tokens.now
+	suite.Equal(stream.ErrUnauthorized, other.VerifyToken(1, token.Token))
+}
+
+func (suite *tokenUseCaseSuite) TestDisabled() {
+	tokens := NewTokenUseCase("")
+
+	_, err := tokens.IssueToken(1)
+	suite.Equal(stream.ErrStreamDisabled, err)
+	suite.Equal(stream.ErrStreamDisabled, tokens.VerifyToken(1, "1.abc"))
+}
+
+func TestTokenUseCase(t *testing.T) {
+	suite.Run(t, new(tokenUseCaseSuite))
+}
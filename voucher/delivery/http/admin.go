@@ -0,0 +1,76 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/problem"
+	"avito-intership/voucher"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type AdminHandler struct {
+	Handler
+}
+
+func NewAdminHandler(useCase voucher.VoucherUseCase) *AdminHandler {
+	return &AdminHandler{
+		Handler: Handler{useCase: useCase},
+	}
+}
+
+// IssueBatchEndpoint выпускает count кодов; currency по умолчанию "RUB", usage_limit - 1, count - 1
+func (h AdminHandler) IssueBatchEndpoint(w http.ResponseWriter, r *http.Request) {
+	amount, err := strconv.ParseFloat(r.FormValue("amount"), 32)
+	if err != nil {
+		problem.Write(w, apperror.BadArgument("amount"))
+		return
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, r.FormValue("expires_at"))
+	if err != nil {
+		problem.Write(w, apperror.BadArgument("expires_at"))
+		return
+	}
+
+	var usageLimit int64 = 1
+	if limit := r.FormValue("usage_limit"); limit != "" {
+		usageLimit, err = strconv.ParseInt(limit, 10, 64)
+		if err != nil {
+			problem.Write(w, apperror.BadArgument("usage_limit"))
+			return
+		}
+	}
+
+	count := 1
+	if c := r.FormValue("count"); c != "" {
+		count, err = strconv.Atoi(c)
+		if err != nil {
+			problem.Write(w, apperror.BadArgument("count"))
+			return
+		}
+	}
+
+	batch, err := h.useCase.IssueBatch(float32(amount), r.FormValue("currency"), expiresAt, usageLimit, count)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(batch, w)
+}
+
+func (h AdminHandler) GetBatchEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	batch, err := h.useCase.GetBatch(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(batch, w)
+}
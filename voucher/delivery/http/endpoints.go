@@ -0,0 +1,77 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/auth"
+	"avito-intership/models"
+	"avito-intership/problem"
+	"avito-intership/voucher"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+)
+
+type Handler struct {
+	useCase voucher.VoucherUseCase
+}
+
+func NewHandler(useCase voucher.VoucherUseCase) *Handler {
+	return &Handler{
+		useCase: useCase,
+	}
+}
+
+type StatusMessage struct {
+	Success bool    `json:"success"`
+	Message *string `json:"message"`
+}
+
+type RedemptionStatus struct {
+	StatusMessage
+	Redemption *models.Redemption `json:"redemption"`
+}
+
+func (h Handler) writeJSON(value interface{}, w http.ResponseWriter) {
+	w.Header().Add("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		problem.Write(w, err)
+	}
+}
+
+func (h Handler) parseId(r *http.Request, w http.ResponseWriter) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil || id <= 0 {
+		problem.Write(w, apperror.BadArgument("id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (h Handler) RedeemEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	if err := auth.AuthorizeOwn(r.Context(), auth.ScopeCredit, auth.ScopeRedeemVouchers, id); err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	code := r.FormValue("code")
+	if code == "" {
+		problem.Write(w, apperror.BadArgument("code"))
+		return
+	}
+
+	redemption, err := h.useCase.Redeem(code, id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(RedemptionStatus{StatusMessage{Success: true}, redemption}, w)
+}
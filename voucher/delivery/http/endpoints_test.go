@@ -0,0 +1,183 @@
+package http
+
+import (
+	"avito-intership/auth"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"avito-intership/server/middleware"
+	"avito-intership/voucher"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type voucherHandlerSuite struct {
+	suite.Suite
+
+	useCase       *mocks.VoucherUseCase
+	testingServer *httptest.Server
+}
+
+func (suite *voucherHandlerSuite) SetupSuite() {
+	useCase := new(mocks.VoucherUseCase)
+
+	router := mux.NewRouter()
+	router.Use(middleware.WithPrincipal(&models.Principal{Service: "marketing",
+		Scopes: []string{auth.ScopeCredit}}))
+	RegisterEndpoints(router, useCase)
+	RegisterAdminEndpoints(router.PathPrefix("/api/v1/admin").Subrouter(), useCase)
+
+	suite.testingServer = httptest.NewServer(router)
+	suite.useCase = useCase
+}
+
+func (suite *voucherHandlerSuite) TearDownSuite() {
+	defer suite.testingServer.Close()
+}
+
+func (suite *voucherHandlerSuite) TestRedeem() {
+	var id int64 = 1
+	redemption := &models.Redemption{Code: "ABCD-EFGH-JKLM", UserId: id, Amount: 10, Currency: "USD", Credited: 750}
+	suite.useCase.On("Redeem", "ABCD-EFGH-JKLM", id).Return(redemption, nil)
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/balance/%d/redeem?code=ABCD-EFGH-JKLM",
+		suite.testingServer.URL, id), "", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody RedemptionStatus
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.True(responseBody.Success)
+	suite.Equal(redemption, responseBody.Redemption)
+}
+
+func (suite *voucherHandlerSuite) TestRedeem_Errors() {
+	cases := []struct {
+		name   string
+		code   string
+		err    error
+		status int
+	}{
+		{name: "unknown code", code: "NONE", err: voucher.ErrVoucherNotFound, status: http.StatusNotFound},
+		{name: "expired", code: "OLD", err: voucher.ErrVoucherExpired, status: http.StatusConflict},
+		{name: "used up", code: "USED", err: voucher.ErrVoucherUsedUp, status: http.StatusConflict},
+		{name: "already redeemed", code: "MINE", err: voucher.ErrAlreadyRedeemed, status: http.StatusConflict},
+	}
+
+	for _, c := range cases {
+		suite.Run(c.name, func() {
+			suite.useCase.On("Redeem", c.code, int64(2)).Return(nil, c.err)
+
+			response, err := http.Post(fmt.Sprintf("%s/api/v1/balance/2/redeem?code=%s",
+				suite.testingServer.URL, c.code), "", bytes.NewBuffer([]byte{}))
+			suite.NoError(err, "request should not produce error")
+			defer response.Body.Close()
+
+			suite.Equal(c.status, response.StatusCode)
+		})
+	}
+}
+
+func (suite *voucherHandlerSuite) TestRedeem_NoCode() {
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/balance/2/redeem", suite.testingServer.URL),
+		"", bytes.NewBuffer([]byte{}))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *voucherHandlerSuite) TestIssueBatch() {
+	expiresAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	batch := &models.VoucherBatch{Id: 3, Amount: 500, Currency: "RUB", UsageLimit: 1, ExpiresAt: expiresAt,
+		Vouchers: []*models.Voucher{{Code: "ABCD-EFGH-JKLM", BatchId: 3}}}
+	suite.useCase.On("IssueBatch", float32(500), "", mock.MatchedBy(func(t time.Time) bool {
+		return t.Equal(expiresAt)
+	}), int64(1), 20).Return(batch, nil)
+
+	data := url.Values{}
+	data.Set("amount", "500")
+	data.Set("expires_at", "2030-01-01T00:00:00Z")
+	data.Set("count", "20")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/vouchers", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.VoucherBatch
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(batch.Id, responseBody.Id)
+	suite.Len(responseBody.Vouchers, 1)
+}
+
+func (suite *voucherHandlerSuite) TestIssueBatch_BadExpiry() {
+	data := url.Values{}
+	data.Set("amount", "500")
+	data.Set("expires_at", "tomorrow")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/vouchers", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *voucherHandlerSuite) TestGetBatch_NotFound() {
+	suite.useCase.On("GetBatch", int64(404)).Return(nil, voucher.ErrVoucherNotFound)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/admin/vouchers/404", suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusNotFound, response.StatusCode)
+}
+
+// TestUserRedeem проверяет, что пользователь погашает ваучеры только на свой счет
+func (suite *voucherHandlerSuite) TestUserRedeem() {
+	useCase := new(mocks.VoucherUseCase)
+	router := mux.NewRouter()
+	router.Use(middleware.WithPrincipal(&models.Principal{UserId: 3, Scopes: auth.UserScopes}))
+	RegisterEndpoints(router, useCase)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	useCase.On("Redeem", "ABCD-EFGH-JKLM", int64(3)).
+		Return(&models.Redemption{Code: "ABCD-EFGH-JKLM", UserId: 3, Amount: 500, Currency: "RUB", Credited: 500}, nil)
+
+	cases := []struct {
+		userId int64
+		status int
+	}{
+		{userId: 3, status: http.StatusOK},
+		{userId: 4, status: http.StatusForbidden},
+	}
+
+	for _, c := range cases {
+		response, err := http.Post(fmt.Sprintf("%s/api/v1/balance/%d/redeem?code=ABCD-EFGH-JKLM", server.URL, c.userId),
+			"", bytes.NewBuffer([]byte{}))
+		suite.NoError(err, "request should not produce error")
+		response.Body.Close()
+
+		suite.Equal(c.status, response.StatusCode)
+	}
+
+	useCase.AssertNotCalled(suite.T(), "Redeem", mock.Anything, int64(4))
+}
+
+func TestVoucherHandler(t *testing.T) {
+	suite.Run(t, new(voucherHandlerSuite))
+}
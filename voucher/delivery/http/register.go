@@ -0,0 +1,24 @@
+package http
+
+import (
+	"avito-intership/voucher"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+func RegisterEndpoints(router *mux.Router, uc voucher.VoucherUseCase) {
+	handler := NewHandler(uc)
+
+	router.HandleFunc("/api/v1/balance/{id:[0-9]+}/redeem", handler.RedeemEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+}
+
+// RegisterAdminEndpoints регистрирует выпуск ваучеров, router - подмаршрутизатор /api/v1/admin
+func RegisterAdminEndpoints(router *mux.Router, uc voucher.VoucherUseCase) {
+	handler := NewAdminHandler(uc)
+
+	router.HandleFunc("/vouchers", handler.IssueBatchEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/vouchers/{id:[0-9]+}", handler.GetBatchEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+}
@@ -0,0 +1,12 @@
+package voucher
+
+import "avito-intership/apperror"
+
+var (
+	ErrVoucherNotFound = apperror.New(apperror.NotFound, "voucher not found")
+	ErrVoucherExpired  = apperror.New(apperror.Conflict, "voucher has expired")
+	ErrVoucherUsedUp   = apperror.New(apperror.Conflict, "voucher usage limit is reached")
+	ErrAlreadyRedeemed = apperror.New(apperror.Conflict, "voucher is already redeemed by this user")
+	ErrBadBatch        = apperror.New(apperror.InvalidArgument,
+		"batch must have a positive amount, count and usage limit and a future expiry")
+)
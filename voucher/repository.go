@@ -0,0 +1,16 @@
+package voucher
+
+import (
+	"avito-intership/models"
+	"time"
+)
+
+type VoucherRepository interface {
+	// CreateBatch сохраняет выпуск вместе с кодами batch.Vouchers
+	CreateBatch(batch *models.VoucherBatch) (*models.VoucherBatch, error)
+	GetBatch(id int64) (*models.VoucherBatch, error)
+	// GetVoucherBatch возвращает выпуск, к которому относится код, без списка кодов
+	GetVoucherBatch(code string) (*models.VoucherBatch, error)
+	// Redeem в одной транзакции гасит код и зачисляет credited рублей на баланс пользователя
+	Redeem(code string, userId int64, credited float32, at time.Time) error
+}
@@ -0,0 +1,189 @@
+package postgres
+
+import (
+	"avito-intership/balance"
+	balancePostgres "avito-intership/balance/repository/postgres"
+	"avito-intership/models"
+	"avito-intership/voucher"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+type VoucherRepository struct {
+	db *sql.DB
+}
+
+func NewVoucherRepository(dbConn *sql.DB) *VoucherRepository {
+	return &VoucherRepository{dbConn}
+}
+
+const batchColumns = "id, amount, currency, usage_limit, expires_at, created_at"
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanBatch(row scanner) (*models.VoucherBatch, error) {
+	var b models.VoucherBatch
+
+	err := row.Scan(&b.Id, &b.Amount, &b.Currency, &b.UsageLimit, &b.ExpiresAt, &b.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, voucher.ErrVoucherNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func (r VoucherRepository) CreateBatch(batch *models.VoucherBatch) (result *models.VoucherBatch, err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	row := tx.QueryRow(
+		`INSERT INTO voucher_batches (amount, currency, usage_limit, expires_at) VALUES ($1, $2, $3, $4)
+		RETURNING `+batchColumns,
+		batch.Amount, batch.Currency, batch.UsageLimit, batch.ExpiresAt)
+	result, err = scanBatch(row)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Vouchers = make([]*models.Voucher, 0, len(batch.Vouchers))
+	for _, v := range batch.Vouchers {
+		_, err = tx.Exec("INSERT INTO vouchers (code, batch_id) VALUES ($1, $2)", v.Code, result.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		result.Vouchers = append(result.Vouchers, &models.Voucher{Code: v.Code, BatchId: result.Id})
+	}
+
+	return result, nil
+}
+
+func (r VoucherRepository) GetBatch(id int64) (*models.VoucherBatch, error) {
+	row := r.db.QueryRow("SELECT "+batchColumns+" FROM voucher_batches WHERE id = $1", id)
+	batch, err := scanBatch(row)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query("SELECT code, batch_id, uses FROM vouchers WHERE batch_id = $1 ORDER BY code", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	batch.Vouchers = make([]*models.Voucher, 0)
+	for rows.Next() {
+		var v models.Voucher
+		err = rows.Scan(&v.Code, &v.BatchId, &v.Uses)
+		if err != nil {
+			return nil, err
+		}
+
+		batch.Vouchers = append(batch.Vouchers, &v)
+	}
+
+	return batch, rows.Err()
+}
+
+func (r VoucherRepository) GetVoucherBatch(code string) (*models.VoucherBatch, error) {
+	row := r.db.QueryRow(
+		`SELECT b.id, b.amount, b.currency, b.usage_limit, b.expires_at, b.created_at
+		FROM vouchers v JOIN voucher_batches b ON b.id = v.batch_id WHERE v.code = $1`, code)
+	return scanBatch(row)
+}
+
+// Redeem блокирует строку ваучера до конца транзакции, поэтому параллельные погашения одного кода
+// выполняются последовательно и видят актуальное число использований.
+// Зачисление выполняется в той же транзакции операцией пополнения со ссылкой на код
+func (r VoucherRepository) Redeem(code string, userId int64, credited float32, at time.Time) (err error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	var batchId, uses, usageLimit int64
+	var expiresAt time.Time
+	row := tx.QueryRow(
+		`SELECT v.batch_id, v.uses, b.usage_limit, b.expires_at
+		FROM vouchers v JOIN voucher_batches b ON b.id = v.batch_id
+		WHERE v.code = $1 FOR UPDATE OF v`, code)
+	err = row.Scan(&batchId, &uses, &usageLimit, &expiresAt)
+	if err == sql.ErrNoRows {
+		return voucher.ErrVoucherNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if !at.Before(expiresAt) {
+		return voucher.ErrVoucherExpired
+	}
+	if uses >= usageLimit {
+		return voucher.ErrVoucherUsedUp
+	}
+
+	result, err := tx.Exec(
+		`INSERT INTO voucher_redemptions (code, user_id, credited, date) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (code, user_id) DO NOTHING`, code, userId, credited, at)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return voucher.ErrAlreadyRedeemed
+	}
+
+	_, err = tx.Exec("UPDATE vouchers SET uses = uses + 1 WHERE code = $1", code)
+	if err != nil {
+		return err
+	}
+
+	err = balancePostgres.Credit(tx, userId, credited, batchId, balance.RefillType, maskCode(code))
+	return err
+}
+
+// maskCode скрывает код ваучера, кроме последней группы: история операций видна пользователю и сервисам,
+// а по полному коду ваучер с несколькими погашениями может погасить кто угодно
+func maskCode(code string) string {
+	last := strings.LastIndex(code, "-")
+	if last < 0 {
+		last = len(code)
+	}
+
+	masked := []byte(code)
+	for i := 0; i < last; i++ {
+		if masked[i] != '-' {
+			masked[i] = '*'
+		}
+	}
+
+	return string(masked)
+}
@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"avito-intership/models"
+	"avito-intership/utils"
+	"avito-intership/voucher"
+	"database/sql"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/suite"
+	"log"
+	"sync"
+	"testing"
+	"time"
+)
+
+type voucherRepositorySuite struct {
+	suite.Suite
+
+	db       *sql.DB
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+
+	repository voucher.VoucherRepository
+}
+
+func (suite *voucherRepositorySuite) SetupSuite() {
+	db, pool, resource := utils.DockerDBUp()
+	err := utils.InitTable(db, "../../../init.sql")
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	suite.repository = NewVoucherRepository(db)
+	suite.db = db
+	suite.pool = pool
+	suite.resource = resource
+}
+
+func (suite *voucherRepositorySuite) createBatch(usageLimit int64, expiresAt time.Time, codes ...string) *models.VoucherBatch {
+	batch := &models.VoucherBatch{Amount: 100, Currency: "RUB", UsageLimit: usageLimit, ExpiresAt: expiresAt}
+	for _, code := range codes {
+		batch.Vouchers = append(batch.Vouchers, &models.Voucher{Code: code})
+	}
+
+	created, err := suite.repository.CreateBatch(batch)
+	suite.Require().NoError(err, "creating batch should not produce error")
+
+	return created
+}
+
+func (suite *voucherRepositorySuite) balance(userId int64) float32 {
+	var amount float32
+	err := suite.db.QueryRow("SELECT amount FROM balances WHERE id = $1", userId).Scan(&amount)
+	if err == sql.ErrNoRows {
+		return 0
+	}
+	suite.Require().NoError(err)
+
+	return amount
+}
+
+func (suite *voucherRepositorySuite) TestCreateBatch() {
+	created := suite.createBatch(1, time.Now().Add(time.Hour), "AAAA-0001", "AAAA-0002")
+
+	batch, err := suite.repository.GetBatch(created.Id)
+	suite.NoError(err, "getting batch should not produce error")
+	suite.Len(batch.Vouchers, 2)
+	suite.Equal(created.Id, batch.Vouchers[0].BatchId)
+
+	byCode, err := suite.repository.GetVoucherBatch("AAAA-0002")
+	suite.NoError(err, "getting batch by code should not produce error")
+	suite.Equal(created.Id, byCode.Id)
+}
+
+func (suite *voucherRepositorySuite) TestRedeem() {
+	suite.createBatch(2, time.Now().Add(time.Hour), "BBBB-0001")
+
+	err := suite.repository.Redeem("BBBB-0001", 1, 100, time.Now())
+	suite.NoError(err, "redeeming voucher should not produce error")
+	suite.Equal(float32(100), suite.balance(1))
+
+	var reference string
+	var trType string
+	err = suite.db.QueryRow("SELECT reference, type FROM transactions WHERE user_id = 1").Scan(&reference, &trType)
+	suite.NoError(err)
+	suite.Equal("****-0001", reference, "full code is not stored in history")
+	suite.Equal("fill", trType)
+
+	err = suite.repository.Redeem("BBBB-0001", 1, 100, time.Now())
+	suite.Equal(voucher.ErrAlreadyRedeemed, err, "user redeems voucher only once")
+
+	err = suite.repository.Redeem("BBBB-0001", 2, 100, time.Now())
+	suite.NoError(err, "second user should redeem voucher")
+
+	err = suite.repository.Redeem("BBBB-0001", 3, 100, time.Now())
+	suite.Equal(voucher.ErrVoucherUsedUp, err)
+	suite.Equal(float32(0), suite.balance(3))
+}
+
+func (suite *voucherRepositorySuite) TestRedeem_Expired() {
+	suite.createBatch(1, time.Now().Add(time.Hour), "CCCC-0001")
+
+	err := suite.repository.Redeem("CCCC-0001", 4, 100, time.Now().Add(2*time.Hour))
+	suite.Equal(voucher.ErrVoucherExpired, err)
+}
+
+func (suite *voucherRepositorySuite) TestRedeem_NotFound() {
+	err := suite.repository.Redeem("NONE-0000", 5, 100, time.Now())
+
+	suite.Equal(voucher.ErrVoucherNotFound, err)
+}
+
+func (suite *voucherRepositorySuite) TestRedeem_Concurrent() {
+	suite.createBatch(1, time.Now().Add(time.Hour), "DDDD-0001")
+
+	var wg sync.WaitGroup
+	results := make(chan error, 10)
+	for i := int64(0); i < 10; i++ {
+		wg.Add(1)
+		go func(userId int64) {
+			defer wg.Done()
+			results <- suite.repository.Redeem("DDDD-0001", userId, 100, time.Now())
+		}(100 + i)
+	}
+	wg.Wait()
+	close(results)
+
+	redeemed := 0
+	for err := range results {
+		if err == nil {
+			redeemed++
+		} else {
+			suite.Equal(voucher.ErrVoucherUsedUp, err)
+		}
+	}
+	suite.Equal(1, redeemed, "single-use voucher is redeemed exactly once")
+}
+
+func (suite *voucherRepositorySuite) TearDownSuite() {
+	err := utils.DropTable(suite.db, []string{"voucher_redemptions", "vouchers", "voucher_batches"})
+	if err != nil {
+		log.Println(err)
+	}
+
+	if err := suite.pool.Purge(suite.resource); err != nil {
+		log.Fatalf("Could not purge resource: %s", err)
+	}
+}
+
+func TestVoucherRepository(t *testing.T) {
+	suite.Run(t, new(voucherRepositorySuite))
+}
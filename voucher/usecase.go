@@ -0,0 +1,13 @@
+package voucher
+
+import (
+	"avito-intership/models"
+	"time"
+)
+
+type VoucherUseCase interface {
+	// IssueBatch выпускает count уникальных кодов
+	IssueBatch(amount float32, currency string, expiresAt time.Time, usageLimit int64, count int) (*models.VoucherBatch, error)
+	GetBatch(id int64) (*models.VoucherBatch, error)
+	Redeem(code string, userId int64) (*models.Redemption, error)
+}
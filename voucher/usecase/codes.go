@@ -0,0 +1,37 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"strings"
+)
+
+// Алфавит без похожих символов (0/O, 1/I); 32 символа, поэтому байт % 32 распределен равномерно
+const codeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+const (
+	codeGroups    = 3
+	codeGroupSize = 4
+)
+
+// newCode генерирует код вида XXXX-XXXX-XXXX
+func newCode() (string, error) {
+	random := make([]byte, codeGroups*codeGroupSize)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+
+	var code strings.Builder
+	for i, b := range random {
+		if i > 0 && i%codeGroupSize == 0 {
+			code.WriteByte('-')
+		}
+		code.WriteByte(codeAlphabet[int(b)%len(codeAlphabet)])
+	}
+
+	return code.String(), nil
+}
+
+// normalizeCode позволяет вводить код в любом регистре и с пробелами по краям
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/exchange"
+	"avito-intership/models"
+	"avito-intership/voucher"
+	"log"
+	"time"
+)
+
+// MaxBatchSize ограничивает число кодов в одном выпуске
+const MaxBatchSize = 10000
+
+type VoucherUseCase struct {
+	repository voucher.VoucherRepository
+	exchanger  exchange.Exchanger
+}
+
+func NewVoucherUseCase(repository voucher.VoucherRepository, exchanger exchange.Exchanger) *VoucherUseCase {
+	return &VoucherUseCase{
+		repository: repository,
+		exchanger:  exchanger,
+	}
+}
+
+func (u VoucherUseCase) IssueBatch(amount float32, currency string, expiresAt time.Time, usageLimit int64,
+	count int) (*models.VoucherBatch, error) {
+	if currency == "" {
+		currency = exchange.RUB
+	}
+	if !exchange.IsSupported(currency) {
+		return nil, exchange.ErrUnsupportedCurrency
+	}
+
+	amount, _ = exchange.Round(exchange.Decimal(amount), currency).Float32()
+	if amount <= 0 || usageLimit <= 0 || count <= 0 || count > MaxBatchSize || !expiresAt.After(time.Now()) {
+		return nil, voucher.ErrBadBatch
+	}
+
+	batch := &models.VoucherBatch{
+		Amount:     amount,
+		Currency:   currency,
+		UsageLimit: usageLimit,
+		ExpiresAt:  expiresAt,
+		Vouchers:   make([]*models.Voucher, 0, count),
+	}
+
+	codes := make(map[string]bool, count)
+	for len(batch.Vouchers) < count {
+		code, err := newCode()
+		if err != nil {
+			return nil, err
+		}
+		if codes[code] {
+			continue
+		}
+
+		codes[code] = true
+		batch.Vouchers = append(batch.Vouchers, &models.Voucher{Code: code})
+	}
+
+	return u.repository.CreateBatch(batch)
+}
+
+func (u VoucherUseCase) GetBatch(id int64) (*models.VoucherBatch, error) {
+	return u.repository.GetBatch(id)
+}
+
+func (u VoucherUseCase) Redeem(code string, userId int64) (*models.Redemption, error) {
+	code = normalizeCode(code)
+
+	batch, err := u.repository.GetVoucherBatch(code)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !now.Before(batch.ExpiresAt) {
+		return nil, voucher.ErrVoucherExpired
+	}
+
+	// Номинал в иностранной валюте зачисляется в рублях по биржевому курсу на момент погашения
+	credited := batch.Amount
+	if batch.Currency != exchange.RUB {
+		conversion, err := u.exchanger.Convert(batch.Amount, batch.Currency, exchange.RUB)
+		if err != nil {
+			log.Println(err)
+			return nil, balance.ErrRateUnavailable
+		}
+
+		credited = conversion.Amount
+	}
+
+	err = u.repository.Redeem(code, userId, credited, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Redemption{
+		Code:     code,
+		UserId:   userId,
+		Amount:   batch.Amount,
+		Currency: batch.Currency,
+		Credited: credited,
+	}, nil
+}
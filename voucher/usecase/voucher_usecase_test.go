@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"avito-intership/balance"
+	"avito-intership/exchange"
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"avito-intership/voucher"
+	"errors"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"regexp"
+	"testing"
+	"time"
+)
+
+type voucherUseCaseSuite struct {
+	suite.Suite
+	repository *mocks.VoucherRepository
+	exchanger  *mocks.Exchanger
+	useCase    voucher.VoucherUseCase
+}
+
+func (suite *voucherUseCaseSuite) SetupTest() {
+	repository := new(mocks.VoucherRepository)
+	exchanger := new(mocks.Exchanger)
+
+	suite.repository = repository
+	suite.exchanger = exchanger
+	suite.useCase = NewVoucherUseCase(repository, exchanger)
+}
+
+func (suite *voucherUseCaseSuite) TestIssueBatch_Ok() {
+	expiresAt := time.Now().Add(24 * time.Hour)
+	codeFormat := regexp.MustCompile(`^[A-Z2-9]{4}-[A-Z2-9]{4}-[A-Z2-9]{4}$`)
+
+	suite.repository.On("CreateBatch", mock.MatchedBy(func(b *models.VoucherBatch) bool {
+		codes := make(map[string]bool)
+		for _, v := range b.Vouchers {
+			if !codeFormat.MatchString(v.Code) {
+				return false
+			}
+			codes[v.Code] = true
+		}
+
+		return b.Amount == 500 && b.Currency == "RUB" && b.UsageLimit == 1 && len(codes) == 50
+	})).Return(&models.VoucherBatch{Id: 1}, nil)
+
+	batch, err := suite.useCase.IssueBatch(500, "", expiresAt, 1, 50)
+
+	suite.NoError(err)
+	suite.Equal(int64(1), batch.Id)
+}
+
+func (suite *voucherUseCaseSuite) TestIssueBatch_Invalid() {
+	future := time.Now().Add(time.Hour)
+	cases := []struct {
+		name       string
+		amount     float32
+		currency   string
+		expiresAt  time.Time
+		usageLimit int64
+		count      int
+		err        error
+	}{
+		{name: "zero amount", amount: 0, expiresAt: future, usageLimit: 1, count: 1, err: voucher.ErrBadBatch},
+		{name: "zero usage limit", amount: 10, expiresAt: future, usageLimit: 0, count: 1, err: voucher.ErrBadBatch},
+		{name: "zero count", amount: 10, expiresAt: future, usageLimit: 1, count: 0, err: voucher.ErrBadBatch},
+		{name: "too many codes", amount: 10, expiresAt: future, usageLimit: 1, count: MaxBatchSize + 1,
+			err: voucher.ErrBadBatch},
+		{name: "past expiry", amount: 10, expiresAt: time.Now().Add(-time.Hour), usageLimit: 1, count: 1,
+			err: voucher.ErrBadBatch},
+		{name: "unsupported currency", amount: 10, currency: "XYZ", expiresAt: future, usageLimit: 1, count: 1,
+			err: exchange.ErrUnsupportedCurrency},
+	}
+
+	for _, c := range cases {
+		suite.Run(c.name, func() {
+			_, err := suite.useCase.IssueBatch(c.amount, c.currency, c.expiresAt, c.usageLimit, c.count)
+
+			suite.Equal(c.err, err)
+		})
+	}
+	suite.repository.AssertNotCalled(suite.T(), "CreateBatch", mock.Anything)
+}
+
+func (suite *voucherUseCaseSuite) TestRedeem_RUB() {
+	var userId int64 = 1
+	batch := &models.VoucherBatch{Id: 2, Amount: 300, Currency: "RUB", UsageLimit: 1,
+		ExpiresAt: time.Now().Add(time.Hour)}
+
+	suite.repository.On("GetVoucherBatch", "ABCD-EFGH-JKLM").Return(batch, nil)
+	suite.repository.On("Redeem", "ABCD-EFGH-JKLM", userId, float32(300), mock.Anything).Return(nil)
+
+	redemption, err := suite.useCase.Redeem(" abcd-efgh-jklm ", userId)
+
+	suite.NoError(err)
+	suite.Equal(&models.Redemption{Code: "ABCD-EFGH-JKLM", UserId: userId, Amount: 300, Currency: "RUB",
+		Credited: 300}, redemption)
+	suite.exchanger.AssertNotCalled(suite.T(), "Convert", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *voucherUseCaseSuite) TestRedeem_Currency() {
+	var userId int64 = 1
+	batch := &models.VoucherBatch{Id: 2, Amount: 10, Currency: "USD", UsageLimit: 1,
+		ExpiresAt: time.Now().Add(time.Hour)}
+
+	suite.repository.On("GetVoucherBatch", "USD1-USD2-USD3").Return(batch, nil)
+	suite.exchanger.On("Convert", float32(10), "USD", "RUB").Return(&models.Conversion{Amount: 750}, nil)
+	suite.repository.On("Redeem", "USD1-USD2-USD3", userId, float32(750), mock.Anything).Return(nil)
+
+	redemption, err := suite.useCase.Redeem("USD1-USD2-USD3", userId)
+
+	suite.NoError(err)
+	suite.Equal(float32(10), redemption.Amount)
+	suite.Equal(float32(750), redemption.Credited)
+}
+
+func (suite *voucherUseCaseSuite) TestRedeem_RateUnavailable() {
+	batch := &models.VoucherBatch{Id: 2, Amount: 10, Currency: "USD", UsageLimit: 1,
+		ExpiresAt: time.Now().Add(time.Hour)}
+
+	suite.repository.On("GetVoucherBatch", "USD1-USD2-USD3").Return(batch, nil)
+	suite.exchanger.On("Convert", float32(10), "USD", "RUB").Return(nil, errors.New("rates are down"))
+
+	_, err := suite.useCase.Redeem("USD1-USD2-USD3", 1)
+
+	suite.Equal(balance.ErrRateUnavailable, err)
+	suite.repository.AssertNotCalled(suite.T(), "Redeem", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *voucherUseCaseSuite) TestRedeem_Expired() {
+	batch := &models.VoucherBatch{Id: 2, Amount: 10, Currency: "RUB", UsageLimit: 1,
+		ExpiresAt: time.Now().Add(-time.Hour)}
+
+	suite.repository.On("GetVoucherBatch", "OLD1-OLD2-OLD3").Return(batch, nil)
+
+	_, err := suite.useCase.Redeem("OLD1-OLD2-OLD3", 1)
+
+	suite.Equal(voucher.ErrVoucherExpired, err)
+	suite.repository.AssertNotCalled(suite.T(), "Redeem", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *voucherUseCaseSuite) TestRedeem_UsedUp() {
+	var userId int64 = 1
+	batch := &models.VoucherBatch{Id: 2, Amount: 10, Currency: "RUB", UsageLimit: 1,
+		ExpiresAt: time.Now().Add(time.Hour)}
+
+	suite.repository.On("GetVoucherBatch", "USED-USED-USED").Return(batch, nil)
+	suite.repository.On("Redeem", "USED-USED-USED", userId, float32(10), mock.Anything).
+		Return(voucher.ErrVoucherUsedUp)
+
+	_, err := suite.useCase.Redeem("USED-USED-USED", userId)
+
+	suite.Equal(voucher.ErrVoucherUsedUp, err)
+}
+
+func TestVoucherUseCase(t *testing.T) {
+	suite.Run(t, new(voucherUseCaseSuite))
+}
@@ -0,0 +1,161 @@
+package http
+
+import (
+	"avito-intership/apperror"
+	"avito-intership/problem"
+	"avito-intership/webhook"
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type AdminHandler struct {
+	useCase webhook.SubscriptionUseCase
+}
+
+func NewAdminHandler(useCase webhook.SubscriptionUseCase) *AdminHandler {
+	return &AdminHandler{
+		useCase: useCase,
+	}
+}
+
+type StatusMessage struct {
+	Success bool    `json:"success"`
+	Message *string `json:"message"`
+}
+
+func (h AdminHandler) writeStatus(success bool, message *string, w *http.ResponseWriter) {
+	status := StatusMessage{
+		Success: success,
+		Message: message,
+	}
+
+	(*w).Header().Add("Content-Type", "application/json")
+	_ = json.NewEncoder(*w).Encode(status)
+}
+
+func (h AdminHandler) writeJSON(value interface{}, w http.ResponseWriter) {
+	w.Header().Add("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		problem.Write(w, err)
+	}
+}
+
+func (h AdminHandler) parseId(r *http.Request, w http.ResponseWriter) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil || id <= 0 {
+		problem.Write(w, apperror.BadArgument("id"))
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (h AdminHandler) GetSubscriptionsEndpoint(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.useCase.GetSubscriptions()
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(subscriptions, w)
+}
+
+// SubscribeEndpoint принимает типы событий списком через запятую в event_types
+func (h AdminHandler) SubscribeEndpoint(w http.ResponseWriter, r *http.Request) {
+	var eventTypes []string
+	if value := r.FormValue("event_types"); value != "" {
+		eventTypes = strings.Split(value, ",")
+	}
+
+	subscription, err := h.useCase.Subscribe(r.FormValue("url"), eventTypes)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(subscription, w)
+}
+
+func (h AdminHandler) GetSubscriptionEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	subscription, err := h.useCase.GetSubscription(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(subscription, w)
+}
+
+func (h AdminHandler) UnsubscribeEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	err := h.useCase.Unsubscribe(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeStatus(true, nil, &w)
+}
+
+func (h AdminHandler) GetDeadLettersEndpoint(w http.ResponseWriter, r *http.Request) {
+	var subscriptionId int64
+	if value := r.FormValue("subscription_id"); value != "" {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || id <= 0 {
+			problem.Write(w, apperror.BadArgument("subscription_id"))
+			return
+		}
+		subscriptionId = id
+	}
+
+	deadLetters, err := h.useCase.GetDeadLetters(subscriptionId)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(deadLetters, w)
+}
+
+func (h AdminHandler) GetDeadLetterEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	deadLetter, err := h.useCase.GetDeadLetter(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(deadLetter, w)
+}
+
+func (h AdminHandler) ReplayEndpoint(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.parseId(r, w)
+	if !ok {
+		return
+	}
+
+	delivery, err := h.useCase.Replay(id)
+	if err != nil {
+		problem.Write(w, err)
+		return
+	}
+
+	h.writeJSON(delivery, w)
+}
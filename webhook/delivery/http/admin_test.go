@@ -0,0 +1,128 @@
+package http
+
+import (
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"avito-intership/webhook"
+	"encoding/json"
+	"fmt"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+type adminHandlerSuite struct {
+	suite.Suite
+
+	useCase       *mocks.SubscriptionUseCase
+	testingServer *httptest.Server
+}
+
+func (suite *adminHandlerSuite) SetupSuite() {
+	useCase := new(mocks.SubscriptionUseCase)
+
+	router := mux.NewRouter()
+	RegisterAdminEndpoints(router.PathPrefix("/api/v1/admin").Subrouter(), useCase)
+
+	suite.testingServer = httptest.NewServer(router)
+	suite.useCase = useCase
+}
+
+func (suite *adminHandlerSuite) TearDownSuite() {
+	defer suite.testingServer.Close()
+}
+
+func (suite *adminHandlerSuite) TestSubscribe() {
+	suite.useCase.On("Subscribe", "https://billing.internal/hooks", []string{"balance.*", "transfer.completed"}).
+		Return(&models.Subscription{Id: 1, Url: "https://billing.internal/hooks", Secret: "secret"}, nil)
+
+	data := url.Values{}
+	data.Set("url", "https://billing.internal/hooks")
+	data.Set("event_types", "balance.*,transfer.completed")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/webhooks", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.Subscription
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal("secret", responseBody.Secret)
+}
+
+func (suite *adminHandlerSuite) TestSubscribe_BadSubscription() {
+	suite.useCase.On("Subscribe", "nowhere", []string(nil)).Return(nil, webhook.ErrBadSubscription)
+
+	data := url.Values{}
+	data.Set("url", "nowhere")
+
+	response, err := http.PostForm(fmt.Sprintf("%s/api/v1/admin/webhooks", suite.testingServer.URL), data)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusBadRequest, response.StatusCode)
+}
+
+func (suite *adminHandlerSuite) TestUnsubscribe_NotFound() {
+	suite.useCase.On("Unsubscribe", int64(404)).Return(webhook.ErrSubscriptionNotFound)
+
+	request, err := http.NewRequest(http.MethodDelete,
+		fmt.Sprintf("%s/api/v1/admin/webhooks/404", suite.testingServer.URL), nil)
+	suite.NoError(err, "creating request should not produce error")
+
+	response, err := http.DefaultClient.Do(request)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusNotFound, response.StatusCode)
+}
+
+func (suite *adminHandlerSuite) TestGetDeadLetters() {
+	suite.useCase.On("GetDeadLetters", int64(3)).
+		Return([]*models.DeadLetter{{Id: 1, SubscriptionId: 3, LastError: "subscriber responded with 500"}}, nil)
+
+	response, err := http.Get(fmt.Sprintf("%s/api/v1/admin/webhooks/dead-letters?subscription_id=3",
+		suite.testingServer.URL))
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody []*models.DeadLetter
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Len(responseBody, 1)
+}
+
+func (suite *adminHandlerSuite) TestReplay() {
+	suite.useCase.On("Replay", int64(1)).Return(&models.Delivery{Id: 9, SubscriptionId: 3}, nil)
+	suite.useCase.On("Replay", int64(2)).Return(nil, webhook.ErrAlreadyReplayed)
+
+	response, err := http.Post(fmt.Sprintf("%s/api/v1/admin/webhooks/dead-letters/1/replay", suite.testingServer.URL),
+		"", nil)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	var responseBody models.Delivery
+	err = json.NewDecoder(response.Body).Decode(&responseBody)
+	suite.NoError(err, "decoding should not produce error")
+
+	suite.Equal(http.StatusOK, response.StatusCode)
+	suite.Equal(int64(9), responseBody.Id)
+
+	response, err = http.Post(fmt.Sprintf("%s/api/v1/admin/webhooks/dead-letters/2/replay", suite.testingServer.URL),
+		"", nil)
+	suite.NoError(err, "request should not produce error")
+	defer response.Body.Close()
+
+	suite.Equal(http.StatusConflict, response.StatusCode)
+}
+
+func TestAdminHandler(t *testing.T) {
+	suite.Run(t, new(adminHandlerSuite))
+}
@@ -0,0 +1,27 @@
+package http
+
+import (
+	"avito-intership/webhook"
+	"github.com/gorilla/mux"
+	"net/http"
+)
+
+// RegisterAdminEndpoints регистрирует управление подписками на события, router - подмаршрутизатор /api/v1/admin
+func RegisterAdminEndpoints(router *mux.Router, uc webhook.SubscriptionUseCase) {
+	handler := NewAdminHandler(uc)
+
+	router.HandleFunc("/webhooks", handler.GetSubscriptionsEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/webhooks", handler.SubscribeEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+	router.HandleFunc("/webhooks/{id:[0-9]+}", handler.GetSubscriptionEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/webhooks/{id:[0-9]+}", handler.UnsubscribeEndpoint).
+		Methods(http.MethodOptions, http.MethodDelete)
+	router.HandleFunc("/webhooks/dead-letters", handler.GetDeadLettersEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/webhooks/dead-letters/{id:[0-9]+}", handler.GetDeadLetterEndpoint).
+		Methods(http.MethodOptions, http.MethodGet)
+	router.HandleFunc("/webhooks/dead-letters/{id:[0-9]+}/replay", handler.ReplayEndpoint).
+		Methods(http.MethodOptions, http.MethodPost)
+}
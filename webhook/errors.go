@@ -0,0 +1,12 @@
+package webhook
+
+import "avito-intership/apperror"
+
+var (
+	ErrSubscriptionNotFound = apperror.New(apperror.NotFound, "subscription not found")
+	ErrBadSubscription      = apperror.New(apperror.InvalidArgument,
+		"subscription must have an absolute http(s) url and at least one event type "+
+			"like \"balance.debited\", \"balance.*\" or \"*\"")
+	ErrDeadLetterNotFound = apperror.New(apperror.NotFound, "dead letter not found")
+	ErrAlreadyReplayed    = apperror.New(apperror.Conflict, "dead letter is already replayed")
+)
@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"avito-intership/models"
+	"time"
+)
+
+type SubscriptionRepository interface {
+	CreateSubscription(subscription *models.Subscription) (*models.Subscription, error)
+	GetSubscription(id int64) (*models.Subscription, error)
+	GetSubscriptions() ([]*models.Subscription, error)
+	// DeleteSubscription удаляет подписку вместе с ее доставками
+	DeleteSubscription(id int64) error
+}
+
+type DeliveryRepository interface {
+	// Enqueue ставит событие в очередь доставки подписчикам, повторная постановка того же события игнорируется
+	Enqueue(event *models.Event, subscriptionIds []int64) error
+	// ClaimDue выбирает до limit доставок, время которых наступило к at, и откладывает их на lease,
+	// чтобы одну доставку не отправили несколько процессов одновременно
+	ClaimDue(at time.Time, lease time.Duration, limit int) ([]*models.Delivery, error)
+	MarkDelivered(id int64) error
+	ScheduleRetry(id int64, attempts int, nextAttemptAt time.Time, lastError string) error
+	// MoveToDeadLetter убирает доставку из очереди в таблицу dead letter
+	MoveToDeadLetter(id int64, attempts int, lastError string) (*models.DeadLetter, error)
+	// GetDeadLetters возвращает dead letter подписки, нулевой subscriptionId - всех подписок
+	GetDeadLetters(subscriptionId int64) ([]*models.DeadLetter, error)
+	GetDeadLetter(id int64) (*models.DeadLetter, error)
+	// Replay ставит событие из dead letter в очередь доставки заново
+	Replay(id int64) (*models.Delivery, error)
+}
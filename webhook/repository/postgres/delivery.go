@@ -0,0 +1,235 @@
+package postgres
+
+import (
+	"avito-intership/models"
+	"avito-intership/webhook"
+	"database/sql"
+	"encoding/json"
+	"time"
+)
+
+type DeliveryRepository struct {
+	db *sql.DB
+}
+
+func NewDeliveryRepository(dbConn *sql.DB) *DeliveryRepository {
+	return &DeliveryRepository{dbConn}
+}
+
+const deliveryColumns = "d.id, d.subscription_id, s.url, s.secret, d.event, d.attempts, d.next_attempt_at, " +
+	"d.last_error, d.created_at"
+
+const deadLetterColumns = "id, subscription_id, event, attempts, last_error, failed_at, replayed_at"
+
+func scanDelivery(row scanner) (*models.Delivery, error) {
+	var delivery models.Delivery
+	var event []byte
+	var lastError sql.NullString
+
+	err := row.Scan(&delivery.Id, &delivery.SubscriptionId, &delivery.Url, &delivery.Secret, &event,
+		&delivery.Attempts, &delivery.NextAttemptAt, &lastError, &delivery.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(event, &delivery.Event)
+	if err != nil {
+		return nil, err
+	}
+	if lastError.Valid {
+		delivery.LastError = &lastError.String
+	}
+
+	return &delivery, nil
+}
+
+func scanDeadLetter(row scanner) (*models.DeadLetter, error) {
+	var deadLetter models.DeadLetter
+	var event []byte
+	var replayedAt sql.NullTime
+
+	err := row.Scan(&deadLetter.Id, &deadLetter.SubscriptionId, &event, &deadLetter.Attempts, &deadLetter.LastError,
+		&deadLetter.FailedAt, &replayedAt)
+	if err == sql.ErrNoRows {
+		return nil, webhook.ErrDeadLetterNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(event, &deadLetter.Event)
+	if err != nil {
+		return nil, err
+	}
+	if replayedAt.Valid {
+		deadLetter.ReplayedAt = &replayedAt.Time
+	}
+
+	return &deadLetter, nil
+}
+
+func (r DeliveryRepository) Enqueue(event *models.Event, subscriptionIds []int64) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	for _, subscriptionId := range subscriptionIds {
+		_, err = tx.Exec(
+			`INSERT INTO webhook_deliveries (subscription_id, event_id, event) VALUES ($1, $2, $3)
+			ON CONFLICT (subscription_id, event_id) DO NOTHING`, subscriptionId, event.Id, payload)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r DeliveryRepository) ClaimDue(at time.Time, lease time.Duration, limit int) ([]*models.Delivery, error) {
+	rows, err := r.db.Query(
+		`UPDATE webhook_deliveries d SET next_attempt_at = $2
+		FROM webhook_subscriptions s
+		WHERE s.id = d.subscription_id AND d.id IN (
+			SELECT id FROM webhook_deliveries
+			WHERE delivered_at IS NULL AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, id LIMIT $3 FOR UPDATE SKIP LOCKED)
+		RETURNING `+deliveryColumns, at, at.Add(lease), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]*models.Delivery, 0)
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r DeliveryRepository) MarkDelivered(id int64) error {
+	_, err := r.db.Exec("UPDATE webhook_deliveries SET delivered_at = NOW() WHERE id = $1", id)
+	return err
+}
+
+func (r DeliveryRepository) ScheduleRetry(id int64, attempts int, nextAttemptAt time.Time, lastError string) error {
+	_, err := r.db.Exec(
+		"UPDATE webhook_deliveries SET attempts = $2, next_attempt_at = $3, last_error = $4 WHERE id = $1",
+		id, attempts, nextAttemptAt, lastError)
+	return err
+}
+
+func (r DeliveryRepository) MoveToDeadLetter(id int64, attempts int, lastError string) (*models.DeadLetter, error) {
+	return scanDeadLetter(r.db.QueryRow(
+		`WITH moved AS (
+			DELETE FROM webhook_deliveries WHERE id = $1 RETURNING subscription_id, event_id, event
+		)
+		INSERT INTO webhook_dead_letters (subscription_id, event_id, event, attempts, last_error)
+		SELECT subscription_id, event_id, event, $2, $3 FROM moved
+		RETURNING `+deadLetterColumns, id, attempts, lastError))
+}
+
+func (r DeliveryRepository) GetDeadLetters(subscriptionId int64) ([]*models.DeadLetter, error) {
+	rows, err := r.db.Query(
+		`SELECT `+deadLetterColumns+` FROM webhook_dead_letters
+		WHERE $1 = 0 OR subscription_id = $1 ORDER BY failed_at DESC, id DESC`, subscriptionId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deadLetters := make([]*models.DeadLetter, 0)
+	for rows.Next() {
+		deadLetter, err := scanDeadLetter(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		deadLetters = append(deadLetters, deadLetter)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deadLetters, nil
+}
+
+func (r DeliveryRepository) GetDeadLetter(id int64) (*models.DeadLetter, error) {
+	return scanDeadLetter(r.db.QueryRow("SELECT "+deadLetterColumns+" FROM webhook_dead_letters WHERE id = $1", id))
+}
+
+func (r DeliveryRepository) Replay(id int64) (*models.Delivery, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			_ = tx.Commit()
+		}
+	}()
+
+	deadLetter, err := scanDeadLetter(tx.QueryRow(
+		"SELECT "+deadLetterColumns+" FROM webhook_dead_letters WHERE id = $1 FOR UPDATE", id))
+	if err != nil {
+		return nil, err
+	}
+	if deadLetter.ReplayedAt != nil {
+		err = webhook.ErrAlreadyReplayed
+		return nil, err
+	}
+
+	// Доставленное или снова ожидающее доставки событие ставится в очередь с начала
+	var deliveryId int64
+	err = tx.QueryRow(
+		`INSERT INTO webhook_deliveries (subscription_id, event_id, event)
+		SELECT subscription_id, event_id, event FROM webhook_dead_letters WHERE id = $1
+		ON CONFLICT (subscription_id, event_id) DO UPDATE
+		SET attempts = 0, next_attempt_at = NOW(), last_error = NULL, delivered_at = NULL
+		RETURNING id`, id).Scan(&deliveryId)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec("UPDATE webhook_dead_letters SET replayed_at = NOW() WHERE id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := scanDelivery(tx.QueryRow(
+		`SELECT `+deliveryColumns+` FROM webhook_deliveries d
+		JOIN webhook_subscriptions s ON s.id = d.subscription_id
+		WHERE d.id = $1`, deliveryId))
+	if err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"avito-intership/models"
+	"avito-intership/utils"
+	"avito-intership/webhook"
+	"database/sql"
+	"encoding/json"
+	"github.com/ory/dockertest"
+	"github.com/stretchr/testify/suite"
+	"log"
+	"testing"
+	"time"
+)
+
+type deliveryRepositorySuite struct {
+	suite.Suite
+
+	db       *sql.DB
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+
+	subscriptions webhook.SubscriptionRepository
+	deliveries    webhook.DeliveryRepository
+}
+
+func (suite *deliveryRepositorySuite) SetupSuite() {
+	db, pool, resource := utils.DockerDBUp()
+	err := utils.InitTable(db, "../../../init.sql")
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	suite.subscriptions = NewSubscriptionRepository(db)
+	suite.deliveries = NewDeliveryRepository(db)
+	suite.db = db
+	suite.pool = pool
+	suite.resource = resource
+}
+
+func (suite *deliveryRepositorySuite) subscribe() *models.Subscription {
+	subscription, err := suite.subscriptions.CreateSubscription(&models.Subscription{
+		Url: "http://localhost/hooks", EventTypes: []string{"balance.*"}, Secret: "secret"})
+	suite.Require().NoError(err, "creating subscription should not produce error")
+
+	return subscription
+}
+
+func (suite *deliveryRepositorySuite) TestSubscriptions() {
+	created := suite.subscribe()
+	suite.Equal([]string{"balance.*"}, created.EventTypes)
+
+	subscription, err := suite.subscriptions.GetSubscription(created.Id)
+	suite.NoError(err, "getting subscription should not produce error")
+	suite.Equal("secret", subscription.Secret)
+
+	suite.NoError(suite.subscriptions.DeleteSubscription(created.Id))
+	_, err = suite.subscriptions.GetSubscription(created.Id)
+	suite.Equal(webhook.ErrSubscriptionNotFound, err)
+	suite.Equal(webhook.ErrSubscriptionNotFound, suite.subscriptions.DeleteSubscription(created.Id))
+}
+
+func (suite *deliveryRepositorySuite) TestDeliveryLifecycle() {
+	subscription := suite.subscribe()
+	event := &models.Event{Id: 100, Type: "balance.debited", SchemaVersion: 1, UserId: 1,
+		Payload: json.RawMessage(`{"amount":-100}`)}
+
+	suite.NoError(suite.deliveries.Enqueue(event, []int64{subscription.Id}))
+	suite.NoError(suite.deliveries.Enqueue(event, []int64{subscription.Id}), "enqueueing twice is ignored")
+
+	now := time.Now()
+	claimed, err := suite.deliveries.ClaimDue(now.Add(time.Second), time.Minute, 10)
+	suite.NoError(err, "claiming deliveries should not produce error")
+	suite.Len(claimed, 1)
+	suite.Equal(subscription.Url, claimed[0].Url)
+	suite.Equal("secret", claimed[0].Secret)
+	suite.Equal(event.Id, claimed[0].Event.Id)
+
+	claimedAgain, err := suite.deliveries.ClaimDue(now.Add(time.Second), time.Minute, 10)
+	suite.NoError(err, "claiming deliveries should not produce error")
+	suite.Len(claimedAgain, 0, "claimed delivery is leased")
+
+	suite.NoError(suite.deliveries.ScheduleRetry(claimed[0].Id, 1, now, "subscriber responded with 500"))
+	claimed, err = suite.deliveries.ClaimDue(now.Add(time.Second), time.Minute, 10)
+	suite.NoError(err, "claiming deliveries should not produce error")
+	suite.Len(claimed, 1)
+	suite.Equal(1, claimed[0].Attempts)
+
+	deadLetter, err := suite.deliveries.MoveToDeadLetter(claimed[0].Id, 8, "subscriber responded with 500")
+	suite.NoError(err, "moving to dead letter should not produce error")
+	suite.Equal(event.Id, deadLetter.Event.Id)
+
+	deadLetters, err := suite.deliveries.GetDeadLetters(subscription.Id)
+	suite.NoError(err, "getting dead letters should not produce error")
+	suite.Len(deadLetters, 1)
+
+	replayed, err := suite.deliveries.Replay(deadLetter.Id)
+	suite.NoError(err, "replaying should not produce error")
+	suite.Equal(0, replayed.Attempts)
+	suite.Equal(event.Id, replayed.Event.Id)
+
+	_, err = suite.deliveries.Replay(deadLetter.Id)
+	suite.Equal(webhook.ErrAlreadyReplayed, err)
+
+	suite.NoError(suite.deliveries.MarkDelivered(replayed.Id))
+	claimed, err = suite.deliveries.ClaimDue(time.Now().Add(time.Hour), time.Minute, 10)
+	suite.NoError(err, "claiming deliveries should not produce error")
+	suite.Len(claimed, 0)
+
+	_, err = suite.deliveries.GetDeadLetter(-1)
+	suite.Equal(webhook.ErrDeadLetterNotFound, err)
+}
+
+func (suite *deliveryRepositorySuite) TearDownSuite() {
+	err := utils.DropTable(suite.db, []string{"webhook_dead_letters", "webhook_deliveries", "webhook_subscriptions"})
+	if err != nil {
+		log.Println(err)
+	}
+
+	if err := suite.pool.Purge(suite.resource); err != nil {
+		log.Fatalf("Could not purge resource: %s", err)
+	}
+}
+
+func TestDeliveryRepository(t *testing.T) {
+	suite.Run(t, new(deliveryRepositorySuite))
+}
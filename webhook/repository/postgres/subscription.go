@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"avito-intership/models"
+	"avito-intership/webhook"
+	"database/sql"
+	"github.com/lib/pq"
+)
+
+type SubscriptionRepository struct {
+	db *sql.DB
+}
+
+func NewSubscriptionRepository(dbConn *sql.DB) *SubscriptionRepository {
+	return &SubscriptionRepository{dbConn}
+}
+
+const subscriptionColumns = "id, url, event_types, secret, created_at"
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSubscription(row scanner) (*models.Subscription, error) {
+	var subscription models.Subscription
+	err := row.Scan(&subscription.Id, &subscription.Url, pq.Array(&subscription.EventTypes), &subscription.Secret,
+		&subscription.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, webhook.ErrSubscriptionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &subscription, nil
+}
+
+func (r SubscriptionRepository) CreateSubscription(subscription *models.Subscription) (*models.Subscription, error) {
+	return scanSubscription(r.db.QueryRow(
+		`INSERT INTO webhook_subscriptions (url, event_types, secret) VALUES ($1, $2, $3)
+		RETURNING `+subscriptionColumns,
+		subscription.Url, pq.Array(subscription.EventTypes), subscription.Secret))
+}
+
+func (r SubscriptionRepository) GetSubscription(id int64) (*models.Subscription, error) {
+	return scanSubscription(r.db.QueryRow("SELECT "+subscriptionColumns+" FROM webhook_subscriptions WHERE id = $1", id))
+}
+
+func (r SubscriptionRepository) GetSubscriptions() ([]*models.Subscription, error) {
+	rows, err := r.db.Query("SELECT " + subscriptionColumns + " FROM webhook_subscriptions ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	subscriptions := make([]*models.Subscription, 0)
+	for rows.Next() {
+		subscription, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func (r SubscriptionRepository) DeleteSubscription(id int64) error {
+	result, err := r.db.Exec("DELETE FROM webhook_subscriptions WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return webhook.ErrSubscriptionNotFound
+	}
+
+	return nil
+}
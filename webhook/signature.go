@@ -0,0 +1,47 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Заголовки запроса доставки
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+var eventTypePattern = regexp.MustCompile(`^(\*|[a-z_]+(\.[a-z_]+)*(\.\*)?)$`)
+
+// IsEventType проверяет формат типа события в подписке
+func IsEventType(eventType string) bool {
+	return eventTypePattern.MatchString(eventType)
+}
+
+// Matches проверяет, подходит ли событие eventType под тип из подписки pattern
+func Matches(pattern string, eventType string) bool {
+	if pattern == "*" {
+		return true
+	}
+	if strings.HasSuffix(pattern, ".*") {
+		return strings.HasPrefix(eventType, strings.TrimSuffix(pattern, "*"))
+	}
+
+	return pattern == eventType
+}
+
+// Sign возвращает подпись тела запроса: "sha256=" и HMAC-SHA256 от "<timestamp>.<body>" в hex.
+// Получатель проверяет подпись тем же ключом и отбрасывает запросы со старым timestamp
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,33 @@
+package webhook
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestMatches(t *testing.T) {
+	assert.True(t, Matches("*", "balance.debited"))
+	assert.True(t, Matches("balance.*", "balance.debited"))
+	assert.True(t, Matches("balance.debited", "balance.debited"))
+	assert.False(t, Matches("balance.*", "transfer.completed"))
+	assert.False(t, Matches("balance.*", "balancer.debited"))
+	assert.False(t, Matches("balance.credited", "balance.debited"))
+}
+
+func TestIsEventType(t *testing.T) {
+	for _, eventType := range []string{"*", "balance.*", "balance.low", "transfer.completed"} {
+		assert.True(t, IsEventType(eventType), eventType)
+	}
+	for _, eventType := range []string{"", "balance.", "*.debited", "Balance.Low", "balance.*.low"} {
+		assert.False(t, IsEventType(eventType), eventType)
+	}
+}
+
+func TestSign(t *testing.T) {
+	signature := Sign("secret", 1637201785, []byte(`{"id":1}`))
+
+	assert.Equal(t, signature, Sign("secret", 1637201785, []byte(`{"id":1}`)))
+	assert.NotEqual(t, signature, Sign("other", 1637201785, []byte(`{"id":1}`)))
+	assert.NotEqual(t, signature, Sign("secret", 1637201786, []byte(`{"id":1}`)))
+	assert.Regexp(t, "^sha256=[0-9a-f]{64}$", signature)
+}
@@ -0,0 +1,13 @@
+package webhook
+
+import "avito-intership/models"
+
+type SubscriptionUseCase interface {
+	Subscribe(url string, eventTypes []string) (*models.Subscription, error)
+	GetSubscription(id int64) (*models.Subscription, error)
+	GetSubscriptions() ([]*models.Subscription, error)
+	Unsubscribe(id int64) error
+	GetDeadLetters(subscriptionId int64) ([]*models.DeadLetter, error)
+	GetDeadLetter(id int64) (*models.DeadLetter, error)
+	Replay(id int64) (*models.Delivery, error)
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"avito-intership/models"
+	"avito-intership/webhook"
+)
+
+// Dispatcher - публикатор outbox, который ставит событие в очередь доставки подходящим подписчикам.
+// Сама доставка выполняется Sender, чтобы недоступный подписчик не задерживал остальные события
+type Dispatcher struct {
+	subscriptions webhook.SubscriptionRepository
+	deliveries    webhook.DeliveryRepository
+}
+
+func NewDispatcher(subscriptions webhook.SubscriptionRepository, deliveries webhook.DeliveryRepository) *Dispatcher {
+	return &Dispatcher{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+	}
+}
+
+func (d *Dispatcher) Publish(event *models.Event) error {
+	subscriptions, err := d.subscriptions.GetSubscriptions()
+	if err != nil {
+		return err
+	}
+
+	ids := make([]int64, 0)
+	for _, subscription := range subscriptions {
+		for _, pattern := range subscription.EventTypes {
+			if webhook.Matches(pattern, event.Type) {
+				ids = append(ids, subscription.Id)
+				break
+			}
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return d.deliveries.Enqueue(event, ids)
+}
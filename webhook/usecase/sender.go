@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"avito-intership/models"
+	"avito-intership/webhook"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	sendInterval  = time.Second
+	sendBatchSize = 50
+	sendTimeout   = 10 * time.Second
+	// claimLease должен быть больше sendTimeout, иначе доставку может взять другой процесс
+	claimLease = time.Minute
+	// После maxAttempts неудачных попыток доставка переносится в dead letter
+	maxAttempts    = 8
+	retryBaseDelay = 10 * time.Second
+)
+
+// Sender отправляет доставки подписчикам и повторяет неудачные с экспоненциальной задержкой
+type Sender struct {
+	deliveries webhook.DeliveryRepository
+	client     *http.Client
+	interval   time.Duration
+	now        func() time.Time
+}
+
+func NewSender(deliveries webhook.DeliveryRepository) *Sender {
+	return &Sender{
+		deliveries: deliveries,
+		client:     &http.Client{Timeout: sendTimeout},
+		interval:   sendInterval,
+		now:        time.Now,
+	}
+}
+
+// retryDelay возвращает задержку перед попыткой после attempts неудачных: 10s, 20s, 40s...
+func retryDelay(attempts int) time.Duration {
+	return retryBaseDelay << uint(attempts-1)
+}
+
+func (s *Sender) send(delivery *models.Delivery) error {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, delivery.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := s.now().Unix()
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(webhook.DeliveryHeader, strconv.FormatInt(delivery.Id, 10))
+	request.Header.Set(webhook.TimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(webhook.SignatureHeader, webhook.Sign(delivery.Secret, timestamp, body))
+	request.Header.Set("X-Event-Id", strconv.FormatInt(delivery.Event.Id, 10))
+	request.Header.Set("X-Event-Type", delivery.Event.Type)
+	request.Header.Set("X-Schema-Version", strconv.Itoa(delivery.Event.SchemaVersion))
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("subscriber responded with %d", response.StatusCode)
+	}
+
+	return nil
+}
+
+// SendDue отправляет доставки, время которых наступило, и возвращает количество успешных
+func (s *Sender) SendDue() (int, error) {
+	deliveries, err := s.deliveries.ClaimDue(s.now(), claimLease, sendBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, delivery := range deliveries {
+		sendErr := s.send(delivery)
+		if sendErr == nil {
+			err = s.deliveries.MarkDelivered(delivery.Id)
+			if err != nil {
+				return delivered, err
+			}
+			delivered++
+			continue
+		}
+
+		attempts := delivery.Attempts + 1
+		if attempts >= maxAttempts {
+			log.Printf("webhook delivery %d moved to dead letter: %v", delivery.Id, sendErr)
+			_, err = s.deliveries.MoveToDeadLetter(delivery.Id, attempts, sendErr.Error())
+		} else {
+			err = s.deliveries.ScheduleRetry(delivery.Id, attempts, s.now().Add(retryDelay(attempts)), sendErr.Error())
+		}
+		if err != nil {
+			return delivered, err
+		}
+	}
+
+	return delivered, nil
+}
+
+// Run периодически отправляет доставки до отмены ctx
+func (s *Sender) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := s.SendDue(); err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"avito-intership/webhook"
+	"encoding/json"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type senderSuite struct {
+	suite.Suite
+	deliveries *mocks.DeliveryRepository
+	sender     *Sender
+	now        time.Time
+}
+
+func (suite *senderSuite) SetupTest() {
+	suite.deliveries = new(mocks.DeliveryRepository)
+	suite.sender = NewSender(suite.deliveries)
+	suite.now = time.Date(2021, 11, 18, 2, 16, 0, 0, time.UTC)
+	suite.sender.now = func() time.Time { return suite.now }
+}
+
+func (suite *senderSuite) delivery(url string, attempts int) *models.Delivery {
+	return &models.Delivery{Id: 5, SubscriptionId: 1, Url: url, Secret: "secret", Attempts: attempts,
+		Event: &models.Event{Id: 12, Type: "balance.debited", SchemaVersion: 1, UserId: 1,
+			Payload: json.RawMessage(`{"amount":-100}`)}}
+}
+
+func (suite *senderSuite) TestSendDue_SignedDelivery() {
+	var headers http.Header
+	var body []byte
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	suite.deliveries.On("ClaimDue", suite.now, claimLease, sendBatchSize).
+		Return([]*models.Delivery{suite.delivery(receiver.URL, 0)}, nil)
+	suite.deliveries.On("MarkDelivered", int64(5)).Return(nil)
+
+	delivered, err := suite.sender.SendDue()
+
+	suite.NoError(err)
+	suite.Equal(1, delivered)
+	suite.Equal("5", headers.Get(webhook.DeliveryHeader))
+	suite.Equal("balance.debited", headers.Get("X-Event-Type"))
+
+	timestamp, err := strconv.ParseInt(headers.Get(webhook.TimestampHeader), 10, 64)
+	suite.NoError(err)
+	suite.Equal(suite.now.Unix(), timestamp)
+	suite.Equal(webhook.Sign("secret", timestamp, body), headers.Get(webhook.SignatureHeader))
+
+	var event models.Event
+	suite.NoError(json.Unmarshal(body, &event))
+	suite.Equal(int64(12), event.Id)
+}
+
+func (suite *senderSuite) TestSendDue_RetryWithBackoff() {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	suite.deliveries.On("ClaimDue", suite.now, claimLease, sendBatchSize).
+		Return([]*models.Delivery{suite.delivery(receiver.URL, 2)}, nil)
+	suite.deliveries.On("ScheduleRetry", int64(5), 3, suite.now.Add(40*time.Second), mock.Anything).Return(nil)
+
+	delivered, err := suite.sender.SendDue()
+
+	suite.NoError(err)
+	suite.Equal(0, delivered)
+	suite.deliveries.AssertExpectations(suite.T())
+}
+
+func (suite *senderSuite) TestSendDue_DeadLetter() {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer receiver.Close()
+
+	suite.deliveries.On("ClaimDue", suite.now, claimLease, sendBatchSize).
+		Return([]*models.Delivery{suite.delivery(receiver.URL, maxAttempts-1)}, nil)
+	suite.deliveries.On("MoveToDeadLetter", int64(5), maxAttempts, "subscriber responded with 410").
+		Return(&models.DeadLetter{Id: 1}, nil)
+
+	_, err := suite.sender.SendDue()
+
+	suite.NoError(err)
+	suite.deliveries.AssertNotCalled(suite.T(), "ScheduleRetry", mock.Anything, mock.Anything, mock.Anything,
+		mock.Anything)
+	suite.deliveries.AssertExpectations(suite.T())
+}
+
+func (suite *senderSuite) TestRetryDelay() {
+	suite.Equal(10*time.Second, retryDelay(1))
+	suite.Equal(20*time.Second, retryDelay(2))
+	suite.Equal(640*time.Second, retryDelay(7))
+}
+
+func TestSender(t *testing.T) {
+	suite.Run(t, new(senderSuite))
+}
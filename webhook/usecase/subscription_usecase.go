@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"avito-intership/models"
+	"avito-intership/webhook"
+	"crypto/rand"
+	"encoding/hex"
+	"net/url"
+	"strings"
+)
+
+const secretLength = 32
+
+type SubscriptionUseCase struct {
+	subscriptions webhook.SubscriptionRepository
+	deliveries    webhook.DeliveryRepository
+}
+
+func NewSubscriptionUseCase(subscriptions webhook.SubscriptionRepository,
+	deliveries webhook.DeliveryRepository) *SubscriptionUseCase {
+	return &SubscriptionUseCase{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+	}
+}
+
+func newSecret() (string, error) {
+	secret := make([]byte, secretLength)
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+// Subscribe создает подписку со случайным ключом подписи, ключ возвращается только здесь
+func (u SubscriptionUseCase) Subscribe(rawUrl string, eventTypes []string) (*models.Subscription, error) {
+	endpoint, err := url.Parse(strings.TrimSpace(rawUrl))
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, webhook.ErrBadSubscription
+	}
+
+	types := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if !webhook.IsEventType(eventType) {
+			return nil, webhook.ErrBadSubscription
+		}
+		types = append(types, eventType)
+	}
+	if len(types) == 0 {
+		return nil, webhook.ErrBadSubscription
+	}
+
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	return u.subscriptions.CreateSubscription(&models.Subscription{Url: endpoint.String(), EventTypes: types,
+		Secret: secret})
+}
+
+func (u SubscriptionUseCase) GetSubscription(id int64) (*models.Subscription, error) {
+	subscription, err := u.subscriptions.GetSubscription(id)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Secret = ""
+	return subscription, nil
+}
+
+func (u SubscriptionUseCase) GetSubscriptions() ([]*models.Subscription, error) {
+	subscriptions, err := u.subscriptions.GetSubscriptions()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, subscription := range subscriptions {
+		subscription.Secret = ""
+	}
+	return subscriptions, nil
+}
+
+func (u SubscriptionUseCase) Unsubscribe(id int64) error {
+	return u.subscriptions.DeleteSubscription(id)
+}
+
+func (u SubscriptionUseCase) GetDeadLetters(subscriptionId int64) ([]*models.DeadLetter, error) {
+	return u.deliveries.GetDeadLetters(subscriptionId)
+}
+
+func (u SubscriptionUseCase) GetDeadLetter(id int64) (*models.DeadLetter, error) {
+	return u.deliveries.GetDeadLetter(id)
+}
+
+func (u SubscriptionUseCase) Replay(id int64) (*models.Delivery, error) {
+	return u.deliveries.Replay(id)
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"avito-intership/mocks"
+	"avito-intership/models"
+	"avito-intership/webhook"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+)
+
+type subscriptionUseCaseSuite struct {
+	suite.Suite
+	subscriptions *mocks.SubscriptionRepository
+	deliveries    *mocks.DeliveryRepository
+	useCase       webhook.SubscriptionUseCase
+	dispatcher    *Dispatcher
+}
+
+func (suite *subscriptionUseCaseSuite) SetupTest() {
+	suite.subscriptions = new(mocks.SubscriptionRepository)
+	suite.deliveries = new(mocks.DeliveryRepository)
+	suite.useCase = NewSubscriptionUseCase(suite.subscriptions, suite.deliveries)
+	suite.dispatcher = NewDispatcher(suite.subscriptions, suite.deliveries)
+}
+
+func (suite *subscriptionUseCaseSuite) TestSubscribe() {
+	suite.subscriptions.On("CreateSubscription", mock.MatchedBy(func(s *models.Subscription) bool {
+		return s.Url == "https://billing.internal/hooks" && len(s.EventTypes) == 2 &&
+			s.EventTypes[0] == "balance.*" && len(s.Secret) == 2*secretLength
+	})).Return(func(s *models.Subscription) *models.Subscription { return s }, nil)
+
+	subscription, err := suite.useCase.Subscribe(" https://billing.internal/hooks ",
+		[]string{"balance.*", " transfer.completed"})
+
+	suite.NoError(err)
+	suite.NotEmpty(subscription.Secret, "secret is returned on creation")
+}
+
+func (suite *subscriptionUseCaseSuite) TestSubscribe_Validation() {
+	cases := []struct {
+		url        string
+		eventTypes []string
+	}{
+		{"billing.internal/hooks", []string{"*"}},
+		{"ftp://billing.internal/hooks", []string{"*"}},
+		{"https://billing.internal/hooks", nil},
+		{"https://billing.internal/hooks", []string{"balance debited"}},
+	}
+
+	for _, c := range cases {
+		_, err := suite.useCase.Subscribe(c.url, c.eventTypes)
+		suite.Equal(webhook.ErrBadSubscription, err, c.url)
+	}
+	suite.subscriptions.AssertNotCalled(suite.T(), "CreateSubscription", mock.Anything)
+}
+
+func (suite *subscriptionUseCaseSuite) TestGetSubscriptions_HidesSecret() {
+	suite.subscriptions.On("GetSubscriptions").
+		Return([]*models.Subscription{{Id: 1, Secret: "secret"}}, nil)
+
+	subscriptions, err := suite.useCase.GetSubscriptions()
+
+	suite.NoError(err)
+	suite.Empty(subscriptions[0].Secret)
+}
+
+func (suite *subscriptionUseCaseSuite) TestDispatch_MatchingSubscriptions() {
+	suite.subscriptions.On("GetSubscriptions").Return([]*models.Subscription{
+		{Id: 1, EventTypes: []string{"balance.*"}},
+		{Id: 2, EventTypes: []string{"transfer.completed"}},
+		{Id: 3, EventTypes: []string{"balance.debited", "*"}},
+	}, nil)
+	event := &models.Event{Id: 12, Type: "balance.debited"}
+	suite.deliveries.On("Enqueue", event, []int64{1, 3}).Return(nil)
+
+	err := suite.dispatcher.Publish(event)
+
+	suite.NoError(err)
+	suite.deliveries.AssertExpectations(suite.T())
+}
+
+func (suite *subscriptionUseCaseSuite) TestDispatch_NoSubscribers() {
+	suite.subscriptions.On("GetSubscriptions").Return([]*models.Subscription{
+		{Id: 1, EventTypes: []string{"transfer.completed"}},
+	}, nil)
+
+	err := suite.dispatcher.Publish(&models.Event{Id: 13, Type: "balance.credited"})
+
+	suite.NoError(err)
+	suite.deliveries.AssertNotCalled(suite.T(), "Enqueue", mock.Anything, mock.Anything)
+}
+
+func TestSubscriptionUseCase(t *testing.T) {
+	suite.Run(t, new(subscriptionUseCaseSuite))
+}